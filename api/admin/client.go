@@ -76,3 +76,10 @@ func (c *Client) Stacktrace() (bool, error) {
 	err := c.requester.SendRequest("stacktrace", struct{}{}, res)
 	return res.Success, err
 }
+
+// ReloadPeerAccessList ...
+func (c *Client) ReloadPeerAccessList() (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("reloadPeerAccessList", struct{}{}, res)
+	return res.Success, err
+}
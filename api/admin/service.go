@@ -35,18 +35,21 @@ type Admin struct {
 	performance  Performance
 	chainManager chains.Manager
 	httpServer   *api.Server
+	// reloads the list of peers this node may be connected to
+	reloadAccessList func() error
 }
 
 // NewService returns a new admin API service
-func NewService(log logging.Logger, chainManager chains.Manager, httpServer *api.Server) (*common.HTTPHandler, error) {
+func NewService(log logging.Logger, chainManager chains.Manager, httpServer *api.Server, reloadAccessList func() error) (*common.HTTPHandler, error) {
 	newServer := rpc.NewServer()
 	codec := cjson.NewCodec()
 	newServer.RegisterCodec(codec, "application/json")
 	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
 	if err := newServer.RegisterService(&Admin{
-		log:          log,
-		chainManager: chainManager,
-		httpServer:   httpServer,
+		log:              log,
+		chainManager:     chainManager,
+		httpServer:       httpServer,
+		reloadAccessList: reloadAccessList,
 	}, "admin"); err != nil {
 		return nil, err
 	}
@@ -136,3 +139,16 @@ func (service *Admin) Stacktrace(_ *http.Request, _ *struct{}, reply *api.Succes
 	stacktrace := []byte(logging.Stacktrace{Global: true}.String())
 	return ioutil.WriteFile(stacktraceFile, stacktrace, 0600)
 }
+
+// ReloadPeerAccessList re-reads the peer access list file and applies it
+// without restarting the node. Peers that are no longer allowed are
+// disconnected.
+func (service *Admin) ReloadPeerAccessList(_ *http.Request, _ *struct{}, reply *api.SuccessResponse) error {
+	service.log.Info("Admin: ReloadPeerAccessList called")
+
+	if err := service.reloadAccessList(); err != nil {
+		return err
+	}
+	reply.Success = true
+	return nil
+}
@@ -64,6 +64,16 @@ func (h *Health) RegisterHeartbeat(name string, hb Heartbeater, max time.Duratio
 	})
 }
 
+// RegisterCheckFunc adds a Check with default options and the given CheckFn
+func (h *Health) RegisterCheckFunc(name string, checkFn func() (interface{}, error)) error {
+	return h.RegisterCheck(&check{
+		name:            name,
+		checkFn:         checkFn,
+		initialDelay:    constants.DefaultHealthCheckInitialDelay,
+		executionPeriod: constants.DefaultHealthCheckExecutionPeriod,
+	})
+}
+
 // RegisterMonotonicCheckFunc adds a Check with default options and the given CheckFn
 // After it passes once, its logic (checkFunc) is never run again; it just passes
 func (h *Health) RegisterMonotonicCheckFunc(name string, checkFn func() (interface{}, error)) error {
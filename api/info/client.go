@@ -6,7 +6,9 @@ package info
 import (
 	"time"
 
+	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/network"
+	"github.com/liraxapp/avalanchego/utils/json"
 	"github.com/liraxapp/avalanchego/utils/rpc"
 )
 
@@ -68,6 +70,16 @@ func (c *Client) IsBootstrapped(chain string) (bool, error) {
 	return res.IsBootstrapped, err
 }
 
+// GetBlockIDAtHeight ...
+func (c *Client) GetBlockIDAtHeight(chain string, height uint64) (ids.ID, error) {
+	res := &GetBlockIDAtHeightReply{}
+	err := c.requester.SendRequest("getBlockIDAtHeight", &GetBlockIDAtHeightArgs{
+		Chain:  chain,
+		Height: json.Uint64(height),
+	}, res)
+	return res.BlockID, err
+}
+
 // GetTxFee ...
 func (c *Client) GetTxFee() (*GetTxFeeResponse, error) {
 	res := &GetTxFeeResponse{}
@@ -177,6 +177,34 @@ func (service *Info) IsBootstrapped(_ *http.Request, args *IsBootstrappedArgs, r
 	return nil
 }
 
+// GetBlockIDAtHeightArgs are the arguments for calling GetBlockIDAtHeight
+type GetBlockIDAtHeightArgs struct {
+	// Alias of the chain
+	// Can also be the string representation of the chain's ID
+	Chain  string      `json:"chain"`
+	Height json.Uint64 `json:"height"`
+}
+
+// GetBlockIDAtHeightReply are the results from calling GetBlockIDAtHeight
+type GetBlockIDAtHeightReply struct {
+	BlockID ids.ID `json:"blockID"`
+}
+
+// GetBlockIDAtHeight returns the ID of the block accepted at [args.Height] of
+// the Snowman chain [args.Chain]
+func (service *Info) GetBlockIDAtHeight(_ *http.Request, args *GetBlockIDAtHeightArgs, reply *GetBlockIDAtHeightReply) error {
+	service.log.Info("Info: GetBlockIDAtHeight called with chain: %s, height: %d", args.Chain, args.Height)
+	if args.Chain == "" {
+		return fmt.Errorf("argument 'chain' not given")
+	}
+	chainID, err := service.chainManager.Lookup(args.Chain)
+	if err != nil {
+		return fmt.Errorf("there is no chain with alias/ID '%s'", args.Chain)
+	}
+	reply.BlockID, err = service.chainManager.GetBlockIDAtHeight(chainID, uint64(args.Height))
+	return err
+}
+
 // GetTxFeeResponse ...
 type GetTxFeeResponse struct {
 	CreationTxFee json.Uint64 `json:"creationTxFee"`
@@ -71,3 +71,13 @@ func (c *Client) DeleteUser(user api.UserPass) (bool, error) {
 	err := c.requester.SendRequest("deleteUser", &user, res)
 	return res.Success, err
 }
+
+// ChangePassword changes the password of [user] to [newPassword]
+func (c *Client) ChangePassword(user api.UserPass, newPassword string) (bool, error) {
+	res := &api.SuccessResponse{}
+	err := c.requester.SendRequest("changePassword", &ChangePasswordArgs{
+		UserPass:    user,
+		NewPassword: newPassword,
+	}, res)
+	return res.Success, err
+}
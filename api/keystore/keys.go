@@ -0,0 +1,193 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/encdb"
+	"github.com/liraxapp/avalanchego/database/prefixdb"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+var errRotating = errors.New("the user's data is being re-encrypted")
+
+// passwordKeys are the keys derived from the passwords of a user. The key of
+// the user's previous password is kept until the user's data has been
+// re-encrypted with the key of the current password.
+type passwordKeys struct {
+	lock sync.RWMutex
+	keys encdb.Keys
+}
+
+// Key implements the KeyProvider interface
+func (k *passwordKeys) Key(keyID uint32) ([]byte, error) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	return k.keys.Key(keyID)
+}
+
+// CurrentKeyID implements the KeyProvider interface
+func (k *passwordKeys) CurrentKeyID() (uint32, error) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+
+	return k.keys.CurrentKeyID()
+}
+
+func (k *passwordKeys) add(keyID uint32, key []byte) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	k.keys[keyID] = key
+}
+
+func (k *passwordKeys) remove(keyID uint32) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	delete(k.keys, keyID)
+}
+
+// dataDB returns the database that [username]'s data is encrypted in. If the
+// re-encryption of the user's data was interrupted, it's resumed. Assumes the
+// lock is held and that [pword] is the user's password.
+func (ks *Keystore) dataDB(username, pword string) (*encdb.Database, error) {
+	db, ok := ks.dataDBs[username]
+	if !ok {
+		provider := ks.provider
+		if provider == nil {
+			keyID, err := ks.keyID(username)
+			if err != nil {
+				return nil, err
+			}
+			keys := &passwordKeys{keys: encdb.Keys{keyID: encdb.PasswordKey([]byte(pword))}}
+			prevKeyID, prevKey, err := ks.previousKey(username, keyID, keys.keys[keyID])
+			switch {
+			case err == nil:
+				keys.keys[prevKeyID] = prevKey
+			case err != database.ErrNotFound:
+				return nil, err
+			}
+			ks.passwordKeys[username] = keys
+			provider = keys
+		}
+
+		var err error
+		db, err = encdb.NewWithKeyProvider(provider, prefixdb.New([]byte(username), ks.bcDB))
+		if err != nil {
+			return nil, err
+		}
+		ks.dataDBs[username] = db
+	}
+
+	if ks.provider != nil || ks.rotating[username] {
+		return db, nil
+	}
+	keys := ks.passwordKeys[username]
+	keyID, err := keys.CurrentKeyID()
+	if err != nil {
+		return nil, err
+	}
+	key, err := keys.Key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	switch prevKeyID, _, err := ks.previousKey(username, keyID, key); err {
+	case nil:
+		ks.rotate(username, db, prevKeyID)
+		return db, nil
+	case database.ErrNotFound:
+		return db, nil
+	default:
+		return nil, err
+	}
+}
+
+// keyID returns the ID of the key derived from [username]'s current password.
+// Assumes the lock is held.
+func (ks *Keystore) keyID(username string) (uint32, error) {
+	keyIDBytes, err := ks.keyIDDB.Get([]byte(username))
+	if err == database.ErrNotFound {
+		// Users that haven't changed their password use the first key
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	p := wrappers.Packer{Bytes: keyIDBytes}
+	keyID := p.UnpackInt()
+	return keyID, p.Err
+}
+
+// previousKey returns the ID and the key of [username]'s previous password, if
+// the user's data hasn't been re-encrypted with the key of the current
+// password, [keyID], yet. Otherwise, database.ErrNotFound is returned. Assumes
+// the lock is held.
+func (ks *Keystore) previousKey(username string, keyID uint32, key []byte) (uint32, []byte, error) {
+	rotationDB, err := encdb.NewWithKeyProvider(encdb.Keys{keyID: key}, ks.rotationDB)
+	if err != nil {
+		return 0, nil, err
+	}
+	rotationBytes, err := rotationDB.Get([]byte(username))
+	if err != nil {
+		return 0, nil, err
+	}
+	p := wrappers.Packer{Bytes: rotationBytes}
+	prevKeyID := p.UnpackInt()
+	prevKey := p.UnpackBytes()
+	return prevKeyID, prevKey, p.Err
+}
+
+// rotate re-encrypts [db], the data of [username], with the current key in the
+// background. Once the data has been re-encrypted, the key of the user's
+// previous password, [prevKeyID], is forgotten. Assumes the lock is held.
+func (ks *Keystore) rotate(username string, db *encdb.Database, prevKeyID uint32) {
+	ks.rotating[username] = true
+	ks.rotations.Add(1)
+	go func() {
+		defer ks.rotations.Done()
+
+		rotated, err := db.Rotate(encdb.DefaultRotationBatchSize)
+
+		ks.lock.Lock()
+		defer ks.lock.Unlock()
+
+		delete(ks.rotating, username)
+		if err != nil {
+			ks.log.Error("Keystore: re-encrypting the data of %s failed due to %s", username, err)
+			return
+		}
+		if keys, ok := ks.passwordKeys[username]; ok {
+			if err := ks.rotationDB.Delete([]byte(username)); err != nil {
+				ks.log.Error("Keystore: finishing the re-encryption of the data of %s failed due to %s", username, err)
+				return
+			}
+			keys.remove(prevKeyID)
+		}
+		ks.log.Info("Keystore: re-encrypted %d values of %s", rotated, username)
+	}()
+}
+
+// rotateAll re-encrypts the data of every user with the current key of the
+// provider in the background
+func (ks *Keystore) rotateAll() error {
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	it := ks.userDB.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		username := string(it.Key())
+		db, err := ks.dataDB(username, "")
+		if err != nil {
+			return err
+		}
+		ks.rotate(username, db, 0)
+	}
+	return it.Error()
+}
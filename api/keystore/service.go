@@ -23,6 +23,7 @@ import (
 	"github.com/liraxapp/avalanchego/utils/formatting"
 	"github.com/liraxapp/avalanchego/utils/logging"
 	"github.com/liraxapp/avalanchego/utils/password"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
 
 	jsoncodec "github.com/liraxapp/avalanchego/utils/json"
 )
@@ -67,6 +68,14 @@ type Keystore struct {
 	// Used to persist users and their data
 	userDB database.Database
 	bcDB   database.Database
+	// Key: username
+	// Value: The ID of the key derived from the user's current password
+	keyIDDB database.Database
+	// Key: username
+	// Value: The ID and the key of the user's previous password, encrypted
+	// with the key of the current password. Only present until the user's
+	// data has been re-encrypted with the key of the current password.
+	rotationDB database.Database
 	//           BaseDB
 	//          /      \
 	//    UserDB        BlockchainDB
@@ -74,10 +83,37 @@ type Keystore struct {
 	//               Usr     Usr    Usr
 	//             /  |  \
 	//          BID  BID  BID
+
+	// provider supplies the keys that the users' data is encrypted with. If
+	// nil, the keys are derived from the users' passwords.
+	provider encdb.KeyProvider
+
+	// Key: username
+	// Value: The database the user's data is encrypted in. Is populated the
+	// first time the user's data is accessed, since the keys may be derived
+	// from the user's password, and is kept so that the user's data is
+	// re-encrypted and written through the same database.
+	dataDBs map[string]*encdb.Database
+	// Key: username
+	// Value: The keys derived from the user's passwords, if [provider] is nil
+	passwordKeys map[string]*passwordKeys
+	// Set of users whose data is being re-encrypted
+	rotating map[string]bool
+	// Tracks the routines re-encrypting the users' data
+	rotations sync.WaitGroup
 }
 
-// Initialize the keystore
+// Initialize the keystore. The users' data is encrypted with keys derived from
+// their passwords.
 func (ks *Keystore) Initialize(log logging.Logger, db database.Database) error {
+	return ks.InitializeWithKeyProvider(log, db, nil)
+}
+
+// InitializeWithKeyProvider initializes the keystore. If [provider] isn't nil,
+// the users' data is encrypted with the keys it supplies rather than with keys
+// derived from their passwords, and any data that isn't encrypted with its
+// current key is re-encrypted in the background.
+func (ks *Keystore) InitializeWithKeyProvider(log logging.Logger, db database.Database, provider encdb.KeyProvider) error {
 	c := codec.New(codec.DefaultTagName, maxSliceLength)
 	manager := codec.NewManager(maxPackerSize)
 	if err := manager.RegisterCodec(codecVersion, c); err != nil {
@@ -89,7 +125,16 @@ func (ks *Keystore) Initialize(log logging.Logger, db database.Database) error {
 	ks.users = make(map[string]*password.Hash)
 	ks.userDB = prefixdb.New([]byte("users"), db)
 	ks.bcDB = prefixdb.New([]byte("bcs"), db)
-	return nil
+	ks.keyIDDB = prefixdb.New([]byte("keyIDs"), db)
+	ks.rotationDB = prefixdb.New([]byte("rotations"), db)
+	ks.provider = provider
+	ks.dataDBs = make(map[string]*encdb.Database)
+	ks.passwordKeys = make(map[string]*passwordKeys)
+	ks.rotating = make(map[string]bool)
+	if provider == nil {
+		return nil
+	}
+	return ks.rotateAll()
 }
 
 // CreateHandler returns a new service object that can send requests to thisAPI.
@@ -189,6 +234,14 @@ func (ks *Keystore) ExportUser(_ *http.Request, args *ExportUserArgs, reply *Exp
 	if !user.Check(args.Password) {
 		return fmt.Errorf("incorrect password for user %q", args.Username)
 	}
+	// Values that haven't been re-encrypted yet couldn't be decrypted once
+	// imported
+	if _, err := ks.dataDB(args.Username, args.Password); err != nil {
+		return err
+	}
+	if ks.rotating[args.Username] {
+		return errRotating
+	}
 
 	userDB := prefixdb.New([]byte(args.Username), ks.bcDB)
 
@@ -273,13 +326,32 @@ func (ks *Keystore) ImportUser(r *http.Request, args *ImportUserArgs, reply *api
 
 	userDataDB := prefixdb.New([]byte(args.Username), ks.bcDB)
 	dataBatch := userDataDB.NewBatch()
+	keyID := uint32(0)
 	for _, kvp := range userData.Data {
 		if err := dataBatch.Put(kvp.Key, kvp.Value); err != nil {
 			return fmt.Errorf("error on database put: %w", err)
 		}
+		valueKeyID, err := encdb.KeyID(kvp.Value)
+		if err != nil {
+			return err
+		}
+		if valueKeyID > keyID {
+			keyID = valueKeyID
+		}
 	}
 
-	if err := atomic.WriteAll(dataBatch, userBatch); err != nil {
+	// The imported data was encrypted with the key of the exported user's
+	// current password
+	keyIDBatch := ks.keyIDDB.NewBatch()
+	if keyID != 0 {
+		p := wrappers.Packer{MaxSize: wrappers.IntLen}
+		p.PackInt(keyID)
+		if err := keyIDBatch.Put([]byte(args.Username), p.Bytes); err != nil {
+			return err
+		}
+	}
+
+	if err := atomic.WriteAll(dataBatch, userBatch, keyIDBatch); err != nil {
 		return err
 	}
 
@@ -308,12 +380,22 @@ func (ks *Keystore) DeleteUser(_ *http.Request, args *api.UserPass, reply *api.S
 	case !usr.Check(args.Password):
 		return fmt.Errorf("incorrect password for user %q", args.Username)
 	}
+	if _, err := ks.dataDB(args.Username, args.Password); err != nil {
+		return err
+	}
+	if ks.rotating[args.Username] {
+		return errRotating
+	}
 
 	userNameBytes := []byte(args.Username)
 	userBatch := ks.userDB.NewBatch()
 	if err := userBatch.Delete(userNameBytes); err != nil {
 		return err
 	}
+	keyIDBatch := ks.keyIDDB.NewBatch()
+	if err := keyIDBatch.Delete(userNameBytes); err != nil {
+		return err
+	}
 
 	userDataDB := prefixdb.New(userNameBytes, ks.bcDB)
 	dataBatch := userDataDB.NewBatch()
@@ -331,12 +413,121 @@ func (ks *Keystore) DeleteUser(_ *http.Request, args *api.UserPass, reply *api.S
 		return err
 	}
 
-	if err := atomic.WriteAll(dataBatch, userBatch); err != nil {
+	if err := atomic.WriteAll(dataBatch, userBatch, keyIDBatch); err != nil {
 		return err
 	}
 
 	// delete from users map.
 	delete(ks.users, args.Username)
+	delete(ks.dataDBs, args.Username)
+	delete(ks.passwordKeys, args.Username)
+
+	reply.Success = true
+	return nil
+}
+
+// ChangePasswordArgs are the arguments to ChangePassword
+type ChangePasswordArgs struct {
+	// The username and current password
+	api.UserPass
+	// The new password
+	NewPassword string `json:"newPassword"`
+}
+
+// ChangePassword changes the password of a user. If the user's data is
+// encrypted with a key derived from the password, it's re-encrypted with the
+// key derived from the new password in the background. Until then, the user
+// can't be exported or deleted, and the password can't be changed again.
+func (ks *Keystore) ChangePassword(_ *http.Request, args *ChangePasswordArgs, reply *api.SuccessResponse) error {
+	ks.log.Info("Keystore: ChangePassword called for %s", args.Username)
+
+	ks.lock.Lock()
+	defer ks.lock.Unlock()
+
+	usr, err := ks.getUser(args.Username)
+	switch {
+	case err != nil || usr == nil:
+		return fmt.Errorf("user doesn't exist: %s", args.Username)
+	case !usr.Check(args.Password):
+		return fmt.Errorf("incorrect password for user %q", args.Username)
+	}
+	if err := password.IsValid(args.NewPassword, password.OK); err != nil {
+		return err
+	}
+
+	db, err := ks.dataDB(args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	if ks.rotating[args.Username] {
+		return errRotating
+	}
+
+	newUsr := &password.Hash{}
+	if err := newUsr.Set(args.NewPassword); err != nil {
+		return err
+	}
+	usrBytes, err := ks.codec.Marshal(codecVersion, newUsr)
+	if err != nil {
+		return err
+	}
+
+	userNameBytes := []byte(args.Username)
+	userBatch := ks.userDB.NewBatch()
+	if err := userBatch.Put(userNameBytes, usrBytes); err != nil {
+		return err
+	}
+
+	if ks.provider != nil {
+		// The user's data isn't encrypted with a key derived from the password
+		if err := userBatch.Write(); err != nil {
+			return err
+		}
+		ks.users[args.Username] = newUsr
+		reply.Success = true
+		return nil
+	}
+
+	keys := ks.passwordKeys[args.Username]
+	prevKeyID, err := keys.CurrentKeyID()
+	if err != nil {
+		return err
+	}
+	prevKey, err := keys.Key(prevKeyID)
+	if err != nil {
+		return err
+	}
+	keyID := prevKeyID + 1
+	key := encdb.PasswordKey([]byte(args.NewPassword))
+
+	keyIDBatch := ks.keyIDDB.NewBatch()
+	p := wrappers.Packer{MaxSize: wrappers.IntLen}
+	p.PackInt(keyID)
+	if err := keyIDBatch.Put(userNameBytes, p.Bytes); err != nil {
+		return err
+	}
+
+	// Keep the previous key, so that the data that hasn't been re-encrypted
+	// yet can still be read if the node restarts
+	rotationDB, err := encdb.NewWithKeyProvider(encdb.Keys{keyID: key}, ks.rotationDB)
+	if err != nil {
+		return err
+	}
+	rotationBatch := rotationDB.NewBatch()
+	p = wrappers.Packer{MaxSize: wrappers.IntLen + wrappers.IntLen + len(prevKey)}
+	p.PackInt(prevKeyID)
+	p.PackBytes(prevKey)
+	if err := rotationBatch.Put(userNameBytes, p.Bytes); err != nil {
+		return err
+	}
+
+	if err := atomic.WriteAll(userBatch, keyIDBatch, rotationBatch); err != nil {
+		return err
+	}
+
+	ks.users[args.Username] = newUsr
+	keys.add(keyID, key)
+	ks.rotate(args.Username, db, prevKeyID)
 
 	reply.Success = true
 	return nil
@@ -365,9 +556,11 @@ func (ks *Keystore) GetDatabase(bID ids.ID, username, password string) (database
 		return nil, fmt.Errorf("incorrect password for user %q", username)
 	}
 
-	userDB := prefixdb.New([]byte(username), ks.bcDB)
-	bcDB := prefixdb.NewNested(bID[:], userDB)
-	return encdb.New([]byte(password), bcDB)
+	userDB, err := ks.dataDB(username, password)
+	if err != nil {
+		return nil, err
+	}
+	return prefixdb.NewNested(bID[:], userDB), nil
 }
 
 // AddUser attempts to register this username and password as a new user of the
@@ -11,8 +11,11 @@ import (
 	"testing"
 
 	"github.com/liraxapp/avalanchego/api"
+	"github.com/liraxapp/avalanchego/database/encdb"
+	"github.com/liraxapp/avalanchego/database/memdb"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/utils/formatting"
+	"github.com/liraxapp/avalanchego/utils/logging"
 )
 
 var (
@@ -423,3 +426,123 @@ func TestServiceDeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceChangePassword(t *testing.T) {
+	db := memdb.New()
+	ks := &Keystore{}
+	if err := ks.Initialize(logging.NoLog{}, db); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.CreateUser(nil, &api.UserPass{Username: "bob", Password: strongPassword}, &api.SuccessResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	if userDB, err := ks.GetDatabase(ids.Empty, "bob", strongPassword); err != nil {
+		t.Fatal(err)
+	} else if err := userDB.Put([]byte("hello"), []byte("world")); err != nil {
+		t.Fatal(err)
+	}
+
+	newPassword := strongPassword + "!"
+	if err := ks.ChangePassword(nil, &ChangePasswordArgs{
+		UserPass:    api.UserPass{Username: "bob", Password: "wrong"},
+		NewPassword: newPassword,
+	}, &api.SuccessResponse{}); err == nil {
+		t.Fatalf("Should have errored due to the wrong password")
+	}
+	reply := api.SuccessResponse{}
+	if err := ks.ChangePassword(nil, &ChangePasswordArgs{
+		UserPass:    api.UserPass{Username: "bob", Password: strongPassword},
+		NewPassword: newPassword,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if !reply.Success {
+		t.Fatalf("Password should have been changed")
+	}
+	ks.rotations.Wait()
+
+	if _, err := ks.GetDatabase(ids.Empty, "bob", strongPassword); err == nil {
+		t.Fatalf("Should have errored due to the old password")
+	}
+	if has, err := ks.rotationDB.Has([]byte("bob")); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatalf("The previous key should have been forgotten")
+	}
+
+	// The data can be read with the new password after a restart, and from
+	// another node that the user is exported to
+	restartedKS := &Keystore{}
+	if err := restartedKS.Initialize(logging.NoLog{}, db); err != nil {
+		t.Fatal(err)
+	}
+	exportReply := ExportUserReply{}
+	if err := restartedKS.ExportUser(nil, &ExportUserArgs{
+		UserPass: api.UserPass{Username: "bob", Password: newPassword},
+		Encoding: formatting.Hex,
+	}, &exportReply); err != nil {
+		t.Fatal(err)
+	}
+	importedKS, err := CreateTestKeystore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := importedKS.ImportUser(nil, &ImportUserArgs{
+		UserPass: api.UserPass{Username: "bob", Password: newPassword},
+		User:     exportReply.User,
+		Encoding: exportReply.Encoding,
+	}, &api.SuccessResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, ks := range []*Keystore{restartedKS, importedKS} {
+		userDB, err := ks.GetDatabase(ids.Empty, "bob", newPassword)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val, err := userDB.Get([]byte("hello")); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(val, []byte("world")) {
+			t.Fatalf("Should have read '%s' from the db", "world")
+		}
+	}
+}
+
+func TestServiceKeyProvider(t *testing.T) {
+	db := memdb.New()
+	key0 := bytes.Repeat([]byte{0}, 32)
+	key1 := bytes.Repeat([]byte{1}, 32)
+
+	ks := &Keystore{}
+	if err := ks.InitializeWithKeyProvider(logging.NoLog{}, db, encdb.Keys{0: key0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.CreateUser(nil, &api.UserPass{Username: "bob", Password: strongPassword}, &api.SuccessResponse{}); err != nil {
+		t.Fatal(err)
+	}
+	if userDB, err := ks.GetDatabase(ids.Empty, "bob", strongPassword); err != nil {
+		t.Fatal(err)
+	} else if err := userDB.Put([]byte("hello"), []byte("world")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Adding a key re-encrypts the existing data after a restart
+	rotatedKS := &Keystore{}
+	if err := rotatedKS.InitializeWithKeyProvider(logging.NoLog{}, db, encdb.Keys{0: key0, 1: key1}); err != nil {
+		t.Fatal(err)
+	}
+	rotatedKS.rotations.Wait()
+
+	newKS := &Keystore{}
+	if err := newKS.InitializeWithKeyProvider(logging.NoLog{}, db, encdb.Keys{1: key1}); err != nil {
+		t.Fatal(err)
+	}
+	newKS.rotations.Wait()
+	userDB, err := newKS.GetDatabase(ids.Empty, "bob", strongPassword)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val, err := userDB.Get([]byte("hello")); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(val, []byte("world")) {
+		t.Fatalf("Should have read '%s' from the db", "world")
+	}
+}
@@ -0,0 +1,102 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"sync"
+)
+
+// Budget is a number of bytes that is shared by a tree of sized caches. A
+// budget is split between its children in proportion to their weights, and is
+// re-split whenever a child is added. For example, the node's budget is split
+// evenly between its chains, and each chain's budget is split between the
+// caches of the chain.
+//
+// A nil *Budget is valid and doesn't constrain the caches added to it.
+type Budget struct {
+	// lock is shared by every budget in the tree
+	lock *sync.Mutex
+
+	weight   int
+	bytes    int
+	children []*Budget
+	cache    *SizedLRU
+}
+
+// NewBudget returns a new budget of [bytes]
+func NewBudget(bytes int) *Budget {
+	return &Budget{
+		lock:   &sync.Mutex{},
+		weight: 1,
+		bytes:  bytes,
+	}
+}
+
+// Split returns a new budget that is given a share of this budget proportional
+// to [weight]
+func (b *Budget) Split(weight int) *Budget {
+	if b == nil {
+		return nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.split(weight)
+}
+
+// Track bounds the size of [c] to a share of this budget proportional to
+// [weight]
+func (b *Budget) Track(weight int, c *SizedLRU) {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	child := b.split(weight)
+	child.cache = c
+	c.setMaxSize(child.bytes)
+}
+
+// Bytes returns the number of bytes currently given to this budget
+func (b *Budget) Bytes() int {
+	if b == nil {
+		return 0
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.bytes
+}
+
+func (b *Budget) split(weight int) *Budget {
+	if weight <= 0 {
+		weight = 1
+	}
+	child := &Budget{
+		lock:   b.lock,
+		weight: weight,
+	}
+	b.children = append(b.children, child)
+	b.rebalance()
+	return child
+}
+
+// rebalance splits this budget between its children. Assumes the lock is held.
+func (b *Budget) rebalance() {
+	totalWeight := 0
+	for _, child := range b.children {
+		totalWeight += child.weight
+	}
+	for _, child := range b.children {
+		child.bytes = int(int64(b.bytes) * int64(child.weight) / int64(totalWeight))
+		if child.cache != nil {
+			child.cache.setMaxSize(child.bytes)
+		}
+		child.rebalance()
+	}
+}
@@ -0,0 +1,51 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/liraxapp/avalanchego/ids"
+)
+
+func TestBudget(t *testing.T) {
+	budget := NewBudget(8 * EntryOverhead)
+
+	chain1 := budget.Split(1)
+	if bytes := chain1.Bytes(); bytes != 8*EntryOverhead {
+		t.Fatalf("Wrong budget: Returned: %d ; Expected: %d", bytes, 8*EntryOverhead)
+	}
+
+	cache1 := &SizedLRU{}
+	chain1.Track(3, cache1)
+	cache2 := &SizedLRU{}
+	chain1.Track(1, cache2)
+	if cache1.MaxSize != 6*EntryOverhead || cache2.MaxSize != 2*EntryOverhead {
+		t.Fatalf("Wrong split: %d and %d", cache1.MaxSize, cache2.MaxSize)
+	}
+
+	for i := byte(0); i < 6; i++ {
+		cache1.Put(ids.ID{i}, nil)
+	}
+
+	// Adding a second chain halves the budget of the first
+	chain2 := budget.Split(1)
+	if bytes := chain2.Bytes(); bytes != 4*EntryOverhead {
+		t.Fatalf("Wrong budget: Returned: %d ; Expected: %d", bytes, 4*EntryOverhead)
+	} else if cache1.MaxSize != 3*EntryOverhead {
+		t.Fatalf("Wrong budget: Returned: %d ; Expected: %d", cache1.MaxSize, 3*EntryOverhead)
+	} else if cache1.Len() != 3 {
+		t.Fatalf("Shrinking the budget should have evicted elements")
+	}
+}
+
+func TestNilBudget(t *testing.T) {
+	budget := (*Budget)(nil)
+
+	cache := &SizedLRU{MaxSize: EntryOverhead}
+	budget.Split(1).Track(1, cache)
+	if cache.MaxSize != EntryOverhead {
+		t.Fatalf("A nil budget shouldn't change the size of a cache")
+	}
+}
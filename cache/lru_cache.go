@@ -27,6 +27,9 @@ type LRU struct {
 	entryMap  map[ids.ID]*list.Element
 	entryList *list.List
 	Size      int
+
+	// number of elements removed to make space for others
+	capacityEvictions int
 }
 
 // Put implements the cache interface
@@ -61,6 +64,25 @@ func (c *LRU) Flush() {
 	c.flush()
 }
 
+// Len returns the number of elements in the cache
+func (c *LRU) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.init()
+	c.resize()
+	return c.entryList.Len()
+}
+
+// CapacityEvictions returns the number of elements that were removed from the
+// cache to make space for others
+func (c *LRU) CapacityEvictions() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.capacityEvictions
+}
+
 func (c *LRU) init() {
 	if c.entryMap == nil {
 		c.entryMap = make(map[ids.ID]*list.Element, minCacheSize)
@@ -80,6 +102,7 @@ func (c *LRU) resize() {
 
 		val := e.Value.(*entry)
 		delete(c.entryMap, val.Key)
+		c.capacityEvictions++
 	}
 }
 
@@ -94,6 +117,7 @@ func (c *LRU) put(key ids.ID, value interface{}) {
 
 			val := e.Value.(*entry)
 			delete(c.entryMap, val.Key)
+			c.capacityEvictions++
 			val.Key = key
 			val.Value = value
 		} else {
@@ -0,0 +1,130 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metercacher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liraxapp/avalanchego/cache"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils/timer"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+var (
+	_ cache.Cacher       = &Cache{}
+	_ cache.Deduplicator = &Deduplicator{}
+)
+
+// Cache tracks the hit rate of a cache and the amount of time each operation
+// takes
+type Cache struct {
+	cacheMetrics
+	cache cache.Cacher
+	clock timer.Clock
+}
+
+// New returns a new cache that reports metrics prefixed with [name]. If
+// [cache] reports its length, size or capacity evictions, those are reported
+// as well.
+func New(
+	namespace,
+	name string,
+	registerer prometheus.Registerer,
+	cache cache.Cacher,
+) (*Cache, error) {
+	meterCache := &Cache{cache: cache}
+	errs := wrappers.Errs{}
+	errs.Add(
+		meterCache.cacheMetrics.Initialize(namespace, name, registerer),
+		registerSizeMetrics(namespace, name, registerer, cache),
+	)
+	return meterCache, errs.Err
+}
+
+// Put implements the cache.Cacher interface
+func (c *Cache) Put(key ids.ID, value interface{}) {
+	start := c.clock.Time()
+	c.cache.Put(key, value)
+	end := c.clock.Time()
+	c.put.Observe(float64(end.Sub(start)))
+}
+
+// Get implements the cache.Cacher interface
+func (c *Cache) Get(key ids.ID) (interface{}, bool) {
+	start := c.clock.Time()
+	value, has := c.cache.Get(key)
+	end := c.clock.Time()
+	c.get.Observe(float64(end.Sub(start)))
+	if has {
+		c.hits.Inc()
+	} else {
+		c.misses.Inc()
+	}
+	return value, has
+}
+
+// Evict implements the cache.Cacher interface
+func (c *Cache) Evict(key ids.ID) {
+	start := c.clock.Time()
+	c.cache.Evict(key)
+	end := c.clock.Time()
+	c.evict.Observe(float64(end.Sub(start)))
+}
+
+// Flush implements the cache.Cacher interface
+func (c *Cache) Flush() {
+	start := c.clock.Time()
+	c.cache.Flush()
+	end := c.clock.Time()
+	c.flush.Observe(float64(end.Sub(start)))
+}
+
+// Deduplicator tracks the hit rate of a deduplicator and the amount of time
+// each operation takes
+type Deduplicator struct {
+	deduplicatorMetrics
+	deduplicator cache.Deduplicator
+	clock        timer.Clock
+}
+
+// NewDeduplicator returns a new deduplicator that reports metrics prefixed
+// with [name]. If [deduplicator] reports its length or capacity evictions,
+// those are reported as well.
+func NewDeduplicator(
+	namespace,
+	name string,
+	registerer prometheus.Registerer,
+	deduplicator cache.Deduplicator,
+) (*Deduplicator, error) {
+	meterDeduplicator := &Deduplicator{deduplicator: deduplicator}
+	errs := wrappers.Errs{}
+	errs.Add(
+		meterDeduplicator.deduplicatorMetrics.Initialize(namespace, name, registerer),
+		registerSizeMetrics(namespace, name, registerer, deduplicator),
+	)
+	return meterDeduplicator, errs.Err
+}
+
+// Deduplicate implements the cache.Deduplicator interface
+func (d *Deduplicator) Deduplicate(value cache.Evictable) cache.Evictable {
+	start := d.clock.Time()
+	result := d.deduplicator.Deduplicate(value)
+	end := d.clock.Time()
+	d.deduplicate.Observe(float64(end.Sub(start)))
+	if result != value {
+		d.hits.Inc()
+	} else {
+		d.misses.Inc()
+	}
+	return result
+}
+
+// Flush implements the cache.Deduplicator interface
+func (d *Deduplicator) Flush() {
+	start := d.clock.Time()
+	d.deduplicator.Flush()
+	end := d.clock.Time()
+	d.flush.Observe(float64(end.Sub(start)))
+}
@@ -0,0 +1,174 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metercacher
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/liraxapp/avalanchego/cache"
+	"github.com/liraxapp/avalanchego/ids"
+)
+
+type evictable struct {
+	id      ids.ID
+	evicted int
+}
+
+func (e *evictable) ID() ids.ID { return e.id }
+func (e *evictable) Evict()     { e.evicted++ }
+
+func TestCache(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c, err := New("", "test", registry, &cache.LRU{Size: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id1 := ids.ID{1}
+	if _, found := c.Get(id1); found {
+		t.Fatalf("Retrieved value when none exists")
+	}
+
+	c.Put(id1, 1)
+	if value, found := c.Get(id1); !found {
+		t.Fatalf("Failed to retrieve value when one exists")
+	} else if value != 1 {
+		t.Fatalf("Failed to retrieve correct value when one exists")
+	}
+
+	c.Evict(id1)
+	if _, found := c.Get(id1); found {
+		t.Fatalf("Retrieved value after it was evicted")
+	}
+
+	if hits := testutil.ToFloat64(c.hits); hits != 1 {
+		t.Fatalf("Wrong number of hits: Returned: %v ; Expected: 1", hits)
+	} else if misses := testutil.ToFloat64(c.misses); misses != 2 {
+		t.Fatalf("Wrong number of misses: Returned: %v ; Expected: 2", misses)
+	}
+
+	metrics, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]uint64)
+	for _, metric := range metrics {
+		if histogram := metric.GetMetric()[0].GetHistogram(); histogram != nil {
+			counts[metric.GetName()] = histogram.GetSampleCount()
+		}
+	}
+	if count := counts["test_get"]; count != 3 {
+		t.Fatalf("Wrong number of gets: Returned: %d ; Expected: 3", count)
+	} else if count := counts["test_put"]; count != 1 {
+		t.Fatalf("Wrong number of puts: Returned: %d ; Expected: 1", count)
+	} else if count := counts["test_evict"]; count != 1 {
+		t.Fatalf("Wrong number of evictions: Returned: %d ; Expected: 1", count)
+	}
+}
+
+func TestCacheSizeMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	sizedCache := &cache.SizedLRU{MaxSize: 10 * cache.EntryOverhead, Size: cache.BytesSize}
+	c, err := New("", "test", registry, sizedCache)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Put(ids.ID{1}, []byte{1, 2, 3})
+	c.Put(ids.ID{2}, []byte{1})
+
+	metrics, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gauges := make(map[string]float64)
+	for _, metric := range metrics {
+		if gauge := metric.GetMetric()[0].GetGauge(); gauge != nil {
+			gauges[metric.GetName()] = gauge.GetValue()
+		}
+	}
+	if length := gauges["test_len"]; length != 2 {
+		t.Fatalf("Wrong length: Returned: %v ; Expected: 2", length)
+	} else if size := gauges["test_size_bytes"]; size != 2*cache.EntryOverhead+4 {
+		t.Fatalf("Wrong size: Returned: %v ; Expected: %d", size, 2*cache.EntryOverhead+4)
+	}
+}
+
+func TestCacheCapacityEvictions(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c, err := New("", "test", registry, &cache.LRU{Size: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := NewDeduplicator("", "test_dedup", registry, &cache.EvictableLRU{Size: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Put(ids.ID{1}, 1)
+	c.Put(ids.ID{2}, 2)
+	c.Put(ids.ID{3}, 3)
+	c.Evict(ids.ID{3})
+	c.Flush()
+	d.Deduplicate(&evictable{id: ids.ID{1}})
+	d.Deduplicate(&evictable{id: ids.ID{2}})
+	d.Flush()
+
+	metrics, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counters := make(map[string]float64)
+	for _, metric := range metrics {
+		if counter := metric.GetMetric()[0].GetCounter(); counter != nil {
+			counters[metric.GetName()] = counter.GetValue()
+		}
+	}
+	if evictions := counters["test_capacity_evictions"]; evictions != 2 {
+		t.Fatalf("Wrong number of capacity evictions: Returned: %v ; Expected: 2", evictions)
+	} else if evictions := counters["test_dedup_capacity_evictions"]; evictions != 1 {
+		t.Fatalf("Wrong number of capacity evictions: Returned: %v ; Expected: 1", evictions)
+	}
+}
+
+func TestDeduplicator(t *testing.T) {
+	d, err := NewDeduplicator("", "test", prometheus.NewRegistry(), &cache.EvictableLRU{Size: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value1 := &evictable{id: ids.ID{1}}
+	if returnedValue := d.Deduplicate(value1); returnedValue != value1 {
+		t.Fatalf("Returned unknown value")
+	}
+	duplicate1 := &evictable{id: ids.ID{1}}
+	if returnedValue := d.Deduplicate(duplicate1); returnedValue != value1 {
+		t.Fatalf("Returned unknown value")
+	}
+	value2 := &evictable{id: ids.ID{2}}
+	if returnedValue := d.Deduplicate(value2); returnedValue != value2 {
+		t.Fatalf("Returned unknown value")
+	} else if value1.evicted != 1 {
+		t.Fatalf("Value should have been evicted")
+	}
+
+	if hits := testutil.ToFloat64(d.hits); hits != 1 {
+		t.Fatalf("Wrong number of hits: Returned: %v ; Expected: 1", hits)
+	} else if misses := testutil.ToFloat64(d.misses); misses != 2 {
+		t.Fatalf("Wrong number of misses: Returned: %v ; Expected: 2", misses)
+	}
+}
+
+func TestDuplicateRegistration(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	if _, err := New("", "test", registry, &cache.LRU{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New("", "test", registry, &cache.LRU{}); err == nil {
+		t.Fatalf("Should have errored registering the same metrics twice")
+	}
+}
@@ -0,0 +1,151 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package metercacher
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liraxapp/avalanchego/utils/timer"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+func newLatencyMetric(namespace, name, method string) prometheus.Histogram {
+	return prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_%s", name, method),
+		Help:      fmt.Sprintf("Latency of a %s call in nanoseconds", method),
+		Buckets:   timer.NanosecondsBuckets,
+	})
+}
+
+func newCounterMetric(namespace, name, suffix, help string) prometheus.Counter {
+	return prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_%s", name, suffix),
+		Help:      help,
+	})
+}
+
+// lener is implemented by caches that can report how many elements they hold
+type lener interface {
+	Len() int
+}
+
+// sizer is implemented by caches that can report how many bytes they hold
+type sizer interface {
+	CurrentSize() int
+}
+
+// evictioner is implemented by caches that can report how many elements they
+// removed to make space for others
+type evictioner interface {
+	CapacityEvictions() int
+}
+
+// registerSizeMetrics registers gauges reporting the number of elements and
+// bytes held by [c], and a counter of the elements [c] removed to make space
+// for others, if [c] exposes them
+func registerSizeMetrics(
+	namespace,
+	name string,
+	registerer prometheus.Registerer,
+	c interface{},
+) error {
+	errs := wrappers.Errs{}
+	if l, ok := c.(lener); ok {
+		errs.Add(registerer.Register(prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_len", name),
+				Help:      "Number of elements in the cache",
+			},
+			func() float64 { return float64(l.Len()) },
+		)))
+	}
+	if s, ok := c.(sizer); ok {
+		errs.Add(registerer.Register(prometheus.NewGaugeFunc(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_size_bytes", name),
+				Help:      "Number of bytes used by the elements in the cache",
+			},
+			func() float64 { return float64(s.CurrentSize()) },
+		)))
+	}
+	if e, ok := c.(evictioner); ok {
+		errs.Add(registerer.Register(prometheus.NewCounterFunc(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      fmt.Sprintf("%s_capacity_evictions", name),
+				Help:      "Number of elements removed from the cache to make space for others",
+			},
+			func() float64 { return float64(e.CapacityEvictions()) },
+		)))
+	}
+	return errs.Err
+}
+
+type cacheMetrics struct {
+	get,
+	put,
+	evict,
+	flush prometheus.Histogram
+
+	hits,
+	misses prometheus.Counter
+}
+
+func (m *cacheMetrics) Initialize(
+	namespace,
+	name string,
+	registerer prometheus.Registerer,
+) error {
+	m.get = newLatencyMetric(namespace, name, "get")
+	m.put = newLatencyMetric(namespace, name, "put")
+	m.evict = newLatencyMetric(namespace, name, "evict")
+	m.flush = newLatencyMetric(namespace, name, "flush")
+	m.hits = newCounterMetric(namespace, name, "hits", "Number of cache lookups that found the element")
+	m.misses = newCounterMetric(namespace, name, "misses", "Number of cache lookups that didn't find the element")
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.get),
+		registerer.Register(m.put),
+		registerer.Register(m.evict),
+		registerer.Register(m.flush),
+		registerer.Register(m.hits),
+		registerer.Register(m.misses),
+	)
+	return errs.Err
+}
+
+type deduplicatorMetrics struct {
+	deduplicate,
+	flush prometheus.Histogram
+
+	hits,
+	misses prometheus.Counter
+}
+
+func (m *deduplicatorMetrics) Initialize(
+	namespace,
+	name string,
+	registerer prometheus.Registerer,
+) error {
+	m.deduplicate = newLatencyMetric(namespace, name, "deduplicate")
+	m.flush = newLatencyMetric(namespace, name, "flush")
+	m.hits = newCounterMetric(namespace, name, "hits", "Number of deduplications that returned a previously provided value")
+	m.misses = newCounterMetric(namespace, name, "misses", "Number of deduplications that returned the provided value")
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.deduplicate),
+		registerer.Register(m.flush),
+		registerer.Register(m.hits),
+		registerer.Register(m.misses),
+	)
+	return errs.Err
+}
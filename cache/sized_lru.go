@@ -0,0 +1,297 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"container/list"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+const (
+	// EntryOverhead is an estimate of the number of bytes used by the key and
+	// the bookkeeping of every entry in a SizedLRU
+	EntryOverhead = 128
+)
+
+// BytesSize returns the size of [value] if it is a byte slice, exposes its
+// serialized form through a Bytes method, or is fixed-size data such as an ID,
+// a slice of IDs or an integer. Otherwise it returns 0, so callers caching
+// other values should wrap it with a size function that knows their types.
+func BytesSize(value interface{}) int {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case []byte:
+		return len(v)
+	case interface{ Bytes() []byte }:
+		return len(v.Bytes())
+	}
+	if size := binary.Size(value); size > 0 {
+		return size
+	}
+	return 0
+}
+
+type sizedEntry struct {
+	Key   ids.ID
+	Value interface{}
+	Size  int
+}
+
+// SizedLRU is a key value store bounded by the number of bytes its elements
+// use. If the bound is attempted to be exceeded, then the least recently used
+// elements are removed from the cache until the insertion fits.
+//
+// The size of an element is [EntryOverhead] plus the result of [Size] on the
+// element's value. If [Size] is nil, every value is assumed to be empty.
+type SizedLRU struct {
+	lock        sync.Mutex
+	entryMap    map[ids.ID]*list.Element
+	entryList   *list.List
+	currentSize int
+
+	MaxSize int
+	Size    func(value interface{}) int
+
+	// number of elements removed to make space for others
+	capacityEvictions int
+
+	metrics *sizedLRUMetrics
+}
+
+// NewSizedLRU returns a new SizedLRU that reports its hits, misses, evictions
+// and size as metrics prefixed with [name]. As it reports its own metrics, it
+// shouldn't also be wrapped in a metercacher of the same name.
+func NewSizedLRU(
+	namespace,
+	name string,
+	registerer prometheus.Registerer,
+	maxSize int,
+	size func(value interface{}) int,
+) (*SizedLRU, error) {
+	metrics := &sizedLRUMetrics{}
+	return &SizedLRU{
+		MaxSize: maxSize,
+		Size:    size,
+		metrics: metrics,
+	}, metrics.Initialize(namespace, name, registerer)
+}
+
+// Put implements the cache interface
+func (c *SizedLRU) Put(key ids.ID, value interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value)
+}
+
+// Get implements the cache interface
+func (c *SizedLRU) Get(key ids.ID) (interface{}, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+// Evict implements the cache interface
+func (c *SizedLRU) Evict(key ids.ID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(key)
+}
+
+// Flush implements the cache interface
+func (c *SizedLRU) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.flush()
+}
+
+// Len returns the number of elements in the cache
+func (c *SizedLRU) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.init()
+	return c.entryList.Len()
+}
+
+// CurrentSize returns the number of bytes used by the elements in the cache
+func (c *SizedLRU) CurrentSize() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.currentSize
+}
+
+// CapacityEvictions returns the number of elements that were removed from the
+// cache to make space for others
+func (c *SizedLRU) CapacityEvictions() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.capacityEvictions
+}
+
+// setMaxSize changes the bound of the cache, evicting elements if needed
+func (c *SizedLRU) setMaxSize(maxSize int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.MaxSize = maxSize
+	c.init()
+	c.resize(0)
+}
+
+func (c *SizedLRU) init() {
+	if c.entryMap == nil {
+		c.entryMap = make(map[ids.ID]*list.Element, minCacheSize)
+	}
+	if c.entryList == nil {
+		c.entryList = list.New()
+	}
+}
+
+// resize evicts the least recently used elements until [extra] more bytes can
+// be added to the cache
+func (c *SizedLRU) resize(extra int) {
+	for c.entryList.Len() > 0 && c.currentSize+extra > c.MaxSize {
+		c.remove(c.entryList.Front())
+		c.capacityEvictions++
+		if c.metrics != nil {
+			c.metrics.evictions.Inc()
+		}
+	}
+}
+
+func (c *SizedLRU) remove(e *list.Element) {
+	c.entryList.Remove(e)
+
+	val := e.Value.(*sizedEntry)
+	delete(c.entryMap, val.Key)
+	c.currentSize -= val.Size
+	c.updateSizeMetrics()
+}
+
+func (c *SizedLRU) put(key ids.ID, value interface{}) {
+	c.init()
+
+	if e, ok := c.entryMap[key]; ok {
+		c.remove(e)
+	}
+
+	size := EntryOverhead
+	if c.Size != nil {
+		size += c.Size(value)
+	}
+	if size > c.MaxSize {
+		// The element would never fit in the cache
+		return
+	}
+	c.resize(size)
+
+	c.entryMap[key] = c.entryList.PushBack(&sizedEntry{
+		Key:   key,
+		Value: value,
+		Size:  size,
+	})
+	c.currentSize += size
+	c.updateSizeMetrics()
+}
+
+func (c *SizedLRU) get(key ids.ID) (interface{}, bool) {
+	c.init()
+
+	if e, ok := c.entryMap[key]; ok {
+		c.entryList.MoveToBack(e)
+		if c.metrics != nil {
+			c.metrics.hits.Inc()
+		}
+
+		val := e.Value.(*sizedEntry)
+		return val.Value, true
+	}
+	if c.metrics != nil {
+		c.metrics.misses.Inc()
+	}
+	return struct{}{}, false
+}
+
+func (c *SizedLRU) evict(key ids.ID) {
+	c.init()
+
+	if e, ok := c.entryMap[key]; ok {
+		c.remove(e)
+	}
+}
+
+func (c *SizedLRU) flush() {
+	c.entryMap = make(map[ids.ID]*list.Element, minCacheSize)
+	c.entryList = list.New()
+	c.currentSize = 0
+	c.updateSizeMetrics()
+}
+
+func (c *SizedLRU) updateSizeMetrics() {
+	if c.metrics != nil {
+		c.metrics.len.Set(float64(c.entryList.Len()))
+		c.metrics.size.Set(float64(c.currentSize))
+	}
+}
+
+type sizedLRUMetrics struct {
+	hits, misses, evictions prometheus.Counter
+	len, size               prometheus.Gauge
+}
+
+func (m *sizedLRUMetrics) Initialize(
+	namespace,
+	name string,
+	registerer prometheus.Registerer,
+) error {
+	m.hits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_hits", name),
+		Help:      "Number of cache lookups that found the element",
+	})
+	m.misses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_misses", name),
+		Help:      "Number of cache lookups that didn't find the element",
+	})
+	m.evictions = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_evictions", name),
+		Help:      "Number of elements removed from the cache to make space",
+	})
+	m.len = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_len", name),
+		Help:      "Number of elements in the cache",
+	})
+	m.size = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      fmt.Sprintf("%s_size_bytes", name),
+		Help:      "Number of bytes used by the elements in the cache",
+	})
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.hits),
+		registerer.Register(m.misses),
+		registerer.Register(m.evictions),
+		registerer.Register(m.len),
+		registerer.Register(m.size),
+	)
+	return errs.Err
+}
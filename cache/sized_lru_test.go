@@ -0,0 +1,133 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/liraxapp/avalanchego/ids"
+)
+
+func TestSizedLRU(t *testing.T) {
+	cache := SizedLRU{MaxSize: 2*EntryOverhead + 10, Size: BytesSize}
+
+	id1 := ids.ID{1}
+	if _, found := cache.Get(id1); found {
+		t.Fatalf("Retrieved value when none exists")
+	}
+
+	cache.Put(id1, []byte{1, 2, 3, 4, 5})
+	if value, found := cache.Get(id1); !found {
+		t.Fatalf("Failed to retrieve value when one exists")
+	} else if len(value.([]byte)) != 5 {
+		t.Fatalf("Failed to retrieve correct value when one exists")
+	}
+	if size := cache.CurrentSize(); size != EntryOverhead+5 {
+		t.Fatalf("Wrong size: Returned: %d ; Expected: %d", size, EntryOverhead+5)
+	}
+
+	// Replacing a value updates the size of the entry
+	cache.Put(id1, []byte{1})
+	if size := cache.CurrentSize(); size != EntryOverhead+1 {
+		t.Fatalf("Wrong size: Returned: %d ; Expected: %d", size, EntryOverhead+1)
+	}
+
+	cache.Evict(id1)
+	if _, found := cache.Get(id1); found {
+		t.Fatalf("Retrieved value when none exists")
+	} else if size := cache.CurrentSize(); size != 0 {
+		t.Fatalf("Wrong size: Returned: %d ; Expected: 0", size)
+	}
+}
+
+func TestSizedLRUEviction(t *testing.T) {
+	cache := SizedLRU{MaxSize: 2*EntryOverhead + 10, Size: BytesSize}
+
+	id1 := ids.ID{1}
+	id2 := ids.ID{2}
+	id3 := ids.ID{3}
+
+	cache.Put(id1, make([]byte, 5))
+	cache.Put(id2, make([]byte, 5))
+	cache.Get(id1)
+
+	// Evicts id2, the least recently used value
+	cache.Put(id3, make([]byte, 1))
+	if _, found := cache.Get(id2); found {
+		t.Fatalf("Retrieved value when none exists")
+	}
+	if _, found := cache.Get(id1); !found {
+		t.Fatalf("Failed to retrieve value when one exists")
+	}
+	if _, found := cache.Get(id3); !found {
+		t.Fatalf("Failed to retrieve value when one exists")
+	}
+
+	// A value that needs the whole cache evicts everything else
+	cache.Put(id2, make([]byte, EntryOverhead+10))
+	if cache.Len() != 1 {
+		t.Fatalf("Wrong length: Returned: %d ; Expected: 1", cache.Len())
+	}
+
+	// A value larger than the cache isn't cached
+	cache.Put(id1, make([]byte, 2*EntryOverhead+10))
+	if _, found := cache.Get(id1); found {
+		t.Fatalf("Retrieved value that can't fit in the cache")
+	}
+	if _, found := cache.Get(id2); !found {
+		t.Fatalf("Failed to retrieve value when one exists")
+	}
+
+	cache.Flush()
+	if cache.Len() != 0 || cache.CurrentSize() != 0 {
+		t.Fatalf("Flush should have emptied the cache")
+	}
+}
+
+func TestSizedLRUMetrics(t *testing.T) {
+	cache, err := NewSizedLRU("", "test", prometheus.NewRegistry(), 2*EntryOverhead, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Put(ids.ID{1}, nil)
+	cache.Put(ids.ID{2}, nil)
+	cache.Put(ids.ID{3}, nil)
+	cache.Get(ids.ID{1})
+	cache.Get(ids.ID{3})
+
+	if hits := testutil.ToFloat64(cache.metrics.hits); hits != 1 {
+		t.Fatalf("Wrong number of hits: Returned: %f ; Expected: 1", hits)
+	} else if misses := testutil.ToFloat64(cache.metrics.misses); misses != 1 {
+		t.Fatalf("Wrong number of misses: Returned: %f ; Expected: 1", misses)
+	} else if evictions := testutil.ToFloat64(cache.metrics.evictions); evictions != 1 {
+		t.Fatalf("Wrong number of evictions: Returned: %f ; Expected: 1", evictions)
+	} else if length := testutil.ToFloat64(cache.metrics.len); length != 2 {
+		t.Fatalf("Wrong length: Returned: %f ; Expected: 2", length)
+	} else if size := testutil.ToFloat64(cache.metrics.size); size != 2*EntryOverhead {
+		t.Fatalf("Wrong size: Returned: %f ; Expected: %d", size, 2*EntryOverhead)
+	}
+}
+
+func TestBytesSize(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		size  int
+	}{
+		{nil, 0},
+		{[]byte{1, 2, 3}, 3},
+		{ids.ID{}, len(ids.ID{})},
+		{[]ids.ID{{}, {}}, 2 * len(ids.ID{})},
+		{uint32(0), 4},
+		{&struct{ p *int }{}, 0},
+	}
+	for _, test := range tests {
+		if size := BytesSize(test.value); size != test.size {
+			t.Fatalf("Wrong size of %T: Returned: %d ; Expected: %d", test.value, size, test.size)
+		}
+	}
+}
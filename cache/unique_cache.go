@@ -16,6 +16,9 @@ type EvictableLRU struct {
 	entryMap  map[ids.ID]*list.Element
 	entryList *list.List
 	Size      int
+
+	// number of elements removed to make space for others
+	capacityEvictions int
 }
 
 // Deduplicate implements the Deduplicator interface
@@ -34,6 +37,25 @@ func (c *EvictableLRU) Flush() {
 	c.flush()
 }
 
+// Len returns the number of elements in the cache
+func (c *EvictableLRU) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.init()
+	c.resize()
+	return c.entryList.Len()
+}
+
+// CapacityEvictions returns the number of elements that were removed from the
+// cache to make space for others
+func (c *EvictableLRU) CapacityEvictions() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.capacityEvictions
+}
+
 func (c *EvictableLRU) init() {
 	if c.entryMap == nil {
 		c.entryMap = make(map[ids.ID]*list.Element)
@@ -54,6 +76,7 @@ func (c *EvictableLRU) resize() {
 		val := e.Value.(Evictable)
 		delete(c.entryMap, val.ID())
 		val.Evict()
+		c.capacityEvictions++
 	}
 }
 
@@ -70,6 +93,7 @@ func (c *EvictableLRU) deduplicate(value Evictable) Evictable {
 			val := e.Value.(Evictable)
 			delete(c.entryMap, val.ID())
 			val.Evict()
+			c.capacityEvictions++
 
 			e.Value = value
 		} else {
@@ -89,7 +113,9 @@ func (c *EvictableLRU) flush() {
 	c.init()
 
 	size := c.Size
+	capacityEvictions := c.capacityEvictions
 	c.Size = 0
 	c.resize()
 	c.Size = size
+	c.capacityEvictions = capacityEvictions
 }
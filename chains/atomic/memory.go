@@ -8,6 +8,7 @@ import (
 	"sync"
 
 	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/journal"
 	"github.com/liraxapp/avalanchego/database/prefixdb"
 	"github.com/liraxapp/avalanchego/database/versiondb"
 	"github.com/liraxapp/avalanchego/ids"
@@ -32,6 +33,10 @@ type Memory struct {
 	codec codec.Manager
 	locks map[ids.ID]*rcLock
 	db    database.Database
+
+	// journal, if set, is used to atomically write shared memory alongside
+	// the chain state it was modified with
+	journal *journal.Journal
 }
 
 // Initialize the SharedMemory
@@ -49,6 +54,11 @@ func (m *Memory) Initialize(log logging.Logger, db database.Database) error {
 	return nil
 }
 
+// SetJournal makes every write to shared memory go through [j]. The batches
+// passed to SharedMemory must write to the database that [j] applies commits
+// to.
+func (m *Memory) SetJournal(j *journal.Journal) { m.journal = j }
+
 // NewSharedMemory returns a new SharedMemory
 func (m *Memory) NewSharedMemory(id ids.ID) SharedMemory {
 	return &sharedMemory{
@@ -100,6 +110,14 @@ func (m *Memory) releaseLock(sharedID ids.ID) *sync.Mutex {
 	return &rc.lock
 }
 
+// write applies [baseBatch] and [batches] atomically
+func (m *Memory) write(baseBatch database.Batch, batches ...database.Batch) error {
+	if m.journal == nil {
+		return WriteAll(baseBatch, batches...)
+	}
+	return m.journal.Commit(append([]database.Batch{baseBatch}, batches...)...)
+}
+
 // sharedID calculates the ID of the shared memory space
 func (m *Memory) sharedID(id1, id2 ids.ID) ids.ID {
 	if bytes.Compare(id1[:], id2[:]) == 1 {
@@ -8,7 +8,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/liraxapp/avalanchego/database/journal"
 	"github.com/liraxapp/avalanchego/database/memdb"
+	"github.com/liraxapp/avalanchego/database/prefixdb"
+	"github.com/liraxapp/avalanchego/database/versiondb"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/utils/logging"
 )
@@ -74,3 +77,42 @@ func TestSharedMemory(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, [][]byte{{1}, {5}}, values, "wrong indexed values returned")
 }
+
+func TestSharedMemoryJournal(t *testing.T) {
+	baseDB := memdb.New()
+	j := journal.New(prefixdb.New([]byte("journal"), baseDB), baseDB)
+	_, _, err := j.Recover()
+	assert.NoError(t, err)
+
+	m := Memory{}
+	err = m.Initialize(logging.NoLog{}, prefixdb.New([]byte("shared memory"), baseDB))
+	assert.NoError(t, err)
+	m.SetJournal(j)
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+
+	sm0 := m.NewSharedMemory(chainID0)
+	sm1 := m.NewSharedMemory(chainID1)
+
+	chainDB := versiondb.New(prefixdb.New(chainID0[:], baseDB))
+	err = chainDB.Put([]byte{6}, []byte{7})
+	assert.NoError(t, err)
+	batch, err := chainDB.CommitBatch()
+	assert.NoError(t, err)
+
+	err = sm0.Put(chainID1, []*Element{{
+		Key:   []byte{0},
+		Value: []byte{1},
+	}}, batch)
+	assert.NoError(t, err)
+	chainDB.Abort()
+
+	values, err := sm1.Get(chainID0, [][]byte{{0}})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{{1}}, values, "wrong values returned")
+
+	value, err := chainDB.Get([]byte{6})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{7}, value, "wrong chain state returned")
+}
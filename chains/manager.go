@@ -11,8 +11,11 @@ import (
 	"github.com/liraxapp/avalanchego/api"
 	"github.com/liraxapp/avalanchego/api/health"
 	"github.com/liraxapp/avalanchego/api/keystore"
+	"github.com/liraxapp/avalanchego/cache"
 	"github.com/liraxapp/avalanchego/chains/atomic"
+	"github.com/liraxapp/avalanchego/chains/verify"
 	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/compressdb"
 	"github.com/liraxapp/avalanchego/database/prefixdb"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/network"
@@ -45,6 +48,14 @@ const (
 	defaultChannelSize = 1024
 )
 
+var (
+	errInconsistentChain = errors.New("chain's accepted state is inconsistent")
+
+	// Namespace of the node's database recording which chains were created
+	// with a compressed database
+	compressionMarkerPrefix = []byte("compression")
+)
+
 // Manager manages the chains running on this node.
 // It can:
 //   * Create a chain
@@ -84,6 +95,10 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Returns the ID of the block accepted at the given height of the Snowman
+	// chain with the given ID
+	GetBlockIDAtHeight(chainID ids.ID, height uint64) (ids.ID, error)
+
 	Shutdown()
 }
 
@@ -119,13 +134,19 @@ type ManagerConfig struct {
 	DecisionEvents          *triggers.EventDispatcher
 	ConsensusEvents         *triggers.EventDispatcher
 	DB                      database.Database
-	Router                  router.Router      // Routes incoming messages to the appropriate chain
-	Net                     network.Network    // Sends consensus messages to other validators
-	ConsensusParams         avcon.Parameters   // The consensus parameters (alpha, beta, etc.) for new chains
-	Validators              validators.Manager // Validators validating on this chain
-	NodeID                  ids.ShortID        // The ID of this node
-	NetworkID               uint32             // ID of the network this node is connected to
-	Server                  *api.Server        // Handles HTTP API calls
+	VerifyDB                bool                            // Verify the accepted state of chains before running them
+	StateSyncEnabled        bool                            // Sync the state of chains whose VM supports it while bootstrapping
+	DBCompression           map[string]compressdb.Algorithm // Compression to use for new chains, keyed by chain ID or alias
+	DBCompressionThreshold  int                             // Values smaller than this are stored uncompressed
+	CacheBudget             *cache.Budget                   // Memory budget split evenly between the caches of every chain
+	Router                  router.Router                   // Routes incoming messages to the appropriate chain
+	Net                     network.Network                 // Sends consensus messages to other validators
+	ConsensusParams         avcon.Parameters                // The consensus parameters (alpha, beta, etc.) for new chains
+	SubnetConsensusParams   map[ids.ID]avcon.Parameters     // Overrides [ConsensusParams] for new chains in specific subnets
+	Validators              validators.Manager              // Validators validating on this chain
+	NodeID                  ids.ShortID                     // The ID of this node
+	NetworkID               uint32                          // ID of the network this node is connected to
+	Server                  *api.Server                     // Handles HTTP API calls
 	Keystore                *keystore.Keystore
 	AtomicMemory            *atomic.Memory
 	AVAXAssetID             ids.ID
@@ -151,6 +172,11 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]*router.Handler
+	// Key: ID of a Snowman chain
+	// Value: The VM of the chain, which indexes its accepted blocks by height
+	heightIndexes map[ids.ID]block.HeightIndexedChainVM
+	// Subnets whose sample size is being checked against their validator set
+	sampleSizeChecks ids.Set
 }
 
 // New returns a new Manager
@@ -158,6 +184,7 @@ func New(config *ManagerConfig) Manager {
 	m := &manager{
 		ManagerConfig: *config,
 		chains:        make(map[ids.ID]*router.Handler),
+		heightIndexes: make(map[ids.ID]block.HeightIndexedChainVM),
 	}
 	m.Initialize()
 	return m
@@ -209,6 +236,9 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 
 	m.chainsLock.Lock()
 	m.chains[chainParams.ID] = chain.Handler
+	if vm, ok := chain.VM.(block.HeightIndexedChainVM); ok {
+		m.heightIndexes[chainParams.ID] = vm
+	}
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -252,6 +282,7 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 		SNLookup:            m,
 		Namespace:           fmt.Sprintf("%s_%s_vm", constants.PlatformName, primaryAlias),
 		Metrics:             m.ConsensusParams.Metrics,
+		CacheBudget:         m.CacheBudget.Split(1),
 	}
 
 	// Get a factory for the vm we want to use on our chain
@@ -292,7 +323,7 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 		}
 	}
 
-	consensusParams := m.ConsensusParams
+	consensusParams, subnetParams := m.consensusParams(chainParams.SubnetID)
 	consensusParams.Namespace = fmt.Sprintf("%s_%s", constants.PlatformName, primaryAlias)
 
 	// The validators of this blockchain
@@ -307,6 +338,19 @@ func (m *manager) buildChain(chainParams ChainParameters) (*chain, error) {
 		return nil, fmt.Errorf("couldn't get validator set of subnet with ID %s. The subnet may not exist", chainParams.SubnetID)
 	}
 
+	// The validator set of a subnet may not be known yet when its chains are
+	// created, and it changes afterwards, so it's also checked continuously
+	if subnetParams {
+		if numVdrs := vdrs.Len(); numVdrs == 0 {
+			m.Log.Warn("subnet %s has no validators yet. Its consensus parameters will be checked against its validator set once it does", chainParams.SubnetID)
+		} else if err := checkSampleSize(chainParams.SubnetID, consensusParams.K, numVdrs); err != nil {
+			return nil, err
+		}
+		if err := m.registerSampleSizeCheck(chainParams.SubnetID, consensusParams.K, vdrs); err != nil {
+			return nil, err
+		}
+	}
+
 	beacons := vdrs
 	if chainParams.CustomBeacons != nil {
 		beacons = chainParams.CustomBeacons
@@ -393,7 +437,10 @@ func (m *manager) createAvalancheChain(
 	ctx.Lock.Lock()
 	defer ctx.Lock.Unlock()
 
-	db := prefixdb.New(ctx.ChainID[:], m.DB)
+	db, err := m.chainDB(ctx)
+	if err != nil {
+		return nil, err
+	}
 	vmDB := prefixdb.New([]byte("vm"), db)
 	vertexDB := prefixdb.New([]byte("vertex"), db)
 	vertexBootstrappingDB := prefixdb.New([]byte("vertex_bs"), db)
@@ -412,6 +459,11 @@ func (m *manager) createAvalancheChain(
 	// VM uses this channel to notify engine that a block is ready to be made
 	msgChan := make(chan common.Message, defaultChannelSize)
 
+	// Passes messages from the consensus engine and the VM to the network
+	sender := sender.Sender{}
+	sender.Initialize(ctx, m.Net, m.ManagerConfig.Router, m.TimeoutManager)
+	ctx.AppSender = &sender
+
 	if err := vm.Initialize(ctx, vmDB, genesisData, msgChan, fxs); err != nil {
 		return nil, fmt.Errorf("error during vm's Initialize: %w", err)
 	}
@@ -419,11 +471,15 @@ func (m *manager) createAvalancheChain(
 	// Handles serialization/deserialization of vertices and also the
 	// persistence of vertices
 	vtxManager := &state.Serializer{}
-	vtxManager.Initialize(ctx, vm, vertexDB)
+	if err := vtxManager.Initialize(ctx, vm, vertexDB); err != nil {
+		return nil, fmt.Errorf("error during vertex manager's Initialize: %w", err)
+	}
 
-	// Passes messages from the consensus engine to the network
-	sender := sender.Sender{}
-	sender.Initialize(ctx, m.Net, m.ManagerConfig.Router, m.TimeoutManager)
+	if m.VerifyDB {
+		if err := m.verifyAvalancheChain(ctx, vtxManager); err != nil {
+			return nil, err
+		}
+	}
 
 	sampleK := consensusParams.K
 	if uint64(sampleK) > bootstrapWeight {
@@ -505,9 +561,13 @@ func (m *manager) createSnowmanChain(
 	ctx.Lock.Lock()
 	defer ctx.Lock.Unlock()
 
-	db := prefixdb.New(ctx.ChainID[:], m.DB)
+	db, err := m.chainDB(ctx)
+	if err != nil {
+		return nil, err
+	}
 	vmDB := prefixdb.New([]byte("vm"), db)
 	bootstrappingDB := prefixdb.New([]byte("bs"), db)
+	heightDB := prefixdb.New([]byte("height"), db)
 
 	blocked, err := queue.New(bootstrappingDB)
 	if err != nil {
@@ -518,14 +578,34 @@ func (m *manager) createSnowmanChain(
 	// VM uses this channel to notify engine that a block is ready to be made
 	msgChan := make(chan common.Message, defaultChannelSize)
 
+	// Passes messages from the consensus engine and the VM to the network
+	sender := sender.Sender{}
+	sender.Initialize(ctx, m.Net, m.ManagerConfig.Router, m.TimeoutManager)
+	ctx.AppSender = &sender
+
 	// Initialize the VM
 	if err := vm.Initialize(ctx, vmDB, genesisData, msgChan, fxs); err != nil {
 		return nil, err
 	}
+	// A VM that runs in another process only claims the interfaces it
+	// supports once it's initialized
+	if negotiator, ok := vm.(block.Negotiator); ok {
+		vm = negotiator.Negotiated()
+	}
 
-	// Passes messages from the consensus engine to the network
-	sender := sender.Sender{}
-	sender.Initialize(ctx, m.Net, m.ManagerConfig.Router, m.TimeoutManager)
+	if m.VerifyDB {
+		if err := m.verifySnowmanChain(ctx, vm); err != nil {
+			return nil, err
+		}
+	}
+
+	// Index the accepted blocks of VMs that don't index them by height
+	if _, ok := vm.(block.HeightIndexedChainVM); !ok {
+		vm, err = smeng.NewHeightIndexedVM(vm, heightDB)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't index the chain by height: %w", err)
+		}
+	}
 
 	sampleK := consensusParams.K
 	if uint64(sampleK) > bootstrapWeight {
@@ -548,6 +628,7 @@ func (m *manager) createSnowmanChain(
 			Blocked:      blocked,
 			VM:           vm,
 			Bootstrapped: m.unblockChains,
+			StateSync:    m.StateSyncEnabled,
 		},
 		Params:    consensusParams,
 		Consensus: &smcon.Topological{},
@@ -593,6 +674,144 @@ func (m *manager) createSnowmanChain(
 	}, nil
 }
 
+// consensusParams returns the consensus parameters of chains in the subnet
+// [subnetID] and whether they were overridden for that subnet
+func (m *manager) consensusParams(subnetID ids.ID) (avcon.Parameters, bool) {
+	params, ok := m.SubnetConsensusParams[subnetID]
+	if !ok {
+		return m.ConsensusParams, false
+	}
+	params.Metrics = m.ConsensusParams.Metrics
+	return params, true
+}
+
+// registerSampleSizeCheck adds a health check that fails while the subnet
+// [subnetID] has fewer validators in [vdrs] than its polls sample
+func (m *manager) registerSampleSizeCheck(subnetID ids.ID, k int, vdrs validators.Set) error {
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+
+	if m.sampleSizeChecks.Contains(subnetID) {
+		return nil
+	}
+	name := fmt.Sprintf("subnet.%s.sample_size", subnetID)
+	err := m.HealthService.RegisterCheckFunc(name, func() (interface{}, error) {
+		numVdrs := vdrs.Len()
+		details := map[string]int{
+			"k":          k,
+			"validators": numVdrs,
+		}
+		return details, checkSampleSize(subnetID, k, numVdrs)
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't add sample size health check for subnet %s: %w", subnetID, err)
+	}
+	m.sampleSizeChecks.Add(subnetID)
+	return nil
+}
+
+// checkSampleSize returns an error if polls that sample [k] validators can't
+// be made in the subnet [subnetID], which has [numVdrs] validators. Polls
+// sample validators without replacement.
+func checkSampleSize(subnetID ids.ID, k int, numVdrs int) error {
+	if k > numVdrs {
+		return fmt.Errorf("consensus parameters of subnet %s sample k=%d validators but the subnet only has %d", subnetID, k, numVdrs)
+	}
+	return nil
+}
+
+// chainDB returns the database of the chain described by [ctx]. Compression
+// can only be enabled for chains whose database is empty, as the values of
+// an existing chain aren't tagged with their encoding. Chains that were
+// created with compression keep reading their values through compressdb even
+// if compression is no longer configured for them.
+func (m *manager) chainDB(ctx *snow.Context) (database.Database, error) {
+	db := prefixdb.New(ctx.ChainID[:], m.DB)
+	markerDB := prefixdb.New(compressionMarkerPrefix, m.DB)
+
+	algorithm, configured := m.compressionAlgorithm(ctx.ChainID)
+	compressed, err := markerDB.Has(ctx.ChainID[:])
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read compression marker: %w", err)
+	}
+	switch {
+	case compressed && !configured:
+		algorithm = compressdb.None
+	case !compressed && configured:
+		empty, err := isEmpty(db)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't check whether the chain's database is empty: %w", err)
+		}
+		if !empty {
+			ctx.Log.Warn("not compressing the chain's database as it was created without compression")
+			return db, nil
+		}
+		if err := markerDB.Put(ctx.ChainID[:], []byte(algorithm)); err != nil {
+			return nil, fmt.Errorf("couldn't write compression marker: %w", err)
+		}
+	case !compressed:
+		return db, nil
+	}
+
+	ctx.Log.Info("compressing the chain's database with %s", algorithm)
+	return compressdb.New(
+		fmt.Sprintf("%s_compressdb", ctx.Namespace),
+		ctx.Metrics,
+		algorithm,
+		m.DBCompressionThreshold,
+		db,
+	)
+}
+
+// compressionAlgorithm returns the compression algorithm configured for the
+// chain [chainID], either by its ID or by one of its aliases
+func (m *manager) compressionAlgorithm(chainID ids.ID) (compressdb.Algorithm, bool) {
+	if algorithm, ok := m.DBCompression[chainID.String()]; ok {
+		return algorithm, true
+	}
+	for _, alias := range m.Aliases(chainID) {
+		if algorithm, ok := m.DBCompression[alias]; ok {
+			return algorithm, true
+		}
+	}
+	return "", false
+}
+
+// isEmpty returns true if [db] contains no keys
+func isEmpty(db database.Iteratee) (bool, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	if it.Next() {
+		return false, nil
+	}
+	return true, it.Error()
+}
+
+// verifySnowmanChain checks the accepted chain of [vm]. An inconsistent chain
+// isn't run.
+func (m *manager) verifySnowmanChain(ctx *snow.Context, vm block.ChainVM) error {
+	report := verify.Snowman(vm)
+	if report.Consistent() {
+		ctx.Log.Info("verified accepted state: %s", report)
+		return nil
+	}
+	ctx.Log.Error("accepted state is inconsistent: %s", report)
+	return errInconsistentChain
+}
+
+// verifyAvalancheChain checks the accepted DAG of [manager]. An inconsistent DAG
+// isn't run.
+func (m *manager) verifyAvalancheChain(ctx *snow.Context, manager vertex.Manager) error {
+	report := verify.Avalanche(manager)
+	if report.Consistent() {
+		ctx.Log.Info("verified accepted state: %s", report)
+		return nil
+	}
+	ctx.Log.Error("accepted state is inconsistent: %s", report)
+	return errInconsistentChain
+}
+
 func (m *manager) SubnetID(chainID ids.ID) (ids.ID, error) {
 	m.chainsLock.Lock()
 	defer m.chainsLock.Unlock()
@@ -615,6 +834,24 @@ func (m *manager) IsBootstrapped(id ids.ID) bool {
 	return chain.Engine().IsBootstrapped()
 }
 
+func (m *manager) GetBlockIDAtHeight(chainID ids.ID, height uint64) (ids.ID, error) {
+	m.chainsLock.Lock()
+	chain, exists := m.chains[chainID]
+	vm, indexed := m.heightIndexes[chainID]
+	m.chainsLock.Unlock()
+	switch {
+	case !exists:
+		return ids.ID{}, errors.New("unknown chain ID")
+	case !indexed:
+		return ids.ID{}, errors.New("chain isn't indexed by height")
+	}
+
+	ctx := chain.Context()
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+	return vm.GetBlockIDAtHeight(height)
+}
+
 // Shutdown stops all the chains
 func (m *manager) Shutdown() {
 	m.Log.Info("shutting down chain manager")
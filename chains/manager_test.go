@@ -0,0 +1,81 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liraxapp/avalanchego/api/health"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/consensus/avalanche"
+	"github.com/liraxapp/avalanchego/snow/consensus/snowball"
+	"github.com/liraxapp/avalanchego/snow/validators"
+	"github.com/liraxapp/avalanchego/utils/logging"
+)
+
+func TestConsensusParamsOverride(t *testing.T) {
+	registerer := prometheus.NewRegistry()
+	subnetID := ids.GenerateTestID()
+	m := &manager{ManagerConfig: ManagerConfig{
+		ConsensusParams: avalanche.Parameters{
+			Parameters: snowball.Parameters{
+				Metrics: registerer,
+				K:       20,
+			},
+		},
+		SubnetConsensusParams: map[ids.ID]avalanche.Parameters{
+			subnetID: {Parameters: snowball.Parameters{K: 3}},
+		},
+	}}
+
+	params, overridden := m.consensusParams(subnetID)
+	if !overridden {
+		t.Fatalf("parameters of subnet %s should have been overridden", subnetID)
+	}
+	if params.K != 3 {
+		t.Fatalf("K = %d ; Expected 3", params.K)
+	}
+	if params.Metrics != registerer {
+		t.Fatal("overridden parameters should use the node's registerer")
+	}
+
+	params, overridden = m.consensusParams(ids.GenerateTestID())
+	if overridden {
+		t.Fatal("parameters of other subnets shouldn't have been overridden")
+	}
+	if params.K != 20 {
+		t.Fatalf("K = %d ; Expected 20", params.K)
+	}
+}
+
+func TestCheckSampleSize(t *testing.T) {
+	subnetID := ids.GenerateTestID()
+	if err := checkSampleSize(subnetID, 3, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkSampleSize(subnetID, 3, 2); err == nil {
+		t.Fatal("should have errored when sampling more validators than the subnet has")
+	}
+}
+
+func TestRegisterSampleSizeCheck(t *testing.T) {
+	m := &manager{ManagerConfig: ManagerConfig{
+		HealthService: health.NewService(logging.NoLog{}),
+	}}
+	subnetID := ids.GenerateTestID()
+	vdrs := validators.NewSet()
+
+	if err := m.registerSampleSizeCheck(subnetID, 1, vdrs); err != nil {
+		t.Fatal(err)
+	}
+	// Other chains of the same subnet share the check
+	if err := m.registerSampleSizeCheck(subnetID, 1, vdrs); err != nil {
+		t.Fatal(err)
+	}
+	if !m.sampleSizeChecks.Contains(subnetID) {
+		t.Fatalf("subnet %s should be checked", subnetID)
+	}
+}
@@ -53,3 +53,6 @@ func (mm MockManager) SubnetID(ids.ID) (ids.ID, error) { return ids.ID{}, nil }
 
 // IsBootstrapped ...
 func (mm MockManager) IsBootstrapped(ids.ID) bool { return false }
+
+// GetBlockIDAtHeight ...
+func (mm MockManager) GetBlockIDAtHeight(ids.ID, uint64) (ids.ID, error) { return ids.ID{}, nil }
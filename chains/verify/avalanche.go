@@ -0,0 +1,87 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package verify
+
+import (
+	"fmt"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/choices"
+	"github.com/liraxapp/avalanchego/snow/engine/avalanche/vertex"
+	"github.com/liraxapp/avalanchego/utils/hashing"
+)
+
+// Avalanche walks the accepted DAG of [manager] from its accepted frontier back
+// to the genesis vertices. Every vertex is checked to be marked as accepted,
+// for its ID to be the hash of its bytes and for its height to be one more
+// than the highest of its parents.
+func Avalanche(manager vertex.Manager) *Report {
+	report := &Report{Complete: true}
+
+	type entry struct {
+		vtxID ids.ID
+		depth int
+	}
+	queue := []entry(nil)
+	queued := ids.Set{}
+	for _, vtxID := range manager.Edge() {
+		queue = append(queue, entry{vtxID: vtxID})
+		queued.Add(vtxID)
+	}
+
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		vtxID, depth := next.vtxID, next.depth
+
+		vtx, err := manager.GetVertex(vtxID)
+		if err != nil {
+			report.fault(vtxID, depth, errMissing)
+			report.Complete = false
+			continue
+		}
+		report.Checked++
+
+		if status := vtx.Status(); status != choices.Accepted {
+			report.fault(vtxID, depth, fmt.Errorf("%w: status is %s", errNotAccepted, status))
+		}
+
+		// Height and Parents fail if the vertex's bytes couldn't be parsed
+		height, err := vtx.Height()
+		if err != nil {
+			report.fault(vtxID, depth, errUnparseable)
+			report.Complete = false
+			continue
+		}
+		parents, err := vtx.Parents()
+		if err != nil {
+			report.fault(vtxID, depth, errUnparseable)
+			report.Complete = false
+			continue
+		}
+		if hashing.ComputeHash256Array(vtx.Bytes()) != vtxID {
+			report.fault(vtxID, depth, errWrongID)
+		}
+
+		// Faulty parents are reported when they are visited, so the height is
+		// only checked if every parent's height is known
+		expectedHeight, knownHeight := uint64(0), true
+		for _, parent := range parents {
+			parentID := parent.ID()
+			if parentHeight, err := parent.Height(); err != nil {
+				knownHeight = false
+			} else if parentHeight+1 > expectedHeight {
+				expectedHeight = parentHeight + 1
+			}
+			if !queued.Contains(parentID) {
+				queue = append(queue, entry{vtxID: parentID, depth: depth + 1})
+				queued.Add(parentID)
+			}
+		}
+		if knownHeight && height != expectedHeight {
+			report.fault(vtxID, depth, errWrongHeight)
+		}
+	}
+	return report
+}
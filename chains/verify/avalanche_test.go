@@ -0,0 +1,92 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package verify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/choices"
+	"github.com/liraxapp/avalanchego/snow/consensus/avalanche"
+	"github.com/liraxapp/avalanchego/snow/engine/avalanche/vertex"
+	"github.com/liraxapp/avalanchego/utils/hashing"
+)
+
+var errUnknownVertex = errors.New("unknown vertex")
+
+func newTestVertex(b byte, parents ...avalanche.Vertex) *avalanche.TestVertex {
+	bytes := []byte{b}
+	height := uint64(0)
+	for _, parent := range parents {
+		parentHeight, _ := parent.Height()
+		if parentHeight+1 > height {
+			height = parentHeight + 1
+		}
+	}
+	return &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     hashing.ComputeHash256Array(bytes),
+			StatusV: choices.Accepted,
+		},
+		ParentsV: parents,
+		HeightV:  height,
+		BytesV:   bytes,
+	}
+}
+
+func testManager(t *testing.T, edge []ids.ID, vtxs ...*avalanche.TestVertex) *vertex.TestManager {
+	manager := &vertex.TestManager{T: t}
+	manager.Default(true)
+	manager.EdgeF = func() []ids.ID { return edge }
+	manager.GetVertexF = func(vtxID ids.ID) (avalanche.Vertex, error) {
+		for _, vtx := range vtxs {
+			if vtx.ID() == vtxID {
+				return vtx, nil
+			}
+		}
+		return nil, errUnknownVertex
+	}
+	return manager
+}
+
+func TestAvalancheConsistent(t *testing.T) {
+	vtx0 := newTestVertex(0)
+	vtx1 := newTestVertex(1, vtx0)
+	vtx2 := newTestVertex(2, vtx0)
+	vtx3 := newTestVertex(3, vtx1, vtx2)
+	manager := testManager(t, []ids.ID{vtx3.ID()}, vtx0, vtx1, vtx2, vtx3)
+
+	report := Avalanche(manager)
+	if !report.Consistent() {
+		t.Fatalf("DAG should have been consistent: %s", report)
+	} else if report.Checked != 4 {
+		t.Fatalf("Checked %d vertices; Expected 4", report.Checked)
+	}
+}
+
+func TestAvalancheFaults(t *testing.T) {
+	vtx0 := newTestVertex(0)
+	vtx1 := newTestVertex(1, vtx0)
+	vtx2 := newTestVertex(2, vtx0)
+	vtx3 := newTestVertex(3, vtx1, vtx2)
+
+	vtx1.BytesV = []byte{0xff}
+	vtx2.StatusV = choices.Processing
+	vtx3.HeightV = 5
+	manager := testManager(t, []ids.ID{vtx3.ID()}, vtx0, vtx1, vtx2, vtx3)
+
+	report := Avalanche(manager)
+	if len(report.Faults) != 3 {
+		t.Fatalf("Found %d faults; Expected 3: %s", len(report.Faults), report)
+	} else if report.Faults[0].Err != errWrongHeight {
+		t.Fatalf("Expected the wrong height to be reported first, got %s", report.Faults[0])
+	}
+
+	// vtx0 was lost
+	manager = testManager(t, []ids.ID{vtx3.ID()}, vtx1, vtx2, vtx3)
+	if report := Avalanche(manager); report.Complete {
+		t.Fatalf("Walk shouldn't have reached genesis")
+	}
+}
@@ -0,0 +1,76 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package verify checks the consistency of the containers a chain has
+// accepted.
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/liraxapp/avalanchego/ids"
+)
+
+var (
+	errMissing     = errors.New("container is missing")
+	errWrongID     = errors.New("container's ID doesn't match its bytes")
+	errUnparseable = errors.New("container's bytes couldn't be parsed")
+	errNotAccepted = errors.New("container isn't marked as accepted")
+	errWrongHeight = errors.New("container's height isn't consistent with its parents")
+	errCycle       = errors.New("container is its own ancestor")
+)
+
+// Fault is an inconsistency found in an accepted container
+type Fault struct {
+	// ID of the faulty container
+	ContainerID ids.ID
+	// Number of accepted containers between the faulty container and the
+	// accepted frontier
+	Depth int
+	Err   error
+}
+
+func (f Fault) String() string {
+	return fmt.Sprintf("%s at depth %d: %s", f.ContainerID, f.Depth, f.Err)
+}
+
+// Report describes the consistency of the accepted containers of a chain
+type Report struct {
+	// Number of accepted containers that were checked
+	Checked int
+	// True iff every container was traced back to genesis
+	Complete bool
+	// Inconsistencies found, from the accepted frontier towards genesis
+	Faults []Fault
+}
+
+// Consistent returns true iff no faults were found
+func (r *Report) Consistent() bool { return r.Complete && len(r.Faults) == 0 }
+
+func (r *Report) String() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("checked %d accepted containers", r.Checked))
+	if !r.Complete {
+		sb.WriteString(", without reaching genesis")
+	}
+	if len(r.Faults) == 0 {
+		sb.WriteString(", found no faults")
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf(", found %d faults:", len(r.Faults)))
+	for _, fault := range r.Faults {
+		sb.WriteString("\n    ")
+		sb.WriteString(fault.String())
+	}
+	return sb.String()
+}
+
+func (r *Report) fault(containerID ids.ID, depth int, err error) {
+	r.Faults = append(r.Faults, Fault{
+		ContainerID: containerID,
+		Depth:       depth,
+		Err:         err,
+	})
+}
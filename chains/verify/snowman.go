@@ -0,0 +1,81 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package verify
+
+import (
+	"fmt"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/choices"
+	"github.com/liraxapp/avalanchego/snow/consensus/snowman"
+	"github.com/liraxapp/avalanchego/snow/engine/snowman/block"
+)
+
+// heighter is implemented by blocks that know their height
+type heighter interface {
+	Height() uint64
+}
+
+// Snowman walks the accepted chain of [vm] from its last accepted block back
+// to genesis. Every block is re-parsed from its bytes to check that its ID
+// matches, that it is marked as accepted and, if the block exposes its
+// height, that its height is one more than its parent's.
+func Snowman(vm block.ChainVM) *Report {
+	report := &Report{}
+
+	// IDs of the walked blocks, from the last accepted block towards genesis
+	path := []ids.ID(nil)
+	visited := ids.Set{}
+
+	blkID := vm.LastAccepted()
+	for depth := 0; ; depth++ {
+		if visited.Contains(blkID) {
+			report.fault(blkID, depth, errCycle)
+			break
+		}
+		visited.Add(blkID)
+
+		blk, err := vm.GetBlock(blkID)
+		if err != nil {
+			report.fault(blkID, depth, errMissing)
+			break
+		}
+		report.Checked++
+		path = append(path, blkID)
+
+		if parsed, err := vm.ParseBlock(blk.Bytes()); err != nil {
+			report.fault(blkID, depth, errUnparseable)
+		} else if parsed.ID() != blkID || blk.ID() != blkID {
+			report.fault(blkID, depth, errWrongID)
+		}
+		if status := blk.Status(); status != choices.Accepted {
+			report.fault(blkID, depth, fmt.Errorf("%w: status is %s", errNotAccepted, status))
+		}
+
+		parent := blk.Parent()
+		if isGenesisParent(parent) {
+			if blkHeight, ok := blk.(heighter); ok && blkHeight.Height() != 0 {
+				report.fault(blkID, depth, errWrongHeight)
+			}
+			report.Complete = true
+			break
+		}
+		if parent.Status() == choices.Unknown {
+			report.fault(parent.ID(), depth+1, errMissing)
+			break
+		}
+		if blkHeight, ok := blk.(heighter); ok {
+			if parentHeight, ok := parent.(heighter); ok && parentHeight.Height()+1 != blkHeight.Height() {
+				report.fault(blkID, depth, errWrongHeight)
+			}
+		}
+		blkID = parent.ID()
+	}
+	return report
+}
+
+// isGenesisParent returns true if [parent] is the parent of a genesis block
+func isGenesisParent(parent snowman.Block) bool {
+	return parent == nil || (parent.ID() == ids.Empty && parent.Status() == choices.Unknown)
+}
@@ -0,0 +1,123 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package verify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/choices"
+	"github.com/liraxapp/avalanchego/snow/consensus/snowman"
+	"github.com/liraxapp/avalanchego/snow/engine/snowman/block"
+	"github.com/liraxapp/avalanchego/utils/hashing"
+)
+
+var errUnknownBlock = errors.New("unknown block")
+
+// testChain returns a vm whose accepted chain is made of [length] blocks,
+// from genesis to the last accepted block. The ID of a block is the hash of its
+// bytes.
+func testChain(t *testing.T, length int) (*block.TestVM, []*snowman.TestBlock) {
+	blks := make([]*snowman.TestBlock, length)
+	parent := snowman.Block(&snowman.TestBlock{TestDecidable: choices.TestDecidable{
+		IDV:     ids.Empty,
+		StatusV: choices.Unknown,
+	}})
+	for i := range blks {
+		bytes := []byte{byte(i)}
+		blks[i] = &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     hashing.ComputeHash256Array(bytes),
+				StatusV: choices.Accepted,
+			},
+			ParentV: parent,
+			HeightV: uint64(i),
+			BytesV:  bytes,
+		}
+		parent = blks[i]
+	}
+
+	vm := &block.TestVM{}
+	vm.T = t
+	vm.Default(true)
+	vm.LastAcceptedF = func() ids.ID { return blks[len(blks)-1].ID() }
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID() == blkID {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+	vm.ParseBlockF = func(b []byte) (snowman.Block, error) {
+		return &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{IDV: hashing.ComputeHash256Array(b)},
+			BytesV:        b,
+		}, nil
+	}
+	return vm, blks
+}
+
+func TestSnowmanConsistent(t *testing.T) {
+	vm, blks := testChain(t, 4)
+
+	report := Snowman(vm)
+	if !report.Consistent() {
+		t.Fatalf("Chain should have been consistent: %s", report)
+	} else if report.Checked != len(blks) {
+		t.Fatalf("Checked %d blocks; Expected %d", report.Checked, len(blks))
+	}
+}
+
+func TestSnowmanCorruptedTail(t *testing.T) {
+	vm, blks := testChain(t, 4)
+
+	// The block at height 2 no longer hashes to its ID
+	blks[2].BytesV = []byte{0xff}
+
+	report := Snowman(vm)
+	if report.Consistent() {
+		t.Fatalf("Chain shouldn't have been consistent")
+	} else if !report.Complete {
+		t.Fatalf("Walk should have reached genesis")
+	} else if len(report.Faults) != 1 {
+		t.Fatalf("Found %d faults; Expected 1", len(report.Faults))
+	} else if fault := report.Faults[0]; fault.ContainerID != blks[2].ID() || fault.Depth != 1 {
+		t.Fatalf("Wrong fault reported: %s", fault)
+	}
+}
+
+func TestSnowmanMissingParent(t *testing.T) {
+	vm, blks := testChain(t, 4)
+
+	// The block at height 1 was lost
+	blks[2].ParentV = &snowman.TestBlock{TestDecidable: choices.TestDecidable{
+		IDV:     blks[1].ID(),
+		StatusV: choices.Unknown,
+	}}
+
+	report := Snowman(vm)
+	if report.Complete {
+		t.Fatalf("Walk shouldn't have reached genesis")
+	} else if len(report.Faults) != 1 || report.Faults[0].Err != errMissing {
+		t.Fatalf("Missing block should have been reported")
+	}
+}
+
+func TestSnowmanWrongHeight(t *testing.T) {
+	vm, blks := testChain(t, 3)
+
+	blks[1].HeightV = 5
+
+	report := Snowman(vm)
+	if len(report.Faults) != 2 {
+		t.Fatalf("Found %d faults; Expected 2", len(report.Faults))
+	}
+	for _, fault := range report.Faults {
+		if fault.Err != errWrongHeight {
+			t.Fatalf("Wrong fault reported: %s", fault)
+		}
+	}
+}
@@ -0,0 +1,718 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package boltdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/nodb"
+	"github.com/liraxapp/avalanchego/utils"
+)
+
+const (
+	// Name is the name of this database engine
+	Name = "boltdb"
+
+	// FileName is the name of the file, within the database directory, that
+	// holds the B+tree.
+	FileName = "bolt.db"
+
+	// StatsProperty is the property that can be passed to Stat to receive the
+	// JSON encoded bolt statistics.
+	StatsProperty = "boltdb.stats"
+
+	// DefaultInitialMmapSize is the default number of bytes to initially
+	// memory map. Bolt must re-map the file as it grows, which can't happen
+	// while a snapshot is held, so this should comfortably exceed the expected
+	// size of the database.
+	DefaultInitialMmapSize = 1 << 30 // 1 GiB
+
+	// maxBatchDelay is the longest a Put or Delete that overlaps with other
+	// writes waits for them to be coalesced into the same transaction
+	maxBatchDelay = time.Millisecond
+
+	// iteratorPageSize is the number of key/value pairs an iterator reads per
+	// read transaction.
+	iteratorPageSize = 1024
+
+	dirPermissions  = 0o700
+	filePermissions = 0o600
+	openTimeout     = time.Second
+)
+
+var (
+	bucketName = []byte("avalanche")
+
+	// ErrSnapshotExpired is returned when reading from a snapshot whose
+	// lifetime has passed
+	ErrSnapshotExpired = errors.New("snapshot expired")
+)
+
+// Database is a persistent key-value store backed by a bolt B+tree. Apart from
+// basic data storage functionality it also supports batch writes, snapshots and
+// iterating over the keyspace in binary-alphabetical order.
+//
+// Keys must be non-empty.
+//
+// Iterators read the database in pages, so they don't hold a transaction open
+// between calls to Next and may observe writes that happen while iterating.
+// Snapshots hold a read transaction open until they are released. Bolt can't
+// grow its memory map while a read transaction is open, so a write that needs
+// to grow the database beyond the initial memory map size will block until all
+// outstanding snapshots are released. Callers that can't guarantee a snapshot
+// is released promptly should bound its lifetime with NewSnapshotWithLifetime.
+//
+// Calls to Put and Delete that overlap with other writes are coalesced into a
+// single transaction.
+type Database struct {
+	lock sync.RWMutex
+	db   *bolt.DB
+
+	// number of calls to Put and Delete in progress
+	writers int32
+}
+
+// New returns a bolt database stored in [dir]. The directory is created if it
+// doesn't exist.
+func New(dir string, initialMmapSize int) (*Database, error) {
+	if initialMmapSize <= 0 {
+		initialMmapSize = DefaultInitialMmapSize
+	}
+	if err := os.MkdirAll(dir, dirPermissions); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(filepath.Join(dir, FileName), filePermissions, &bolt.Options{
+		Timeout:         openTimeout,
+		InitialMmapSize: initialMmapSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	db.MaxBatchDelay = maxBatchDelay
+	return &Database{db: db}, nil
+}
+
+// Has returns if the key is set in the database
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return false, database.ErrClosed
+	}
+	has := false
+	err := db.db.View(func(tx *bolt.Tx) error {
+		_, has = get(tx, key)
+		return nil
+	})
+	return has, updateError(err)
+}
+
+// Get returns the value the key maps to in the database
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	var value []byte
+	err := db.db.View(func(tx *bolt.Tx) error {
+		v, has := get(tx, key)
+		if !has {
+			return database.ErrNotFound
+		}
+		value = utils.CopyBytes(v)
+		return nil
+	})
+	return value, updateError(err)
+}
+
+// Put sets the value of the provided key to the provided value
+func (db *Database) Put(key []byte, value []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return updateError(db.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketName).Put(key, value)
+	}))
+}
+
+// Delete removes the key from the database
+func (db *Database) Delete(key []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return updateError(db.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketName).Delete(key)
+	}))
+}
+
+// NewBatch creates a write/delete-only buffer that is atomically committed to
+// the database when write is called
+func (db *Database) NewBatch() database.Batch { return &batch{db: db} }
+
+// NewIterator creates a lexicographically ordered iterator over the database
+func (db *Database) NewIterator() database.Iterator {
+	return db.newIterator(nil, nil, false)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// database starting at the provided key
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.newIterator(start, nil, false)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// database ignoring keys that do not start with the provided prefix
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	start, limit := database.PrefixRange(prefix)
+	return db.newIterator(start, limit, false)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the database starting at start and ignoring keys that do not start with
+// the provided prefix
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	prefixStart, limit := database.PrefixRange(prefix)
+	if bytes.Compare(start, prefixStart) < 0 {
+		start = prefixStart
+	}
+	return db.newIterator(start, limit, false)
+}
+
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// database ignoring keys outside of the range [start, limit)
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return db.newIterator(start, limit, false)
+}
+
+// NewReverseIterator creates a reverse lexicographically ordered iterator over
+// the database
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.newIterator(nil, nil, true)
+}
+
+// NewReverseIteratorWithPrefix creates a reverse lexicographically ordered
+// iterator over the database ignoring keys that do not start with the provided
+// prefix
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	start, limit := database.PrefixRange(prefix)
+	return db.newIterator(start, limit, true)
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the database ignoring keys outside of the range [start, limit)
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return db.newIterator(start, limit, true)
+}
+
+func (db *Database) newIterator(start, limit []byte, reverse bool) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		view:    db.view,
+		start:   utils.CopyBytes(start),
+		limit:   utils.CopyBytes(limit),
+		reverse: reverse,
+	}
+}
+
+// NewSnapshot returns a read-only view of the current state of the database
+// backed by a bolt read transaction. The snapshot is held until it is
+// released.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	return db.NewSnapshotWithLifetime(0)
+}
+
+// NewSnapshotWithLifetime returns a snapshot that is released automatically
+// once [lifetime] has passed, after which reads from it fail with
+// ErrSnapshotExpired. A non-positive [lifetime] never expires.
+func (db *Database) NewSnapshotWithLifetime(lifetime time.Duration) (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	tx, err := db.db.Begin(false)
+	if err != nil {
+		return nil, updateError(err)
+	}
+	s := &snapshot{tx: tx}
+	if lifetime > 0 {
+		s.lock.Lock()
+		s.expiry = time.AfterFunc(lifetime, s.expire)
+		s.lock.Unlock()
+	}
+	return s, nil
+}
+
+// Stat returns a particular internal stat of the database. The only supported
+// property is StatsProperty.
+func (db *Database) Stat(property string) (string, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return "", database.ErrClosed
+	}
+	if property != StatsProperty {
+		return "", database.ErrNotFound
+	}
+	stats, err := json.Marshal(db.db.Stats())
+	return string(stats), err
+}
+
+// Compact is a no-op, as bolt re-uses freed pages rather than compacting the
+// file in place.
+func (db *Database) Compact(start []byte, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return nil
+}
+
+// Close implements the Database interface
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	err := db.db.Close()
+	db.db = nil
+	return updateError(err)
+}
+
+// update runs [f] in a write transaction. If other writes are in progress, [f]
+// is coalesced with them into a single transaction.
+func (db *Database) update(f func(*bolt.Tx) error) error {
+	defer atomic.AddInt32(&db.writers, -1)
+	if atomic.AddInt32(&db.writers, 1) > 1 {
+		return db.db.Batch(f)
+	}
+	return db.db.Update(f)
+}
+
+// view runs [f] in a read transaction if the database hasn't been closed
+func (db *Database) view(f func(*bolt.Tx) error) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return updateError(db.db.View(f))
+}
+
+// get returns the value [key] maps to in [tx] and whether the key exists. The
+// returned value is only valid for the life of the transaction.
+func get(tx *bolt.Tx, key []byte) ([]byte, bool) {
+	k, v := tx.Bucket(bucketName).Cursor().Seek(key)
+	if k == nil || !bytes.Equal(k, key) {
+		return nil, false
+	}
+	return v, true
+}
+
+type keyValue struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+type batch struct {
+	db     *Database
+	writes []keyValue
+	size   int
+}
+
+// Put the value into the batch for later writing
+func (b *batch) Put(key, value []byte) error {
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false})
+	b.size += len(value)
+	return nil
+}
+
+// Delete the key during writing
+func (b *batch) Delete(key []byte) error {
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
+	b.size++
+	return nil
+}
+
+// ValueSize retrieves the amount of data queued up for writing.
+func (b *batch) ValueSize() int { return b.size }
+
+// Write flushes any accumulated data to disk in a single transaction.
+func (b *batch) Write() error {
+	b.db.lock.RLock()
+	defer b.db.lock.RUnlock()
+
+	if b.db.db == nil {
+		return database.ErrClosed
+	}
+	return updateError(b.db.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(bucketName)
+		for _, kv := range b.writes {
+			if kv.delete {
+				if err := bucket.Delete(kv.key); err != nil {
+					return err
+				}
+			} else if err := bucket.Put(kv.key, kv.value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	if cap(b.writes) > len(b.writes)*database.MaxExcessCapacityFactor {
+		b.writes = make([]keyValue, 0, cap(b.writes)/database.CapacityReductionFactor)
+	} else {
+		b.writes = b.writes[:0]
+	}
+	b.size = 0
+}
+
+// Replay the batch contents.
+func (b *batch) Replay(w database.KeyValueWriter) error {
+	for _, kv := range b.writes {
+		if kv.delete {
+			if err := w.Delete(kv.key); err != nil {
+				return err
+			}
+		} else if err := w.Put(kv.key, kv.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Inner returns itself
+func (b *batch) Inner() database.Batch { return b }
+
+// snapshot wraps a long lived bolt read transaction
+type snapshot struct {
+	lock sync.RWMutex
+	tx   *bolt.Tx
+
+	// releases the snapshot once its lifetime has passed, if it has one
+	expiry  *time.Timer
+	expired bool
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.tx == nil {
+		return false, s.closedErr()
+	}
+	_, has := get(s.tx, key)
+	return has, nil
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.tx == nil {
+		return nil, s.closedErr()
+	}
+	value, has := get(s.tx, key)
+	if !has {
+		return nil, database.ErrNotFound
+	}
+	return utils.CopyBytes(value), nil
+}
+
+// NewIterator creates a lexicographically ordered iterator over the snapshot
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.newIterator(nil, nil, false)
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// snapshot starting at the provided key
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.newIterator(start, nil, false)
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// snapshot ignoring keys that do not start with the provided prefix
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	start, limit := database.PrefixRange(prefix)
+	return s.newIterator(start, limit, false)
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	prefixStart, limit := database.PrefixRange(prefix)
+	if bytes.Compare(start, prefixStart) < 0 {
+		start = prefixStart
+	}
+	return s.newIterator(start, limit, false)
+}
+
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// snapshot ignoring keys outside of the range [start, limit)
+func (s *snapshot) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.newIterator(start, limit, false)
+}
+
+// NewReverseIterator creates a reverse lexicographically ordered iterator over
+// the snapshot
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return s.newIterator(nil, nil, true)
+}
+
+// NewReverseIteratorWithPrefix creates a reverse lexicographically ordered
+// iterator over the snapshot ignoring keys that do not start with the provided
+// prefix
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	start, limit := database.PrefixRange(prefix)
+	return s.newIterator(start, limit, true)
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the snapshot ignoring keys outside of the range [start, limit)
+func (s *snapshot) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.newIterator(start, limit, true)
+}
+
+func (s *snapshot) newIterator(start, limit []byte, reverse bool) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.tx == nil {
+		return &nodb.Iterator{Err: s.closedErr()}
+	}
+	return &iterator{
+		view:    s.view,
+		start:   utils.CopyBytes(start),
+		limit:   utils.CopyBytes(limit),
+		reverse: reverse,
+	}
+}
+
+// Release rolls back the underlying read transaction
+func (s *snapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.release()
+}
+
+// expire releases the snapshot once its lifetime has passed
+func (s *snapshot) expire() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.tx != nil {
+		s.expired = true
+	}
+	s.release()
+}
+
+// release rolls back the underlying read transaction. Assumes the lock is
+// held.
+func (s *snapshot) release() {
+	if s.tx == nil {
+		return
+	}
+	if s.expiry != nil {
+		s.expiry.Stop()
+	}
+	_ = s.tx.Rollback()
+	s.tx = nil
+}
+
+// closedErr returns the error of reading from the snapshot once it has been
+// released. Assumes the lock is held.
+func (s *snapshot) closedErr() error {
+	if s.expired {
+		return ErrSnapshotExpired
+	}
+	return database.ErrClosed
+}
+
+// view runs [f] against the snapshot's transaction if it hasn't been released
+func (s *snapshot) view(f func(*bolt.Tx) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.tx == nil {
+		return s.closedErr()
+	}
+	return f(s.tx)
+}
+
+// iterator reads the keys in the range [start, limit) a page at a time. Each
+// page is read in its own transaction provided by [view].
+type iterator struct {
+	view func(func(*bolt.Tx) error) error
+
+	start, limit []byte
+	reverse      bool
+
+	// The last key that was read from the database. The next page starts
+	// after this key.
+	last []byte
+
+	keys, values [][]byte
+	key, value   []byte
+
+	exhausted bool
+	err       error
+}
+
+// Next implements the Iterator interface
+func (it *iterator) Next() bool {
+	if len(it.keys) == 0 && !it.exhausted && it.err == nil {
+		it.err = it.view(it.readPage)
+	}
+	if len(it.keys) == 0 || it.err != nil {
+		it.key = nil
+		it.value = nil
+		it.keys = nil
+		it.values = nil
+		return false
+	}
+	it.key = it.keys[0]
+	it.value = it.values[0]
+	it.keys = it.keys[1:]
+	it.values = it.values[1:]
+	return true
+}
+
+// readPage reads up to [iteratorPageSize] key/value pairs following [it.last].
+func (it *iterator) readPage(tx *bolt.Tx) error {
+	c := tx.Bucket(bucketName).Cursor()
+
+	var k, v []byte
+	switch {
+	case it.reverse && it.last == nil:
+		k, v = seekBefore(c, it.limit)
+	case it.reverse:
+		k, v = seekBefore(c, it.last)
+	case it.last == nil && len(it.start) == 0:
+		k, v = c.First()
+	case it.last == nil:
+		k, v = c.Seek(it.start)
+	default:
+		k, v = c.Seek(it.last)
+		if bytes.Equal(k, it.last) {
+			k, v = c.Next()
+		}
+	}
+
+	for ; k != nil && len(it.keys) < iteratorPageSize; k, v = it.advance(c) {
+		if !it.inRange(k) {
+			it.exhausted = true
+			return nil
+		}
+		it.keys = append(it.keys, utils.CopyBytes(k))
+		it.values = append(it.values, utils.CopyBytes(v))
+	}
+	if k == nil {
+		it.exhausted = true
+	}
+	if len(it.keys) > 0 {
+		it.last = it.keys[len(it.keys)-1]
+	}
+	return nil
+}
+
+func (it *iterator) advance(c *bolt.Cursor) ([]byte, []byte) {
+	if it.reverse {
+		return c.Prev()
+	}
+	return c.Next()
+}
+
+// inRange returns true if [key] hasn't passed the end of the iteration range.
+func (it *iterator) inRange(key []byte) bool {
+	if it.reverse {
+		return bytes.Compare(key, it.start) >= 0
+	}
+	return len(it.limit) == 0 || bytes.Compare(key, it.limit) < 0
+}
+
+// Error implements the Iterator interface
+func (it *iterator) Error() error { return it.err }
+
+// Key implements the Iterator interface
+func (it *iterator) Key() []byte { return it.key }
+
+// Value implements the Iterator interface
+func (it *iterator) Value() []byte { return it.value }
+
+// Release implements the Iterator interface
+func (it *iterator) Release() {
+	it.key = nil
+	it.value = nil
+	it.keys = nil
+	it.values = nil
+	it.exhausted = true
+}
+
+// seekBefore moves [c] to the last key that is less than [key]. An empty [key]
+// is treated as a key after all keys.
+func seekBefore(c *bolt.Cursor, key []byte) ([]byte, []byte) {
+	if len(key) == 0 {
+		return c.Last()
+	}
+	if k, _ := c.Seek(key); k == nil {
+		return c.Last()
+	}
+	return c.Prev()
+}
+
+func updateError(err error) error {
+	switch err {
+	case bolt.ErrDatabaseNotOpen, bolt.ErrTxClosed:
+		return database.ErrClosed
+	default:
+		return err
+	}
+}
@@ -0,0 +1,208 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package boltdb
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/liraxapp/avalanchego/database"
+)
+
+func TestInterface(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boltdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for i, test := range database.Tests {
+		folder := filepath.Join(dir, fmt.Sprintf("db%d", i))
+
+		db, err := New(folder, 0)
+		if err != nil {
+			t.Fatalf("boltdb.New(%s, 0) errored with %s", folder, err)
+		}
+
+		test(t, db)
+		_ = db.Close()
+	}
+}
+
+func TestIteratorPaging(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boltdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := New(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	numKeys := 3*iteratorPageSize + 1
+	batch := db.NewBatch()
+	for i := 0; i < numKeys; i++ {
+		if err := batch.Put([]byte(fmt.Sprintf("%08d", i)), []byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+
+	iterator := db.NewIterator()
+	defer iterator.Release()
+	for i := 0; i < numKeys; i++ {
+		expectedKey := []byte(fmt.Sprintf("%08d", i))
+		if !iterator.Next() {
+			t.Fatalf("iterator stopped after %d keys", i)
+		} else if key := iterator.Key(); !bytes.Equal(key, expectedKey) {
+			t.Fatalf("iterator.Key Returned: %s ; Expected: %s", key, expectedKey)
+		}
+	}
+	if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	}
+
+	reverseIterator := db.NewReverseIterator()
+	defer reverseIterator.Release()
+	for i := numKeys - 1; i >= 0; i-- {
+		expectedKey := []byte(fmt.Sprintf("%08d", i))
+		if !reverseIterator.Next() {
+			t.Fatalf("reverse iterator stopped at key %d", i)
+		} else if key := reverseIterator.Key(); !bytes.Equal(key, expectedKey) {
+			t.Fatalf("reverseIterator.Key Returned: %s ; Expected: %s", key, expectedKey)
+		}
+	}
+	if reverseIterator.Next() {
+		t.Fatalf("reverseIterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := reverseIterator.Error(); err != nil {
+		t.Fatalf("reverseIterator.Error Returned: %s ; Expected: nil", err)
+	}
+}
+
+func TestReopen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boltdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	key := []byte("hello")
+	value := []byte("world")
+
+	db, err := New(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put(key, value); err != nil {
+		t.Fatal(err)
+	} else if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err = New(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if v, err := db.Get(key); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(v, value) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+	}
+}
+
+func TestSnapshotLifetime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boltdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := New(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	key := []byte("hello")
+	value := []byte("world")
+	if err := db.Put(key, value); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := db.NewSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer snapshot.Release()
+
+	expiringSnapshot, err := db.NewSnapshotWithLifetime(10 * time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := expiringSnapshot.Get(key); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Get: %s", err)
+	} else if !bytes.Equal(v, value) {
+		t.Fatalf("snapshot.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if _, err := expiringSnapshot.Get(key); err != ErrSnapshotExpired {
+		t.Fatalf("snapshot.Get: Returned: %v ; Expected: %s", err, ErrSnapshotExpired)
+	}
+	// Releasing an expired snapshot should be a no-op
+	expiringSnapshot.Release()
+
+	// Snapshots without a lifetime are held until they are released
+	if v, err := snapshot.Get(key); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Get: %s", err)
+	} else if !bytes.Equal(v, value) {
+		t.Fatalf("snapshot.Get: Returned: 0x%x ; Expected: 0x%x", v, value)
+	}
+}
+
+func TestConcurrentPuts(t *testing.T) {
+	dir, err := ioutil.TempDir("", "boltdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := New(dir, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	numWriters := 8
+	errs := make(chan error, numWriters)
+	for i := 0; i < numWriters; i++ {
+		go func(i int) {
+			errs <- db.Put([]byte{byte(i)}, []byte{byte(i)})
+		}(i)
+	}
+	for i := 0; i < numWriters; i++ {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < numWriters; i++ {
+		if v, err := db.Get([]byte{byte(i)}); err != nil {
+			t.Fatalf("Unexpected error on db.Get: %s", err)
+		} else if !bytes.Equal(v, []byte{byte(i)}) {
+			t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, []byte{byte(i)})
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compressdb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Algorithm is the name of a compression algorithm
+type Algorithm string
+
+// Supported compression algorithms
+const (
+	// None stores every value uncompressed. A database that was written with
+	// compression can still be read with it.
+	None   Algorithm = "none"
+	Snappy Algorithm = "snappy"
+	Zstd   Algorithm = "zstd"
+)
+
+// Every stored value starts with a tag saying how the rest of it is encoded
+const (
+	rawTag byte = iota
+	snappyTag
+	zstdTag
+)
+
+var (
+	errUnknownAlgorithm = errors.New("unknown compression algorithm")
+	errUnknownTag       = errors.New("unknown compression tag")
+	errEmptyValue       = errors.New("stored value is missing its compression tag")
+
+	// EncodeAll and DecodeAll are safe for concurrent use. Creating them only
+	// fails when given invalid options.
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// Algorithms lists the supported compression algorithms
+var Algorithms = []Algorithm{None, Snappy, Zstd}
+
+// ParseAlgorithm returns the algorithm named [name]
+func ParseAlgorithm(name string) (Algorithm, error) {
+	for _, algorithm := range Algorithms {
+		if string(algorithm) == name {
+			return algorithm, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", errUnknownAlgorithm, name)
+}
+
+func (a Algorithm) tag() (byte, error) {
+	switch a {
+	case None:
+		return rawTag, nil
+	case Snappy:
+		return snappyTag, nil
+	case Zstd:
+		return zstdTag, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", errUnknownAlgorithm, a)
+	}
+}
+
+// compress returns [value] compressed with the algorithm of [tag] and prefixed
+// with that tag. If compressing doesn't make [value] smaller, it is stored raw.
+func compress(tag byte, value []byte) ([]byte, error) {
+	var compressed []byte
+	switch tag {
+	case snappyTag:
+		compressed = snappy.Encode(nil, value)
+	case zstdTag:
+		compressed = zstdEncoder.EncodeAll(value, nil)
+	}
+	if tag == rawTag || len(compressed) >= len(value) {
+		tag = rawTag
+		compressed = value
+	}
+	encoded := make([]byte, len(compressed)+1)
+	encoded[0] = tag
+	copy(encoded[1:], compressed)
+	return encoded, nil
+}
+
+// decompress returns the value encoded by compress
+func decompress(encoded []byte) ([]byte, error) {
+	if len(encoded) == 0 {
+		return nil, errEmptyValue
+	}
+	switch tag, value := encoded[0], encoded[1:]; tag {
+	case rawTag:
+		return value, nil
+	case snappyTag:
+		return snappy.Decode(nil, value)
+	case zstdTag:
+		return zstdDecoder.DecodeAll(value, nil)
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnknownTag, tag)
+	}
+}
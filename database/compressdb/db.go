@@ -0,0 +1,418 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compressdb
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/nodb"
+	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/timer"
+)
+
+// DefaultThreshold is the default size, in bytes, below which values are
+// stored uncompressed
+const DefaultThreshold = 64
+
+// Database compresses all values that are provided
+type Database struct {
+	metrics
+	lock      sync.RWMutex
+	tag       byte
+	threshold int
+	db        database.Database
+	clock     timer.Clock
+}
+
+// New returns a new database that compresses values of at least [threshold]
+// bytes with [algorithm]. Values written with any algorithm can be read, so
+// the algorithm of an existing database can be changed.
+func New(
+	namespace string,
+	registerer prometheus.Registerer,
+	algorithm Algorithm,
+	threshold int,
+	db database.Database,
+) (*Database, error) {
+	tag, err := algorithm.tag()
+	if err != nil {
+		return nil, err
+	}
+	compressDB := &Database{
+		tag:       tag,
+		threshold: threshold,
+		db:        db,
+	}
+	return compressDB, compressDB.metrics.Initialize(namespace, registerer)
+}
+
+// Has implements the Database interface
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return false, database.ErrClosed
+	}
+	return db.db.Has(key)
+}
+
+// Get implements the Database interface
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	compressedVal, err := db.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return db.decompressValue(compressedVal)
+}
+
+// Put implements the Database interface
+func (db *Database) Put(key, value []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+
+	compressedValue, err := db.compressValue(value)
+	if err != nil {
+		return err
+	}
+	return db.db.Put(key, compressedValue)
+}
+
+// Delete implements the Database interface
+func (db *Database) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return db.db.Delete(key)
+}
+
+// NewBatch implements the Database interface
+func (db *Database) NewBatch() database.Batch {
+	return &batch{
+		Batch: db.db.NewBatch(),
+		db:    db,
+	}
+}
+
+// NewIterator implements the Database interface
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the Database interface
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Database interface
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Database interface
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       db,
+	}
+}
+
+// NewIteratorWithRange implements the Database interface
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewIteratorWithRange(start, limit),
+		db:       db,
+	}
+}
+
+// NewReverseIterator implements the Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithRange(nil, nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewReverseIteratorWithPrefix(prefix),
+		db:       db,
+	}
+}
+
+// NewReverseIteratorWithRange implements the Database interface
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewReverseIteratorWithRange(start, limit),
+		db:       db,
+	}
+}
+
+// NewSnapshot implements the Database interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	snapshot, err := db.db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &snap{
+		Snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
+// Stat implements the Database interface
+func (db *Database) Stat(stat string) (string, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return "", database.ErrClosed
+	}
+	return db.db.Stat(stat)
+}
+
+// Compact implements the Database interface
+func (db *Database) Compact(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	return db.db.Compact(start, limit)
+}
+
+// Close implements the Database interface
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	db.db = nil
+	return nil
+}
+
+type keyValue struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+type batch struct {
+	database.Batch
+
+	db     *Database
+	writes []keyValue
+}
+
+func (b *batch) Put(key, value []byte) error {
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false})
+	compressedValue, err := b.db.compressValue(value)
+	if err != nil {
+		return err
+	}
+	return b.Batch.Put(key, compressedValue)
+}
+
+func (b *batch) Delete(key []byte) error {
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
+	return b.Batch.Delete(key)
+}
+
+func (b *batch) Write() error {
+	b.db.lock.Lock()
+	defer b.db.lock.Unlock()
+
+	if b.db.db == nil {
+		return database.ErrClosed
+	}
+
+	return b.Batch.Write()
+}
+
+// Reset resets the batch for reuse.
+func (b *batch) Reset() {
+	if cap(b.writes) > len(b.writes)*database.MaxExcessCapacityFactor {
+		b.writes = make([]keyValue, 0, cap(b.writes)/database.CapacityReductionFactor)
+	} else {
+		b.writes = b.writes[:0]
+	}
+	b.Batch.Reset()
+}
+
+// Replay replays the batch contents.
+func (b *batch) Replay(w database.KeyValueWriter) error {
+	for _, keyvalue := range b.writes {
+		if keyvalue.delete {
+			if err := w.Delete(keyvalue.key); err != nil {
+				return err
+			}
+		} else if err := w.Put(keyvalue.key, keyvalue.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// snap decompresses the values read from a snapshot of the underlying database
+type snap struct {
+	database.Snapshot
+	db *Database
+}
+
+func (s *snap) Get(key []byte) ([]byte, error) {
+	compressedVal, err := s.Snapshot.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.db.decompressValue(compressedVal)
+}
+
+func (s *snap) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snap) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snap) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snap) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+}
+
+func (s *snap) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithRange(start, limit),
+		db:       s.db,
+	}
+}
+
+func (s *snap) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithRange(nil, nil)
+}
+
+func (s *snap) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewReverseIteratorWithPrefix(prefix),
+		db:       s.db,
+	}
+}
+
+func (s *snap) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewReverseIteratorWithRange(start, limit),
+		db:       s.db,
+	}
+}
+
+type iterator struct {
+	database.Iterator
+	db *Database
+
+	val []byte
+	err error
+}
+
+func (it *iterator) Next() bool {
+	next := it.Iterator.Next()
+	if next {
+		compressedVal := it.Iterator.Value()
+		val, err := it.db.decompressValue(compressedVal)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.val = val
+	} else {
+		it.val = nil
+	}
+	return next
+}
+
+func (it *iterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.Iterator.Error()
+}
+
+func (it *iterator) Value() []byte { return it.val }
+
+func (db *Database) compressValue(value []byte) ([]byte, error) {
+	tag := db.tag
+	if len(value) < db.threshold {
+		tag = rawTag
+	}
+
+	start := db.clock.Time()
+	compressed, err := compress(tag, value)
+	end := db.clock.Time()
+	if err != nil {
+		return nil, err
+	}
+	db.compress.Observe(float64(end.Sub(start)))
+	db.observeWrite(len(value), len(compressed))
+	return compressed, nil
+}
+
+func (db *Database) decompressValue(compressed []byte) ([]byte, error) {
+	start := db.clock.Time()
+	value, err := decompress(compressed)
+	end := db.clock.Time()
+	db.decompress.Observe(float64(end.Sub(start)))
+	return value, err
+}
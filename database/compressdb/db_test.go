@@ -0,0 +1,126 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compressdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/memdb"
+)
+
+func TestInterface(t *testing.T) {
+	for _, algorithm := range Algorithms {
+		for _, threshold := range []int{0, DefaultThreshold} {
+			for _, test := range database.Tests {
+				db, err := New("", prometheus.NewRegistry(), algorithm, threshold, memdb.New())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				test(t, db)
+			}
+		}
+	}
+}
+
+func TestCompression(t *testing.T) {
+	compressible := bytes.Repeat([]byte("avalanche"), 100)
+	small := []byte("tiny")
+
+	for _, algorithm := range []Algorithm{Snappy, Zstd} {
+		baseDB := memdb.New()
+		db, err := New("", prometheus.NewRegistry(), algorithm, DefaultThreshold, baseDB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := db.Put([]byte{1}, compressible); err != nil {
+			t.Fatal(err)
+		} else if err := db.Put([]byte{2}, small); err != nil {
+			t.Fatal(err)
+		}
+
+		if stored, err := baseDB.Get([]byte{1}); err != nil {
+			t.Fatal(err)
+		} else if len(stored) >= len(compressible) {
+			t.Fatalf("%s didn't compress the value: stored %d bytes of a %d byte value", algorithm, len(stored), len(compressible))
+		}
+		if stored, err := baseDB.Get([]byte{2}); err != nil {
+			t.Fatal(err)
+		} else if stored[0] != rawTag || !bytes.Equal(stored[1:], small) {
+			t.Fatalf("%s should have stored the value below the threshold raw", algorithm)
+		}
+		if ratio := db.ratio(); ratio >= 1 {
+			t.Fatalf("%s reported a compression ratio of %f", algorithm, ratio)
+		}
+
+		// Values can be read after the algorithm is changed
+		for _, otherAlgorithm := range Algorithms {
+			otherDB, err := New("", prometheus.NewRegistry(), otherAlgorithm, DefaultThreshold, baseDB)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value, err := otherDB.Get([]byte{1}); err != nil {
+				t.Fatal(err)
+			} else if !bytes.Equal(value, compressible) {
+				t.Fatalf("%s returned the wrong value written with %s", otherAlgorithm, algorithm)
+			}
+		}
+	}
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	for _, algorithm := range Algorithms {
+		if parsed, err := ParseAlgorithm(string(algorithm)); err != nil {
+			t.Fatal(err)
+		} else if parsed != algorithm {
+			t.Fatalf("Parsed %q as %q", algorithm, parsed)
+		}
+	}
+	if _, err := ParseAlgorithm("lz4"); err == nil {
+		t.Fatalf("Should have errored parsing an unknown algorithm")
+	}
+}
+
+func TestCorruptValue(t *testing.T) {
+	baseDB := memdb.New()
+	db, err := New("", prometheus.NewRegistry(), Snappy, 0, baseDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := baseDB.Put([]byte{1}, nil); err != nil {
+		t.Fatal(err)
+	} else if _, err := db.Get([]byte{1}); err == nil {
+		t.Fatalf("Should have errored reading a value without a tag")
+	}
+	if err := baseDB.Put([]byte{1}, []byte{255, 1}); err != nil {
+		t.Fatal(err)
+	} else if _, err := db.Get([]byte{1}); err == nil {
+		t.Fatalf("Should have errored reading a value with an unknown tag")
+	}
+}
+
+func TestReadReferenceZstd(t *testing.T) {
+	// A frame written by the reference zstd implementation, as values written
+	// by earlier versions of this database were
+	frame := []byte{
+		0x28, 0xb5, 0x2f, 0xfd, 0x04, 0x58, 0x65, 0x00, 0x00, 0x30, 0x68, 0x65,
+		0x6c, 0x6c, 0x6f, 0x20, 0x01, 0x00, 0x02, 0x95, 0x22, 0x5a, 0xb1, 0x76,
+		0x1b,
+	}
+	expected := []byte("hello hello hello hello hello hello hello hello")
+
+	value, err := decompress(append([]byte{zstdTag}, frame...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(value, expected) {
+		t.Fatalf("decompress returned %q ; Expected %q", value, expected)
+	}
+}
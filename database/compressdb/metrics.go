@@ -0,0 +1,81 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compressdb
+
+import (
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liraxapp/avalanchego/utils/timer"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+type metrics struct {
+	// Number of value bytes written before and after compression. These are
+	// first in the struct so they are aligned for atomic access.
+	uncompressedBytes,
+	compressedBytes uint64
+
+	compress,
+	decompress prometheus.Histogram
+}
+
+func (m *metrics) Initialize(
+	namespace string,
+	registerer prometheus.Registerer,
+) error {
+	m.compress = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "compress",
+		Help:      "Latency of compressing a value in nanoseconds",
+		Buckets:   timer.NanosecondsBuckets,
+	})
+	m.decompress = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "decompress",
+		Help:      "Latency of decompressing a value in nanoseconds",
+		Buckets:   timer.NanosecondsBuckets,
+	})
+	uncompressedBytes := prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "uncompressed_bytes",
+		Help:      "Number of value bytes written, before compression",
+	}, func() float64 { return float64(atomic.LoadUint64(&m.uncompressedBytes)) })
+	compressedBytes := prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "compressed_bytes",
+		Help:      "Number of value bytes written, after compression",
+	}, func() float64 { return float64(atomic.LoadUint64(&m.compressedBytes)) })
+	ratio := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "compression_ratio",
+		Help:      "Size of the written values after compression relative to their size before compression",
+	}, m.ratio)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.compress),
+		registerer.Register(m.decompress),
+		registerer.Register(uncompressedBytes),
+		registerer.Register(compressedBytes),
+		registerer.Register(ratio),
+	)
+	return errs.Err
+}
+
+// observeWrite records that [uncompressed] bytes were stored as [compressed]
+// bytes
+func (m *metrics) observeWrite(uncompressed, compressed int) {
+	atomic.AddUint64(&m.uncompressedBytes, uint64(uncompressed))
+	atomic.AddUint64(&m.compressedBytes, uint64(compressed))
+}
+
+func (m *metrics) ratio() float64 {
+	uncompressed := atomic.LoadUint64(&m.uncompressedBytes)
+	if uncompressed == 0 {
+		return 1
+	}
+	return float64(atomic.LoadUint64(&m.compressedBytes)) / float64(uncompressed)
+}
@@ -56,6 +56,7 @@ type Database interface {
 	KeyValueWriter
 	Batcher
 	Iteratee
+	Snapshotter
 	Stater
 	Compacter
 	io.Closer
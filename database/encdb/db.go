@@ -6,6 +6,7 @@ package encdb
 import (
 	"crypto/cipher"
 	"crypto/rand"
+	"errors"
 	"sync"
 
 	"golang.org/x/crypto/chacha20poly1305"
@@ -14,35 +15,67 @@ import (
 	"github.com/liraxapp/avalanchego/database/nodb"
 	"github.com/liraxapp/avalanchego/utils"
 	"github.com/liraxapp/avalanchego/utils/codec"
-	"github.com/liraxapp/avalanchego/utils/hashing"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
 )
 
 const (
-	codecVersion = 0
+	// unkeyedCodecVersion is the format of values written before keys were
+	// versioned. They are always encrypted with key 0.
+	unkeyedCodecVersion = 0
+	// keyedCodecVersion is the format of values that are prefixed with the ID
+	// of the key they were encrypted with
+	keyedCodecVersion = 1
 )
 
+var errUnknownCodecVersion = errors.New("unknown encrypted value codec version")
+
 // Database encrypts all values that are provided
 type Database struct {
-	lock   sync.RWMutex
-	codec  codec.Manager
-	cipher cipher.AEAD
-	db     database.Database
+	lock     sync.RWMutex
+	codec    codec.Manager
+	provider KeyProvider
+	db       database.Database
+
+	cipherLock sync.Mutex
+	// currentKeyID is the ID of the key new values are encrypted with
+	currentKeyID uint32
+	// ciphers caches the ciphers of the keys fetched from [provider]
+	ciphers map[uint32]cipher.AEAD
 }
 
-// New returns a new encrypted database
+// New returns a new encrypted database with a key derived from [password]
 func New(password []byte, db database.Database) (*Database, error) {
-	h := hashing.ComputeHash256(password)
-	aead, err := chacha20poly1305.NewX(h)
+	return NewWithKeyProvider(NewPasswordKeyProvider(password), db)
+}
+
+// NewWithKeyProvider returns a new encrypted database that uses the keys
+// supplied by [provider]
+func NewWithKeyProvider(provider KeyProvider, db database.Database) (*Database, error) {
+	c := codec.NewDefault()
+	manager := codec.NewDefaultManager()
+	errs := wrappers.Errs{}
+	errs.Add(
+		manager.RegisterCodec(unkeyedCodecVersion, c),
+		manager.RegisterCodec(keyedCodecVersion, c),
+	)
+	if errs.Errored() {
+		return nil, errs.Err
+	}
+
+	keyID, err := provider.CurrentKeyID()
 	if err != nil {
 		return nil, err
 	}
-	c := codec.NewDefault()
-	manager := codec.NewDefaultManager()
-	return &Database{
-		codec:  manager,
-		cipher: aead,
-		db:     db,
-	}, manager.RegisterCodec(codecVersion, c)
+	encDB := &Database{
+		codec:        manager,
+		provider:     provider,
+		db:           db,
+		currentKeyID: keyID,
+		ciphers:      make(map[uint32]cipher.AEAD),
+	}
+	// Make sure the current key is usable before any values are written
+	_, err = encDB.cipher(keyID)
+	return encDB, err
 }
 
 // Has implements the Database interface
@@ -135,6 +168,71 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	}
 }
 
+// NewIteratorWithRange implements the Database interface
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewIteratorWithRange(start, limit),
+		db:       db,
+	}
+}
+
+// NewReverseIterator implements the Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithRange(nil, nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewReverseIteratorWithPrefix(prefix),
+		db:       db,
+	}
+}
+
+// NewReverseIteratorWithRange implements the Database interface
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return &iterator{
+		Iterator: db.db.NewReverseIteratorWithRange(start, limit),
+		db:       db,
+	}
+}
+
+// NewSnapshot implements the Database interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	snapshot, err := db.db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &snap{
+		Snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	db.lock.RLock()
@@ -173,6 +271,8 @@ type keyValue struct {
 	key    []byte
 	value  []byte
 	delete bool
+	// keyID is the ID of the key [value] was encrypted with
+	keyID uint32
 }
 
 type batch struct {
@@ -183,16 +283,20 @@ type batch struct {
 }
 
 func (b *batch) Put(key, value []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false})
 	encValue, err := b.db.encrypt(value)
 	if err != nil {
 		return err
 	}
+	keyID, err := KeyID(encValue)
+	if err != nil {
+		return err
+	}
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), utils.CopyBytes(value), false, keyID})
 	return b.Batch.Put(key, encValue)
 }
 
 func (b *batch) Delete(key []byte) error {
-	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true})
+	b.writes = append(b.writes, keyValue{utils.CopyBytes(key), nil, true, 0})
 	return b.Batch.Delete(key)
 }
 
@@ -204,9 +308,48 @@ func (b *batch) Write() error {
 		return database.ErrClosed
 	}
 
+	// If the current key changed since the values were encrypted, a rotation
+	// may already have passed their keys, so they're encrypted again
+	keyID, _, err := b.db.currentCipher()
+	if err != nil {
+		return err
+	}
+	for _, kv := range b.writes {
+		if !kv.delete && kv.keyID != keyID {
+			if err := b.reencrypt(); err != nil {
+				return err
+			}
+			break
+		}
+	}
 	return b.Batch.Write()
 }
 
+// reencrypt replaces the writes of the underlying batch with writes of values
+// encrypted with the current key
+func (b *batch) reencrypt() error {
+	b.Batch.Reset()
+	for i, kv := range b.writes {
+		if kv.delete {
+			if err := b.Batch.Delete(kv.key); err != nil {
+				return err
+			}
+			continue
+		}
+		encValue, err := b.db.encrypt(kv.value)
+		if err != nil {
+			return err
+		}
+		if b.writes[i].keyID, err = KeyID(encValue); err != nil {
+			return err
+		}
+		if err := b.Batch.Put(kv.key, encValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Reset resets the batch for reuse.
 func (b *batch) Reset() {
 	if cap(b.writes) > len(b.writes)*database.MaxExcessCapacityFactor {
@@ -231,6 +374,64 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 	return nil
 }
 
+// snap decrypts the values read from a snapshot of the underlying database
+type snap struct {
+	database.Snapshot
+	db *Database
+}
+
+func (s *snap) Get(key []byte) ([]byte, error) {
+	encVal, err := s.Snapshot.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return s.db.decrypt(encVal)
+}
+
+func (s *snap) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snap) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snap) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snap) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+}
+
+func (s *snap) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithRange(start, limit),
+		db:       s.db,
+	}
+}
+
+func (s *snap) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithRange(nil, nil)
+}
+
+func (s *snap) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewReverseIteratorWithPrefix(prefix),
+		db:       s.db,
+	}
+}
+
+func (s *snap) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return &iterator{
+		Iterator: s.Snapshot.NewReverseIteratorWithRange(start, limit),
+		db:       s.db,
+	}
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
@@ -269,22 +470,113 @@ type encryptedValue struct {
 	Nonce      []byte `serialize:"true"`
 }
 
+type keyedEncryptedValue struct {
+	KeyID      uint32 `serialize:"true"`
+	Ciphertext []byte `serialize:"true"`
+	Nonce      []byte `serialize:"true"`
+}
+
+// cipher returns the cipher of the key with ID [keyID], fetching the key from
+// the provider if it hasn't been used yet
+func (db *Database) cipher(keyID uint32) (cipher.AEAD, error) {
+	db.cipherLock.Lock()
+	defer db.cipherLock.Unlock()
+
+	return db.cipherLocked(keyID)
+}
+
+func (db *Database) cipherLocked(keyID uint32) (cipher.AEAD, error) {
+	if aead, ok := db.ciphers[keyID]; ok {
+		return aead, nil
+	}
+	key, err := db.provider.Key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	db.ciphers[keyID] = aead
+	return aead, nil
+}
+
+// currentCipher returns the ID and the cipher of the key new values should be
+// encrypted with
+func (db *Database) currentCipher() (uint32, cipher.AEAD, error) {
+	db.cipherLock.Lock()
+	defer db.cipherLock.Unlock()
+
+	aead, err := db.cipherLocked(db.currentKeyID)
+	return db.currentKeyID, aead, err
+}
+
 func (db *Database) encrypt(plaintext []byte) ([]byte, error) {
+	keyID, aead, err := db.currentCipher()
+	if err != nil {
+		return nil, err
+	}
 	nonce := make([]byte, chacha20poly1305.NonceSizeX)
 	if _, err := rand.Read(nonce); err != nil {
 		return nil, err
 	}
-	ciphertext := db.cipher.Seal(nil, nonce, plaintext, nil)
-	return db.codec.Marshal(codecVersion, &encryptedValue{
+	ciphertext := aead.Seal(nil, nonce, plaintext, nil)
+	return db.codec.Marshal(keyedCodecVersion, &keyedEncryptedValue{
+		KeyID:      keyID,
 		Ciphertext: ciphertext,
 		Nonce:      nonce,
 	})
 }
 
 func (db *Database) decrypt(ciphertext []byte) ([]byte, error) {
-	val := encryptedValue{}
-	if _, err := db.codec.Unmarshal(ciphertext, &val); err != nil {
+	val, err := db.parse(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := db.cipher(val.KeyID)
+	if err != nil {
 		return nil, err
 	}
-	return db.cipher.Open(nil, val.Nonce, val.Ciphertext, nil)
+	return aead.Open(nil, val.Nonce, val.Ciphertext, nil)
+}
+
+// KeyID returns the ID of the key that [value], as stored in the underlying
+// database, was encrypted with
+func KeyID(value []byte) (uint32, error) {
+	p := wrappers.Packer{Bytes: value}
+	switch version := p.UnpackShort(); {
+	case p.Errored():
+		return 0, p.Err
+	case version == unkeyedCodecVersion:
+		return 0, nil
+	case version == keyedCodecVersion:
+		keyID := p.UnpackInt()
+		return keyID, p.Err
+	default:
+		return 0, errUnknownCodecVersion
+	}
+}
+
+// parse unmarshals an encrypted value written in any of the supported formats
+func (db *Database) parse(ciphertext []byte) (*keyedEncryptedValue, error) {
+	p := wrappers.Packer{Bytes: ciphertext}
+	switch version := p.UnpackShort(); {
+	case p.Errored():
+		return nil, p.Err
+	case version == unkeyedCodecVersion:
+		val := encryptedValue{}
+		if _, err := db.codec.Unmarshal(ciphertext, &val); err != nil {
+			return nil, err
+		}
+		return &keyedEncryptedValue{
+			Ciphertext: val.Ciphertext,
+			Nonce:      val.Nonce,
+		}, nil
+	case version == keyedCodecVersion:
+		val := &keyedEncryptedValue{}
+		_, err := db.codec.Unmarshal(ciphertext, val)
+		return val, err
+	default:
+		return nil, errUnknownCodecVersion
+	}
 }
@@ -4,8 +4,11 @@
 package encdb
 
 import (
+	"bytes"
 	"testing"
 
+	"golang.org/x/crypto/chacha20poly1305"
+
 	"github.com/liraxapp/avalanchego/database"
 	"github.com/liraxapp/avalanchego/database/memdb"
 )
@@ -22,3 +25,108 @@ func TestInterface(t *testing.T) {
 		test(t, db)
 	}
 }
+
+func TestUnkeyedValues(t *testing.T) {
+	pw := []byte("lol totally a secure password")
+	unencryptedDB := memdb.New()
+	db, err := New(pw, unencryptedDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Write a value in the format used before keys were versioned
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	aead, err := db.cipher(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacyValue, err := db.codec.Marshal(unkeyedCodecVersion, &encryptedValue{
+		Ciphertext: aead.Seal(nil, nonce, []byte("hello"), nil),
+		Nonce:      nonce,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := unencryptedDB.Put([]byte{1}, legacyValue); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, err := db.Get([]byte{1}); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(value, []byte("hello")) {
+		t.Fatalf("Wrong value returned")
+	}
+}
+
+func TestRotate(t *testing.T) {
+	keys := Keys{0: bytes.Repeat([]byte{0}, chacha20poly1305.KeySize)}
+	unencryptedDB := memdb.New()
+	db, err := NewWithKeyProvider(keys, unencryptedDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	numValues := 10
+	for i := 0; i < numValues; i++ {
+		if err := db.Put([]byte{byte(i)}, []byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A batch that is written after the rotation isn't left with the old key
+	batch := db.NewBatch()
+	if err := batch.Put([]byte{byte(numValues)}, []byte{byte(numValues)}); err != nil {
+		t.Fatal(err)
+	}
+
+	keys[1] = bytes.Repeat([]byte{1}, chacha20poly1305.KeySize)
+	if rotated, err := db.Rotate(3); err != nil {
+		t.Fatal(err)
+	} else if rotated != numValues {
+		t.Fatalf("Should have re-encrypted %d values but re-encrypted %d", numValues, rotated)
+	}
+	if err := batch.Write(); err != nil {
+		t.Fatal(err)
+	}
+
+	it := unencryptedDB.NewIterator()
+	for it.Next() {
+		if keyID, err := KeyID(it.Value()); err != nil {
+			t.Fatal(err)
+		} else if keyID != 1 {
+			t.Fatalf("Value is encrypted with key %d; Expected 1", keyID)
+		}
+	}
+	it.Release()
+
+	// A database that only knows the new key can read every value
+	newDB, err := NewWithKeyProvider(Keys{1: keys[1]}, unencryptedDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < numValues; i++ {
+		if value, err := newDB.Get([]byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		} else if !bytes.Equal(value, []byte{byte(i)}) {
+			t.Fatalf("Wrong value returned")
+		}
+	}
+
+	// Rotating again doesn't re-encrypt anything
+	if rotated, err := db.Rotate(3); err != nil {
+		t.Fatal(err)
+	} else if rotated != 0 {
+		t.Fatalf("Shouldn't have re-encrypted any values but re-encrypted %d", rotated)
+	}
+}
+
+func TestRotateUnknownKey(t *testing.T) {
+	db, err := NewWithKeyProvider(Keys{1: bytes.Repeat([]byte{1}, chacha20poly1305.KeySize)}, memdb.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.provider = Keys{}
+	if _, err := db.Rotate(0); err == nil {
+		t.Fatalf("Should have errored when the provider has no keys")
+	}
+}
@@ -0,0 +1,198 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/liraxapp/avalanchego/utils/hashing"
+)
+
+// Sources that keys can be read from
+const (
+	FileKeys   = "file"
+	EnvKeys    = "env"
+	SocketKeys = "socket"
+)
+
+// KeySources lists the sources that keys can be read from
+var KeySources = []string{FileKeys, EnvKeys, SocketKeys}
+
+var (
+	errUnknownKeySource = errors.New("unknown encryption key source")
+	errNoKeys           = errors.New("no encryption keys were provided")
+	errUnknownKeyID     = errors.New("unknown encryption key ID")
+	errMalformedKey     = errors.New("malformed encryption key entry")
+	errDuplicateKeyID   = errors.New("duplicated encryption key ID")
+)
+
+// KeyProvider supplies the keys used to encrypt and decrypt values. Keys are
+// identified by an ID that is stored alongside every value, so values written
+// with an old key can still be read after a new key becomes current.
+type KeyProvider interface {
+	// Key returns the 32 byte key with ID [keyID]
+	Key(keyID uint32) ([]byte, error)
+
+	// CurrentKeyID returns the ID of the key new values should be encrypted
+	// with
+	CurrentKeyID() (uint32, error)
+}
+
+// Keys is a fixed set of keys. The key with the largest ID is the current
+// key.
+type Keys map[uint32][]byte
+
+// NewKeyProvider returns a provider that reads the keys from [source], which
+// should be one of KeySources. [location] is the path of the file, the name of
+// the environment variable or the path of the socket the keys are read from.
+func NewKeyProvider(source, location string) (KeyProvider, error) {
+	switch source {
+	case FileKeys:
+		return NewFileKeyProvider(location), nil
+	case EnvKeys:
+		return NewEnvKeyProvider(location), nil
+	case SocketKeys:
+		return NewSocketKeyProvider(location), nil
+	default:
+		return nil, fmt.Errorf("%w: %q, expected one of %v", errUnknownKeySource, source, KeySources)
+	}
+}
+
+// PasswordKey returns the key derived from [password]
+func PasswordKey(password []byte) []byte { return hashing.ComputeHash256(password) }
+
+// NewPasswordKeyProvider returns a provider whose only key, with ID 0, is
+// derived from [password]
+func NewPasswordKeyProvider(password []byte) KeyProvider {
+	return Keys{0: PasswordKey(password)}
+}
+
+// Key implements the KeyProvider interface
+func (k Keys) Key(keyID uint32) ([]byte, error) {
+	key, ok := k[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", errUnknownKeyID, keyID)
+	}
+	return key, nil
+}
+
+// CurrentKeyID implements the KeyProvider interface
+func (k Keys) CurrentKeyID() (uint32, error) {
+	if len(k) == 0 {
+		return 0, errNoKeys
+	}
+	current := uint32(0)
+	for keyID := range k {
+		if keyID > current {
+			current = keyID
+		}
+	}
+	return current, nil
+}
+
+// ParseKeys parses a list of keys of the form <id>:<hex encoded key>,
+// separated by whitespace or commas
+func ParseKeys(s string) (Keys, error) {
+	entries := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+	if len(entries) == 0 {
+		return nil, errNoKeys
+	}
+
+	keys := make(Keys, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("%w: %q", errMalformedKey, entry)
+		}
+		keyID, err := strconv.ParseUint(parts[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errMalformedKey, err)
+		}
+		key, err := hex.DecodeString(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errMalformedKey, err)
+		}
+		if len(key) != chacha20poly1305.KeySize {
+			return nil, fmt.Errorf("%w: key %d is %d bytes but should be %d", errMalformedKey, keyID, len(key), chacha20poly1305.KeySize)
+		}
+		if _, exists := keys[uint32(keyID)]; exists {
+			return nil, fmt.Errorf("%w: %d", errDuplicateKeyID, keyID)
+		}
+		keys[uint32(keyID)] = key
+	}
+	return keys, nil
+}
+
+// fileKeyProvider reads the keys from a file every time they are requested,
+// so new keys can be added to the file while the database is open
+type fileKeyProvider struct{ path string }
+
+// NewFileKeyProvider returns a provider that reads the keys, in the format
+// accepted by ParseKeys, from the file at [path]
+func NewFileKeyProvider(path string) KeyProvider { return &fileKeyProvider{path: path} }
+
+func (p *fileKeyProvider) keys() (Keys, error) {
+	b, err := ioutil.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseKeys(string(b))
+}
+
+func (p *fileKeyProvider) Key(keyID uint32) ([]byte, error) {
+	keys, err := p.keys()
+	if err != nil {
+		return nil, err
+	}
+	return keys.Key(keyID)
+}
+
+func (p *fileKeyProvider) CurrentKeyID() (uint32, error) {
+	keys, err := p.keys()
+	if err != nil {
+		return 0, err
+	}
+	return keys.CurrentKeyID()
+}
+
+// envKeyProvider reads the keys from an environment variable
+type envKeyProvider struct{ name string }
+
+// NewEnvKeyProvider returns a provider that reads the keys, in the format
+// accepted by ParseKeys, from the environment variable [name]
+func NewEnvKeyProvider(name string) KeyProvider { return &envKeyProvider{name: name} }
+
+func (p *envKeyProvider) keys() (Keys, error) {
+	value, ok := os.LookupEnv(p.name)
+	if !ok {
+		return nil, fmt.Errorf("%w: environment variable %s isn't set", errNoKeys, p.name)
+	}
+	return ParseKeys(value)
+}
+
+func (p *envKeyProvider) Key(keyID uint32) ([]byte, error) {
+	keys, err := p.keys()
+	if err != nil {
+		return nil, err
+	}
+	return keys.Key(keyID)
+}
+
+func (p *envKeyProvider) CurrentKeyID() (uint32, error) {
+	keys, err := p.keys()
+	if err != nil {
+		return 0, err
+	}
+	return keys.CurrentKeyID()
+}
@@ -0,0 +1,126 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/liraxapp/avalanchego/database/memdb"
+)
+
+var (
+	testKey0 = bytes.Repeat([]byte{0xaa}, 32)
+	testKey1 = bytes.Repeat([]byte{0xbb}, 32)
+
+	testKeys = fmt.Sprintf("0:%s, 1:%s\n", hex.EncodeToString(testKey0), hex.EncodeToString(testKey1))
+)
+
+func checkProvider(t *testing.T, provider KeyProvider) {
+	if keyID, err := provider.CurrentKeyID(); err != nil {
+		t.Fatal(err)
+	} else if keyID != 1 {
+		t.Fatalf("Wrong current key ID: Returned: %d ; Expected: 1", keyID)
+	}
+	if key, err := provider.Key(0); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(key, testKey0) {
+		t.Fatalf("Wrong key returned")
+	}
+	if _, err := provider.Key(2); err == nil {
+		t.Fatalf("Should have errored fetching an unknown key")
+	}
+
+	db, err := NewWithKeyProvider(provider, memdb.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put([]byte{1}, []byte{2}); err != nil {
+		t.Fatal(err)
+	} else if value, err := db.Get([]byte{1}); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(value, []byte{2}) {
+		t.Fatalf("Wrong value returned")
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	keys, err := ParseKeys(testKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkProvider(t, keys)
+
+	tests := map[string]error{
+		"":     errNoKeys,
+		"0":    errMalformedKey,
+		"x:00": errMalformedKey,
+		"0:zz": errMalformedKey,
+		"0:00": errMalformedKey,
+		testKeys + " 1:" + hex.EncodeToString(testKey0): errDuplicateKeyID,
+	}
+	for input, expectedErr := range tests {
+		if _, err := ParseKeys(input); !errors.Is(err, expectedErr) {
+			t.Fatalf("ParseKeys(%q) returned %v but should have returned %v", input, err, expectedErr)
+		}
+	}
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "encdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "keys")
+	if err := ioutil.WriteFile(path, []byte(testKeys), 0600); err != nil {
+		t.Fatal(err)
+	}
+	checkProvider(t, NewFileKeyProvider(path))
+}
+
+func TestEnvKeyProvider(t *testing.T) {
+	name := "ENCDB_TEST_KEYS"
+	if err := os.Setenv(name, testKeys); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Unsetenv(name)
+
+	checkProvider(t, NewEnvKeyProvider(name))
+
+	if _, err := NewEnvKeyProvider("ENCDB_TEST_UNSET_KEYS").CurrentKeyID(); err == nil {
+		t.Fatalf("Should have errored when the environment variable isn't set")
+	}
+}
+
+func TestSocketKeyProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "encdb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	keys, err := ParseKeys(testKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "kms.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() { _ = ServeKeys(listener, keys) }()
+
+	checkProvider(t, NewSocketKeyProvider(path))
+}
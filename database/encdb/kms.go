@@ -0,0 +1,99 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"time"
+)
+
+const (
+	keyServiceName = "KeyService"
+	kmsDialTimeout = 5 * time.Second
+)
+
+// KeyService serves the keys of a KeyProvider to processes connecting over a
+// local socket. It is meant to stand in for an external key management
+// service.
+type KeyService struct{ provider KeyProvider }
+
+// KeyArgs are the arguments to KeyService.Key
+type KeyArgs struct {
+	KeyID uint32 `json:"keyID"`
+}
+
+// KeyReply is the reply from KeyService.Key
+type KeyReply struct {
+	Key []byte `json:"key"`
+}
+
+// CurrentKeyIDArgs are the arguments to KeyService.CurrentKeyID
+type CurrentKeyIDArgs struct{}
+
+// CurrentKeyIDReply is the reply from KeyService.CurrentKeyID
+type CurrentKeyIDReply struct {
+	KeyID uint32 `json:"keyID"`
+}
+
+// Key returns the key with the requested ID
+func (s *KeyService) Key(args *KeyArgs, reply *KeyReply) error {
+	key, err := s.provider.Key(args.KeyID)
+	reply.Key = key
+	return err
+}
+
+// CurrentKeyID returns the ID of the current key
+func (s *KeyService) CurrentKeyID(_ *CurrentKeyIDArgs, reply *CurrentKeyIDReply) error {
+	keyID, err := s.provider.CurrentKeyID()
+	reply.KeyID = keyID
+	return err
+}
+
+// ServeKeys serves the keys of [provider] to the connections accepted by
+// [listener] until [listener] is closed
+func ServeKeys(listener net.Listener, provider KeyProvider) error {
+	server := rpc.NewServer()
+	if err := server.RegisterName(keyServiceName, &KeyService{provider: provider}); err != nil {
+		return err
+	}
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go server.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
+
+// socketKeyProvider fetches keys from a KeyService listening on a unix socket
+type socketKeyProvider struct{ path string }
+
+// NewSocketKeyProvider returns a provider that fetches the keys from a
+// KeyService listening on the unix socket at [path]
+func NewSocketKeyProvider(path string) KeyProvider { return &socketKeyProvider{path: path} }
+
+func (p *socketKeyProvider) call(method string, args, reply interface{}) error {
+	conn, err := net.DialTimeout("unix", p.path, kmsDialTimeout)
+	if err != nil {
+		return err
+	}
+	client := jsonrpc.NewClient(conn)
+	defer client.Close()
+
+	return client.Call(keyServiceName+"."+method, args, reply)
+}
+
+func (p *socketKeyProvider) Key(keyID uint32) ([]byte, error) {
+	reply := KeyReply{}
+	err := p.call("Key", &KeyArgs{KeyID: keyID}, &reply)
+	return reply.Key, err
+}
+
+func (p *socketKeyProvider) CurrentKeyID() (uint32, error) {
+	reply := CurrentKeyIDReply{}
+	err := p.call("CurrentKeyID", &CurrentKeyIDArgs{}, &reply)
+	return reply.KeyID, err
+}
@@ -0,0 +1,107 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/utils"
+)
+
+// DefaultRotationBatchSize is the number of values Rotate looks at while
+// holding the database's lock
+const DefaultRotationBatchSize = 1024
+
+// Rotate makes the provider's current key the key new values are encrypted
+// with, and re-encrypts every value that was encrypted with a different key.
+//
+// Values are re-encrypted [batchSize] at a time, and the database can be used
+// in between batches. Returns the number of values that were re-encrypted.
+func (db *Database) Rotate(batchSize int) (int, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultRotationBatchSize
+	}
+
+	keyID, err := db.provider.CurrentKeyID()
+	if err != nil {
+		return 0, err
+	}
+
+	db.cipherLock.Lock()
+	_, err = db.cipherLocked(keyID)
+	if err == nil {
+		db.currentKeyID = keyID
+	}
+	db.cipherLock.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	rotated := 0
+	start := []byte(nil)
+	for {
+		next, batchRotated, err := db.rotateBatch(start, keyID, batchSize)
+		rotated += batchRotated
+		if err != nil || next == nil {
+			return rotated, err
+		}
+		start = next
+	}
+}
+
+// rotateBatch re-encrypts the values of up to [batchSize] keys, starting at
+// [start], that weren't encrypted with [keyID]. Returns the key to continue
+// from, or nil if there are no more keys.
+func (db *Database) rotateBatch(start []byte, keyID uint32, batchSize int) ([]byte, int, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return nil, 0, database.ErrClosed
+	}
+
+	it := db.db.NewIteratorWithStart(start)
+	defer it.Release()
+
+	batch := db.db.NewBatch()
+	scanned, rotated := 0, 0
+	lastKey := []byte(nil)
+	for scanned < batchSize && it.Next() {
+		scanned++
+		lastKey = utils.CopyBytes(it.Key())
+
+		encValue := it.Value()
+		val, err := db.parse(encValue)
+		if err != nil {
+			return nil, 0, err
+		}
+		if val.KeyID == keyID {
+			continue
+		}
+
+		plaintext, err := db.decrypt(encValue)
+		if err != nil {
+			return nil, 0, err
+		}
+		newEncValue, err := db.encrypt(plaintext)
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := batch.Put(lastKey, newEncValue); err != nil {
+			return nil, 0, err
+		}
+		rotated++
+	}
+	if err := it.Error(); err != nil {
+		return nil, 0, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, 0, err
+	}
+
+	if scanned < batchSize {
+		return nil, rotated, nil
+	}
+	// Continue from the key immediately after the last key that was scanned
+	return append(lastKey, 0), rotated, nil
+}
@@ -0,0 +1,50 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package engine
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/boltdb"
+	"github.com/liraxapp/avalanchego/database/leveldb"
+)
+
+// Names of the supported on-disk database engines
+const (
+	LevelDB = leveldb.Name
+	BoltDB  = boltdb.Name
+)
+
+// Version is the version of the on-disk database format. It is included in
+// the path of the database so that incompatible formats are never mixed.
+const Version = "v1.0.0"
+
+// Names lists the supported on-disk database engines
+var Names = []string{LevelDB, BoltDB}
+
+// Path returns the directory, under the data directory [dbDir], that the
+// database of type [engine] for the network [networkName] is stored in.
+// LevelDB databases are stored directly under the network directory so that
+// existing data directories keep working.
+func Path(dbDir, networkName, engine string) string {
+	if engine == LevelDB {
+		return path.Join(dbDir, networkName, Version)
+	}
+	return path.Join(dbDir, networkName, engine, Version)
+}
+
+// Open opens, creating if needed, the on-disk database of type [engine] stored
+// at [path]
+func Open(engine, path string) (database.Database, error) {
+	switch engine {
+	case LevelDB:
+		return leveldb.New(path, 0, 0, 0)
+	case BoltDB:
+		return boltdb.New(path, 0)
+	default:
+		return nil, fmt.Errorf("unknown database engine %q, expected one of %v", engine, Names)
+	}
+}
@@ -7,7 +7,8 @@
 
 package database
 
-// Iterator iterates over a database's key/value pairs in ascending key order.
+// Iterator iterates over a database's key/value pairs in ascending key order,
+// or in descending key order if it was created as a reverse iterator.
 //
 // When it encounters an error any seek will return false and will yield no key/
 // value pairs. The error can be queried by calling the Error method. Calling
@@ -55,4 +56,39 @@ type Iteratee interface {
 	// a subset of database content with a particular key prefix starting at a
 	// specified key.
 	NewIteratorWithStartAndPrefix(start, prefix []byte) Iterator
+
+	// NewIteratorWithRange creates a binary-alphabetical iterator over the
+	// subset of database content with keys in the range [start, limit). A nil
+	// or empty start is treated as a key before all keys in the database, and
+	// a nil or empty limit is treated as a key after all keys in the database.
+	NewIteratorWithRange(start, limit []byte) Iterator
+
+	// NewReverseIterator creates a reverse binary-alphabetical iterator over
+	// the entire keyspace contained within the key-value database.
+	NewReverseIterator() Iterator
+
+	// NewReverseIteratorWithPrefix creates a reverse binary-alphabetical
+	// iterator over a subset of database content with a particular key prefix.
+	NewReverseIteratorWithPrefix(prefix []byte) Iterator
+
+	// NewReverseIteratorWithRange creates a reverse binary-alphabetical
+	// iterator over the subset of database content with keys in the range
+	// [start, limit). The bounds are treated the same way as in
+	// NewIteratorWithRange.
+	NewReverseIteratorWithRange(start, limit []byte) Iterator
+}
+
+// PrefixRange returns the key range [start, limit) that contains exactly the
+// keys that begin with [prefix]. If every key is greater than or equal to
+// [prefix], the returned limit is nil.
+func PrefixRange(prefix []byte) (start []byte, limit []byte) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if c := prefix[i]; c < 0xff {
+			limit = make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i] = c + 1
+			break
+		}
+	}
+	return prefix, limit
 }
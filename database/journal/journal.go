@@ -0,0 +1,157 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package journal provides crash safe commits of batches that were built on top
+// of different databases sharing the same base database.
+package journal
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/liraxapp/avalanchego/database"
+)
+
+var errNotRecovered = errors.New("journal must be recovered before committing")
+
+// Journal writes a set of batches to a base database such that either all of
+// them are eventually applied or none of them are.
+//
+// Before the batches are applied, their contents are recorded in a separate
+// journal database. The record is removed once the batches have been written.
+// If the node crashes in between, Recover replays the record on the next
+// startup. A record that was only partially persisted is discarded, which rolls
+// the commit back, as none of its writes could have been applied yet.
+type Journal struct {
+	lock sync.Mutex
+
+	// journal holds the records of the commits that are in flight
+	journal database.Database
+
+	// db is the database the batches are applied to
+	db database.Database
+
+	// nextID is the key of the next record to be written
+	nextID uint64
+
+	// recovered is set once all previously recorded commits have been
+	// applied. It is cleared if a commit was only partially applied, as no
+	// more commits may be made until that commit is finished.
+	recovered bool
+}
+
+// New returns a new journal that records commits in [journal] and applies them
+// to [db]. Recover must be called before any commits are made.
+func New(journal, db database.Database) *Journal {
+	return &Journal{
+		journal: journal,
+		db:      db,
+	}
+}
+
+// Commit atomically writes [batches] to the base database. Every batch must
+// write to [db], either directly or through its Inner batch.
+func (j *Journal) Commit(batches ...database.Batch) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if !j.recovered {
+		return errNotRecovered
+	}
+
+	r := &record{}
+	for _, batch := range batches {
+		if err := batch.Inner().Replay(r); err != nil {
+			return err
+		}
+	}
+	if len(r.ops) == 0 {
+		return nil
+	}
+
+	recordBytes, err := r.Bytes()
+	if err != nil {
+		return err
+	}
+
+	key := recordKey(j.nextID)
+	if err := j.journal.Put(key, recordBytes); err != nil {
+		return fmt.Errorf("couldn't record commit: %w", err)
+	}
+	j.nextID++
+
+	if err := j.apply(key, r); err != nil {
+		// The record may have been partially applied. It is left in the journal
+		// so the commit is finished by the next call to Recover.
+		j.recovered = false
+		return fmt.Errorf("couldn't apply commit: %w", err)
+	}
+	return nil
+}
+
+// Recover finishes every commit that was recorded but not removed from the
+// journal, in the order they were made. It returns the number of commits that
+// were replayed and the number that were rolled back.
+func (j *Journal) Recover() (int, int, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	type pending struct {
+		key   []byte
+		value []byte
+	}
+	records := []pending(nil)
+
+	it := j.journal.NewIterator()
+	for it.Next() {
+		records = append(records, pending{
+			key:   it.Key(),
+			value: it.Value(),
+		})
+	}
+	err := it.Error()
+	it.Release()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	replayed, rolledBack := 0, 0
+	for _, p := range records {
+		r, err := parseRecord(p.value)
+		if err != nil {
+			if err := j.journal.Delete(p.key); err != nil {
+				return replayed, rolledBack, err
+			}
+			rolledBack++
+			continue
+		}
+		if err := j.apply(p.key, r); err != nil {
+			return replayed, rolledBack, err
+		}
+		replayed++
+	}
+
+	j.nextID = 0
+	j.recovered = true
+	return replayed, rolledBack, nil
+}
+
+// apply writes [r] to the base database and then removes it from the journal
+func (j *Journal) apply(key []byte, r *record) error {
+	batch := j.db.NewBatch()
+	if err := r.Replay(batch); err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	return j.journal.Delete(key)
+}
+
+func recordKey(id uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, id)
+	return key
+}
@@ -0,0 +1,214 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package journal
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/memdb"
+	"github.com/liraxapp/avalanchego/database/prefixdb"
+	"github.com/liraxapp/avalanchego/database/versiondb"
+)
+
+var errTest = errors.New("non-nil error")
+
+type failingDB struct{ database.Database }
+
+func (db *failingDB) NewBatch() database.Batch { return &failingBatch{db.Database.NewBatch()} }
+
+type failingBatch struct{ database.Batch }
+
+func (b *failingBatch) Write() error { return errTest }
+
+func newJournal(t *testing.T, baseDB database.Database) *Journal {
+	j := New(prefixdb.New([]byte("journal"), baseDB), baseDB)
+	if _, _, err := j.Recover(); err != nil {
+		t.Fatal(err)
+	}
+	return j
+}
+
+func TestCommit(t *testing.T) {
+	baseDB := memdb.New()
+	j := newJournal(t, baseDB)
+
+	db0 := versiondb.New(prefixdb.New([]byte{0}, baseDB))
+	db1 := versiondb.New(prefixdb.New([]byte{1}, baseDB))
+	key := []byte("hello")
+	value0 := []byte("world")
+	value1 := []byte("there")
+	if err := db0.Put(key, value0); err != nil {
+		t.Fatal(err)
+	} else if err := db1.Put(key, value1); err != nil {
+		t.Fatal(err)
+	}
+
+	batch0, err := db0.CommitBatch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch1, err := db1.CommitBatch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Commit(batch0, batch1); err != nil {
+		t.Fatal(err)
+	}
+	db0.Abort()
+	db1.Abort()
+
+	if v, err := db0.Get(key); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(v, value0) {
+		t.Fatalf("Wrong value returned")
+	} else if v, err := db1.Get(key); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(v, value1) {
+		t.Fatalf("Wrong value returned")
+	}
+
+	it := j.journal.NewIterator()
+	defer it.Release()
+	if it.Next() {
+		t.Fatalf("Journal should be empty after a successful commit")
+	}
+}
+
+func TestCommitBeforeRecover(t *testing.T) {
+	baseDB := memdb.New()
+	j := New(prefixdb.New([]byte("journal"), baseDB), baseDB)
+
+	batch := baseDB.NewBatch()
+	if err := batch.Put([]byte{1}, []byte{2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Commit(batch); err != errNotRecovered {
+		t.Fatalf("Should have errored committing before recovering")
+	}
+}
+
+func TestRecoverReplays(t *testing.T) {
+	baseDB := memdb.New()
+	journalDB := prefixdb.New([]byte("journal"), baseDB)
+
+	if err := baseDB.Put([]byte{2}, []byte{2}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &record{}
+	if err := r.Put([]byte{1}, []byte{1}); err != nil {
+		t.Fatal(err)
+	} else if err := r.Delete([]byte{2}); err != nil {
+		t.Fatal(err)
+	}
+	recordBytes, err := r.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := journalDB.Put(recordKey(0), recordBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	j := New(journalDB, baseDB)
+	replayed, rolledBack, err := j.Recover()
+	switch {
+	case err != nil:
+		t.Fatal(err)
+	case replayed != 1:
+		t.Fatalf("Should have replayed 1 commit but replayed %d", replayed)
+	case rolledBack != 0:
+		t.Fatalf("Shouldn't have rolled back any commits but rolled back %d", rolledBack)
+	}
+
+	if v, err := baseDB.Get([]byte{1}); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(v, []byte{1}) {
+		t.Fatalf("Wrong value returned")
+	}
+	if has, err := baseDB.Has([]byte{2}); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatalf("Deleted key should have been removed")
+	}
+	if has, err := journalDB.Has(recordKey(0)); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatalf("Replayed record should have been removed")
+	}
+}
+
+func TestRecoverRollsBackTornRecord(t *testing.T) {
+	baseDB := memdb.New()
+	journalDB := prefixdb.New([]byte("journal"), baseDB)
+
+	r := &record{}
+	if err := r.Put([]byte{1}, []byte{1}); err != nil {
+		t.Fatal(err)
+	}
+	recordBytes, err := r.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := journalDB.Put(recordKey(0), recordBytes[:len(recordBytes)-1]); err != nil {
+		t.Fatal(err)
+	}
+
+	j := New(journalDB, baseDB)
+	replayed, rolledBack, err := j.Recover()
+	switch {
+	case err != nil:
+		t.Fatal(err)
+	case replayed != 0:
+		t.Fatalf("Shouldn't have replayed any commits but replayed %d", replayed)
+	case rolledBack != 1:
+		t.Fatalf("Should have rolled back 1 commit but rolled back %d", rolledBack)
+	}
+
+	if has, err := baseDB.Has([]byte{1}); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatalf("Rolled back commit shouldn't have been applied")
+	}
+	if has, err := journalDB.Has(recordKey(0)); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatalf("Rolled back record should have been removed")
+	}
+}
+
+func TestCommitApplyFailure(t *testing.T) {
+	baseDB := memdb.New()
+	journalDB := prefixdb.New([]byte("journal"), baseDB)
+	j := New(journalDB, &failingDB{Database: baseDB})
+	if _, _, err := j.Recover(); err != nil {
+		t.Fatal(err)
+	}
+
+	batch := baseDB.NewBatch()
+	if err := batch.Put([]byte{1}, []byte{1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Commit(batch); err == nil {
+		t.Fatalf("Should have errored applying the commit")
+	}
+	if err := j.Commit(batch); err != errNotRecovered {
+		t.Fatalf("Should have refused to commit after a failed commit")
+	}
+
+	// Simulate restarting the node with a working database
+	j = New(journalDB, baseDB)
+	if replayed, _, err := j.Recover(); err != nil {
+		t.Fatal(err)
+	} else if replayed != 1 {
+		t.Fatalf("Should have replayed 1 commit but replayed %d", replayed)
+	}
+	if v, err := baseDB.Get([]byte{1}); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(v, []byte{1}) {
+		t.Fatalf("Wrong value returned")
+	}
+}
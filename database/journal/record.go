@@ -0,0 +1,123 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package journal
+
+import (
+	"bytes"
+	"errors"
+	"math"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/hashing"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+const recordVersion = 0
+
+var (
+	errBadChecksum         = errors.New("journal record checksum mismatch")
+	errUnknownVersion      = errors.New("unknown journal record version")
+	errTrailingRecordBytes = errors.New("journal record has trailing bytes")
+)
+
+type op struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// record is the set of writes made by a single commit. Its serialized form is
+// a checksum of the body followed by the body, so a record that was only
+// partially persisted is detected rather than applied.
+type record struct {
+	ops []op
+}
+
+// Put implements the database.KeyValueWriter interface
+func (r *record) Put(key, value []byte) error {
+	r.ops = append(r.ops, op{
+		key:   utils.CopyBytes(key),
+		value: utils.CopyBytes(value),
+	})
+	return nil
+}
+
+// Delete implements the database.KeyValueWriter interface
+func (r *record) Delete(key []byte) error {
+	r.ops = append(r.ops, op{
+		key:    utils.CopyBytes(key),
+		delete: true,
+	})
+	return nil
+}
+
+// Replay writes every operation of the record to [w] in order
+func (r *record) Replay(w database.KeyValueWriter) error {
+	for _, op := range r.ops {
+		if op.delete {
+			if err := w.Delete(op.key); err != nil {
+				return err
+			}
+		} else if err := w.Put(op.key, op.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *record) Bytes() ([]byte, error) {
+	p := wrappers.Packer{MaxSize: math.MaxInt32}
+	p.PackByte(recordVersion)
+	p.PackInt(uint32(len(r.ops)))
+	for _, op := range r.ops {
+		p.PackBool(op.delete)
+		p.PackBytes(op.key)
+		if !op.delete {
+			p.PackBytes(op.value)
+		}
+	}
+	if p.Errored() {
+		return nil, p.Err
+	}
+
+	checksum := hashing.ComputeHash256(p.Bytes)
+	return append(checksum, p.Bytes...), nil
+}
+
+func parseRecord(b []byte) (*record, error) {
+	if len(b) < hashing.HashLen {
+		return nil, errBadChecksum
+	}
+	checksum, body := b[:hashing.HashLen], b[hashing.HashLen:]
+	if !bytes.Equal(checksum, hashing.ComputeHash256(body)) {
+		return nil, errBadChecksum
+	}
+
+	p := wrappers.Packer{Bytes: body}
+	if version := p.UnpackByte(); p.Errored() || version != recordVersion {
+		return nil, errUnknownVersion
+	}
+
+	numOps := p.UnpackInt()
+	r := &record{}
+	for i := uint32(0); i < numOps && !p.Errored(); i++ {
+		op := op{
+			delete: p.UnpackBool(),
+			key:    p.UnpackBytes(),
+		}
+		if !op.delete {
+			op.value = p.UnpackBytes()
+		}
+		r.ops = append(r.ops, op)
+	}
+	switch {
+	case p.Errored():
+		return nil, p.Err
+	case p.Offset != len(body):
+		return nil, errTrailingRecordBytes
+	default:
+		return r, nil
+	}
+}
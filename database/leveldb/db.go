@@ -18,6 +18,9 @@ import (
 )
 
 const (
+	// Name is the name of this database engine
+	Name = "leveldb"
+
 	// minBlockCacheSize is the minimum number of bytes to use for block caching
 	// in leveldb.
 	minBlockCacheSize = 8 * opt.MiB
@@ -140,6 +143,44 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return &iter{db.DB.NewIterator(iterRange, nil)}
 }
 
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// database ignoring keys outside of the range [start, limit)
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return &iter{db.DB.NewIterator(newRange(start, limit), nil)}
+}
+
+// NewReverseIterator creates a reverse lexicographically ordered iterator over
+// the database
+func (db *Database) NewReverseIterator() database.Iterator {
+	return &reverseIter{iter: iter{db.DB.NewIterator(new(util.Range), nil)}}
+}
+
+// NewReverseIteratorWithPrefix creates a reverse lexicographically ordered
+// iterator over the database ignoring keys that do not start with the provided
+// prefix
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &reverseIter{iter: iter{db.DB.NewIterator(util.BytesPrefix(prefix), nil)}}
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the database ignoring keys outside of the range [start, limit)
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return &reverseIter{iter: iter{db.DB.NewIterator(newRange(start, limit), nil)}}
+}
+
+// NewSnapshot returns a read-only view of the current state of the database
+// backed by a native leveldb snapshot
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if db.errored {
+		return nil, database.ErrAvoidCorruption
+	}
+	snapshot, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, db.handleError(err)
+	}
+	return &snap{snapshot: snapshot, db: db}, nil
+}
+
 // Stat returns a particular internal stat of the database.
 func (db *Database) Stat(property string) (string, error) {
 	stat, err := db.DB.GetProperty(property)
@@ -244,6 +285,86 @@ func (r *replayer) Delete(key []byte) {
 	r.err = r.writer.Delete(key)
 }
 
+// snap is a wrapper around a leveldb snapshot.
+type snap struct {
+	snapshot *leveldb.Snapshot
+	db       *Database
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snap) Has(key []byte) (bool, error) {
+	if s.db.errored {
+		return false, database.ErrAvoidCorruption
+	}
+	has, err := s.snapshot.Has(key, nil)
+	return has, s.db.handleError(err)
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snap) Get(key []byte) ([]byte, error) {
+	if s.db.errored {
+		return nil, database.ErrAvoidCorruption
+	}
+	value, err := s.snapshot.Get(key, nil)
+	return value, s.db.handleError(err)
+}
+
+// NewIterator creates a lexicographically ordered iterator over the snapshot
+func (s *snap) NewIterator() database.Iterator {
+	return &iter{s.snapshot.NewIterator(new(util.Range), nil)}
+}
+
+// NewIteratorWithStart creates a lexicographically ordered iterator over the
+// snapshot starting at the provided key
+func (s *snap) NewIteratorWithStart(start []byte) database.Iterator {
+	return &iter{s.snapshot.NewIterator(&util.Range{Start: start}, nil)}
+}
+
+// NewIteratorWithPrefix creates a lexicographically ordered iterator over the
+// snapshot ignoring keys that do not start with the provided prefix
+func (s *snap) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &iter{s.snapshot.NewIterator(util.BytesPrefix(prefix), nil)}
+}
+
+// NewIteratorWithStartAndPrefix creates a lexicographically ordered iterator
+// over the snapshot starting at start and ignoring keys that do not start with
+// the provided prefix
+func (s *snap) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return &iter{s.snapshot.NewIterator(iterRange, nil)}
+}
+
+// NewIteratorWithRange creates a lexicographically ordered iterator over the
+// snapshot ignoring keys outside of the range [start, limit)
+func (s *snap) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return &iter{s.snapshot.NewIterator(newRange(start, limit), nil)}
+}
+
+// NewReverseIterator creates a reverse lexicographically ordered iterator over
+// the snapshot
+func (s *snap) NewReverseIterator() database.Iterator {
+	return &reverseIter{iter: iter{s.snapshot.NewIterator(new(util.Range), nil)}}
+}
+
+// NewReverseIteratorWithPrefix creates a reverse lexicographically ordered
+// iterator over the snapshot ignoring keys that do not start with the provided
+// prefix
+func (s *snap) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return &reverseIter{iter: iter{s.snapshot.NewIterator(util.BytesPrefix(prefix), nil)}}
+}
+
+// NewReverseIteratorWithRange creates a reverse lexicographically ordered
+// iterator over the snapshot ignoring keys outside of the range [start, limit)
+func (s *snap) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return &reverseIter{iter: iter{s.snapshot.NewIterator(newRange(start, limit), nil)}}
+}
+
+// Release releases the underlying leveldb snapshot
+func (s *snap) Release() { s.snapshot.Release() }
+
 type iter struct{ iterator.Iterator }
 
 // Error implements the Iterator interface
@@ -255,9 +376,33 @@ func (it *iter) Key() []byte { return utils.CopyBytes(it.Iterator.Key()) }
 // Value implements the Iterator interface
 func (it *iter) Value() []byte { return utils.CopyBytes(it.Iterator.Value()) }
 
+// reverseIter walks the underlying iterator from its last key to its first
+type reverseIter struct {
+	iter
+	initialized bool
+}
+
+// Next implements the Iterator interface
+func (it *reverseIter) Next() bool {
+	if !it.initialized {
+		it.initialized = true
+		return it.Iterator.Last()
+	}
+	return it.Iterator.Prev()
+}
+
+// newRange returns the leveldb range [start, limit). An empty limit is treated
+// as a key after all keys.
+func newRange(start, limit []byte) *util.Range {
+	if len(limit) == 0 {
+		limit = nil
+	}
+	return &util.Range{Start: start, Limit: limit}
+}
+
 func updateError(err error) error {
 	switch err {
-	case leveldb.ErrClosed:
+	case leveldb.ErrClosed, leveldb.ErrSnapshotReleased:
 		return database.ErrClosed
 	case leveldb.ErrNotFound:
 		return database.ErrNotFound
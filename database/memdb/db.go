@@ -23,6 +23,10 @@ const (
 type Database struct {
 	lock sync.RWMutex
 	db   map[string][]byte
+
+	// True if [db] is referenced by a snapshot. If so, the map must be copied
+	// before it is modified.
+	shared bool
 }
 
 // New returns a map with the Database interface methods implemented.
@@ -78,6 +82,7 @@ func (db *Database) Put(key []byte, value []byte) error {
 	if db.db == nil {
 		return database.ErrClosed
 	}
+	db.unshare()
 	db.db[string(key)] = utils.CopyBytes(value)
 	return nil
 }
@@ -90,6 +95,7 @@ func (db *Database) Delete(key []byte) error {
 	if db.db == nil {
 		return database.ErrClosed
 	}
+	db.unshare()
 	delete(db.db, string(key))
 	return nil
 }
@@ -114,30 +120,50 @@ func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
 
 // NewIteratorWithStartAndPrefix implements the Database interface
 func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return db.newIterator(start, nil, prefix, false)
+}
+
+// NewIteratorWithRange implements the Database interface
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return db.newIterator(start, limit, nil, false)
+}
+
+// NewReverseIterator implements the Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithRange(nil, nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.newIterator(nil, nil, prefix, true)
+}
+
+// NewReverseIteratorWithRange implements the Database interface
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return db.newIterator(start, limit, nil, true)
+}
+
+func (db *Database) newIterator(start, limit, prefix []byte, reverse bool) database.Iterator {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
 
 	if db.db == nil {
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return newIterator(db.db, start, limit, prefix, reverse)
+}
 
-	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.db))
-	for key := range db.db {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
-		}
-	}
-	sort.Strings(keys) // Keys need to be in sorted order
-	values := make([][]byte, 0, len(keys))
-	for _, key := range keys {
-		values = append(values, db.db[key])
-	}
-	return &iterator{
-		keys:   keys,
-		values: values,
+// NewSnapshot implements the Database interface. The snapshot shares the
+// current map with the database, which copies it on the next write.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
 	}
+	db.shared = true
+	return &snapshot{db: db.db}, nil
 }
 
 // Stat implements the Database interface
@@ -154,6 +180,20 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return nil
 }
 
+// unshare copies the underlying map if it is referenced by a snapshot. Assumes
+// the write lock is held.
+func (db *Database) unshare() {
+	if !db.shared {
+		return
+	}
+	newDB := make(map[string][]byte, len(db.db))
+	for key, value := range db.db {
+		newDB[key] = value
+	}
+	db.db = newDB
+	db.shared = false
+}
+
 type keyValue struct {
 	key    []byte
 	value  []byte
@@ -189,6 +229,7 @@ func (b *batch) Write() error {
 	if b.db.db == nil {
 		return database.ErrClosed
 	}
+	b.db.unshare()
 
 	for _, kv := range b.writes {
 		key := string(kv.key)
@@ -228,6 +269,129 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 // Inner returns itself
 func (b *batch) Inner() database.Batch { return b }
 
+// snapshot is an immutable view of the map held by the database at the time the
+// snapshot was taken.
+type snapshot struct {
+	lock sync.RWMutex
+	db   map[string][]byte
+}
+
+// Has implements the Snapshot interface
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.db == nil {
+		return false, database.ErrClosed
+	}
+	_, ok := s.db[string(key)]
+	return ok, nil
+}
+
+// Get implements the Snapshot interface
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.db == nil {
+		return nil, database.ErrClosed
+	}
+	if entry, ok := s.db[string(key)]; ok {
+		return utils.CopyBytes(entry), nil
+	}
+	return nil, database.ErrNotFound
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return s.newIterator(start, nil, prefix, false)
+}
+
+// NewIteratorWithRange implements the Snapshot interface
+func (s *snapshot) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.newIterator(start, limit, nil, false)
+}
+
+// NewReverseIterator implements the Snapshot interface
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithRange(nil, nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Snapshot interface
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.newIterator(nil, nil, prefix, true)
+}
+
+// NewReverseIteratorWithRange implements the Snapshot interface
+func (s *snapshot) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.newIterator(start, limit, nil, true)
+}
+
+func (s *snapshot) newIterator(start, limit, prefix []byte, reverse bool) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(s.db, start, limit, prefix, reverse)
+}
+
+// Release implements the Snapshot interface
+func (s *snapshot) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.db = nil
+}
+
+// newIterator returns an iterator over the sorted keys of [db] that start with
+// [prefix] and are in the range [start, limit). An empty [limit] is treated as
+// a key after all keys. If [reverse] is true, the keys are sorted in
+// descending order.
+func newIterator(db map[string][]byte, start, limit, prefix []byte, reverse bool) *iterator {
+	startString := string(start)
+	limitString := string(limit)
+	prefixString := string(prefix)
+	keys := make([]string, 0, len(db))
+	for key := range db {
+		if strings.HasPrefix(key, prefixString) &&
+			key >= startString &&
+			(len(limitString) == 0 || key < limitString) {
+			keys = append(keys, key)
+		}
+	}
+	// Keys need to be in sorted order
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	} else {
+		sort.Strings(keys)
+	}
+	values := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, db[key])
+	}
+	return &iterator{
+		keys:   keys,
+		values: values,
+	}
+}
+
 type iterator struct {
 	initialized bool
 	keys        []string
@@ -105,6 +105,62 @@ func (db *Database) NewIteratorWithStartAndPrefix(
 	return it
 }
 
+// NewIteratorWithRange implements the Database interface
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	startTime := db.clock.Time()
+	it := &iterator{
+		iterator: db.db.NewIteratorWithRange(start, limit),
+		db:       db,
+	}
+	end := db.clock.Time()
+	db.newIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+// NewReverseIterator implements the Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithRange(nil, nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	startTime := db.clock.Time()
+	it := &iterator{
+		iterator: db.db.NewReverseIteratorWithPrefix(prefix),
+		db:       db,
+	}
+	end := db.clock.Time()
+	db.newIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+// NewReverseIteratorWithRange implements the Database interface
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	startTime := db.clock.Time()
+	it := &iterator{
+		iterator: db.db.NewReverseIteratorWithRange(start, limit),
+		db:       db,
+	}
+	end := db.clock.Time()
+	db.newIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+// NewSnapshot implements the Database interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	start := db.clock.Time()
+	snapshot, err := db.db.NewSnapshot()
+	end := db.clock.Time()
+	db.newSnapshot.Observe(float64(end.Sub(start)))
+	if err != nil {
+		return nil, err
+	}
+	return &snap{
+		snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	start := db.clock.Time()
@@ -192,6 +248,97 @@ func (b *batch) Inner() database.Batch {
 	return inner
 }
 
+type snap struct {
+	snapshot database.Snapshot
+	db       *Database
+}
+
+func (s *snap) Has(key []byte) (bool, error) {
+	start := s.db.clock.Time()
+	has, err := s.snapshot.Has(key)
+	end := s.db.clock.Time()
+	s.db.sHas.Observe(float64(end.Sub(start)))
+	return has, err
+}
+
+func (s *snap) Get(key []byte) ([]byte, error) {
+	start := s.db.clock.Time()
+	value, err := s.snapshot.Get(key)
+	end := s.db.clock.Time()
+	s.db.sGet.Observe(float64(end.Sub(start)))
+	return value, err
+}
+
+func (s *snap) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snap) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snap) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snap) NewIteratorWithStartAndPrefix(
+	start,
+	prefix []byte,
+) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (s *snap) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewIteratorWithRange(start, limit),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (s *snap) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithRange(nil, nil)
+}
+
+func (s *snap) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewReverseIteratorWithPrefix(prefix),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (s *snap) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	startTime := s.db.clock.Time()
+	it := &iterator{
+		iterator: s.snapshot.NewReverseIteratorWithRange(start, limit),
+		db:       s.db,
+	}
+	end := s.db.clock.Time()
+	s.db.sNewIterator.Observe(float64(end.Sub(startTime)))
+	return it
+}
+
+func (s *snap) Release() {
+	start := s.db.clock.Time()
+	s.snapshot.Release()
+	end := s.db.clock.Time()
+	s.db.sRelease.Observe(float64(end.Sub(start)))
+}
+
 type iterator struct {
 	iterator database.Iterator
 	db       *Database
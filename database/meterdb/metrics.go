@@ -28,6 +28,7 @@ type metrics struct {
 	delete,
 	newBatch,
 	newIterator,
+	newSnapshot,
 	stat,
 	compact,
 	close,
@@ -42,7 +43,11 @@ type metrics struct {
 	iError,
 	iKey,
 	iValue,
-	iRelease prometheus.Histogram
+	iRelease,
+	sHas,
+	sGet,
+	sNewIterator,
+	sRelease prometheus.Histogram
 }
 
 func (m *metrics) Initialize(
@@ -55,6 +60,7 @@ func (m *metrics) Initialize(
 	m.delete = newMetric(namespace, "delete")
 	m.newBatch = newMetric(namespace, "new_batch")
 	m.newIterator = newMetric(namespace, "new_iterator")
+	m.newSnapshot = newMetric(namespace, "new_snapshot")
 	m.stat = newMetric(namespace, "stat")
 	m.compact = newMetric(namespace, "compact")
 	m.close = newMetric(namespace, "close")
@@ -70,6 +76,10 @@ func (m *metrics) Initialize(
 	m.iKey = newMetric(namespace, "iterator_key")
 	m.iValue = newMetric(namespace, "iterator_value")
 	m.iRelease = newMetric(namespace, "iterator_release")
+	m.sHas = newMetric(namespace, "snapshot_has")
+	m.sGet = newMetric(namespace, "snapshot_get")
+	m.sNewIterator = newMetric(namespace, "snapshot_new_iterator")
+	m.sRelease = newMetric(namespace, "snapshot_release")
 
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -79,6 +89,7 @@ func (m *metrics) Initialize(
 		registerer.Register(m.delete),
 		registerer.Register(m.newBatch),
 		registerer.Register(m.newIterator),
+		registerer.Register(m.newSnapshot),
 		registerer.Register(m.stat),
 		registerer.Register(m.compact),
 		registerer.Register(m.close),
@@ -94,6 +105,10 @@ func (m *metrics) Initialize(
 		registerer.Register(m.iKey),
 		registerer.Register(m.iValue),
 		registerer.Register(m.iRelease),
+		registerer.Register(m.sHas),
+		registerer.Register(m.sGet),
+		registerer.Register(m.sNewIterator),
+		registerer.Register(m.sRelease),
 	)
 	return errs.Err
 }
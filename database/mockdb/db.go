@@ -30,6 +30,11 @@ type Database struct {
 	OnNewIteratorWithStart          func([]byte) database.Iterator
 	OnNewIteratorWithPrefix         func([]byte) database.Iterator
 	OnNewIteratorWithStartAndPrefix func([]byte, []byte) database.Iterator
+	OnNewIteratorWithRange          func([]byte, []byte) database.Iterator
+	OnNewReverseIterator            func() database.Iterator
+	OnNewReverseIteratorWithPrefix  func([]byte) database.Iterator
+	OnNewReverseIteratorWithRange   func([]byte, []byte) database.Iterator
+	OnNewSnapshot                   func() (database.Snapshot, error)
 	OnStat                          func(string) (string, error)
 	OnCompact                       func([]byte, []byte) error
 	OnClose                         func() error
@@ -107,6 +112,46 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return db.OnNewIteratorWithStartAndPrefix(start, prefix)
 }
 
+// NewIteratorWithRange implements the database.Database interface
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	if db.OnNewIteratorWithRange == nil {
+		return nil
+	}
+	return db.OnNewIteratorWithRange(start, limit)
+}
+
+// NewReverseIterator implements the database.Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	if db.OnNewReverseIterator == nil {
+		return nil
+	}
+	return db.OnNewReverseIterator()
+}
+
+// NewReverseIteratorWithPrefix implements the database.Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	if db.OnNewReverseIteratorWithPrefix == nil {
+		return nil
+	}
+	return db.OnNewReverseIteratorWithPrefix(prefix)
+}
+
+// NewReverseIteratorWithRange implements the database.Database interface
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	if db.OnNewReverseIteratorWithRange == nil {
+		return nil
+	}
+	return db.OnNewReverseIteratorWithRange(start, limit)
+}
+
+// NewSnapshot implements the database.Database interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if db.OnNewSnapshot == nil {
+		return nil, errNoFunction
+	}
+	return db.OnNewSnapshot()
+}
+
 // Stat implements the database.Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	if db.OnStat == nil {
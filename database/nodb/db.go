@@ -39,6 +39,21 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return &Iterator{}
 }
 
+// NewIteratorWithRange returns a new empty iterator
+func (*Database) NewIteratorWithRange(_, _ []byte) database.Iterator { return &Iterator{} }
+
+// NewReverseIterator returns a new empty iterator
+func (*Database) NewReverseIterator() database.Iterator { return &Iterator{} }
+
+// NewReverseIteratorWithPrefix returns a new empty iterator
+func (*Database) NewReverseIteratorWithPrefix([]byte) database.Iterator { return &Iterator{} }
+
+// NewReverseIteratorWithRange returns a new empty iterator
+func (*Database) NewReverseIteratorWithRange(_, _ []byte) database.Iterator { return &Iterator{} }
+
+// NewSnapshot returns an error
+func (*Database) NewSnapshot() (database.Snapshot, error) { return nil, database.ErrClosed }
+
 // Stat returns an error
 func (*Database) Stat(string) (string, error) { return "", database.ErrClosed }
 
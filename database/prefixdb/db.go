@@ -165,6 +165,65 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	return it
 }
 
+// NewIteratorWithRange implements the Database interface.
+// It is safe to modify [start] and [limit] after this method returns.
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newIteratorWithRange(db.db, start, limit, false)
+}
+
+// NewReverseIterator implements the Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithRange(nil, nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface.
+// It is safe to modify [prefix] after this method returns.
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newReverseIteratorWithPrefix(db.db, prefix)
+}
+
+// NewReverseIteratorWithRange implements the Database interface.
+// It is safe to modify [start] and [limit] after this method returns.
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.newIteratorWithRange(db.db, start, limit, true)
+}
+
+// NewSnapshot implements the Database interface
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	snapshot, err := db.db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &snap{
+		Snapshot: snapshot,
+		db:       db,
+	}, nil
+}
+
 // Stat implements the Database interface
 func (db *Database) Stat(stat string) (string, error) {
 	db.lock.RLock()
@@ -220,6 +279,43 @@ func (db *Database) prefix(key []byte) []byte {
 	return prefixedKey
 }
 
+// newIteratorWithRange returns an iterator over the keys of [iteratee] in the
+// range [start, limit) of this database. An empty [limit] is treated as the
+// end of this database's keyspace.
+func (db *Database) newIteratorWithRange(iteratee database.Iteratee, start, limit []byte, reverse bool) database.Iterator {
+	prefixedStart := db.prefix(start)
+	var prefixedLimit []byte
+	if len(limit) == 0 {
+		_, prefixedLimit = database.PrefixRange(db.dbPrefix)
+	} else {
+		prefixedLimit = db.prefix(limit)
+	}
+
+	it := &iterator{db: db}
+	if reverse {
+		it.Iterator = iteratee.NewReverseIteratorWithRange(prefixedStart, prefixedLimit)
+	} else {
+		it.Iterator = iteratee.NewIteratorWithRange(prefixedStart, prefixedLimit)
+	}
+	db.bufferPool.Put(prefixedStart)
+	if len(limit) != 0 {
+		db.bufferPool.Put(prefixedLimit)
+	}
+	return it
+}
+
+// newReverseIteratorWithPrefix returns a reverse iterator over the keys of
+// [iteratee] that start with [prefix] in this database.
+func (db *Database) newReverseIteratorWithPrefix(iteratee database.Iteratee, prefix []byte) database.Iterator {
+	prefixedPrefix := db.prefix(prefix)
+	it := &iterator{
+		Iterator: iteratee.NewReverseIteratorWithPrefix(prefixedPrefix),
+		db:       db,
+	}
+	db.bufferPool.Put(prefixedPrefix)
+	return it
+}
+
 type keyValue struct {
 	key    []byte
 	value  []byte
@@ -309,6 +405,83 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 	return nil
 }
 
+// snap is a snapshot of the underlying database that only exposes keys
+// with this database's prefix
+type snap struct {
+	database.Snapshot
+	db *Database
+}
+
+// Has implements the Snapshot interface
+// [key] may be modified after this method returns.
+func (s *snap) Has(key []byte) (bool, error) {
+	prefixedKey := s.db.prefix(key)
+	has, err := s.Snapshot.Has(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return has, err
+}
+
+// Get implements the Snapshot interface
+// [key] may be modified after this method returns.
+func (s *snap) Get(key []byte) ([]byte, error) {
+	prefixedKey := s.db.prefix(key)
+	val, err := s.Snapshot.Get(prefixedKey)
+	s.db.bufferPool.Put(prefixedKey)
+	return val, err
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snap) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snap) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snap) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Snapshot interface
+// It is safe to modify [start] and [prefix] after this method returns.
+func (s *snap) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	prefixedStart := s.db.prefix(start)
+	prefixedPrefix := s.db.prefix(prefix)
+	it := &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(prefixedStart, prefixedPrefix),
+		db:       s.db,
+	}
+	s.db.bufferPool.Put(prefixedStart)
+	s.db.bufferPool.Put(prefixedPrefix)
+	return it
+}
+
+// NewIteratorWithRange implements the Snapshot interface
+// It is safe to modify [start] and [limit] after this method returns.
+func (s *snap) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.db.newIteratorWithRange(s.Snapshot, start, limit, false)
+}
+
+// NewReverseIterator implements the Snapshot interface
+func (s *snap) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithRange(nil, nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Snapshot interface
+// It is safe to modify [prefix] after this method returns.
+func (s *snap) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.db.newReverseIteratorWithPrefix(s.Snapshot, prefix)
+}
+
+// NewReverseIteratorWithRange implements the Snapshot interface
+// It is safe to modify [start] and [limit] after this method returns.
+func (s *snap) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.db.newIteratorWithRange(s.Snapshot, start, limit, true)
+}
+
 type iterator struct {
 	database.Iterator
 	db *Database
@@ -100,6 +100,54 @@ func (db *DatabaseClient) NewIteratorWithStartAndPrefix(start, prefix []byte) da
 	}
 }
 
+// NewIteratorWithRange returns a new iterator over the provided range
+func (db *DatabaseClient) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return db.newIteratorWithRange(start, limit, false)
+}
+
+// NewReverseIterator implements the Database interface
+func (db *DatabaseClient) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithRange(nil, nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *DatabaseClient) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewReverseIteratorWithRange(database.PrefixRange(prefix))
+}
+
+// NewReverseIteratorWithRange returns a new reverse iterator over the provided
+// range
+func (db *DatabaseClient) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return db.newIteratorWithRange(start, limit, true)
+}
+
+func (db *DatabaseClient) newIteratorWithRange(start, limit []byte, reverse bool) database.Iterator {
+	resp, err := db.client.NewIteratorWithRange(context.Background(), &rpcdbproto.NewIteratorWithRangeRequest{
+		Start:   start,
+		Limit:   limit,
+		Reverse: reverse,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: updateError(err)}
+	}
+	return &iterator{
+		db: db,
+		id: resp.Id,
+	}
+}
+
+// NewSnapshot attempts to take a snapshot of the remote database
+func (db *DatabaseClient) NewSnapshot() (database.Snapshot, error) {
+	resp, err := db.client.NewSnapshot(context.Background(), &rpcdbproto.NewSnapshotRequest{})
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return &snapshot{
+		db: db,
+		id: resp.Id,
+	}, nil
+}
+
 // Stat attempts to return the statistic of this database
 func (db *DatabaseClient) Stat(property string) (string, error) {
 	resp, err := db.client.Stat(context.Background(), &rpcdbproto.StatRequest{
@@ -204,6 +252,112 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 
 func (b *batch) Inner() database.Batch { return b }
 
+type snapshot struct {
+	db *DatabaseClient
+	id uint64
+}
+
+// Has attempts to return if the snapshot has a key with the provided value.
+func (s *snapshot) Has(key []byte) (bool, error) {
+	resp, err := s.db.client.SnapshotHas(context.Background(), &rpcdbproto.SnapshotHasRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return false, updateError(err)
+	}
+	return resp.Has, nil
+}
+
+// Get attempts to return the value that was mapped to the key that was
+// provided when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	resp, err := s.db.client.SnapshotGet(context.Background(), &rpcdbproto.SnapshotGetRequest{
+		Id:  s.id,
+		Key: key,
+	})
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return resp.Value, nil
+}
+
+// NewIterator implements the Snapshot interface
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the Snapshot interface
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Snapshot interface
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix returns a new iterator over the snapshot
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	resp, err := s.db.client.SnapshotNewIteratorWithStartAndPrefix(context.Background(), &rpcdbproto.SnapshotNewIteratorWithStartAndPrefixRequest{
+		Id:     s.id,
+		Start:  start,
+		Prefix: prefix,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: updateError(err)}
+	}
+	return &iterator{
+		db: s.db,
+		id: resp.Id,
+	}
+}
+
+// NewIteratorWithRange returns a new iterator over the provided range of the
+// snapshot
+func (s *snapshot) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.newIteratorWithRange(start, limit, false)
+}
+
+// NewReverseIterator implements the Snapshot interface
+func (s *snapshot) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithRange(nil, nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Snapshot interface
+func (s *snapshot) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewReverseIteratorWithRange(database.PrefixRange(prefix))
+}
+
+// NewReverseIteratorWithRange returns a new reverse iterator over the provided
+// range of the snapshot
+func (s *snapshot) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	return s.newIteratorWithRange(start, limit, true)
+}
+
+func (s *snapshot) newIteratorWithRange(start, limit []byte, reverse bool) database.Iterator {
+	resp, err := s.db.client.SnapshotNewIteratorWithRange(context.Background(), &rpcdbproto.SnapshotNewIteratorWithRangeRequest{
+		Id:      s.id,
+		Start:   start,
+		Limit:   limit,
+		Reverse: reverse,
+	})
+	if err != nil {
+		return &nodb.Iterator{Err: updateError(err)}
+	}
+	return &iterator{
+		db: s.db,
+		id: resp.Id,
+	}
+}
+
+// Release frees any resources held by the snapshot
+func (s *snapshot) Release() {
+	_, _ = s.db.client.SnapshotRelease(context.Background(), &rpcdbproto.SnapshotReleaseRequest{
+		Id: s.id,
+	})
+}
+
 type iterator struct {
 	db    *DatabaseClient
 	id    uint64
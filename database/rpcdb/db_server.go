@@ -25,6 +25,9 @@ type DatabaseServer struct {
 
 	nextIteratorID uint64
 	iterators      map[uint64]database.Iterator
+
+	nextSnapshotID uint64
+	snapshots      map[uint64]database.Snapshot
 }
 
 // NewServer returns a database instance that is managed remotely
@@ -33,6 +36,7 @@ func NewServer(db database.Database) *DatabaseServer {
 		db:        db,
 		batch:     db.NewBatch(),
 		iterators: make(map[uint64]database.Iterator),
+		snapshots: make(map[uint64]database.Snapshot),
 	}
 }
 
@@ -122,6 +126,23 @@ func (db *DatabaseServer) NewIteratorWithStartAndPrefix(_ context.Context, req *
 	return &rpcdbproto.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
 }
 
+// NewIteratorWithRange allocates an iterator over the requested range and
+// returns the iterator ID
+func (db *DatabaseServer) NewIteratorWithRange(_ context.Context, req *rpcdbproto.NewIteratorWithRangeRequest) (*rpcdbproto.NewIteratorWithStartAndPrefixResponse, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	id := db.nextIteratorID
+	if req.Reverse {
+		db.iterators[id] = db.db.NewReverseIteratorWithRange(req.Start, req.Limit)
+	} else {
+		db.iterators[id] = db.db.NewIteratorWithRange(req.Start, req.Limit)
+	}
+
+	db.nextIteratorID++
+	return &rpcdbproto.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
+}
+
 // IteratorNext attempts to call next on the requested iterator
 func (db *DatabaseServer) IteratorNext(_ context.Context, req *rpcdbproto.IteratorNextRequest) (*rpcdbproto.IteratorNextResponse, error) {
 	db.lock.Lock()
@@ -162,3 +183,116 @@ func (db *DatabaseServer) IteratorRelease(_ context.Context, req *rpcdbproto.Ite
 	}
 	return &rpcdbproto.IteratorReleaseResponse{}, nil
 }
+
+// NewSnapshot takes a snapshot of the managed database and returns the snapshot
+// ID
+func (db *DatabaseServer) NewSnapshot(context.Context, *rpcdbproto.NewSnapshotRequest) (*rpcdbproto.NewSnapshotResponse, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	snapshot, err := db.db.NewSnapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	id := db.nextSnapshotID
+	db.snapshots[id] = snapshot
+
+	db.nextSnapshotID++
+	return &rpcdbproto.NewSnapshotResponse{Id: id}, nil
+}
+
+// SnapshotHas delegates the Has call to the requested snapshot and returns the
+// result
+func (db *DatabaseServer) SnapshotHas(_ context.Context, req *rpcdbproto.SnapshotHasRequest) (*rpcdbproto.HasResponse, error) {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	has, err := snapshot.Has(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcdbproto.HasResponse{Has: has}, nil
+}
+
+// SnapshotGet delegates the Get call to the requested snapshot and returns the
+// result
+func (db *DatabaseServer) SnapshotGet(_ context.Context, req *rpcdbproto.SnapshotGetRequest) (*rpcdbproto.GetResponse, error) {
+	snapshot, err := db.getSnapshot(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	value, err := snapshot.Get(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcdbproto.GetResponse{Value: value}, nil
+}
+
+// SnapshotNewIteratorWithStartAndPrefix allocates an iterator over the
+// requested snapshot and returns the iterator ID
+func (db *DatabaseServer) SnapshotNewIteratorWithStartAndPrefix(_ context.Context, req *rpcdbproto.SnapshotNewIteratorWithStartAndPrefixRequest) (*rpcdbproto.NewIteratorWithStartAndPrefixResponse, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	snapshot, exists := db.snapshots[req.Id]
+	if !exists {
+		return nil, database.ErrClosed
+	}
+
+	id := db.nextIteratorID
+	it := snapshot.NewIteratorWithStartAndPrefix(req.Start, req.Prefix)
+	db.iterators[id] = it
+
+	db.nextIteratorID++
+	return &rpcdbproto.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
+}
+
+// SnapshotNewIteratorWithRange allocates an iterator over the requested range
+// of the requested snapshot and returns the iterator ID
+func (db *DatabaseServer) SnapshotNewIteratorWithRange(_ context.Context, req *rpcdbproto.SnapshotNewIteratorWithRangeRequest) (*rpcdbproto.NewIteratorWithStartAndPrefixResponse, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	snapshot, exists := db.snapshots[req.Id]
+	if !exists {
+		return nil, database.ErrClosed
+	}
+
+	id := db.nextIteratorID
+	if req.Reverse {
+		db.iterators[id] = snapshot.NewReverseIteratorWithRange(req.Start, req.Limit)
+	} else {
+		db.iterators[id] = snapshot.NewIteratorWithRange(req.Start, req.Limit)
+	}
+
+	db.nextIteratorID++
+	return &rpcdbproto.NewIteratorWithStartAndPrefixResponse{Id: id}, nil
+}
+
+// SnapshotRelease attempts to release the resources allocated to a snapshot
+func (db *DatabaseServer) SnapshotRelease(_ context.Context, req *rpcdbproto.SnapshotReleaseRequest) (*rpcdbproto.SnapshotReleaseResponse, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	snapshot, exists := db.snapshots[req.Id]
+	if exists {
+		delete(db.snapshots, req.Id)
+		snapshot.Release()
+	}
+	return &rpcdbproto.SnapshotReleaseResponse{}, nil
+}
+
+// getSnapshot returns the requested snapshot. A snapshot that has already been
+// released is reported as closed.
+func (db *DatabaseServer) getSnapshot(id uint64) (database.Snapshot, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	snapshot, exists := db.snapshots[id]
+	if !exists {
+		return nil, database.ErrClosed
+	}
+	return snapshot, nil
+}
@@ -6,12 +6,11 @@ package rpcdbproto
 import (
 	context "context"
 	fmt "fmt"
-	math "math"
-
 	proto "github.com/golang/protobuf/proto"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	math "math"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -742,6 +741,61 @@ func (m *NewIteratorWithStartAndPrefixResponse) GetId() uint64 {
 	return 0
 }
 
+type NewIteratorWithRangeRequest struct {
+	Start                []byte   `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Limit                []byte   `protobuf:"bytes,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Reverse              bool     `protobuf:"varint,3,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewIteratorWithRangeRequest) Reset()         { *m = NewIteratorWithRangeRequest{} }
+func (m *NewIteratorWithRangeRequest) String() string { return proto.CompactTextString(m) }
+func (*NewIteratorWithRangeRequest) ProtoMessage()    {}
+func (*NewIteratorWithRangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{19}
+}
+
+func (m *NewIteratorWithRangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewIteratorWithRangeRequest.Unmarshal(m, b)
+}
+func (m *NewIteratorWithRangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewIteratorWithRangeRequest.Marshal(b, m, deterministic)
+}
+func (m *NewIteratorWithRangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewIteratorWithRangeRequest.Merge(m, src)
+}
+func (m *NewIteratorWithRangeRequest) XXX_Size() int {
+	return xxx_messageInfo_NewIteratorWithRangeRequest.Size(m)
+}
+func (m *NewIteratorWithRangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewIteratorWithRangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewIteratorWithRangeRequest proto.InternalMessageInfo
+
+func (m *NewIteratorWithRangeRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *NewIteratorWithRangeRequest) GetLimit() []byte {
+	if m != nil {
+		return m.Limit
+	}
+	return nil
+}
+
+func (m *NewIteratorWithRangeRequest) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
 type IteratorNextRequest struct {
 	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -753,7 +807,7 @@ func (m *IteratorNextRequest) Reset()         { *m = IteratorNextRequest{} }
 func (m *IteratorNextRequest) String() string { return proto.CompactTextString(m) }
 func (*IteratorNextRequest) ProtoMessage()    {}
 func (*IteratorNextRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{19}
+	return fileDescriptor_af52f4b90339c3f4, []int{20}
 }
 
 func (m *IteratorNextRequest) XXX_Unmarshal(b []byte) error {
@@ -794,7 +848,7 @@ func (m *IteratorNextResponse) Reset()         { *m = IteratorNextResponse{} }
 func (m *IteratorNextResponse) String() string { return proto.CompactTextString(m) }
 func (*IteratorNextResponse) ProtoMessage()    {}
 func (*IteratorNextResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{20}
+	return fileDescriptor_af52f4b90339c3f4, []int{21}
 }
 
 func (m *IteratorNextResponse) XXX_Unmarshal(b []byte) error {
@@ -847,7 +901,7 @@ func (m *IteratorErrorRequest) Reset()         { *m = IteratorErrorRequest{} }
 func (m *IteratorErrorRequest) String() string { return proto.CompactTextString(m) }
 func (*IteratorErrorRequest) ProtoMessage()    {}
 func (*IteratorErrorRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{21}
+	return fileDescriptor_af52f4b90339c3f4, []int{22}
 }
 
 func (m *IteratorErrorRequest) XXX_Unmarshal(b []byte) error {
@@ -885,7 +939,7 @@ func (m *IteratorErrorResponse) Reset()         { *m = IteratorErrorResponse{} }
 func (m *IteratorErrorResponse) String() string { return proto.CompactTextString(m) }
 func (*IteratorErrorResponse) ProtoMessage()    {}
 func (*IteratorErrorResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{22}
+	return fileDescriptor_af52f4b90339c3f4, []int{23}
 }
 
 func (m *IteratorErrorResponse) XXX_Unmarshal(b []byte) error {
@@ -917,7 +971,7 @@ func (m *IteratorReleaseRequest) Reset()         { *m = IteratorReleaseRequest{}
 func (m *IteratorReleaseRequest) String() string { return proto.CompactTextString(m) }
 func (*IteratorReleaseRequest) ProtoMessage()    {}
 func (*IteratorReleaseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{23}
+	return fileDescriptor_af52f4b90339c3f4, []int{24}
 }
 
 func (m *IteratorReleaseRequest) XXX_Unmarshal(b []byte) error {
@@ -955,7 +1009,7 @@ func (m *IteratorReleaseResponse) Reset()         { *m = IteratorReleaseResponse
 func (m *IteratorReleaseResponse) String() string { return proto.CompactTextString(m) }
 func (*IteratorReleaseResponse) ProtoMessage()    {}
 func (*IteratorReleaseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_af52f4b90339c3f4, []int{24}
+	return fileDescriptor_af52f4b90339c3f4, []int{25}
 }
 
 func (m *IteratorReleaseResponse) XXX_Unmarshal(b []byte) error {
@@ -976,6 +1030,362 @@ func (m *IteratorReleaseResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_IteratorReleaseResponse proto.InternalMessageInfo
 
+type NewSnapshotRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewSnapshotRequest) Reset()         { *m = NewSnapshotRequest{} }
+func (m *NewSnapshotRequest) String() string { return proto.CompactTextString(m) }
+func (*NewSnapshotRequest) ProtoMessage()    {}
+func (*NewSnapshotRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{26}
+}
+
+func (m *NewSnapshotRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewSnapshotRequest.Unmarshal(m, b)
+}
+func (m *NewSnapshotRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewSnapshotRequest.Marshal(b, m, deterministic)
+}
+func (m *NewSnapshotRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewSnapshotRequest.Merge(m, src)
+}
+func (m *NewSnapshotRequest) XXX_Size() int {
+	return xxx_messageInfo_NewSnapshotRequest.Size(m)
+}
+func (m *NewSnapshotRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewSnapshotRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewSnapshotRequest proto.InternalMessageInfo
+
+type NewSnapshotResponse struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *NewSnapshotResponse) Reset()         { *m = NewSnapshotResponse{} }
+func (m *NewSnapshotResponse) String() string { return proto.CompactTextString(m) }
+func (*NewSnapshotResponse) ProtoMessage()    {}
+func (*NewSnapshotResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{27}
+}
+
+func (m *NewSnapshotResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_NewSnapshotResponse.Unmarshal(m, b)
+}
+func (m *NewSnapshotResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_NewSnapshotResponse.Marshal(b, m, deterministic)
+}
+func (m *NewSnapshotResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewSnapshotResponse.Merge(m, src)
+}
+func (m *NewSnapshotResponse) XXX_Size() int {
+	return xxx_messageInfo_NewSnapshotResponse.Size(m)
+}
+func (m *NewSnapshotResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewSnapshotResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NewSnapshotResponse proto.InternalMessageInfo
+
+func (m *NewSnapshotResponse) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type SnapshotHasRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotHasRequest) Reset()         { *m = SnapshotHasRequest{} }
+func (m *SnapshotHasRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotHasRequest) ProtoMessage()    {}
+func (*SnapshotHasRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{28}
+}
+
+func (m *SnapshotHasRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotHasRequest.Unmarshal(m, b)
+}
+func (m *SnapshotHasRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotHasRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotHasRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotHasRequest.Merge(m, src)
+}
+func (m *SnapshotHasRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotHasRequest.Size(m)
+}
+func (m *SnapshotHasRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotHasRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotHasRequest proto.InternalMessageInfo
+
+func (m *SnapshotHasRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SnapshotHasRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type SnapshotGetRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key                  []byte   `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotGetRequest) Reset()         { *m = SnapshotGetRequest{} }
+func (m *SnapshotGetRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotGetRequest) ProtoMessage()    {}
+func (*SnapshotGetRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{29}
+}
+
+func (m *SnapshotGetRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotGetRequest.Unmarshal(m, b)
+}
+func (m *SnapshotGetRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotGetRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotGetRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotGetRequest.Merge(m, src)
+}
+func (m *SnapshotGetRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotGetRequest.Size(m)
+}
+func (m *SnapshotGetRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotGetRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotGetRequest proto.InternalMessageInfo
+
+func (m *SnapshotGetRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SnapshotGetRequest) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+type SnapshotNewIteratorWithStartAndPrefixRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Start                []byte   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	Prefix               []byte   `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) Reset() {
+	*m = SnapshotNewIteratorWithStartAndPrefixRequest{}
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) String() string {
+	return proto.CompactTextString(m)
+}
+func (*SnapshotNewIteratorWithStartAndPrefixRequest) ProtoMessage() {}
+func (*SnapshotNewIteratorWithStartAndPrefixRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{30}
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.Unmarshal(m, b)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.Merge(m, src)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.Size(m)
+}
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotNewIteratorWithStartAndPrefixRequest proto.InternalMessageInfo
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *SnapshotNewIteratorWithStartAndPrefixRequest) GetPrefix() []byte {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+type SnapshotNewIteratorWithRangeRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Start                []byte   `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	Limit                []byte   `protobuf:"bytes,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Reverse              bool     `protobuf:"varint,4,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotNewIteratorWithRangeRequest) Reset()         { *m = SnapshotNewIteratorWithRangeRequest{} }
+func (m *SnapshotNewIteratorWithRangeRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotNewIteratorWithRangeRequest) ProtoMessage()    {}
+func (*SnapshotNewIteratorWithRangeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{31}
+}
+
+func (m *SnapshotNewIteratorWithRangeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotNewIteratorWithRangeRequest.Unmarshal(m, b)
+}
+func (m *SnapshotNewIteratorWithRangeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotNewIteratorWithRangeRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotNewIteratorWithRangeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotNewIteratorWithRangeRequest.Merge(m, src)
+}
+func (m *SnapshotNewIteratorWithRangeRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotNewIteratorWithRangeRequest.Size(m)
+}
+func (m *SnapshotNewIteratorWithRangeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotNewIteratorWithRangeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotNewIteratorWithRangeRequest proto.InternalMessageInfo
+
+func (m *SnapshotNewIteratorWithRangeRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *SnapshotNewIteratorWithRangeRequest) GetStart() []byte {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *SnapshotNewIteratorWithRangeRequest) GetLimit() []byte {
+	if m != nil {
+		return m.Limit
+	}
+	return nil
+}
+
+func (m *SnapshotNewIteratorWithRangeRequest) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
+type SnapshotReleaseRequest struct {
+	Id                   uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotReleaseRequest) Reset()         { *m = SnapshotReleaseRequest{} }
+func (m *SnapshotReleaseRequest) String() string { return proto.CompactTextString(m) }
+func (*SnapshotReleaseRequest) ProtoMessage()    {}
+func (*SnapshotReleaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{32}
+}
+
+func (m *SnapshotReleaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotReleaseRequest.Unmarshal(m, b)
+}
+func (m *SnapshotReleaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotReleaseRequest.Marshal(b, m, deterministic)
+}
+func (m *SnapshotReleaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotReleaseRequest.Merge(m, src)
+}
+func (m *SnapshotReleaseRequest) XXX_Size() int {
+	return xxx_messageInfo_SnapshotReleaseRequest.Size(m)
+}
+func (m *SnapshotReleaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotReleaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotReleaseRequest proto.InternalMessageInfo
+
+func (m *SnapshotReleaseRequest) GetId() uint64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+type SnapshotReleaseResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SnapshotReleaseResponse) Reset()         { *m = SnapshotReleaseResponse{} }
+func (m *SnapshotReleaseResponse) String() string { return proto.CompactTextString(m) }
+func (*SnapshotReleaseResponse) ProtoMessage()    {}
+func (*SnapshotReleaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_af52f4b90339c3f4, []int{33}
+}
+
+func (m *SnapshotReleaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SnapshotReleaseResponse.Unmarshal(m, b)
+}
+func (m *SnapshotReleaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SnapshotReleaseResponse.Marshal(b, m, deterministic)
+}
+func (m *SnapshotReleaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SnapshotReleaseResponse.Merge(m, src)
+}
+func (m *SnapshotReleaseResponse) XXX_Size() int {
+	return xxx_messageInfo_SnapshotReleaseResponse.Size(m)
+}
+func (m *SnapshotReleaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SnapshotReleaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SnapshotReleaseResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*HasRequest)(nil), "rpcdbproto.HasRequest")
 	proto.RegisterType((*HasResponse)(nil), "rpcdbproto.HasResponse")
@@ -996,59 +1406,83 @@ func init() {
 	proto.RegisterType((*NewIteratorRequest)(nil), "rpcdbproto.NewIteratorRequest")
 	proto.RegisterType((*NewIteratorWithStartAndPrefixRequest)(nil), "rpcdbproto.NewIteratorWithStartAndPrefixRequest")
 	proto.RegisterType((*NewIteratorWithStartAndPrefixResponse)(nil), "rpcdbproto.NewIteratorWithStartAndPrefixResponse")
+	proto.RegisterType((*NewIteratorWithRangeRequest)(nil), "rpcdbproto.NewIteratorWithRangeRequest")
 	proto.RegisterType((*IteratorNextRequest)(nil), "rpcdbproto.IteratorNextRequest")
 	proto.RegisterType((*IteratorNextResponse)(nil), "rpcdbproto.IteratorNextResponse")
 	proto.RegisterType((*IteratorErrorRequest)(nil), "rpcdbproto.IteratorErrorRequest")
 	proto.RegisterType((*IteratorErrorResponse)(nil), "rpcdbproto.IteratorErrorResponse")
 	proto.RegisterType((*IteratorReleaseRequest)(nil), "rpcdbproto.IteratorReleaseRequest")
 	proto.RegisterType((*IteratorReleaseResponse)(nil), "rpcdbproto.IteratorReleaseResponse")
+	proto.RegisterType((*NewSnapshotRequest)(nil), "rpcdbproto.NewSnapshotRequest")
+	proto.RegisterType((*NewSnapshotResponse)(nil), "rpcdbproto.NewSnapshotResponse")
+	proto.RegisterType((*SnapshotHasRequest)(nil), "rpcdbproto.SnapshotHasRequest")
+	proto.RegisterType((*SnapshotGetRequest)(nil), "rpcdbproto.SnapshotGetRequest")
+	proto.RegisterType((*SnapshotNewIteratorWithStartAndPrefixRequest)(nil), "rpcdbproto.SnapshotNewIteratorWithStartAndPrefixRequest")
+	proto.RegisterType((*SnapshotNewIteratorWithRangeRequest)(nil), "rpcdbproto.SnapshotNewIteratorWithRangeRequest")
+	proto.RegisterType((*SnapshotReleaseRequest)(nil), "rpcdbproto.SnapshotReleaseRequest")
+	proto.RegisterType((*SnapshotReleaseResponse)(nil), "rpcdbproto.SnapshotReleaseResponse")
 }
 
-func init() { proto.RegisterFile("rpcdb.proto", fileDescriptor_af52f4b90339c3f4) }
+func init() {
+	proto.RegisterFile("rpcdb.proto", fileDescriptor_af52f4b90339c3f4)
+}
 
 var fileDescriptor_af52f4b90339c3f4 = []byte{
-	// 654 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x5d, 0x4f, 0xd4, 0x40,
-	0x14, 0x0d, 0xbb, 0xcb, 0xd7, 0xd9, 0x0f, 0x60, 0x5c, 0xf9, 0xa8, 0xf2, 0x35, 0x88, 0x41, 0x1f,
-	0x88, 0x82, 0xc1, 0x98, 0x90, 0x18, 0x01, 0x03, 0xc6, 0x84, 0x60, 0x21, 0x21, 0x31, 0xbe, 0x0c,
-	0xec, 0x10, 0x1a, 0x97, 0x6d, 0x6d, 0x67, 0x15, 0xdf, 0x7d, 0xf1, 0x5f, 0x9b, 0x4e, 0x6f, 0xdb,
-	0x99, 0xdd, 0x76, 0xd5, 0xb7, 0xb9, 0x73, 0xcf, 0x39, 0x73, 0xe7, 0xce, 0x3d, 0x83, 0x7a, 0x18,
-	0x5c, 0x77, 0xae, 0xb6, 0x83, 0xd0, 0x57, 0x3e, 0x83, 0x0e, 0xf4, 0x9a, 0xaf, 0x00, 0x27, 0x22,
-	0x72, 0xe5, 0xb7, 0xbe, 0x8c, 0x14, 0x9b, 0x45, 0xf5, 0xab, 0xfc, 0xb9, 0x38, 0xb6, 0x36, 0xb6,
-	0xd5, 0x70, 0xe3, 0x25, 0x5f, 0x45, 0x5d, 0xe7, 0xa3, 0xc0, 0xef, 0x45, 0x32, 0x06, 0xdc, 0x8a,
-	0x48, 0x03, 0xa6, 0xdc, 0x78, 0x19, 0x0b, 0x1c, 0x4b, 0x55, 0x2e, 0xb0, 0x81, 0xba, 0xce, 0x93,
-	0x40, 0x1b, 0xe3, 0xdf, 0x45, 0xb7, 0x2f, 0x09, 0x92, 0x04, 0xfc, 0x15, 0x70, 0xd6, 0x2f, 0x17,
-	0xc9, 0x59, 0x15, 0x93, 0xd5, 0x44, 0x5d, 0xb3, 0x12, 0x69, 0xbe, 0x8e, 0xe6, 0x91, 0xec, 0x4a,
-	0x25, 0xcb, 0x8b, 0x99, 0x45, 0x2b, 0x85, 0x10, 0xe9, 0x19, 0xea, 0xe7, 0x4a, 0x64, 0x47, 0x3b,
-	0x98, 0x0a, 0x42, 0x3f, 0x90, 0xa1, 0x4a, 0x78, 0xd3, 0x6e, 0x16, 0x73, 0x8e, 0x46, 0x02, 0xa5,
-	0xab, 0x30, 0xd4, 0x22, 0x25, 0x14, 0xe1, 0xf4, 0x9a, 0xef, 0xa3, 0x75, 0xe8, 0xdf, 0x05, 0xe2,
-	0x3a, 0x53, 0x6c, 0x63, 0x3c, 0x52, 0x22, 0x54, 0xe9, 0x85, 0x75, 0x10, 0xef, 0x76, 0xbd, 0x3b,
-	0x4f, 0xa5, 0x17, 0xd2, 0x01, 0x9f, 0xc3, 0x4c, 0xc6, 0xa6, 0xfa, 0x5a, 0x68, 0x1c, 0x76, 0xfd,
-	0x28, 0xbd, 0x13, 0x9f, 0x41, 0x93, 0x62, 0x02, 0x28, 0xcc, 0x5d, 0x86, 0x9e, 0x92, 0x07, 0x42,
-	0x5d, 0xdf, 0xa6, 0x87, 0x3e, 0x47, 0x2d, 0xe8, 0xab, 0xf8, 0x9d, 0xaa, 0x5b, 0xf5, 0x9d, 0xf9,
-	0xed, 0xfc, 0xc1, 0xb7, 0xf3, 0x3e, 0xbb, 0x1a, 0xc3, 0x76, 0x31, 0xd9, 0xd1, 0x3d, 0x89, 0x16,
-	0x2b, 0x1a, 0xbe, 0x64, 0xc2, 0xad, 0x8e, 0xba, 0x29, 0x92, 0xb7, 0xc1, 0xcc, 0x53, 0xa9, 0x96,
-	0x36, 0xd8, 0xa9, 0xfc, 0xf1, 0x41, 0xc9, 0x50, 0x28, 0x3f, 0x4c, 0x4b, 0xbe, 0xc0, 0x13, 0x63,
-	0xf7, 0xd2, 0x53, 0xb7, 0xe7, 0x71, 0x0f, 0xde, 0xf5, 0x3a, 0x67, 0xa1, 0xbc, 0xf1, 0xee, 0x47,
-	0x77, 0x6a, 0x1e, 0x13, 0x81, 0x86, 0x51, 0xab, 0x28, 0xe2, 0xaf, 0xb1, 0xf9, 0x17, 0x55, 0x7a,
-	0xa6, 0x16, 0x2a, 0x5e, 0x47, 0x6b, 0xd6, 0xdc, 0x8a, 0xd7, 0xe1, 0x9b, 0x78, 0x90, 0xb2, 0x4e,
-	0xe5, 0x7d, 0xf6, 0x4e, 0x83, 0xb0, 0x2f, 0x68, 0xdb, 0x30, 0x92, 0x7b, 0x8c, 0xe9, 0x1b, 0xbf,
-	0xdf, 0xeb, 0xc4, 0x9b, 0xe4, 0x83, 0x7c, 0x23, 0x1d, 0xb9, 0x4a, 0xc1, 0xe8, 0x56, 0xcd, 0xd1,
-	0x7d, 0x9a, 0xab, 0xbf, 0x0f, 0xc3, 0xac, 0x57, 0x43, 0x55, 0x2c, 0xe0, 0xe1, 0x00, 0x8e, 0x5a,
-	0xbd, 0x85, 0xf9, 0xbc, 0xcf, 0x5d, 0x29, 0xb2, 0x09, 0x19, 0x92, 0x58, 0xc2, 0xc2, 0x10, 0x32,
-	0x11, 0xd9, 0xf9, 0x3d, 0x89, 0xa9, 0x23, 0xa1, 0xc4, 0x95, 0x88, 0x24, 0xdb, 0x43, 0xf5, 0x44,
-	0x44, 0xcc, 0x1a, 0x96, 0xfc, 0x6b, 0x70, 0x16, 0x86, 0xf6, 0xa9, 0x21, 0x7b, 0xa8, 0x1e, 0x4b,
-	0x65, 0xf3, 0xf2, 0x1f, 0xc1, 0xe6, 0x99, 0x3f, 0xc1, 0x1e, 0xaa, 0x67, 0xfd, 0x01, 0x5e, 0x3e,
-	0x9c, 0x36, 0xcf, 0xb0, 0x39, 0x7b, 0x8b, 0x89, 0x64, 0x28, 0x59, 0xf9, 0xa0, 0x3a, 0x4e, 0x51,
-	0x8a, 0x04, 0xde, 0xa0, 0x16, 0xfb, 0x98, 0x59, 0x27, 0x18, 0x9f, 0x80, 0xb3, 0x38, 0x9c, 0x20,
-	0xea, 0x01, 0x26, 0xc9, 0xa0, 0xcc, 0x3a, 0xc1, 0xf6, 0xbc, 0xf3, 0xa8, 0x30, 0x47, 0x1a, 0xfb,
-	0x18, 0xd7, 0x0e, 0x66, 0xd6, 0x31, 0xa6, 0xc9, 0x9d, 0xa5, 0x82, 0x0c, 0xb1, 0x3f, 0x02, 0xb9,
-	0xf1, 0xd8, 0xb2, 0x09, 0x1c, 0xfa, 0x06, 0x9c, 0x95, 0xb2, 0x34, 0x89, 0xfd, 0x1a, 0xc3, 0xf2,
-	0x48, 0x13, 0xb1, 0x17, 0xa6, 0xc2, 0xbf, 0xb8, 0xd8, 0x79, 0xf9, 0x1f, 0x0c, 0x2a, 0xe3, 0x13,
-	0x1a, 0xa6, 0xd5, 0xd8, 0xaa, 0x29, 0x51, 0xe0, 0x55, 0x67, 0xad, 0x1c, 0x40, 0x92, 0x17, 0x68,
-	0x5a, 0xbe, 0x61, 0x85, 0x14, 0xd3, 0x7a, 0xce, 0xfa, 0x08, 0x04, 0xa9, 0x7e, 0xc6, 0xcc, 0x80,
-	0x95, 0x18, 0x2f, 0x62, 0xd9, 0x8e, 0x74, 0x36, 0x46, 0x62, 0x12, 0xed, 0xab, 0x09, 0x9d, 0xde,
-	0xfd, 0x13, 0x00, 0x00, 0xff, 0xff, 0x91, 0xec, 0x29, 0xb2, 0xaa, 0x07, 0x00, 0x00,
+	// 858 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x6b, 0x4f, 0x13, 0x4d,
+	0x14, 0x4e, 0xbb, 0xe5, 0x76, 0xb6, 0x17, 0x98, 0xb7, 0x6f, 0x5b, 0x86, 0xfb, 0x56, 0xb4, 0x1a,
+	0x83, 0x0a, 0x06, 0x35, 0x21, 0x31, 0x02, 0x0a, 0xc6, 0x84, 0xd4, 0x85, 0x84, 0x84, 0xf8, 0x65,
+	0x68, 0x07, 0xdb, 0x58, 0xba, 0xeb, 0xee, 0x14, 0x31, 0x7e, 0x33, 0xfe, 0x04, 0xff, 0x9a, 0xff,
+	0xc7, 0xec, 0x74, 0x76, 0x77, 0x66, 0x2f, 0xa5, 0x62, 0xfc, 0xb6, 0x33, 0xf3, 0x9c, 0xe7, 0x9c,
+	0x3d, 0x97, 0xe7, 0x80, 0xee, 0xd8, 0xad, 0xf6, 0xf9, 0x86, 0xed, 0x58, 0xcc, 0x42, 0xc0, 0x0f,
+	0xfc, 0xdb, 0x58, 0x06, 0x38, 0x24, 0xae, 0x49, 0x3f, 0x0f, 0xa8, 0xcb, 0xd0, 0x2c, 0x68, 0x9f,
+	0xe8, 0xd7, 0x5a, 0x66, 0x35, 0xd3, 0xc8, 0x9b, 0xde, 0xa7, 0xb1, 0x02, 0x3a, 0x7f, 0x77, 0x6d,
+	0xab, 0xef, 0x52, 0x0f, 0xd0, 0x21, 0x2e, 0x07, 0x4c, 0x9b, 0xde, 0xa7, 0x47, 0x70, 0x40, 0x59,
+	0x3a, 0x41, 0x1d, 0x74, 0xfe, 0x2e, 0x08, 0xca, 0x30, 0x71, 0x45, 0x7a, 0x03, 0x2a, 0x20, 0xc3,
+	0x83, 0xf1, 0x14, 0xa0, 0x39, 0x48, 0x27, 0x09, 0xad, 0xb2, 0xb2, 0x55, 0x01, 0xf4, 0xe6, 0x20,
+	0xa0, 0x36, 0xd6, 0xa0, 0xb0, 0x4f, 0x7b, 0x94, 0xd1, 0xf4, 0x60, 0x66, 0xa1, 0xe8, 0x43, 0x84,
+	0xd1, 0x7d, 0xd0, 0x8f, 0x19, 0x09, 0x5c, 0x63, 0x98, 0xb6, 0x1d, 0xcb, 0xa6, 0x0e, 0x1b, 0xda,
+	0xcd, 0x98, 0xc1, 0xd9, 0x30, 0x20, 0x3f, 0x84, 0x8a, 0x5f, 0x41, 0x90, 0x73, 0x19, 0x61, 0x02,
+	0xc7, 0xbf, 0x8d, 0x1d, 0x28, 0xee, 0x59, 0x97, 0x36, 0x69, 0x05, 0x8c, 0x65, 0x98, 0x70, 0x19,
+	0x71, 0x98, 0xff, 0xc3, 0xfc, 0xe0, 0xdd, 0xf6, 0xba, 0x97, 0x5d, 0xe6, 0xff, 0x10, 0x3f, 0x18,
+	0x73, 0x50, 0x0a, 0xac, 0x45, 0x7c, 0x45, 0xc8, 0xef, 0xf5, 0x2c, 0xd7, 0xff, 0x27, 0xa3, 0x04,
+	0x05, 0x71, 0x16, 0x00, 0x06, 0x73, 0xa7, 0x4e, 0x97, 0xd1, 0x5d, 0xc2, 0x5a, 0x1d, 0xdf, 0xe9,
+	0x03, 0xc8, 0xd9, 0x03, 0xe6, 0xd5, 0x49, 0x6b, 0xe8, 0x9b, 0x95, 0x8d, 0xb0, 0xe0, 0x1b, 0x61,
+	0x9e, 0x4d, 0x8e, 0x41, 0x5b, 0x30, 0xd5, 0xe6, 0x39, 0x71, 0x6b, 0x59, 0x0e, 0x9f, 0x97, 0xe1,
+	0x4a, 0x46, 0x4d, 0x1f, 0x69, 0x94, 0x01, 0xc9, 0x5e, 0x45, 0x2c, 0x65, 0x40, 0x47, 0xf4, 0xcb,
+	0x5b, 0x46, 0x1d, 0xc2, 0x2c, 0xc7, 0x0f, 0xf9, 0x04, 0xee, 0x48, 0xb7, 0xa7, 0x5d, 0xd6, 0x39,
+	0xf6, 0x72, 0xf0, 0xaa, 0xdf, 0x6e, 0x3a, 0xf4, 0xa2, 0x7b, 0x3d, 0x3a, 0x53, 0x15, 0x98, 0xb4,
+	0x39, 0x4c, 0xa4, 0x4a, 0x9c, 0x8c, 0x67, 0xb0, 0x7e, 0x03, 0xab, 0x28, 0x53, 0x11, 0xb2, 0xdd,
+	0x36, 0xe7, 0xcc, 0x99, 0xd9, 0x6e, 0xdb, 0x68, 0xc1, 0x42, 0xc4, 0xd0, 0x24, 0xfd, 0x8f, 0xf4,
+	0x16, 0xf5, 0x42, 0x35, 0x98, 0x72, 0xe8, 0x15, 0x75, 0x5c, 0x5a, 0xd3, 0xf8, 0x44, 0xf8, 0x47,
+	0x63, 0x1d, 0xfe, 0xf3, 0x3d, 0x1c, 0xd1, 0xeb, 0xa0, 0x19, 0xa2, 0xb1, 0x7c, 0x80, 0xb2, 0x0a,
+	0x13, 0x31, 0x2f, 0xc2, 0xcc, 0x85, 0x35, 0xe8, 0xb7, 0xbd, 0x4b, 0x31, 0x6c, 0xe1, 0x85, 0xdf,
+	0xd7, 0xd9, 0x84, 0xf9, 0xd0, 0xe4, 0xf9, 0xb8, 0x1b, 0xb2, 0xbf, 0x76, 0x1c, 0xcb, 0x49, 0x8b,
+	0xa2, 0x0a, 0xff, 0x47, 0x70, 0xa2, 0x9e, 0x0d, 0xa8, 0x84, 0xc5, 0xec, 0x51, 0xe2, 0xd2, 0x34,
+	0x8a, 0x79, 0xa8, 0xc6, 0x90, 0x4a, 0x53, 0x1c, 0xf7, 0x89, 0xed, 0x76, 0x2c, 0x3f, 0x13, 0x5e,
+	0x82, 0x94, 0xdb, 0x94, 0x62, 0x6d, 0x03, 0xf2, 0x31, 0x92, 0x4c, 0x45, 0x50, 0xf1, 0x84, 0xc8,
+	0x76, 0x92, 0x3a, 0xdd, 0x6c, 0xd7, 0x83, 0x87, 0xbe, 0xdd, 0x58, 0x3d, 0x1b, 0x65, 0x0c, 0xba,
+	0x27, 0x9b, 0xdc, 0xc3, 0x9a, 0xd2, 0xc3, 0xdf, 0xa0, 0x9e, 0xe2, 0x4d, 0x69, 0xc9, 0xf1, 0x9c,
+	0x04, 0x2d, 0xaa, 0xa5, 0xb4, 0x68, 0x4e, 0x6d, 0xd1, 0x06, 0x54, 0xc2, 0xf4, 0xdf, 0x54, 0xdc,
+	0x18, 0x72, 0x58, 0xaf, 0xcd, 0x5f, 0x79, 0x98, 0xde, 0x27, 0x8c, 0x9c, 0x13, 0x97, 0xa2, 0x6d,
+	0xd0, 0x0e, 0x89, 0x8b, 0x14, 0xb9, 0x09, 0xab, 0x86, 0xab, 0xb1, 0x7b, 0x51, 0xf4, 0x6d, 0xd0,
+	0x0e, 0x28, 0x53, 0xed, 0xc2, 0xaa, 0xe1, 0x6a, 0xec, 0x3e, 0xb4, 0x6b, 0x0e, 0x18, 0x4a, 0x91,
+	0x37, 0x5c, 0x8d, 0xdd, 0x0b, 0xbb, 0x97, 0x30, 0x39, 0x94, 0x35, 0x94, 0x2e, 0x75, 0x18, 0x27,
+	0x3d, 0x09, 0x82, 0x17, 0x90, 0xf3, 0x36, 0x01, 0x52, 0x3c, 0x48, 0x6b, 0x04, 0xd7, 0xe2, 0x0f,
+	0xc2, 0x74, 0x17, 0xa6, 0x84, 0xc4, 0x23, 0xc5, 0x83, 0xba, 0x35, 0xf0, 0x42, 0xe2, 0x9b, 0xe0,
+	0xd8, 0x81, 0x09, 0xbe, 0x03, 0x90, 0xe2, 0x46, 0x5e, 0x13, 0x78, 0x3e, 0xe1, 0x45, 0x58, 0xbf,
+	0x03, 0x08, 0xa5, 0x1b, 0x2d, 0xc9, 0xc0, 0xd8, 0x22, 0xc1, 0xcb, 0x69, 0xcf, 0x82, 0xec, 0x47,
+	0x06, 0x96, 0x46, 0x0e, 0x0a, 0x7a, 0x2c, 0x33, 0x8c, 0x33, 0x53, 0xf8, 0xc9, 0x1f, 0x58, 0x88,
+	0x30, 0x1c, 0x28, 0x27, 0x0d, 0x10, 0xba, 0x37, 0x82, 0x4a, 0x1e, 0xb1, 0xdb, 0xf8, 0x7c, 0x0f,
+	0x79, 0x59, 0xbb, 0xd1, 0x8a, 0x4c, 0x91, 0x20, 0xfe, 0x78, 0x35, 0x1d, 0x20, 0x28, 0x4f, 0xa0,
+	0xa0, 0x08, 0x31, 0x4a, 0x34, 0x91, 0xb5, 0x1c, 0xaf, 0x8d, 0x40, 0x08, 0xd6, 0x33, 0x28, 0x45,
+	0xb4, 0x19, 0x19, 0x49, 0x56, 0xaa, 0x0a, 0xe0, 0xfa, 0x48, 0x8c, 0xe0, 0x3e, 0x02, 0x5d, 0x92,
+	0x71, 0xb4, 0x1c, 0x49, 0x63, 0x44, 0xf5, 0xf1, 0x4a, 0xea, 0xbb, 0xe0, 0x7b, 0x03, 0xba, 0xa4,
+	0xf7, 0x2a, 0x5f, 0x7c, 0x11, 0xa4, 0x4b, 0x8a, 0xc4, 0xe3, 0x49, 0x4b, 0x22, 0xcf, 0x38, 0x12,
+	0xf3, 0x33, 0x03, 0xeb, 0x63, 0x2d, 0x04, 0xf4, 0x3c, 0xc9, 0xc5, 0xbf, 0xea, 0xf7, 0xef, 0x19,
+	0x58, 0x1c, 0xb5, 0x39, 0xd0, 0xa3, 0x31, 0xa2, 0xf9, 0xdb, 0x01, 0x38, 0x83, 0x52, 0x64, 0x2d,
+	0xa8, 0x7d, 0x95, 0xbc, 0x5d, 0x70, 0x7d, 0x24, 0x66, 0xc8, 0x7d, 0x3e, 0xc9, 0x9f, 0xb7, 0x7e,
+	0x0f, 0x00, 0x7c, 0xb7, 0xf6, 0x7c, 0xb8, 0x0c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1072,9 +1506,16 @@ type DatabaseClient interface {
 	Close(ctx context.Context, in *CloseRequest, opts ...grpc.CallOption) (*CloseResponse, error)
 	WriteBatch(ctx context.Context, in *WriteBatchRequest, opts ...grpc.CallOption) (*WriteBatchResponse, error)
 	NewIteratorWithStartAndPrefix(ctx context.Context, in *NewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error)
+	NewIteratorWithRange(ctx context.Context, in *NewIteratorWithRangeRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error)
 	IteratorNext(ctx context.Context, in *IteratorNextRequest, opts ...grpc.CallOption) (*IteratorNextResponse, error)
 	IteratorError(ctx context.Context, in *IteratorErrorRequest, opts ...grpc.CallOption) (*IteratorErrorResponse, error)
 	IteratorRelease(ctx context.Context, in *IteratorReleaseRequest, opts ...grpc.CallOption) (*IteratorReleaseResponse, error)
+	NewSnapshot(ctx context.Context, in *NewSnapshotRequest, opts ...grpc.CallOption) (*NewSnapshotResponse, error)
+	SnapshotHas(ctx context.Context, in *SnapshotHasRequest, opts ...grpc.CallOption) (*HasResponse, error)
+	SnapshotGet(ctx context.Context, in *SnapshotGetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	SnapshotNewIteratorWithStartAndPrefix(ctx context.Context, in *SnapshotNewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error)
+	SnapshotNewIteratorWithRange(ctx context.Context, in *SnapshotNewIteratorWithRangeRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error)
+	SnapshotRelease(ctx context.Context, in *SnapshotReleaseRequest, opts ...grpc.CallOption) (*SnapshotReleaseResponse, error)
 }
 
 type databaseClient struct {
@@ -1166,6 +1607,15 @@ func (c *databaseClient) NewIteratorWithStartAndPrefix(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *databaseClient) NewIteratorWithRange(ctx context.Context, in *NewIteratorWithRangeRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error) {
+	out := new(NewIteratorWithStartAndPrefixResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/NewIteratorWithRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *databaseClient) IteratorNext(ctx context.Context, in *IteratorNextRequest, opts ...grpc.CallOption) (*IteratorNextResponse, error) {
 	out := new(IteratorNextResponse)
 	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/IteratorNext", in, out, opts...)
@@ -1193,6 +1643,60 @@ func (c *databaseClient) IteratorRelease(ctx context.Context, in *IteratorReleas
 	return out, nil
 }
 
+func (c *databaseClient) NewSnapshot(ctx context.Context, in *NewSnapshotRequest, opts ...grpc.CallOption) (*NewSnapshotResponse, error) {
+	out := new(NewSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/NewSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotHas(ctx context.Context, in *SnapshotHasRequest, opts ...grpc.CallOption) (*HasResponse, error) {
+	out := new(HasResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotHas", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotGet(ctx context.Context, in *SnapshotGetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotGet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotNewIteratorWithStartAndPrefix(ctx context.Context, in *SnapshotNewIteratorWithStartAndPrefixRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error) {
+	out := new(NewIteratorWithStartAndPrefixResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotNewIteratorWithStartAndPrefix", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotNewIteratorWithRange(ctx context.Context, in *SnapshotNewIteratorWithRangeRequest, opts ...grpc.CallOption) (*NewIteratorWithStartAndPrefixResponse, error) {
+	out := new(NewIteratorWithStartAndPrefixResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotNewIteratorWithRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) SnapshotRelease(ctx context.Context, in *SnapshotReleaseRequest, opts ...grpc.CallOption) (*SnapshotReleaseResponse, error) {
+	out := new(SnapshotReleaseResponse)
+	err := c.cc.Invoke(ctx, "/rpcdbproto.Database/SnapshotRelease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DatabaseServer is the server API for Database service.
 type DatabaseServer interface {
 	Has(context.Context, *HasRequest) (*HasResponse, error)
@@ -1204,9 +1708,16 @@ type DatabaseServer interface {
 	Close(context.Context, *CloseRequest) (*CloseResponse, error)
 	WriteBatch(context.Context, *WriteBatchRequest) (*WriteBatchResponse, error)
 	NewIteratorWithStartAndPrefix(context.Context, *NewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error)
+	NewIteratorWithRange(context.Context, *NewIteratorWithRangeRequest) (*NewIteratorWithStartAndPrefixResponse, error)
 	IteratorNext(context.Context, *IteratorNextRequest) (*IteratorNextResponse, error)
 	IteratorError(context.Context, *IteratorErrorRequest) (*IteratorErrorResponse, error)
 	IteratorRelease(context.Context, *IteratorReleaseRequest) (*IteratorReleaseResponse, error)
+	NewSnapshot(context.Context, *NewSnapshotRequest) (*NewSnapshotResponse, error)
+	SnapshotHas(context.Context, *SnapshotHasRequest) (*HasResponse, error)
+	SnapshotGet(context.Context, *SnapshotGetRequest) (*GetResponse, error)
+	SnapshotNewIteratorWithStartAndPrefix(context.Context, *SnapshotNewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error)
+	SnapshotNewIteratorWithRange(context.Context, *SnapshotNewIteratorWithRangeRequest) (*NewIteratorWithStartAndPrefixResponse, error)
+	SnapshotRelease(context.Context, *SnapshotReleaseRequest) (*SnapshotReleaseResponse, error)
 }
 
 // UnimplementedDatabaseServer can be embedded to have forward compatible implementations.
@@ -1240,6 +1751,9 @@ func (*UnimplementedDatabaseServer) WriteBatch(ctx context.Context, req *WriteBa
 func (*UnimplementedDatabaseServer) NewIteratorWithStartAndPrefix(ctx context.Context, req *NewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewIteratorWithStartAndPrefix not implemented")
 }
+func (*UnimplementedDatabaseServer) NewIteratorWithRange(ctx context.Context, req *NewIteratorWithRangeRequest) (*NewIteratorWithStartAndPrefixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewIteratorWithRange not implemented")
+}
 func (*UnimplementedDatabaseServer) IteratorNext(ctx context.Context, req *IteratorNextRequest) (*IteratorNextResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorNext not implemented")
 }
@@ -1249,6 +1763,24 @@ func (*UnimplementedDatabaseServer) IteratorError(ctx context.Context, req *Iter
 func (*UnimplementedDatabaseServer) IteratorRelease(ctx context.Context, req *IteratorReleaseRequest) (*IteratorReleaseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IteratorRelease not implemented")
 }
+func (*UnimplementedDatabaseServer) NewSnapshot(ctx context.Context, req *NewSnapshotRequest) (*NewSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewSnapshot not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotHas(ctx context.Context, req *SnapshotHasRequest) (*HasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotHas not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotGet(ctx context.Context, req *SnapshotGetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotGet not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotNewIteratorWithStartAndPrefix(ctx context.Context, req *SnapshotNewIteratorWithStartAndPrefixRequest) (*NewIteratorWithStartAndPrefixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotNewIteratorWithStartAndPrefix not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotNewIteratorWithRange(ctx context.Context, req *SnapshotNewIteratorWithRangeRequest) (*NewIteratorWithStartAndPrefixResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotNewIteratorWithRange not implemented")
+}
+func (*UnimplementedDatabaseServer) SnapshotRelease(ctx context.Context, req *SnapshotReleaseRequest) (*SnapshotReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnapshotRelease not implemented")
+}
 
 func RegisterDatabaseServer(s *grpc.Server, srv DatabaseServer) {
 	s.RegisterService(&_Database_serviceDesc, srv)
@@ -1416,6 +1948,24 @@ func _Database_NewIteratorWithStartAndPrefix_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_NewIteratorWithRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewIteratorWithRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).NewIteratorWithRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/NewIteratorWithRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).NewIteratorWithRange(ctx, req.(*NewIteratorWithRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Database_IteratorNext_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IteratorNextRequest)
 	if err := dec(in); err != nil {
@@ -1470,6 +2020,114 @@ func _Database_IteratorRelease_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_NewSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).NewSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/NewSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).NewSnapshot(ctx, req.(*NewSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotHas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotHasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotHas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotHas",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotHas(ctx, req.(*SnapshotHasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotGet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotGet(ctx, req.(*SnapshotGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotNewIteratorWithStartAndPrefix_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotNewIteratorWithStartAndPrefixRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithStartAndPrefix(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotNewIteratorWithStartAndPrefix",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithStartAndPrefix(ctx, req.(*SnapshotNewIteratorWithStartAndPrefixRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotNewIteratorWithRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotNewIteratorWithRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotNewIteratorWithRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotNewIteratorWithRange(ctx, req.(*SnapshotNewIteratorWithRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_SnapshotRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).SnapshotRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpcdbproto.Database/SnapshotRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).SnapshotRelease(ctx, req.(*SnapshotReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Database_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcdbproto.Database",
 	HandlerType: (*DatabaseServer)(nil),
@@ -1510,6 +2168,10 @@ var _Database_serviceDesc = grpc.ServiceDesc{
 			MethodName: "NewIteratorWithStartAndPrefix",
 			Handler:    _Database_NewIteratorWithStartAndPrefix_Handler,
 		},
+		{
+			MethodName: "NewIteratorWithRange",
+			Handler:    _Database_NewIteratorWithRange_Handler,
+		},
 		{
 			MethodName: "IteratorNext",
 			Handler:    _Database_IteratorNext_Handler,
@@ -1522,6 +2184,30 @@ var _Database_serviceDesc = grpc.ServiceDesc{
 			MethodName: "IteratorRelease",
 			Handler:    _Database_IteratorRelease_Handler,
 		},
+		{
+			MethodName: "NewSnapshot",
+			Handler:    _Database_NewSnapshot_Handler,
+		},
+		{
+			MethodName: "SnapshotHas",
+			Handler:    _Database_SnapshotHas_Handler,
+		},
+		{
+			MethodName: "SnapshotGet",
+			Handler:    _Database_SnapshotGet_Handler,
+		},
+		{
+			MethodName: "SnapshotNewIteratorWithStartAndPrefix",
+			Handler:    _Database_SnapshotNewIteratorWithStartAndPrefix_Handler,
+		},
+		{
+			MethodName: "SnapshotNewIteratorWithRange",
+			Handler:    _Database_SnapshotNewIteratorWithRange_Handler,
+		},
+		{
+			MethodName: "SnapshotRelease",
+			Handler:    _Database_SnapshotRelease_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpcdb.proto",
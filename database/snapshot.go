@@ -0,0 +1,24 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+// Snapshot is a read-only, point-in-time view of a database. Writes made to the
+// host database after the snapshot was taken are not visible through it.
+//
+// A snapshot must be released after use. Once released, reads fail with
+// ErrClosed. A snapshot is safe for concurrent use.
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases associated resources. Release should always succeed and
+	// can be called multiple times without causing error.
+	Release()
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+type Snapshotter interface {
+	// NewSnapshot creates a snapshot of the current state of the database.
+	NewSnapshot() (Snapshot, error)
+}
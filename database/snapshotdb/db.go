@@ -0,0 +1,187 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshotdb
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/nodb"
+)
+
+var (
+	errReadOnly = errors.New("snapshot database is read-only")
+
+	_ database.Database = &Database{}
+)
+
+// Database exposes a snapshot through the Database interface, so that code
+// written against a database can read a consistent view of it. All writes fail.
+// Closing the database releases the snapshot.
+type Database struct {
+	lock     sync.RWMutex
+	snapshot database.Snapshot
+}
+
+// New returns a read-only database that reads from [snapshot]
+func New(snapshot database.Snapshot) *Database {
+	return &Database{snapshot: snapshot}
+}
+
+// Has implements the Database interface
+func (db *Database) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.snapshot == nil {
+		return false, database.ErrClosed
+	}
+	return db.snapshot.Has(key)
+}
+
+// Get implements the Database interface
+func (db *Database) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.snapshot == nil {
+		return nil, database.ErrClosed
+	}
+	return db.snapshot.Get(key)
+}
+
+// Put returns an error
+func (*Database) Put(_, _ []byte) error { return errReadOnly }
+
+// Delete returns an error
+func (*Database) Delete([]byte) error { return errReadOnly }
+
+// NewBatch returns a batch that can't be written
+func (*Database) NewBatch() database.Batch { return &batch{} }
+
+// NewIterator implements the Database interface
+func (db *Database) NewIterator() database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the Database interface
+func (db *Database) NewIteratorWithStart(start []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the Database interface
+func (db *Database) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return db.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the Database interface
+func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.snapshot == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.snapshot.NewIteratorWithStartAndPrefix(start, prefix)
+}
+
+// NewIteratorWithRange implements the Database interface
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.snapshot == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.snapshot.NewIteratorWithRange(start, limit)
+}
+
+// NewReverseIterator implements the Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithPrefix(nil)
+}
+
+// NewReverseIteratorWithPrefix implements the Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.snapshot == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.snapshot.NewReverseIteratorWithPrefix(prefix)
+}
+
+// NewReverseIteratorWithRange implements the Database interface
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.snapshot == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return db.snapshot.NewReverseIteratorWithRange(start, limit)
+}
+
+// NewSnapshot returns a view of the same snapshot. Releasing it doesn't release
+// the snapshot held by this database.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.snapshot == nil {
+		return nil, database.ErrClosed
+	}
+	return &view{Snapshot: db.snapshot}, nil
+}
+
+// Stat returns an error
+func (*Database) Stat(string) (string, error) { return "", database.ErrNotFound }
+
+// Compact returns an error
+func (*Database) Compact(_, _ []byte) error { return errReadOnly }
+
+// Close releases the snapshot
+func (db *Database) Close() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.snapshot == nil {
+		return database.ErrClosed
+	}
+	db.snapshot.Release()
+	db.snapshot = nil
+	return nil
+}
+
+// view is a snapshot whose release is a no-op
+type view struct{ database.Snapshot }
+
+// Release does nothing
+func (*view) Release() {}
+
+// batch fails to write any operations
+type batch struct{}
+
+// Put returns an error
+func (*batch) Put(_, _ []byte) error { return errReadOnly }
+
+// Delete returns an error
+func (*batch) Delete([]byte) error { return errReadOnly }
+
+// ValueSize returns 0
+func (*batch) ValueSize() int { return 0 }
+
+// Write returns an error
+func (*batch) Write() error { return errReadOnly }
+
+// Reset does nothing
+func (*batch) Reset() {}
+
+// Replay does nothing
+func (*batch) Replay(database.KeyValueWriter) error { return nil }
+
+// Inner returns itself
+func (b *batch) Inner() database.Batch { return b }
@@ -0,0 +1,80 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snapshotdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/memdb"
+)
+
+func TestReadOnlyView(t *testing.T) {
+	base := memdb.New()
+
+	key := []byte("hello")
+	value := []byte("world")
+	if err := base.Put(key, value); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot, err := base.NewSnapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := New(snapshot)
+
+	if err := base.Put(key, []byte("there")); err != nil {
+		t.Fatal(err)
+	}
+	if err := base.Put([]byte("other"), value); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, err := db.Get(key); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(v, value) {
+		t.Fatalf("expected %s but got %s", value, v)
+	}
+	if has, err := db.Has([]byte("other")); err != nil {
+		t.Fatal(err)
+	} else if has {
+		t.Fatalf("write after the snapshot should not be visible")
+	}
+
+	iter := db.NewIterator()
+	numKeys := 0
+	for iter.Next() {
+		numKeys++
+	}
+	iter.Release()
+	if numKeys != 1 {
+		t.Fatalf("expected 1 key but iterated over %d", numKeys)
+	}
+
+	if err := db.Put(key, value); err != errReadOnly {
+		t.Fatalf("expected %s but got %s", errReadOnly, err)
+	}
+	if err := db.Delete(key); err != errReadOnly {
+		t.Fatalf("expected %s but got %s", errReadOnly, err)
+	}
+	batch := db.NewBatch()
+	if err := batch.Put(key, value); err != errReadOnly {
+		t.Fatalf("expected %s but got %s", errReadOnly, err)
+	}
+	if err := batch.Write(); err != errReadOnly {
+		t.Fatalf("expected %s but got %s", errReadOnly, err)
+	}
+
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Get(key); err != database.ErrClosed {
+		t.Fatalf("expected %s but got %s", database.ErrClosed, err)
+	}
+	if err := db.Close(); err != database.ErrClosed {
+		t.Fatalf("expected %s but got %s", database.ErrClosed, err)
+	}
+}
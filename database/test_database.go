@@ -26,6 +26,13 @@ var (
 		TestIteratorStartPrefix,
 		TestIteratorMemorySafety,
 		TestIteratorClosed,
+		TestIteratorRange,
+		TestReverseIterator,
+		TestReverseIteratorPrefix,
+		TestReverseIteratorRange,
+		TestSnapshot,
+		TestSnapshotIterator,
+		TestSnapshotReleased,
 		TestStatNoPanic,
 		TestCompactNoPanic,
 		TestMemorySafetyDatabase,
@@ -792,6 +799,310 @@ func TestIteratorClosed(t *testing.T, db Database) {
 	}
 }
 
+// TestIteratorRange ...
+func TestIteratorRange(t *testing.T, db Database) {
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	iterator := db.NewIteratorWithRange(key2, key3)
+	if iterator == nil {
+		t.Fatalf("db.NewIteratorWithRange returned nil")
+	}
+	defer iterator.Release()
+	testIteratorContents(t, iterator, [][]byte{key2}, [][]byte{value2})
+
+	iterator = db.NewIteratorWithRange(key2, nil)
+	if iterator == nil {
+		t.Fatalf("db.NewIteratorWithRange returned nil")
+	}
+	defer iterator.Release()
+	testIteratorContents(t, iterator, [][]byte{key2, key3}, [][]byte{value2, value3})
+
+	iterator = db.NewIteratorWithRange(nil, key2)
+	if iterator == nil {
+		t.Fatalf("db.NewIteratorWithRange returned nil")
+	}
+	defer iterator.Release()
+	testIteratorContents(t, iterator, [][]byte{key1}, [][]byte{value1})
+}
+
+// TestReverseIterator ...
+func TestReverseIterator(t *testing.T, db Database) {
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("z")
+	value3 := []byte("world3")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	iterator := db.NewReverseIterator()
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIterator returned nil")
+	}
+	defer iterator.Release()
+	testIteratorContents(t, iterator, [][]byte{key3, key2, key1}, [][]byte{value3, value2, value1})
+}
+
+// TestReverseIteratorPrefix ...
+func TestReverseIteratorPrefix(t *testing.T, db Database) {
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("goodbye")
+	value3 := []byte("world3")
+
+	key4 := []byte("z")
+	value4 := []byte("world4")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key4, value4); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	iterator := db.NewReverseIteratorWithPrefix([]byte("h"))
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIteratorWithPrefix returned nil")
+	}
+	defer iterator.Release()
+	testIteratorContents(t, iterator, [][]byte{key2, key1}, [][]byte{value2, value1})
+}
+
+// TestReverseIteratorRange ...
+func TestReverseIteratorRange(t *testing.T, db Database) {
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	iterator := db.NewReverseIteratorWithRange(key1, key3)
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIteratorWithRange returned nil")
+	}
+	defer iterator.Release()
+	testIteratorContents(t, iterator, [][]byte{key2, key1}, [][]byte{value2, value1})
+
+	iterator = db.NewReverseIteratorWithRange(key2, nil)
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIteratorWithRange returned nil")
+	}
+	defer iterator.Release()
+	testIteratorContents(t, iterator, [][]byte{key3, key2}, [][]byte{value3, value2})
+}
+
+// testIteratorContents asserts that [iterator] yields exactly the provided keys
+// and values, in order, and is then exhausted without error.
+func testIteratorContents(t *testing.T, iterator Iterator, keys, values [][]byte) {
+	for i, expectedKey := range keys {
+		expectedValue := values[i]
+		if !iterator.Next() {
+			t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+		} else if key := iterator.Key(); !bytes.Equal(key, expectedKey) {
+			t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, expectedKey)
+		} else if value := iterator.Value(); !bytes.Equal(value, expectedValue) {
+			t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, expectedValue)
+		}
+	}
+	if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if key := iterator.Key(); key != nil {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: nil", key)
+	} else if value := iterator.Value(); value != nil {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: nil", value)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+}
+
+// TestSnapshot ...
+func TestSnapshot(t *testing.T, db Database) {
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot, err := db.NewSnapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error on db.NewSnapshot: %s", err)
+	}
+	defer snapshot.Release()
+
+	if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Delete(key1); err != nil {
+		t.Fatalf("Unexpected error on db.Delete: %s", err)
+	}
+
+	if has, err := snapshot.Has(key1); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Has: %s", err)
+	} else if !has {
+		t.Fatalf("snapshot.Has unexpectedly returned false on key %s", key1)
+	} else if v, err := snapshot.Get(key1); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Get: %s", err)
+	} else if !bytes.Equal(value1, v) {
+		t.Fatalf("snapshot.Get: Returned: 0x%x ; Expected: 0x%x", v, value1)
+	} else if has, err := snapshot.Has(key2); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Has: %s", err)
+	} else if has {
+		t.Fatalf("snapshot.Has unexpectedly returned true on key %s", key2)
+	} else if v, err := snapshot.Get(key2); err != ErrNotFound {
+		t.Fatalf("Expected %s on snapshot.Get for missing key %s. Returned 0x%x", ErrNotFound, key2, v)
+	}
+
+	if has, err := db.Has(key1); err != nil {
+		t.Fatalf("Unexpected error on db.Has: %s", err)
+	} else if has {
+		t.Fatalf("db.Has unexpectedly returned true on key %s", key1)
+	} else if v, err := db.Get(key2); err != nil {
+		t.Fatalf("Unexpected error on db.Get: %s", err)
+	} else if !bytes.Equal(value2, v) {
+		t.Fatalf("db.Get: Returned: 0x%x ; Expected: 0x%x", v, value2)
+	}
+}
+
+// TestSnapshotIterator ...
+func TestSnapshotIterator(t *testing.T, db Database) {
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("z")
+	value3 := []byte("world3")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot, err := db.NewSnapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error on db.NewSnapshot: %s", err)
+	}
+	defer snapshot.Release()
+
+	if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Delete(key1); err != nil {
+		t.Fatalf("Unexpected error on db.Delete: %s", err)
+	}
+
+	iterator := snapshot.NewIteratorWithPrefix([]byte("h"))
+	if iterator == nil {
+		t.Fatalf("snapshot.NewIteratorWithPrefix returned nil")
+	}
+	defer iterator.Release()
+
+	if !iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+	} else if key := iterator.Key(); !bytes.Equal(key, key1) {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, key1)
+	} else if value := iterator.Value(); !bytes.Equal(value, value1) {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, value1)
+	} else if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if key := iterator.Key(); key != nil {
+		t.Fatalf("iterator.Key Returned: 0x%x ; Expected: nil", key)
+	} else if value := iterator.Value(); value != nil {
+		t.Fatalf("iterator.Value Returned: 0x%x ; Expected: nil", value)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+}
+
+// TestSnapshotReleased ...
+func TestSnapshotReleased(t *testing.T, db Database) {
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot, err := db.NewSnapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error on db.NewSnapshot: %s", err)
+	}
+	snapshot.Release()
+	snapshot.Release()
+
+	if _, err := snapshot.Has(key1); err != ErrClosed {
+		t.Fatalf("Expected %s on snapshot.Has after release", ErrClosed)
+	} else if _, err := snapshot.Get(key1); err != ErrClosed {
+		t.Fatalf("Expected %s on snapshot.Get after release", ErrClosed)
+	}
+
+	iterator := snapshot.NewIterator()
+	if iterator == nil {
+		t.Fatalf("snapshot.NewIterator returned nil")
+	}
+	defer iterator.Release()
+
+	if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != ErrClosed {
+		t.Fatalf("Expected %s on iterator.Error", ErrClosed)
+	}
+
+	if err := db.Close(); err != nil {
+		t.Fatalf("Unexpected error on db.Close: %s", err)
+	}
+
+	if _, err := db.NewSnapshot(); err != ErrClosed {
+		t.Fatalf("Expected %s on db.NewSnapshot after close", ErrClosed)
+	}
+}
+
 // TestStatNoPanic ...
 func TestStatNoPanic(t *testing.T, db Database) {
 	key1 := []byte("hello1")
@@ -119,26 +119,99 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	if db.mem == nil {
 		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return newIterator(
+		db.mem,
+		db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		start,
+		nil,
+		prefix,
+		false,
+	)
+}
 
-	startString := string(start)
-	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.mem))
-	for key := range db.mem {
-		if strings.HasPrefix(key, prefixString) && key >= startString {
-			keys = append(keys, key)
-		}
+// NewIteratorWithRange implements the database.Database interface
+func (db *Database) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
 	}
-	sort.Strings(keys) // Keys need to be in sorted order
-	values := make([]valueDelete, len(keys))
-	for i, key := range keys {
-		values[i] = db.mem[key]
+	return newIterator(
+		db.mem,
+		db.db.NewIteratorWithRange(start, limit),
+		start,
+		limit,
+		nil,
+		false,
+	)
+}
+
+// NewReverseIterator implements the database.Database interface
+func (db *Database) NewReverseIterator() database.Iterator {
+	return db.NewReverseIteratorWithRange(nil, nil)
+}
+
+// NewReverseIteratorWithPrefix implements the database.Database interface
+func (db *Database) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
 	}
+	return newIterator(
+		db.mem,
+		db.db.NewReverseIteratorWithPrefix(prefix),
+		nil,
+		nil,
+		prefix,
+		true,
+	)
+}
 
-	return &iterator{
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
-		keys:     keys,
-		values:   values,
+// NewReverseIteratorWithRange implements the database.Database interface
+func (db *Database) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(
+		db.mem,
+		db.db.NewReverseIteratorWithRange(start, limit),
+		start,
+		limit,
+		nil,
+		true,
+	)
+}
+
+// NewSnapshot implements the database.Database interface. The returned
+// snapshot contains both the uncommitted operations and the state of the
+// underlying database at the time of the call.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return nil, database.ErrClosed
+	}
+	// Holding the lock ensures that a concurrent Commit can't be partially
+	// applied to the underlying snapshot.
+	snapshot, err := db.db.NewSnapshot()
+	if err != nil {
+		return nil, err
 	}
+	mem := make(map[string]valueDelete, len(db.mem))
+	for key, value := range db.mem {
+		mem[key] = value
+	}
+	return &snap{
+		mem:      mem,
+		snapshot: snapshot,
+	}, nil
 }
 
 // Stat implements the database.Database interface
@@ -332,6 +405,191 @@ func (b *batch) Replay(w database.KeyValueWriter) error {
 // Inner returns itself
 func (b *batch) Inner() database.Batch { return b }
 
+// snap is a point-in-time copy of the uncommitted operations layered over a
+// snapshot of the underlying database.
+type snap struct {
+	lock     sync.RWMutex
+	mem      map[string]valueDelete
+	snapshot database.Snapshot
+}
+
+// Has implements the database.Snapshot interface
+func (s *snap) Has(key []byte) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return false, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		return !val.delete, nil
+	}
+	return s.snapshot.Has(key)
+}
+
+// Get implements the database.Snapshot interface
+func (s *snap) Get(key []byte) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return nil, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		if val.delete {
+			return nil, database.ErrNotFound
+		}
+		return utils.CopyBytes(val.value), nil
+	}
+	return s.snapshot.Get(key)
+}
+
+// NewIterator implements the database.Snapshot interface
+func (s *snap) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+// NewIteratorWithStart implements the database.Snapshot interface
+func (s *snap) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+// NewIteratorWithPrefix implements the database.Snapshot interface
+func (s *snap) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+// NewIteratorWithStartAndPrefix implements the database.Snapshot interface
+func (s *snap) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(
+		s.mem,
+		s.snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		start,
+		nil,
+		prefix,
+		false,
+	)
+}
+
+// NewIteratorWithRange implements the database.Snapshot interface
+func (s *snap) NewIteratorWithRange(start, limit []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(
+		s.mem,
+		s.snapshot.NewIteratorWithRange(start, limit),
+		start,
+		limit,
+		nil,
+		false,
+	)
+}
+
+// NewReverseIterator implements the database.Snapshot interface
+func (s *snap) NewReverseIterator() database.Iterator {
+	return s.NewReverseIteratorWithRange(nil, nil)
+}
+
+// NewReverseIteratorWithPrefix implements the database.Snapshot interface
+func (s *snap) NewReverseIteratorWithPrefix(prefix []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(
+		s.mem,
+		s.snapshot.NewReverseIteratorWithPrefix(prefix),
+		nil,
+		nil,
+		prefix,
+		true,
+	)
+}
+
+// NewReverseIteratorWithRange implements the database.Snapshot interface
+func (s *snap) NewReverseIteratorWithRange(start, limit []byte) database.Iterator {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.mem == nil {
+		return &nodb.Iterator{Err: database.ErrClosed}
+	}
+	return newIterator(
+		s.mem,
+		s.snapshot.NewReverseIteratorWithRange(start, limit),
+		start,
+		limit,
+		nil,
+		true,
+	)
+}
+
+// Release implements the database.Snapshot interface
+func (s *snap) Release() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.mem == nil {
+		return
+	}
+	s.mem = nil
+	s.snapshot.Release()
+}
+
+// newIterator returns an iterator that merges the sorted contents of [mem] with
+// the contents of [it]. Only the keys in [mem] that start with [prefix] and are
+// in the range [start, limit) are included, so [it] must iterate over the same
+// subset of the underlying database in the same direction.
+func newIterator(
+	mem map[string]valueDelete,
+	it database.Iterator,
+	start,
+	limit,
+	prefix []byte,
+	reverse bool,
+) *iterator {
+	startString := string(start)
+	limitString := string(limit)
+	prefixString := string(prefix)
+	keys := make([]string, 0, len(mem))
+	for key := range mem {
+		if strings.HasPrefix(key, prefixString) &&
+			key >= startString &&
+			(len(limitString) == 0 || key < limitString) {
+			keys = append(keys, key)
+		}
+	}
+	// Keys need to be in sorted order
+	if reverse {
+		sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	} else {
+		sort.Strings(keys)
+	}
+	values := make([]valueDelete, len(keys))
+	for i, key := range keys {
+		values[i] = mem[key]
+	}
+
+	return &iterator{
+		Iterator: it,
+		keys:     keys,
+		values:   values,
+		reverse:  reverse,
+	}
+}
+
 // iterator walks over both the in memory database and the underlying database
 // at the same time.
 type iterator struct {
@@ -342,7 +600,7 @@ type iterator struct {
 	keys   []string
 	values []valueDelete
 
-	initialized, exhausted bool
+	initialized, exhausted, reverse bool
 }
 
 // Next moves the iterator to the next key/value pair. It returns whether the
@@ -385,7 +643,7 @@ func (it *iterator) Next() bool {
 
 			dbStringKey := string(dbKey)
 			switch {
-			case memKey < dbStringKey:
+			case it.before(memKey, dbStringKey):
 				it.keys = it.keys[1:]
 				it.values = it.values[1:]
 
@@ -394,7 +652,7 @@ func (it *iterator) Next() bool {
 					it.value = memValue.value
 					return true
 				}
-			case dbStringKey < memKey:
+			case it.before(dbStringKey, memKey):
 				it.key = dbKey
 				it.value = it.Iterator.Value()
 				it.exhausted = !it.Iterator.Next()
@@ -414,6 +672,14 @@ func (it *iterator) Next() bool {
 	}
 }
 
+// before returns true if [a] should be visited by the iterator before [b]
+func (it *iterator) before(a, b string) bool {
+	if it.reverse {
+		return a > b
+	}
+	return a < b
+}
+
 // Key implements the Iterator interface
 func (it *iterator) Key() []byte { return it.key }
 
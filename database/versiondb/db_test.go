@@ -371,3 +371,102 @@ func TestSetDatabaseClosed(t *testing.T) {
 		t.Fatalf("Unexpected database from db.GetDatabase")
 	}
 }
+
+func TestSnapshotCommit(t *testing.T) {
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	}
+
+	snapshot, err := db.NewSnapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error on db.NewSnapshot: %s", err)
+	}
+	defer snapshot.Release()
+
+	if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Commit(); err != nil {
+		t.Fatalf("Unexpected error on db.Commit: %s", err)
+	}
+
+	if v, err := snapshot.Get(key1); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Get: %s", err)
+	} else if !bytes.Equal(value1, v) {
+		t.Fatalf("snapshot.Get: Returned: 0x%x ; Expected: 0x%x", v, value1)
+	} else if has, err := snapshot.Has(key2); err != nil {
+		t.Fatalf("Unexpected error on snapshot.Has: %s", err)
+	} else if has {
+		t.Fatalf("snapshot.Has Returned: %v ; Expected: %v", has, false)
+	} else if has, err := baseDB.Has(key2); err != nil {
+		t.Fatalf("Unexpected error on baseDB.Has: %s", err)
+	} else if !has {
+		t.Fatalf("baseDB.Has Returned: %v ; Expected: %v", has, true)
+	}
+}
+
+func TestIterateReverse(t *testing.T) {
+	baseDB := memdb.New()
+	db := New(baseDB)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	key4 := []byte("hello4")
+	value4 := []byte("world4")
+
+	if err := db.Put(key1, value1); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key3, value3); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Put(key4, value4); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Commit(); err != nil {
+		t.Fatalf("Unexpected error on db.Commit: %s", err)
+	}
+
+	// [key2] only exists in memory and [key3] is only deleted in memory
+	if err := db.Put(key2, value2); err != nil {
+		t.Fatalf("Unexpected error on db.Put: %s", err)
+	} else if err := db.Delete(key3); err != nil {
+		t.Fatalf("Unexpected error on db.Delete: %s", err)
+	}
+
+	iterator := db.NewReverseIteratorWithRange(nil, key4)
+	if iterator == nil {
+		t.Fatalf("db.NewReverseIteratorWithRange returned nil")
+	}
+	defer iterator.Release()
+
+	expectedKeys := [][]byte{key2, key1}
+	expectedValues := [][]byte{value2, value1}
+	for i, expectedKey := range expectedKeys {
+		expectedValue := expectedValues[i]
+		if !iterator.Next() {
+			t.Fatalf("iterator.Next Returned: %v ; Expected: %v", false, true)
+		} else if key := iterator.Key(); !bytes.Equal(key, expectedKey) {
+			t.Fatalf("iterator.Key Returned: 0x%x ; Expected: 0x%x", key, expectedKey)
+		} else if value := iterator.Value(); !bytes.Equal(value, expectedValue) {
+			t.Fatalf("iterator.Value Returned: 0x%x ; Expected: 0x%x", value, expectedValue)
+		}
+	}
+	if iterator.Next() {
+		t.Fatalf("iterator.Next Returned: %v ; Expected: %v", true, false)
+	} else if err := iterator.Error(); err != nil {
+		t.Fatalf("iterator.Error Returned: %s ; Expected: nil", err)
+	}
+}
@@ -0,0 +1,159 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// An archive is a portable dump of key/value pairs. It starts with
+// [archiveMagic] and [archiveVersion], followed by any number of records. Each
+// record is the length of the key, the key, the length of the value and the
+// value. Lengths are big-endian uint32s.
+const (
+	archiveVersion uint16 = 1
+
+	// maxRecordFieldLen bounds the size of a key or value in an archive to
+	// protect against reading a corrupted archive
+	maxRecordFieldLen = 1 << 30
+)
+
+var (
+	archiveMagic = []byte("avaxdb")
+
+	errNotArchive       = errors.New("file isn't a database archive")
+	errRecordTooLarge   = errors.New("archive record is too large")
+	errArchiveTruncated = errors.New("archive is truncated")
+)
+
+// archiveWriter writes key/value pairs to an archive
+type archiveWriter struct {
+	w *bufio.Writer
+}
+
+// newArchiveWriter writes the archive header to [w] and returns a writer of
+// the records of the archive
+func newArchiveWriter(w io.Writer) (*archiveWriter, error) {
+	aw := &archiveWriter{w: bufio.NewWriter(w)}
+	if _, err := aw.w.Write(archiveMagic); err != nil {
+		return nil, err
+	}
+	if err := binary.Write(aw.w, binary.BigEndian, archiveVersion); err != nil {
+		return nil, err
+	}
+	return aw, nil
+}
+
+// Write appends the key/value pair to the archive
+func (aw *archiveWriter) Write(key, value []byte) error {
+	if err := aw.writeField(key); err != nil {
+		return err
+	}
+	return aw.writeField(value)
+}
+
+func (aw *archiveWriter) writeField(field []byte) error {
+	if len(field) > maxRecordFieldLen {
+		return errRecordTooLarge
+	}
+	if err := binary.Write(aw.w, binary.BigEndian, uint32(len(field))); err != nil {
+		return err
+	}
+	_, err := aw.w.Write(field)
+	return err
+}
+
+// Flush writes any buffered records to the underlying writer
+func (aw *archiveWriter) Flush() error { return aw.w.Flush() }
+
+// archiveReader reads the records of an archive. It implements the
+// database.Iterator interface so archives can be consumed like a database.
+type archiveReader struct {
+	r          *bufio.Reader
+	key, value []byte
+	err        error
+}
+
+// newArchiveReader reads and verifies the archive header from [r]
+func newArchiveReader(r io.Reader) (*archiveReader, error) {
+	ar := &archiveReader{r: bufio.NewReader(r)}
+	magic := make([]byte, len(archiveMagic))
+	if _, err := io.ReadFull(ar.r, magic); err != nil || !bytes.Equal(magic, archiveMagic) {
+		return nil, errNotArchive
+	}
+	version := uint16(0)
+	if err := binary.Read(ar.r, binary.BigEndian, &version); err != nil {
+		return nil, errNotArchive
+	}
+	if version != archiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %d, expected %d", version, archiveVersion)
+	}
+	return ar, nil
+}
+
+// Next implements the database.Iterator interface
+func (ar *archiveReader) Next() bool {
+	if ar.err != nil {
+		return false
+	}
+
+	key, err := ar.readField()
+	if err == io.EOF {
+		// The archive ended cleanly between two records
+		ar.key, ar.value = nil, nil
+		return false
+	}
+	if err != nil {
+		ar.err = err
+		return false
+	}
+	value, err := ar.readField()
+	if err == io.EOF {
+		err = errArchiveTruncated
+	}
+	if err != nil {
+		ar.err = err
+		return false
+	}
+
+	ar.key, ar.value = key, value
+	return true
+}
+
+func (ar *archiveReader) readField() ([]byte, error) {
+	length := uint32(0)
+	switch err := binary.Read(ar.r, binary.BigEndian, &length); err {
+	case nil:
+	case io.ErrUnexpectedEOF:
+		return nil, errArchiveTruncated
+	default:
+		return nil, err
+	}
+	if length > maxRecordFieldLen {
+		return nil, errRecordTooLarge
+	}
+
+	field := make([]byte, length)
+	if _, err := io.ReadFull(ar.r, field); err != nil {
+		return nil, errArchiveTruncated
+	}
+	return field, nil
+}
+
+// Error implements the database.Iterator interface
+func (ar *archiveReader) Error() error { return ar.err }
+
+// Key implements the database.Iterator interface
+func (ar *archiveReader) Key() []byte { return ar.key }
+
+// Value implements the database.Iterator interface
+func (ar *archiveReader) Value() []byte { return ar.value }
+
+// Release implements the database.Iterator interface
+func (ar *archiveReader) Release() {}
@@ -0,0 +1,87 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/liraxapp/avalanchego/database/memdb"
+)
+
+func TestArchiveRoundTrip(t *testing.T) {
+	src := memdb.New()
+	if err := src.Put([]byte("hello"), []byte("world")); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Put([]byte("empty"), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	aw, err := newArchiveWriter(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numKeys, err := exportIterator(aw, src.NewIterator(), nil); err != nil {
+		t.Fatalf("Unexpected error on exportIterator: %s", err)
+	} else if numKeys != 2 {
+		t.Fatalf("exportIterator wrote %d keys; Expected 2", numKeys)
+	}
+	if err := aw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	ar, err := newArchiveReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Unexpected error on newArchiveReader: %s", err)
+	}
+	dst := memdb.New()
+	if numKeys, err := copyDB(dst, ar); err != nil {
+		t.Fatalf("Unexpected error on copyDB: %s", err)
+	} else if numKeys != 2 {
+		t.Fatalf("copyDB copied %d keys; Expected 2", numKeys)
+	}
+
+	if v, err := dst.Get([]byte("hello")); err != nil {
+		t.Fatalf("Unexpected error on dst.Get: %s", err)
+	} else if !bytes.Equal(v, []byte("world")) {
+		t.Fatalf("dst.Get: Returned: 0x%x ; Expected: 0x%x", v, []byte("world"))
+	}
+	if has, err := dst.Has([]byte("empty")); err != nil {
+		t.Fatalf("Unexpected error on dst.Has: %s", err)
+	} else if !has {
+		t.Fatalf("dst.Has: Returned: %v ; Expected: %v", has, true)
+	}
+}
+
+func TestArchiveTruncated(t *testing.T) {
+	buf := &bytes.Buffer{}
+	aw, err := newArchiveWriter(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := aw.Write([]byte("hello"), []byte("world")); err != nil {
+		t.Fatal(err)
+	}
+	if err := aw.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	ar, err := newArchiveReader(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	if err != nil {
+		t.Fatalf("Unexpected error on newArchiveReader: %s", err)
+	}
+	if ar.Next() {
+		t.Fatalf("Next should have returned false on a truncated archive")
+	} else if err := ar.Error(); err != errArchiveTruncated {
+		t.Fatalf("Error: Returned: %v ; Expected: %v", err, errArchiveTruncated)
+	}
+}
+
+func TestArchiveInvalidHeader(t *testing.T) {
+	if _, err := newArchiveReader(bytes.NewReader([]byte("not an archive"))); err != errNotArchive {
+		t.Fatalf("newArchiveReader: Returned: %v ; Expected: %v", err, errNotArchive)
+	}
+}
@@ -0,0 +1,166 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/liraxapp/avalanchego/database"
+)
+
+// Kinds of differences between two databases
+const (
+	onlyInDB    = "only in db"
+	onlyInOther = "only in other"
+	changed     = "changed"
+)
+
+// diffCounts is the number of differences found in a namespace
+type diffCounts struct {
+	onlyInDB, onlyInOther, changed int
+}
+
+// keyDiff is a single key that differs between two databases
+type keyDiff struct {
+	kind, namespace string
+	key             []byte
+}
+
+// dbDiff summarizes the differences between two databases
+type dbDiff struct {
+	counts map[string]*diffCounts
+	// The first differing keys, in key order
+	keys []keyDiff
+}
+
+func diff(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	config := addDBFlags(fs, "")
+	otherConfig := addDBFlags(fs, "other-")
+	maxKeys := fs.Int("max-keys", 20, "Maximum number of differing keys to print")
+	chains := fs.String("chains", "", "Comma separated list of chain IDs, other than the genesis chains, whose namespaces should be recognized")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ns, err := config.namespaces(*chains)
+	if err != nil {
+		return err
+	}
+
+	db, err := config.open(true)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	otherDB, err := otherConfig.open(true)
+	if err != nil {
+		return err
+	}
+	defer otherDB.Close()
+
+	result, err := diffDBs(db, otherDB, ns, *maxKeys)
+	if err != nil {
+		return err
+	}
+	if len(result.counts) == 0 {
+		fmt.Println("databases are identical")
+		return nil
+	}
+
+	names := make([]string, 0, len(result.counts))
+	for name := range result.counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "namespace\t%s\t%s\t%s\n", onlyInDB, onlyInOther, changed)
+	for _, name := range names {
+		counts := result.counts[name]
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", name, counts.onlyInDB, counts.onlyInOther, counts.changed)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(result.keys) > 0 {
+		fmt.Println()
+	}
+	for _, key := range result.keys {
+		fmt.Printf("%s: %s 0x%x\n", key.kind, key.namespace, key.key)
+	}
+	return nil
+}
+
+// diffDBs compares the contents of [db] and [otherDB]. Up to [maxKeys]
+// differing keys are recorded in the result.
+func diffDBs(db, otherDB database.Iteratee, ns *namespaces, maxKeys int) (*dbDiff, error) {
+	result := &dbDiff{counts: make(map[string]*diffCounts)}
+	record := func(kind string, key []byte) {
+		name := ns.Of(key)
+		counts, ok := result.counts[name]
+		if !ok {
+			counts = &diffCounts{}
+			result.counts[name] = counts
+		}
+		switch kind {
+		case onlyInDB:
+			counts.onlyInDB++
+		case onlyInOther:
+			counts.onlyInOther++
+		default:
+			counts.changed++
+		}
+		if len(result.keys) < maxKeys {
+			result.keys = append(result.keys, keyDiff{
+				kind:      kind,
+				namespace: name,
+				key:       append([]byte(nil), key...),
+			})
+		}
+	}
+
+	iterator := db.NewIterator()
+	defer iterator.Release()
+	otherIterator := otherDB.NewIterator()
+	defer otherIterator.Release()
+
+	hasNext, otherHasNext := iterator.Next(), otherIterator.Next()
+	for hasNext || otherHasNext {
+		cmp := 0
+		switch {
+		case !otherHasNext:
+			cmp = -1
+		case !hasNext:
+			cmp = 1
+		default:
+			cmp = bytes.Compare(iterator.Key(), otherIterator.Key())
+		}
+
+		switch {
+		case cmp < 0:
+			record(onlyInDB, iterator.Key())
+			hasNext = iterator.Next()
+		case cmp > 0:
+			record(onlyInOther, otherIterator.Key())
+			otherHasNext = otherIterator.Next()
+		default:
+			if !bytes.Equal(iterator.Value(), otherIterator.Value()) {
+				record(changed, iterator.Key())
+			}
+			hasNext, otherHasNext = iterator.Next(), otherIterator.Next()
+		}
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+	return result, otherIterator.Error()
+}
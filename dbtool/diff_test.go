@@ -0,0 +1,108 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/liraxapp/avalanchego/database/memdb"
+	"github.com/liraxapp/avalanchego/database/prefixdb"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils/constants"
+)
+
+func TestDiffDBs(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	ns, err := newNamespaces(constants.LocalID, []ids.ID{chainID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := memdb.New()
+	otherDB := memdb.New()
+
+	// Lay out the databases the same way the chain manager does
+	vmDB := prefixdb.New([]byte("vm"), prefixdb.New(chainID[:], db))
+	otherVMDB := prefixdb.New([]byte("vm"), prefixdb.New(chainID[:], otherDB))
+	keystoreDB := prefixdb.New([]byte("keystore"), db)
+
+	errs := []error{
+		vmDB.Put([]byte("same"), []byte("value")),
+		otherVMDB.Put([]byte("same"), []byte("value")),
+		vmDB.Put([]byte("changed"), []byte("value")),
+		otherVMDB.Put([]byte("changed"), []byte("other value")),
+		otherVMDB.Put([]byte("missing"), []byte("value")),
+		keystoreDB.Put([]byte("user"), []byte("value")),
+		db.Put([]byte("genesisID"), []byte("value")),
+	}
+	for _, err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := diffDBs(db, otherDB, ns, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error on diffDBs: %s", err)
+	}
+
+	expected := map[string]diffCounts{
+		chainID.String() + "/vm": {onlyInOther: 1, changed: 1},
+		"keystore":               {onlyInDB: 1},
+		otherNamespace:           {onlyInDB: 1},
+	}
+	if len(result.counts) != len(expected) {
+		t.Fatalf("diffDBs found differences in %d namespaces; Expected %d", len(result.counts), len(expected))
+	}
+	for name, counts := range expected {
+		if found, ok := result.counts[name]; !ok {
+			t.Fatalf("diffDBs didn't report namespace %q", name)
+		} else if *found != counts {
+			t.Fatalf("diffDBs reported %+v in namespace %q; Expected %+v", *found, name, counts)
+		}
+	}
+	if len(result.keys) != 1 {
+		t.Fatalf("diffDBs recorded %d keys; Expected 1", len(result.keys))
+	}
+
+	if result, err := diffDBs(db, db, ns, 1); err != nil {
+		t.Fatalf("Unexpected error on diffDBs: %s", err)
+	} else if len(result.counts) != 0 {
+		t.Fatalf("diffDBs found differences between a database and itself")
+	}
+}
+
+func TestDBStats(t *testing.T) {
+	ns, err := newNamespaces(constants.LocalID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := memdb.New()
+	sharedMemoryDB := prefixdb.New([]byte("shared memory"), db)
+	if err := sharedMemoryDB.Put([]byte{1}, make([]byte, 3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := sharedMemoryDB.Put([]byte{2}, make([]byte, 4)); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := dbStats(db, ns)
+	if err != nil {
+		t.Fatalf("Unexpected error on dbStats: %s", err)
+	}
+	s, ok := result["shared memory"]
+	if !ok || len(result) != 1 {
+		t.Fatalf("dbStats returned namespaces %v; Expected only shared memory", result)
+	}
+	if s.keys != 2 {
+		t.Fatalf("dbStats counted %d keys; Expected 2", s.keys)
+	} else if s.keyBytes != 2*(prefixLen+1) {
+		t.Fatalf("dbStats counted %d key bytes; Expected %d", s.keyBytes, 2*(prefixLen+1))
+	} else if s.valueBytes != 7 {
+		t.Fatalf("dbStats counted %d value bytes; Expected 7", s.valueBytes)
+	} else if s.valueSizes[2] != 1 || s.valueSizes[3] != 1 {
+		t.Fatalf("dbStats computed the wrong value size histogram %v", s.valueSizes)
+	}
+}
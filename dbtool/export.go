@@ -0,0 +1,165 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/liraxapp/avalanchego/database"
+)
+
+var (
+	errMissingOut = errors.New("--out must be provided")
+	errMissingIn  = errors.New("--in must be provided")
+)
+
+func export(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	config := addDBFlags(fs, "")
+	out := fs.String("out", "", "File to write the archive to")
+	selected := fs.String("namespaces", "", "Comma separated list of namespaces to export, as printed by stats. Defaults to the whole database")
+	chains := fs.String("chains", "", "Comma separated list of chain IDs, other than the genesis chains, whose namespaces should be recognized")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *out == "" {
+		return errMissingOut
+	}
+
+	ns, err := config.namespaces(*chains)
+	if err != nil {
+		return err
+	}
+
+	db, err := config.open(true)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	aw, err := newArchiveWriter(f)
+	if err != nil {
+		return err
+	}
+
+	names := []string(nil)
+	for _, name := range strings.Split(*selected, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	numKeys, err := exportDB(aw, db, ns, names)
+	if err != nil {
+		return err
+	}
+	if err := aw.Flush(); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("exported %d keys to %s\n", numKeys, *out)
+	return nil
+}
+
+// exportDB writes the contents of the namespaces [names] of [db] to [aw],
+// returning the number of keys written. If [names] is empty, the whole
+// database is written.
+func exportDB(aw *archiveWriter, db database.Iteratee, ns *namespaces, names []string) (int, error) {
+	if len(names) == 0 {
+		return exportIterator(aw, db.NewIterator(), nil)
+	}
+
+	numKeys := 0
+	for _, name := range names {
+		if prefixes, ok := ns.Prefixes(name); ok {
+			// The keys of the namespaces nested inside of [name] are exported
+			// along with it
+			for _, prefix := range prefixes {
+				exported, err := exportIterator(aw, db.NewIteratorWithPrefix(prefix), nil)
+				numKeys += exported
+				if err != nil {
+					return numKeys, err
+				}
+			}
+		} else if name == otherNamespace {
+			filter := func(key []byte) bool { return ns.Of(key) == otherNamespace }
+			exported, err := exportIterator(aw, db.NewIterator(), filter)
+			numKeys += exported
+			if err != nil {
+				return numKeys, err
+			}
+		} else {
+			return numKeys, fmt.Errorf("unknown namespace %q", name)
+		}
+	}
+	return numKeys, nil
+}
+
+// exportIterator writes the key/value pairs of [iterator] that pass [filter] to
+// [aw]. A nil [filter] accepts every key. [iterator] is released before
+// returning.
+func exportIterator(aw *archiveWriter, iterator database.Iterator, filter func([]byte) bool) (int, error) {
+	defer iterator.Release()
+
+	numKeys := 0
+	for iterator.Next() {
+		key := iterator.Key()
+		if filter != nil && !filter(key) {
+			continue
+		}
+		if err := aw.Write(key, iterator.Value()); err != nil {
+			return numKeys, err
+		}
+		numKeys++
+	}
+	return numKeys, iterator.Error()
+}
+
+func importArchive(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	config := addDBFlags(fs, "")
+	in := fs.String("in", "", "Archive to import")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *in == "" {
+		return errMissingIn
+	}
+
+	f, err := os.Open(*in)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	ar, err := newArchiveReader(f)
+	if err != nil {
+		return err
+	}
+
+	db, err := config.open(false)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	numKeys, err := copyDB(db, ar)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("imported %d keys from %s\n", numKeys, *in)
+	return nil
+}
@@ -0,0 +1,97 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/engine"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils/constants"
+)
+
+var defaultDBDir = filepath.Join(os.ExpandEnv("$HOME"), fmt.Sprintf(".%s", constants.AppName), "db")
+
+// dbConfig describes where a node database is stored on disk
+type dbConfig struct {
+	dir     *string
+	dbType  *string
+	path    *string
+	network *string
+}
+
+// addDBFlags registers the flags needed to locate a node database on [fs]. Every
+// flag name is prefixed with [prefix] so that several databases can be
+// described by the same flag set.
+func addDBFlags(fs *flag.FlagSet, prefix string) *dbConfig {
+	return &dbConfig{
+		dir:     fs.String(prefix+"db-dir", defaultDBDir, "Database directory of the node, as passed to --db-dir"),
+		dbType:  fs.String(prefix+"db-type", engine.LevelDB, fmt.Sprintf("Database engine of the node. One of %v", engine.Names)),
+		path:    fs.String(prefix+"db-path", "", "Path of the versioned database directory. Overrides db-dir and network-id if provided"),
+		network: fs.String(prefix+"network-id", constants.MainnetName, "Network ID of the node"),
+	}
+}
+
+// networkID returns the network ID this database belongs to
+func (c *dbConfig) networkID() (uint32, error) {
+	return constants.NetworkID(*c.network)
+}
+
+// location returns the versioned database directory
+func (c *dbConfig) location() (string, error) {
+	if *c.path != "" {
+		return *c.path, nil
+	}
+	networkID, err := c.networkID()
+	if err != nil {
+		return "", err
+	}
+	return engine.Path(os.ExpandEnv(*c.dir), constants.NetworkName(networkID), *c.dbType), nil
+}
+
+// open opens the database. If [mustExist], an error is returned rather than
+// creating a new, empty, database.
+func (c *dbConfig) open(mustExist bool) (database.Database, error) {
+	dbPath, err := c.location()
+	if err != nil {
+		return nil, err
+	}
+	if mustExist {
+		if _, err := os.Stat(dbPath); err != nil {
+			return nil, fmt.Errorf("couldn't find database: %w", err)
+		}
+	}
+	db, err := engine.Open(*c.dbType, dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't open %s database at %s: %w", *c.dbType, dbPath, err)
+	}
+	return db, nil
+}
+
+// namespaces returns the namespaces of this database, including those of the
+// chains in [chains]
+func (c *dbConfig) namespaces(chains string) (*namespaces, error) {
+	networkID, err := c.networkID()
+	if err != nil {
+		return nil, err
+	}
+	extraChains := []ids.ID(nil)
+	for _, chainStr := range strings.Split(chains, ",") {
+		chainStr = strings.TrimSpace(chainStr)
+		if chainStr == "" {
+			continue
+		}
+		chainID, err := ids.FromString(chainStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse chain ID %q: %w", chainStr, err)
+		}
+		extraChains = append(extraChains, chainID)
+	}
+	return newNamespaces(networkID, extraChains)
+}
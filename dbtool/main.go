@@ -0,0 +1,77 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// command is a dbtool subcommand. It is passed the arguments following the
+// name of the subcommand.
+type command struct {
+	description string
+	run         func(args []string) error
+}
+
+var commands = map[string]command{
+	"diff": {
+		description: "compare the contents of two databases, per namespace",
+		run:         diff,
+	},
+	"export": {
+		description: "write the contents of a database, or some of its namespaces, to an archive",
+		run:         export,
+	},
+	"import": {
+		description: "write the contents of an archive into a database",
+		run:         importArchive,
+	},
+	"migrate": {
+		description: "copy the contents of a database into a database of another engine",
+		run:         migrate,
+	},
+	"serve-keys": {
+		description: "serve encryption keys from a key file over a unix socket, standing in for a key management service",
+		run:         serveKeys,
+	},
+	"stats": {
+		description: "print the number and sizes of the keys and values in every namespace",
+		run:         stats,
+	},
+}
+
+// main is the entry point to the offline database tool.
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "%s failed with: %s\n", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s <command> [flags]\n\ncommands:\n", os.Args[0])
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", name, commands[name].description)
+	}
+}
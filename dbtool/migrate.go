@@ -0,0 +1,87 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/engine"
+)
+
+// maxBatchSize is the number of bytes that will be buffered before a batch is
+// written to the destination database
+const maxBatchSize = 4 * 1024 * 1024
+
+var errMissingPath = errors.New("both --src and --dst must be provided")
+
+func migrate(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	srcType := fs.String("src-type", engine.LevelDB, fmt.Sprintf("Engine of the source database. One of %v", engine.Names))
+	srcPath := fs.String("src", "", "Directory of the source database")
+	dstType := fs.String("dst-type", engine.BoltDB, fmt.Sprintf("Engine of the destination database. One of %v", engine.Names))
+	dstPath := fs.String("dst", "", "Directory of the destination database")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *srcPath == "" || *dstPath == "" {
+		return errMissingPath
+	}
+
+	if _, err := os.Stat(*srcPath); err != nil {
+		return fmt.Errorf("couldn't find source database: %w", err)
+	}
+
+	src, err := engine.Open(*srcType, *srcPath)
+	if err != nil {
+		return fmt.Errorf("couldn't open source database: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := engine.Open(*dstType, *dstPath)
+	if err != nil {
+		return fmt.Errorf("couldn't open destination database: %w", err)
+	}
+	defer dst.Close()
+
+	iterator := src.NewIterator()
+	defer iterator.Release()
+
+	numKeys, err := copyDB(dst, iterator)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("copied %d keys from %s database at %s to %s database at %s\n",
+		numKeys, *srcType, *srcPath, *dstType, *dstPath)
+	return nil
+}
+
+// copyDB writes every key/value pair produced by [iterator] into [dst],
+// returning the number of keys copied. Writes are batched to bound memory
+// usage.
+func copyDB(dst database.Batcher, iterator database.Iterator) (int, error) {
+	numKeys := 0
+	batch := dst.NewBatch()
+	for iterator.Next() {
+		if err := batch.Put(iterator.Key(), iterator.Value()); err != nil {
+			return numKeys, err
+		}
+		numKeys++
+
+		if batch.ValueSize() < maxBatchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return numKeys, err
+		}
+		batch.Reset()
+	}
+	if err := iterator.Error(); err != nil {
+		return numKeys, err
+	}
+	return numKeys, batch.Write()
+}
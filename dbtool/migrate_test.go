@@ -0,0 +1,62 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/liraxapp/avalanchego/database/engine"
+)
+
+func TestCopyDB(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dbtool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	src, err := engine.Open(engine.LevelDB, filepath.Join(dir, "src"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	dst, err := engine.Open(engine.BoltDB, filepath.Join(dir, "dst"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	value := make([]byte, 1024*1024)
+	numKeys := 2*maxBatchSize/len(value) + 1
+	for i := 0; i < numKeys; i++ {
+		value[0] = byte(i)
+		if err := src.Put([]byte(fmt.Sprintf("key%d", i)), value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	iterator := src.NewIterator()
+	defer iterator.Release()
+
+	if copied, err := copyDB(dst, iterator); err != nil {
+		t.Fatalf("Unexpected error on copyDB: %s", err)
+	} else if copied != numKeys {
+		t.Fatalf("copyDB copied %d keys; Expected %d", copied, numKeys)
+	}
+
+	for i := 0; i < numKeys; i++ {
+		value[0] = byte(i)
+		if v, err := dst.Get([]byte(fmt.Sprintf("key%d", i))); err != nil {
+			t.Fatalf("Unexpected error on dst.Get: %s", err)
+		} else if !bytes.Equal(value, v) {
+			t.Fatalf("dst.Get returned the wrong value for key%d", i)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"sort"
+
+	"github.com/liraxapp/avalanchego/genesis"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils/constants"
+	"github.com/liraxapp/avalanchego/utils/hashing"
+)
+
+const (
+	// otherNamespace contains every key that isn't in a known namespace
+	otherNamespace = "other"
+	prefixLen      = hashing.HashLen
+)
+
+var (
+	// Namespaces created by the node, outside of any chain
+	nodeNamespaces = []string{"shared memory", "keystore", "journal", "compression", "peers"}
+
+	// Namespaces created by the chain manager for every chain. Avalanche
+	// chains use vm, vertex, vertex_bs and tx_bs while snowman chains use vm,
+	// bs and height.
+	chainNamespaces = []string{"vm", "vertex", "vertex_bs", "tx_bs", "bs", "height"}
+
+	// Namespaces nested by the platform chain inside of its vm namespace
+	platformVMNamespaces = []string{"syncable state", "state summary", "synced chunks"}
+)
+
+// namespace is a node in the tree of hashed prefixes. Each level of nesting
+// of prefixdb.NewNested adds a hash in front of the keys of a database.
+type namespace struct {
+	// name is empty if keys that end at this node don't form a namespace
+	name     string
+	children map[hashing.Hash256]*namespace
+}
+
+// namespaces maps the prefixes that prefixdb places in front of keys back to
+// human readable names
+type namespaces struct {
+	names    []string
+	prefixes map[string][][]byte
+	root     namespace
+}
+
+// newNamespaces returns the namespaces of a database of the network
+// [networkID]. The namespaces of the genesis chains are always included, those
+// of [extraChains] are added to them.
+func newNamespaces(networkID uint32, extraChains []ids.ID) (*namespaces, error) {
+	genesisBytes, _, err := genesis.Genesis(networkID)
+	if err != nil {
+		return nil, err
+	}
+	_, chainAliases, _, err := genesis.Aliases(genesisBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := &namespaces{
+		prefixes: make(map[string][][]byte),
+		root:     namespace{children: make(map[hashing.Hash256]*namespace)},
+	}
+	for _, name := range nodeNamespaces {
+		ns.add(name, []byte(name))
+	}
+	for chainID, aliases := range chainAliases {
+		ns.addChain(aliases[0], chainID)
+	}
+	for _, chainID := range extraChains {
+		if _, ok := chainAliases[chainID]; !ok {
+			ns.addChain(chainID.String(), chainID)
+		}
+	}
+	sort.Strings(ns.names)
+	return ns, nil
+}
+
+// addChain adds the namespaces of the chain [chainID]. A chain's namespaces
+// are laid out differently depending on whether its database is compressed,
+// so both layouts are added.
+func (ns *namespaces) addChain(chainName string, chainID ids.ID) {
+	chainPrefix := hashing.ComputeHash256(chainID[:])
+	for _, name := range chainNamespaces {
+		// Mirrors the prefix compression performed by prefixdb.New, which
+		// appends the namespace to the hashed prefix of the chain's database
+		prefix := make([]byte, 0, len(chainPrefix)+len(name))
+		prefix = append(prefix, chainPrefix...)
+		prefix = append(prefix, name...)
+
+		// When the chain's database is compressed, compressdb sits between
+		// the chain's prefixdb and the namespace, so the prefixes are nested
+		ns.add(chainName+"/"+name, prefix)
+		ns.add(chainName+"/"+name, chainID[:], []byte(name))
+
+		if name != "vm" || chainID != constants.PlatformChainID {
+			continue
+		}
+		for _, vmName := range platformVMNamespaces {
+			fullName := chainName + "/" + name + "/" + vmName
+			ns.add(fullName, prefix, []byte(vmName))
+			ns.add(fullName, chainID[:], []byte(name), []byte(vmName))
+		}
+	}
+}
+
+// add adds the namespace [name] that contains the keys prefixed by the hashes
+// of [path], in order
+func (ns *namespaces) add(name string, path ...[]byte) {
+	node := &ns.root
+	prefix := make([]byte, 0, len(path)*prefixLen)
+	for _, pathPrefix := range path {
+		hashedPrefix := hashing.ComputeHash256Array(pathPrefix)
+		prefix = append(prefix, hashedPrefix[:]...)
+
+		child, ok := node.children[hashedPrefix]
+		if !ok {
+			child = &namespace{children: make(map[hashing.Hash256]*namespace)}
+			node.children[hashedPrefix] = child
+		}
+		node = child
+	}
+	node.name = name
+
+	if _, ok := ns.prefixes[name]; !ok {
+		ns.names = append(ns.names, name)
+	}
+	ns.prefixes[name] = append(ns.prefixes[name], prefix)
+}
+
+// Names returns the names of the known namespaces, in sorted order
+func (ns *namespaces) Names() []string { return ns.names }
+
+// Prefixes returns the prefixes of the namespace [name], if it is known. A key
+// belongs to the namespace if it starts with any of them.
+func (ns *namespaces) Prefixes(name string) ([][]byte, bool) {
+	prefixes, ok := ns.prefixes[name]
+	return prefixes, ok
+}
+
+// Of returns the name of the most deeply nested namespace that [key] belongs to
+func (ns *namespaces) Of(key []byte) string {
+	name := otherNamespace
+	node := &ns.root
+	for len(key) >= prefixLen {
+		prefix := hashing.Hash256{}
+		copy(prefix[:], key)
+		child, ok := node.children[prefix]
+		if !ok {
+			break
+		}
+		if child.name != "" {
+			name = child.name
+		}
+		node = child
+		key = key[prefixLen:]
+	}
+	return name
+}
@@ -0,0 +1,80 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/compressdb"
+	"github.com/liraxapp/avalanchego/database/memdb"
+	"github.com/liraxapp/avalanchego/database/prefixdb"
+	"github.com/liraxapp/avalanchego/database/versiondb"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils/constants"
+)
+
+func TestNamespacesOf(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	compressedChainID := ids.GenerateTestID()
+	ns, err := newNamespaces(constants.LocalID, []ids.ID{chainID, compressedChainID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db := memdb.New()
+
+	// Lay out the databases the same way the node and the chain manager do
+	compressedDB, err := compressdb.New(
+		"compressdb",
+		prometheus.NewRegistry(),
+		compressdb.Snappy,
+		compressdb.DefaultThreshold,
+		prefixdb.New(compressedChainID[:], db),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	platformVMDB := versiondb.New(prefixdb.New([]byte("vm"), prefixdb.New(constants.PlatformChainID[:], db)))
+
+	tests := []struct {
+		name     string
+		db       database.KeyValueWriter
+		expected string
+	}{
+		{"peers", prefixdb.New([]byte("peers"), db), "peers"},
+		{"chain", prefixdb.New([]byte("vm"), prefixdb.New(chainID[:], db)), chainID.String() + "/vm"},
+		{"height", prefixdb.New([]byte("height"), prefixdb.New(chainID[:], db)), chainID.String() + "/height"},
+		{"compressed chain", prefixdb.New([]byte("bs"), compressedDB), compressedChainID.String() + "/bs"},
+		{"platform vm", platformVMDB, "P/vm"},
+		{"platform vm nested", prefixdb.NewNested([]byte("synced chunks"), platformVMDB), "P/vm/synced chunks"},
+		{"unknown nested", prefixdb.NewNested([]byte("unknown"), compressedDB), otherNamespace},
+		{"other", db, otherNamespace},
+	}
+	for _, test := range tests {
+		if err := test.db.Put([]byte(test.name), []byte("value")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := platformVMDB.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	found := make(map[string]int)
+	iterator := db.NewIterator()
+	defer iterator.Release()
+	for iterator.Next() {
+		found[ns.Of(iterator.Key())]++
+	}
+	for _, test := range tests {
+		if found[test.expected] == 0 {
+			t.Fatalf("no key was attributed to %q", test.expected)
+		}
+	}
+	if found[otherNamespace] != 2 {
+		t.Fatalf("%d keys were attributed to %q; Expected 2", found[otherNamespace], otherNamespace)
+	}
+}
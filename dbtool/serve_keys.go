@@ -0,0 +1,61 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/liraxapp/avalanchego/database/encdb"
+)
+
+var errMissingKeyFile = errors.New("--key-file must be provided")
+
+// serveKeys runs a local stand-in for a key management service, serving the
+// keys of a key file over a unix socket to encrypted databases
+func serveKeys(args []string) error {
+	fs := flag.NewFlagSet("serve-keys", flag.ContinueOnError)
+	keyFile := fs.String("key-file", "", "File containing the keys to serve, as <id>:<hex encoded key> entries. The key with the largest ID is the current key")
+	socket := fs.String("socket", "kms.sock", "Path of the unix socket to listen on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *keyFile == "" {
+		return errMissingKeyFile
+	}
+
+	provider := encdb.NewFileKeyProvider(*keyFile)
+	keyID, err := provider.CurrentKeyID()
+	if err != nil {
+		return fmt.Errorf("couldn't read keys from %s: %w", *keyFile, err)
+	}
+
+	listener, err := net.Listen("unix", *socket)
+	if err != nil {
+		return err
+	}
+	// Closing the listener removes the socket file
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	stopped := make(chan struct{})
+	go func() {
+		<-signals
+		close(stopped)
+		listener.Close()
+	}()
+
+	fmt.Printf("serving keys from %s on %s, current key ID is %d\n", *keyFile, *socket, keyID)
+	err = encdb.ServeKeys(listener, provider)
+	select {
+	case <-stopped:
+		return nil
+	default:
+		return err
+	}
+}
@@ -0,0 +1,122 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/bits"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/liraxapp/avalanchego/database"
+)
+
+// sizeHistogram counts sizes in power of two buckets. Bucket i holds the sizes
+// in [2^(i-1), 2^i), with bucket 0 holding empty entries.
+type sizeHistogram [bits.UintSize + 1]int
+
+func (h *sizeHistogram) observe(size int) { h[bits.Len(uint(size))]++ }
+
+// namespaceStats describes the contents of a namespace
+type namespaceStats struct {
+	keys                 int
+	keyBytes, valueBytes int
+	keySizes             sizeHistogram
+	valueSizes           sizeHistogram
+}
+
+func stats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	config := addDBFlags(fs, "")
+	histograms := fs.Bool("histograms", true, "Print the key and value size histograms of every namespace")
+	chains := fs.String("chains", "", "Comma separated list of chain IDs, other than the genesis chains, whose namespaces should be recognized")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ns, err := config.namespaces(*chains)
+	if err != nil {
+		return err
+	}
+
+	db, err := config.open(true)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	result, err := dbStats(db, ns)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(result))
+	for name := range result {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "namespace\tkeys\tkey bytes\tvalue bytes\n")
+	for _, name := range names {
+		s := result[name]
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\n", name, s.keys, s.keyBytes, s.valueBytes)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if !*histograms {
+		return nil
+	}
+	for _, name := range names {
+		s := result[name]
+		fmt.Printf("\n%s:\n", name)
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintf(w, "size\tkeys\tvalues\n")
+		for i := range s.keySizes {
+			if s.keySizes[i] == 0 && s.valueSizes[i] == 0 {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%d\t%d\n", bucketName(i), s.keySizes[i], s.valueSizes[i])
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bucketName returns the range of sizes counted in the histogram bucket [i]
+func bucketName(i int) string {
+	if i == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("[%d, %d)", uint64(1)<<(i-1), uint64(1)<<i)
+}
+
+// dbStats returns the statistics of every non-empty namespace in [db]
+func dbStats(db database.Iteratee, ns *namespaces) (map[string]*namespaceStats, error) {
+	iterator := db.NewIterator()
+	defer iterator.Release()
+
+	result := make(map[string]*namespaceStats)
+	for iterator.Next() {
+		key, value := iterator.Key(), iterator.Value()
+		name := ns.Of(key)
+		s, ok := result[name]
+		if !ok {
+			s = &namespaceStats{}
+			result[name] = s
+		}
+		s.keys++
+		s.keyBytes += len(key)
+		s.valueBytes += len(value)
+		s.keySizes.observe(len(key))
+		s.valueSizes.observe(len(value))
+	}
+	return result, iterator.Error()
+}
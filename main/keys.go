@@ -22,11 +22,27 @@ const (
 	signatureVerificationEnabledKey = "signature-verification-enabled"
 	dbEnabledKey                    = "db-enabled"
 	dbDirKey                        = "db-dir"
+	dbTypeKey                       = "db-type"
+	dbVerifyKey                     = "db-verify"
+	dbCompressionKey                = "db-compression"
+	dbCompressionThresholdKey       = "db-compression-threshold"
 	publicIPKey                     = "public-ip"
 	dynamicUpdateDurationKey        = "dynamic-update-duration"
 	dynamicPublicIPResolverKey      = "dynamic-public-ip"
 	connMeterResetDurationKey       = "conn-meter-reset-duration"
 	connMeterMaxConnsKey            = "conn-meter-max-conns"
+	networkCompressionKey           = "network-compression"
+	networkPeerInboundBandwidthKey  = "network-peer-inbound-bandwidth"
+	networkPeerOutboundBandwidthKey = "network-peer-outbound-bandwidth"
+	networkAccessListFileKey        = "network-access-list-file"
+	networkPrivateKey               = "network-private"
+	networkCaptureDirKey            = "network-capture-dir"
+	networkCaptureFileSizeKey       = "network-capture-file-size"
+	networkCaptureFilesKey          = "network-capture-files"
+	networkCaptureChainsKey         = "network-capture-chains"
+	networkCaptureOpsKey            = "network-capture-ops"
+	networkMultiplexKey             = "network-multiplex"
+	networkMaxMessageSizeKey        = "network-max-message-size"
 	httpHostKey                     = "http-host"
 	httpPortKey                     = "http-port"
 	httpsEnabledKey                 = "http-tls-enabled"
@@ -36,12 +52,14 @@ const (
 	apiAuthPasswordKey              = "api-auth-password" // #nosec G101
 	bootstrapIPsKey                 = "bootstrap-ips"
 	bootstrapIDsKey                 = "bootstrap-ids"
+	stateSyncEnabledKey             = "state-sync-enabled"
 	stakingPortKey                  = "staking-port"
 	stakingEnabledKey               = "staking-enabled"
 	p2pTLSEnabledKey                = "p2p-tls-enabled"
 	stakingKeyPathKey               = "staking-tls-key-file"
 	stakingCertPathKey              = "staking-tls-cert-file"
 	stakingDisabledWeightKey        = "staking-disabled-weight"
+	cacheMemoryBudgetKey            = "cache-memory-budget"
 	maxNonStakerPendingMsgsKey      = "max-non-staker-pending-msgs"
 	stakerMsgReservedKey            = "staker-msg-reserved"
 	stakerCPUReservedKey            = "staker-cpu-reserved"
@@ -55,6 +73,7 @@ const (
 	benchlistDurationKey            = "benchlist-duration"
 	benchlistMinFailingDurationKey  = "benchlist-min-failing-duration"
 	pluginDirKey                    = "plugin-dir"
+	pluginVMsKey                    = "plugin-vms"
 	logsDirKey                      = "log-dir"
 	logLevelKey                     = "log-level"
 	logDisplayLevelKey              = "log-display-level"
@@ -67,9 +86,12 @@ const (
 	snowAvalancheBatchSizeKey       = "snow-avalanche-batch-size"
 	snowConcurrentRepollsKey        = "snow-concurrent-repolls"
 	whitelistedSubnetsKey           = "whitelisted-subnets"
+	subnetConsensusParamsFileKey    = "subnet-consensus-params-file"
 	adminAPIEnabledKey              = "api-admin-enabled"
 	infoAPIEnabledKey               = "api-info-enabled"
 	keystoreAPIEnabledKey           = "api-keystore-enabled"
+	keystoreKeySourceKey            = "keystore-key-source"
+	keystoreKeyLocationKey          = "keystore-key-location"
 	metricsAPIEnabledKey            = "api-metrics-enabled"
 	healthAPIEnabledKey             = "api-health-enabled"
 	ipcAPIEnabledKey                = "api-ipcs-enabled"
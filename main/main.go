@@ -80,6 +80,12 @@ func main() {
 		log.Error("consensus parameters are invalid: %s", err)
 		return
 	}
+	for subnetID, params := range Config.SubnetConsensusParams {
+		if err := params.Valid(); err != nil {
+			log.Error("consensus parameters of subnet %s are invalid: %s", subnetID, err)
+			return
+		}
+	}
 
 	// Track if assertions should be executed
 	if Config.LoggingConfig.Assertions {
@@ -4,13 +4,14 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"os"
-	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -18,16 +19,21 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
-	"github.com/liraxapp/avalanchego/database/leveldb"
+	"github.com/liraxapp/avalanchego/database/compressdb"
+	"github.com/liraxapp/avalanchego/database/encdb"
+	"github.com/liraxapp/avalanchego/database/engine"
 	"github.com/liraxapp/avalanchego/database/memdb"
 	"github.com/liraxapp/avalanchego/genesis"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/ipcs"
 	"github.com/liraxapp/avalanchego/nat"
+	"github.com/liraxapp/avalanchego/network"
 	"github.com/liraxapp/avalanchego/node"
+	"github.com/liraxapp/avalanchego/snow/consensus/avalanche"
 	"github.com/liraxapp/avalanchego/snow/networking/router"
 	"github.com/liraxapp/avalanchego/staking"
 	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/compression"
 	"github.com/liraxapp/avalanchego/utils/constants"
 	"github.com/liraxapp/avalanchego/utils/dynamicip"
 	"github.com/liraxapp/avalanchego/utils/hashing"
@@ -37,10 +43,6 @@ import (
 	"github.com/liraxapp/avalanchego/utils/units"
 )
 
-const (
-	dbVersion = "v1.0.0"
-)
-
 // Results of parsing the CLI
 var (
 	Config             = node.Config{}
@@ -117,6 +119,10 @@ func avalancheFlagSet() *flag.FlagSet {
 	// Database:
 	fs.Bool(dbEnabledKey, true, "Turn on persistent storage")
 	fs.String(dbDirKey, defaultString, "Database directory for Avalanche state")
+	fs.String(dbTypeKey, engine.LevelDB, fmt.Sprintf("Database engine to use for persistent storage. Should be one of %v", engine.Names))
+	fs.Bool(dbVerifyKey, false, "Verify the accepted blocks and vertices of every chain on startup, refusing to start chains that are inconsistent")
+	fs.String(dbCompressionKey, "", fmt.Sprintf("Comma separated list of <chain ID or alias>:<algorithm> pairs enabling value compression for newly created chains. Algorithm should be one of %v", compressdb.Algorithms))
+	fs.Int(dbCompressionThresholdKey, compressdb.DefaultThreshold, "Values smaller than this many bytes are stored uncompressed")
 
 	// IP:
 	fs.String(publicIPKey, "", "Public IP of this node for P2P communication. If empty, try to discover with NAT. Ignored if dynamic-public-ip is non-empty.")
@@ -138,6 +144,37 @@ func avalancheFlagSet() *flag.FlagSet {
 		"Upgrade at most [conn-meter-max-conns] connections from a given IP per [conn-meter-reset-duration]. "+
 			"If [conn-meter-reset-duration] is 0, incoming connections are not rate-limited.")
 
+	// Peer to peer message compression
+	fs.String(networkCompressionKey, "snappy,gzip",
+		fmt.Sprintf("Comma separated list of compression types, in order of preference, that peers may use for messages "+
+			"carrying containers. Types should be among %v. If 'none', peers never send this node compressed messages.", compression.Types))
+
+	// Peer to peer bandwidth throttling
+	fs.Uint64(networkPeerInboundBandwidthKey, 0, "Number of bytes per second this node reads from each peer. If 0, inbound bandwidth isn't limited.")
+	fs.Uint64(networkPeerOutboundBandwidthKey, 0, "Number of bytes per second this node writes to each peer. If 0, outbound bandwidth isn't limited.")
+
+	// Peer to peer access control
+	fs.String(networkAccessListFileKey, "",
+		"Path to a JSON file listing allowedNodeIDs, deniedNodeIDs, allowedIPs and deniedIPs of peers. "+
+			"IPs may be CIDR ranges. The file can be reloaded through the admin API.")
+	fs.Bool(networkPrivateKey, false,
+		"If true, only connect to peers in allowedNodeIDs of the access list and never gossip this node's IP.")
+
+	// Peer to peer message capture
+	fs.String(networkCaptureDirKey, "", "Directory the messages exchanged with peers are captured to. If empty, messages aren't captured.")
+	fs.Int(networkCaptureFileSizeKey, 64*units.MiB, "Number of bytes written to a capture file before a new one is started")
+	fs.Int(networkCaptureFilesKey, 10, "Number of capture files kept. If 0, capture files are never deleted.")
+	fs.String(networkCaptureChainsKey, "", "Comma separated list of chain IDs whose messages are captured. If empty, messages of all chains are captured.")
+	fs.String(networkCaptureOpsKey, "", "Comma separated list of message types that are captured. If empty, all message types are captured.")
+
+	// Peer to peer transport
+	fs.Bool(networkMultiplexKey, false,
+		"If true, consensus, bootstrapping and gossip messages are sent on separate streams of the connection to a peer, "+
+			"so that large messages don't delay consensus messages. Only used with peers that also enable it. "+
+			"If staking TLS is disabled, all peers must enable it.")
+	fs.Uint(networkMaxMessageSizeKey, uint(network.DefaultMaxMessageSize),
+		"Maximum number of bytes of a message exchanged with a peer. Larger messages are dropped.")
+
 	// HTTP Server:
 	fs.String(httpHostKey, "127.0.0.1", "Address of the HTTP server")
 	fs.Uint(httpPortKey, 9650, "Port of the HTTP server")
@@ -150,6 +187,7 @@ func avalancheFlagSet() *flag.FlagSet {
 	// Bootstrapping:
 	fs.String(bootstrapIPsKey, defaultString, "Comma separated list of bootstrap peer ips to connect to. Example: 127.0.0.1:9630,127.0.0.1:9631")
 	fs.String(bootstrapIDsKey, defaultString, "Comma separated list of bootstrap peer ids to connect to. Example: NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET,NodeID-8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z")
+	fs.Bool(stateSyncEnabledKey, false, "If true, chains whose VM supports it sync their state from a summary agreed on by the bootstrap peers, rather than executing every block since genesis")
 
 	// Staking:
 	fs.Uint(stakingPortKey, 9651, "Port of the consensus server")
@@ -164,6 +202,9 @@ func avalancheFlagSet() *flag.FlagSet {
 	fs.Float64(stakerMsgReservedKey, router.DefaultStakerPortion, "Reserve a portion of the chain message queue's space for stakers.")
 	fs.Float64(stakerCPUReservedKey, router.DefaultStakerPortion, "Reserve a portion of the chain's CPU time for stakers.")
 
+	// Caching:
+	fs.Uint64(cacheMemoryBudgetKey, 512*units.MiB, "Number of bytes the caches of all chains may use. The budget is split evenly between chains.")
+
 	// Network Timeouts:
 	fs.Duration(networkInitialTimeoutKey, 5*time.Second, "Initial timeout value of the adaptive timeout manager, in nanoseconds.")
 	fs.Duration(networkMinimumTimeoutKey, 500*time.Millisecond, "Minimum timeout value of the adaptive timeout manager, in nanoseconds.")
@@ -179,6 +220,7 @@ func avalancheFlagSet() *flag.FlagSet {
 
 	// Plugins:
 	fs.String(pluginDirKey, defaultString, "Plugin directory for Avalanche VMs")
+	fs.String(pluginVMsKey, "", "Comma separated list of <VM ID>:<plugin name>[:dag] entries registering plugins in the plugin directory as VMs. Plugins marked dag serve DAG VMs rather than chain VMs.")
 
 	// Logging:
 	fs.String(logsDirKey, "", "Logging directory for Avalanche")
@@ -198,6 +240,8 @@ func avalancheFlagSet() *flag.FlagSet {
 	fs.Bool(adminAPIEnabledKey, false, "If true, this node exposes the Admin API")
 	fs.Bool(infoAPIEnabledKey, true, "If true, this node exposes the Info API")
 	fs.Bool(keystoreAPIEnabledKey, true, "If true, this node exposes the Keystore API")
+	fs.String(keystoreKeySourceKey, "", fmt.Sprintf("Source of the keys the data of keystore users is encrypted with. Should be one of %v. If empty, the keys are derived from the users' passwords. Data encrypted with keys derived from passwords can't be read after switching to another source.", encdb.KeySources))
+	fs.String(keystoreKeyLocationKey, "", "Path of the file, name of the environment variable or path of the unix socket the keystore keys are read from")
 	fs.Bool(metricsAPIEnabledKey, true, "If true, this node exposes the Metrics API")
 	fs.Bool(healthAPIEnabledKey, true, "If true, this node exposes the Health API")
 	fs.Bool(ipcAPIEnabledKey, false, "If true, IPCs can be opened")
@@ -227,6 +271,11 @@ func avalancheFlagSet() *flag.FlagSet {
 	// Subnet Whitelist
 	fs.String(whitelistedSubnetsKey, "", "Whitelist of subnets to validate.")
 
+	// Subnet Consensus Parameters
+	fs.String(subnetConsensusParamsFileKey, defaultString, "Path to a JSON file of consensus parameters keyed by subnet ID. "+
+		"The parameters are k, alpha, betaVirtuous, betaRogue, concurrentRepolls, parents and batchSize. "+
+		"Parameters that aren't specified for a subnet default to the snow-* flags.")
+
 	// Coreth Config
 	fs.String(corethConfigKey, defaultString, "Specifies config to pass into coreth")
 
@@ -303,15 +352,38 @@ func setNodeConfig(v *viper.Viper) error {
 			dbDir = defaultDbDir
 		}
 		dbDir = os.ExpandEnv(dbDir) // parse any env variables
-		dbPath := path.Join(dbDir, constants.NetworkName(Config.NetworkID), dbVersion)
-		db, err := leveldb.New(dbPath, 0, 0, 0)
+		dbType := v.GetString(dbTypeKey)
+		dbPath := engine.Path(dbDir, constants.NetworkName(Config.NetworkID), dbType)
+		db, err := engine.Open(dbType, dbPath)
 		if err != nil {
-			return fmt.Errorf("couldn't create db at %s: %w", dbPath, err)
+			return fmt.Errorf("couldn't create %s db at %s: %w", dbType, dbPath, err)
 		}
 		Config.DB = db
 	} else {
 		Config.DB = memdb.New()
 	}
+	Config.VerifyDB = v.GetBool(dbVerifyKey)
+
+	Config.DBCompression = make(map[string]compressdb.Algorithm)
+	for _, entry := range strings.Split(v.GetString(dbCompressionKey), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		sep := strings.LastIndex(entry, ":")
+		if sep <= 0 {
+			return fmt.Errorf("couldn't parse db compression entry %q, expected <chain>:<algorithm>", entry)
+		}
+		algorithm, err := compressdb.ParseAlgorithm(entry[sep+1:])
+		if err != nil {
+			return fmt.Errorf("couldn't parse db compression entry %q: %w", entry, err)
+		}
+		Config.DBCompression[entry[:sep]] = algorithm
+	}
+	Config.DBCompressionThreshold = v.GetInt(dbCompressionThresholdKey)
+	if Config.DBCompressionThreshold < 0 {
+		return fmt.Errorf("%s must be non-negative", dbCompressionThresholdKey)
+	}
 
 	// IP Configuration
 	// Resolves our public IP, or does nothing
@@ -356,6 +428,60 @@ func setNodeConfig(v *viper.Viper) error {
 	Config.ConnMeterResetDuration = v.GetDuration(connMeterResetDurationKey)
 	Config.ConnMeterMaxConns = v.GetInt(connMeterMaxConnsKey)
 
+	for _, entry := range strings.Split(v.GetString(networkCompressionKey), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		compressionType, err := compression.ParseType(entry)
+		if err != nil {
+			return fmt.Errorf("couldn't parse network compression entry %q: %w", entry, err)
+		}
+		if compressionType != compression.None {
+			Config.NetworkCompression = append(Config.NetworkCompression, compressionType)
+		}
+	}
+
+	Config.NetworkBandwidth = network.BandwidthConfig{
+		PeerInboundBytesPerSec:  v.GetUint64(networkPeerInboundBandwidthKey),
+		PeerOutboundBytesPerSec: v.GetUint64(networkPeerOutboundBandwidthKey),
+	}
+	Config.NetworkAccessListFile = v.GetString(networkAccessListFileKey)
+	Config.NetworkPrivate = v.GetBool(networkPrivateKey)
+	Config.NetworkMultiplex = v.GetBool(networkMultiplexKey)
+	Config.NetworkMaxMessageSize = v.GetUint32(networkMaxMessageSizeKey)
+	if Config.NetworkMaxMessageSize == 0 {
+		return fmt.Errorf("%s must be positive", networkMaxMessageSizeKey)
+	}
+
+	Config.NetworkRecorder = network.RecorderConfig{
+		Dir:         v.GetString(networkCaptureDirKey),
+		MaxFileSize: v.GetInt(networkCaptureFileSizeKey),
+		MaxFiles:    v.GetInt(networkCaptureFilesKey),
+	}
+	for _, entry := range strings.Split(v.GetString(networkCaptureChainsKey), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		chainID, err := ids.FromString(entry)
+		if err != nil {
+			return fmt.Errorf("couldn't parse network capture chain %q: %w", entry, err)
+		}
+		Config.NetworkRecorder.ChainIDs = append(Config.NetworkRecorder.ChainIDs, chainID)
+	}
+	for _, entry := range strings.Split(v.GetString(networkCaptureOpsKey), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		op, err := network.ParseOp(entry)
+		if err != nil {
+			return fmt.Errorf("couldn't parse network capture op %q: %w", entry, err)
+		}
+		Config.NetworkRecorder.Ops = append(Config.NetworkRecorder.Ops, op)
+	}
+
 	// Staking:
 	Config.EnableStaking = v.GetBool(stakingEnabledKey)
 	Config.EnableP2PTLS = v.GetBool(p2pTLSEnabledKey)
@@ -456,6 +582,7 @@ func setNodeConfig(v *viper.Viper) error {
 			peer.ID = ids.NewShortID(hashing.ComputeHash160Array([]byte(peer.IP.String())))
 		}
 	}
+	Config.StateSyncEnabled = v.GetBool(stateSyncEnabledKey)
 
 	Config.WhitelistedSubnets.Add(constants.PrimaryNetworkID)
 	for _, subnet := range strings.Split(v.GetString(whitelistedSubnetsKey), ",") {
@@ -483,6 +610,25 @@ func setNodeConfig(v *viper.Viper) error {
 			}
 		}
 	}
+	for _, entry := range strings.Split(v.GetString(pluginVMsKey), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		fields := strings.Split(entry, ":")
+		if len(fields) < 2 || len(fields) > 3 || fields[1] == "" || (len(fields) == 3 && fields[2] != "dag") {
+			return fmt.Errorf("couldn't parse plugin vm entry %q, expected <VM ID>:<plugin name>[:dag]", entry)
+		}
+		vmID, err := ids.FromString(fields[0])
+		if err != nil {
+			return fmt.Errorf("couldn't parse plugin vm entry %q: %w", entry, err)
+		}
+		Config.PluginVMs = append(Config.PluginVMs, node.PluginVM{
+			ID:   vmID,
+			Name: fields[1],
+			DAG:  len(fields) == 3,
+		})
+	}
 
 	// HTTP:
 	Config.HTTPHost = v.GetString(httpHostKey)
@@ -508,6 +654,12 @@ func setNodeConfig(v *viper.Viper) error {
 	Config.AdminAPIEnabled = v.GetBool(adminAPIEnabledKey)
 	Config.InfoAPIEnabled = v.GetBool(infoAPIEnabledKey)
 	Config.KeystoreAPIEnabled = v.GetBool(keystoreAPIEnabledKey)
+	if keySource := v.GetString(keystoreKeySourceKey); keySource != "" {
+		Config.KeystoreKeyProvider, err = encdb.NewKeyProvider(keySource, v.GetString(keystoreKeyLocationKey))
+		if err != nil {
+			return err
+		}
+	}
 	Config.MetricsAPIEnabled = v.GetBool(metricsAPIEnabledKey)
 	Config.HealthAPIEnabled = v.GetBool(healthAPIEnabledKey)
 	Config.IPCAPIEnabled = v.GetBool(ipcAPIEnabledKey)
@@ -537,6 +689,9 @@ func setNodeConfig(v *viper.Viper) error {
 	Config.StakerMSGPortion = v.GetFloat64(stakerMsgReservedKey)
 	Config.StakerCPUPortion = v.GetFloat64(stakerCPUReservedKey)
 
+	// Caching
+	Config.CacheMemoryBudget = v.GetUint64(cacheMemoryBudgetKey)
+
 	// Network Timeout
 	Config.NetworkConfig.InitialTimeout = v.GetDuration(networkInitialTimeoutKey)
 	Config.NetworkConfig.MinimumTimeout = v.GetDuration(networkMinimumTimeoutKey)
@@ -638,6 +793,19 @@ func setNodeConfig(v *viper.Viper) error {
 	Config.ConsensusParams.BatchSize = v.GetInt(snowAvalancheBatchSizeKey)
 	Config.ConsensusParams.ConcurrentRepolls = v.GetInt(snowConcurrentRepollsKey)
 
+	// Subnet Consensus Parameters
+	Config.SubnetConsensusParams = make(map[ids.ID]avalanche.Parameters)
+	if subnetParamsFile := v.GetString(subnetConsensusParamsFileKey); subnetParamsFile != defaultString {
+		subnetParamsBytes, err := ioutil.ReadFile(subnetParamsFile)
+		if err != nil {
+			return fmt.Errorf("couldn't read subnet consensus parameters: %w", err)
+		}
+		Config.SubnetConsensusParams, err = parseSubnetConsensusParams(subnetParamsBytes, Config.ConsensusParams)
+		if err != nil {
+			return err
+		}
+	}
+
 	Config.ConsensusGossipFrequency = v.GetDuration(consensusGossipFrequencyKey)
 	Config.ConsensusShutdownTimeout = v.GetDuration(consensusShutdownTimeoutKey)
 
@@ -667,6 +835,64 @@ func setNodeConfig(v *viper.Viper) error {
 	return nil
 }
 
+// subnetConsensusParams are the consensus parameters that can be set for a
+// subnet. Parameters that aren't set default to the node-wide ones.
+type subnetConsensusParams struct {
+	K                 *int `json:"k"`
+	Alpha             *int `json:"alpha"`
+	BetaVirtuous      *int `json:"betaVirtuous"`
+	BetaRogue         *int `json:"betaRogue"`
+	ConcurrentRepolls *int `json:"concurrentRepolls"`
+	Parents           *int `json:"parents"`
+	BatchSize         *int `json:"batchSize"`
+}
+
+// apply returns [params] with the parameters that are set overridden
+func (p *subnetConsensusParams) apply(params avalanche.Parameters) avalanche.Parameters {
+	overrides := []struct {
+		value *int
+		field *int
+	}{
+		{p.K, &params.K},
+		{p.Alpha, &params.Alpha},
+		{p.BetaVirtuous, &params.BetaVirtuous},
+		{p.BetaRogue, &params.BetaRogue},
+		{p.ConcurrentRepolls, &params.ConcurrentRepolls},
+		{p.Parents, &params.Parents},
+		{p.BatchSize, &params.BatchSize},
+	}
+	for _, override := range overrides {
+		if override.value != nil {
+			*override.field = *override.value
+		}
+	}
+	return params
+}
+
+// parseSubnetConsensusParams parses a JSON object that maps subnet IDs to the
+// consensus parameters of their chains. Unknown parameters are rejected.
+func parseSubnetConsensusParams(b []byte, defaults avalanche.Parameters) (map[ids.ID]avalanche.Parameters, error) {
+	rawParams := map[string]json.RawMessage{}
+	if err := json.Unmarshal(b, &rawParams); err != nil {
+		return nil, fmt.Errorf("couldn't parse subnet consensus parameters: %w", err)
+	}
+	subnetParams := make(map[ids.ID]avalanche.Parameters, len(rawParams))
+	for subnet, raw := range rawParams {
+		subnetID, err := ids.FromString(subnet)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse subnet ID %q: %w", subnet, err)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		overrides := subnetConsensusParams{}
+		if err := decoder.Decode(&overrides); err != nil {
+			return nil, fmt.Errorf("couldn't parse consensus parameters of subnet %s: %w", subnetID, err)
+		}
+		subnetParams[subnetID] = overrides.apply(defaults)
+	}
+	return subnetParams, nil
+}
+
 func parseViper() error {
 	v, err := getViper()
 	if err != nil {
@@ -692,7 +918,7 @@ func parseViper() error {
 		args = append(args, networkGeneration)
 
 		format += ", database=%s"
-		args = append(args, dbVersion)
+		args = append(args, engine.Version)
 
 		if GitCommit != "" {
 			format += ", commit=%s"
@@ -0,0 +1,82 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/consensus/avalanche"
+	"github.com/liraxapp/avalanchego/snow/consensus/snowball"
+)
+
+func TestParseSubnetConsensusParams(t *testing.T) {
+	defaults := avalanche.Parameters{
+		Parameters: snowball.Parameters{
+			K:                 20,
+			Alpha:             15,
+			BetaVirtuous:      15,
+			BetaRogue:         20,
+			ConcurrentRepolls: 4,
+		},
+		Parents:   5,
+		BatchSize: 30,
+	}
+	subnetID := ids.GenerateTestID()
+
+	tests := []struct {
+		name          string
+		params        string
+		shouldErr     bool
+		expectedK     int
+		expectedAlpha int
+	}{
+		{"overrides", `{"k": 3, "alpha": 2}`, false, 3, 2},
+		{"field names", `{"K": 5}`, false, 5, defaults.Alpha},
+		{"defaults", `{}`, false, defaults.K, defaults.Alpha},
+		{"metrics", `{"k": 3, "Metrics": {}}`, true, 0, 0},
+		{"namespace", `{"Namespace": "foo"}`, true, 0, 0},
+		{"unknown", `{"beta": 3}`, true, 0, 0},
+		{"wrong type", `{"k": "3"}`, true, 0, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := []byte(fmt.Sprintf(`{%q: %s}`, subnetID, test.params))
+			subnetParams, err := parseSubnetConsensusParams(b, defaults)
+			if test.shouldErr {
+				if err == nil {
+					t.Fatal("should have errored")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			params, ok := subnetParams[subnetID]
+			if !ok {
+				t.Fatalf("missing parameters of subnet %s", subnetID)
+			}
+			if params.K != test.expectedK {
+				t.Fatalf("K = %d ; Expected %d", params.K, test.expectedK)
+			}
+			if params.Alpha != test.expectedAlpha {
+				t.Fatalf("Alpha = %d ; Expected %d", params.Alpha, test.expectedAlpha)
+			}
+			if params.BetaRogue != defaults.BetaRogue || params.Parents != defaults.Parents {
+				t.Fatalf("parameters that weren't set should keep their defaults")
+			}
+		})
+	}
+}
+
+func TestParseSubnetConsensusParamsBadSubnetID(t *testing.T) {
+	if _, err := parseSubnetConsensusParams([]byte(`{"not a subnet": {"k": 3}}`), avalanche.Parameters{}); err == nil {
+		t.Fatal("should have errored on an invalid subnet ID")
+	}
+	if _, err := parseSubnetConsensusParams([]byte(`[]`), avalanche.Parameters{}); err == nil {
+		t.Fatal("should have errored on a JSON array")
+	}
+}
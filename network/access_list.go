@@ -0,0 +1,151 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils/constants"
+)
+
+// AccessListConfig lists the peers this node may be connected to. Node IDs are
+// formatted as NodeID-..., IPs may be single addresses or CIDR ranges. Denied
+// peers are never connected to. If an allow list is non-empty, only peers on it
+// are connected to.
+type AccessListConfig struct {
+	AllowedNodeIDs []string `json:"allowedNodeIDs"`
+	DeniedNodeIDs  []string `json:"deniedNodeIDs"`
+	AllowedIPs     []string `json:"allowedIPs"`
+	DeniedIPs      []string `json:"deniedIPs"`
+}
+
+// accessList decides which peers this node may be connected to. It is safe to
+// use concurrently.
+type accessList struct {
+	// if true, only peers on the node ID allow list may be connected to, even
+	// if the list is empty
+	privateNetwork bool
+
+	lock                          sync.RWMutex
+	allowedNodeIDs, deniedNodeIDs ids.ShortSet
+	allowedIPs, deniedIPs         []*net.IPNet
+}
+
+func newAccessList(privateNetwork bool) *accessList {
+	return &accessList{privateNetwork: privateNetwork}
+}
+
+// Set replaces the lists with the ones in [config]. If [config] is invalid, the
+// lists aren't modified.
+func (a *accessList) Set(config AccessListConfig) error {
+	allowedNodeIDs, err := parseNodeIDs(config.AllowedNodeIDs)
+	if err != nil {
+		return fmt.Errorf("invalid allowed node ID: %w", err)
+	}
+	deniedNodeIDs, err := parseNodeIDs(config.DeniedNodeIDs)
+	if err != nil {
+		return fmt.Errorf("invalid denied node ID: %w", err)
+	}
+	allowedIPs, err := parseIPNets(config.AllowedIPs)
+	if err != nil {
+		return fmt.Errorf("invalid allowed IP: %w", err)
+	}
+	deniedIPs, err := parseIPNets(config.DeniedIPs)
+	if err != nil {
+		return fmt.Errorf("invalid denied IP: %w", err)
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.allowedNodeIDs = allowedNodeIDs
+	a.deniedNodeIDs = deniedNodeIDs
+	a.allowedIPs = allowedIPs
+	a.deniedIPs = deniedIPs
+	return nil
+}
+
+// AllowsNodeID returns true if the peer with ID [nodeID] may be connected to
+func (a *accessList) AllowsNodeID(nodeID ids.ShortID) bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if a.deniedNodeIDs.Contains(nodeID) {
+		return false
+	}
+	if a.privateNetwork || a.allowedNodeIDs.Len() > 0 {
+		return a.allowedNodeIDs.Contains(nodeID)
+	}
+	return true
+}
+
+// AllowsIP returns true if a peer at [ip] may be connected to
+func (a *accessList) AllowsIP(ip net.IP) bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if containsIP(a.deniedIPs, ip) {
+		return false
+	}
+	if len(a.allowedIPs) > 0 {
+		return containsIP(a.allowedIPs, ip)
+	}
+	return true
+}
+
+func containsIP(ipNets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range ipNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseNodeIDs(nodeIDStrs []string) (ids.ShortSet, error) {
+	nodeIDs := ids.ShortSet{}
+	for _, nodeIDStr := range nodeIDStrs {
+		nodeID, err := ids.ShortFromPrefixedString(strings.TrimSpace(nodeIDStr), constants.NodeIDPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", nodeIDStr, err)
+		}
+		nodeIDs.Add(nodeID)
+	}
+	return nodeIDs, nil
+}
+
+// parseIPNets parses CIDR ranges. Single IPs are treated as ranges containing
+// only that IP.
+func parseIPNets(ipNetStrs []string) ([]*net.IPNet, error) {
+	ipNets := make([]*net.IPNet, 0, len(ipNetStrs))
+	for _, ipNetStr := range ipNetStrs {
+		ipNetStr = strings.TrimSpace(ipNetStr)
+		if !strings.Contains(ipNetStr, "/") {
+			ip := net.ParseIP(ipNetStr)
+			if ip == nil {
+				return nil, fmt.Errorf("couldn't parse %q", ipNetStr)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			ipNets = append(ipNets, &net.IPNet{
+				IP:   ip,
+				Mask: net.CIDRMask(bits, bits),
+			})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(ipNetStr)
+		if err != nil {
+			return nil, err
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
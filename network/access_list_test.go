@@ -0,0 +1,95 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils/constants"
+)
+
+func TestAccessListNodeIDs(t *testing.T) {
+	id0 := ids.NewShortID([20]byte{1})
+	id1 := ids.NewShortID([20]byte{2})
+	id2 := ids.NewShortID([20]byte{3})
+
+	a := newAccessList(false)
+	assert.True(t, a.AllowsNodeID(id0), "empty access list should allow all node IDs")
+
+	err := a.Set(AccessListConfig{
+		DeniedNodeIDs: []string{id0.PrefixedString(constants.NodeIDPrefix)},
+	})
+	assert.NoError(t, err)
+	assert.False(t, a.AllowsNodeID(id0))
+	assert.True(t, a.AllowsNodeID(id1))
+
+	err = a.Set(AccessListConfig{
+		AllowedNodeIDs: []string{
+			id0.PrefixedString(constants.NodeIDPrefix),
+			id1.PrefixedString(constants.NodeIDPrefix),
+		},
+		DeniedNodeIDs: []string{id0.PrefixedString(constants.NodeIDPrefix)},
+	})
+	assert.NoError(t, err)
+	assert.False(t, a.AllowsNodeID(id0), "deny list should take precedence over the allow list")
+	assert.True(t, a.AllowsNodeID(id1))
+	assert.False(t, a.AllowsNodeID(id2), "node ID missing from a non-empty allow list was allowed")
+}
+
+func TestAccessListPrivateNetwork(t *testing.T) {
+	id0 := ids.NewShortID([20]byte{1})
+	id1 := ids.NewShortID([20]byte{2})
+
+	a := newAccessList(true)
+	assert.False(t, a.AllowsNodeID(id0), "private network should only allow listed node IDs")
+
+	err := a.Set(AccessListConfig{
+		AllowedNodeIDs: []string{id0.PrefixedString(constants.NodeIDPrefix)},
+	})
+	assert.NoError(t, err)
+	assert.True(t, a.AllowsNodeID(id0))
+	assert.False(t, a.AllowsNodeID(id1))
+}
+
+func TestAccessListIPs(t *testing.T) {
+	a := newAccessList(false)
+	assert.True(t, a.AllowsIP(net.IPv4(1, 2, 3, 4)), "empty access list should allow all IPs")
+
+	err := a.Set(AccessListConfig{
+		AllowedIPs: []string{"10.0.0.0/8", "1.2.3.4", "::1"},
+		DeniedIPs:  []string{"10.1.0.0/16"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, a.AllowsIP(net.IPv4(10, 2, 3, 4)))
+	assert.True(t, a.AllowsIP(net.IPv4(1, 2, 3, 4)))
+	assert.True(t, a.AllowsIP(net.IPv6loopback))
+	assert.False(t, a.AllowsIP(net.IPv4(1, 2, 3, 5)), "IP missing from a non-empty allow list was allowed")
+	assert.False(t, a.AllowsIP(net.IPv4(10, 1, 2, 3)), "deny list should take precedence over the allow list")
+}
+
+func TestAccessListInvalidConfig(t *testing.T) {
+	id0 := ids.NewShortID([20]byte{1})
+
+	a := newAccessList(false)
+	err := a.Set(AccessListConfig{
+		DeniedNodeIDs: []string{id0.PrefixedString(constants.NodeIDPrefix)},
+	})
+	assert.NoError(t, err)
+
+	err = a.Set(AccessListConfig{
+		DeniedIPs: []string{"not an IP"},
+	})
+	assert.Error(t, err)
+	assert.False(t, a.AllowsNodeID(id0), "invalid config shouldn't modify the access list")
+
+	err = a.Set(AccessListConfig{
+		AllowedNodeIDs: []string{"NodeID-invalid"},
+	})
+	assert.Error(t, err)
+	assert.False(t, a.AllowsNodeID(id0), "invalid config shouldn't modify the access list")
+}
@@ -0,0 +1,76 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"sync"
+	"time"
+
+	"github.com/liraxapp/avalanchego/utils/timer"
+)
+
+// BandwidthConfig limits the bandwidth used by each peer. A limit of 0 means
+// the bandwidth isn't limited.
+type BandwidthConfig struct {
+	// Number of bytes per second that may be read from a peer
+	PeerInboundBytesPerSec uint64 `json:"peerInboundBytesPerSec"`
+	// Number of bytes per second that may be written to a peer
+	PeerOutboundBytesPerSec uint64 `json:"peerOutboundBytesPerSec"`
+}
+
+// bandwidthThrottler is a token bucket that limits the rate at which bytes are
+// read from, or written to, a connection. Up to one second worth of unused
+// bandwidth may be saved up for bursts.
+type bandwidthThrottler struct {
+	clock *timer.Clock
+
+	lock        sync.Mutex
+	bytesPerSec float64
+	// number of bytes that may currently be passed through. Is negative if
+	// more bytes were acquired than were available.
+	tokens     float64
+	lastUpdate time.Time
+}
+
+// newBandwidthThrottler returns a throttler allowing [bytesPerSec] bytes per
+// second. Returns nil if [bytesPerSec] is 0, which is a valid throttler that
+// never delays.
+func newBandwidthThrottler(bytesPerSec uint64, clock *timer.Clock) *bandwidthThrottler {
+	if bytesPerSec == 0 {
+		return nil
+	}
+	return &bandwidthThrottler{
+		clock:       clock,
+		bytesPerSec: float64(bytesPerSec),
+		tokens:      float64(bytesPerSec),
+		lastUpdate:  clock.Time(),
+	}
+}
+
+// Acquire [size] bytes of bandwidth. Returns how long the caller must wait
+// before passing the bytes through. Bytes larger than the burst size are
+// allowed, they just result in a proportionally longer wait.
+func (t *bandwidthThrottler) Acquire(size int) time.Duration {
+	if t == nil {
+		return 0
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	currentTime := t.clock.Time()
+	if elapsed := currentTime.Sub(t.lastUpdate); elapsed > 0 {
+		t.tokens += elapsed.Seconds() * t.bytesPerSec
+		if t.tokens > t.bytesPerSec {
+			t.tokens = t.bytesPerSec
+		}
+		t.lastUpdate = currentTime
+	}
+
+	t.tokens -= float64(size)
+	if t.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-t.tokens / t.bytesPerSec * float64(time.Second))
+}
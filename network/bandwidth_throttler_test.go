@@ -0,0 +1,44 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liraxapp/avalanchego/utils/timer"
+)
+
+func TestBandwidthThrottlerUnlimited(t *testing.T) {
+	clock := &timer.Clock{}
+	throttler := newBandwidthThrottler(0, clock)
+	assert.Nil(t, throttler)
+	assert.Zero(t, throttler.Acquire(1<<30))
+}
+
+func TestBandwidthThrottler(t *testing.T) {
+	clock := &timer.Clock{}
+	clock.Set(time.Unix(1000, 0))
+	throttler := newBandwidthThrottler(1000, clock)
+
+	// One second of bandwidth is available for bursts
+	assert.Zero(t, throttler.Acquire(600))
+	assert.Zero(t, throttler.Acquire(400))
+	assert.Equal(t, 500*time.Millisecond, throttler.Acquire(500))
+
+	// The debt must be paid off before any more bytes are allowed
+	clock.Set(clock.Time().Add(500 * time.Millisecond))
+	assert.Equal(t, 100*time.Millisecond, throttler.Acquire(100))
+
+	// Unused bandwidth is only saved up to the burst size
+	clock.Set(clock.Time().Add(time.Hour))
+	assert.Zero(t, throttler.Acquire(1000))
+	assert.Equal(t, time.Millisecond, throttler.Acquire(1))
+
+	// Messages larger than the burst size are allowed after a longer wait
+	clock.Set(clock.Time().Add(time.Hour))
+	assert.Equal(t, 2*time.Second, throttler.Acquire(3000))
+}
@@ -6,6 +6,7 @@ package network
 import (
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/compression"
 )
 
 // Builder extends a Codec to build messages safely
@@ -14,7 +15,8 @@ type Builder struct{ Codec }
 // GetVersion message
 func (m Builder) GetVersion() (Msg, error) { return m.Pack(GetVersion, nil) }
 
-// Version message
+// Version message, as understood by every node. It is sent to peers whose
+// version isn't known yet.
 func (m Builder) Version(networkID, nodeID uint32, myTime uint64, ip utils.IPDesc, myVersion string) (Msg, error) {
 	return m.Pack(Version, map[Field]interface{}{
 		NetworkID:  networkID,
@@ -25,20 +27,75 @@ func (m Builder) Version(networkID, nodeID uint32, myTime uint64, ip utils.IPDes
 	})
 }
 
+// VersionWithOptionalFields message. It is only sent to peers whose version
+// accepts the optional fields of Version. [ip] is this node's IP, signed with
+// its staking key. [compressionTypes] are the compression types this node
+// accepts, in order of preference. [formats] are the message formats this node
+// accepts in addition to the legacy format.
+func (m Builder) VersionWithOptionalFields(
+	networkID,
+	nodeID uint32,
+	myTime uint64,
+	ip SignedIP,
+	myVersion string,
+	compressionTypes []compression.Type,
+	formats []Format,
+) (Msg, error) {
+	compressionBytes := make([]byte, len(compressionTypes))
+	for i, compressionType := range compressionTypes {
+		compressionBytes[i] = byte(compressionType)
+	}
+	formatBytes := make([]byte, len(formats))
+	for i, format := range formats {
+		formatBytes[i] = byte(format)
+	}
+	return m.Pack(Version, map[Field]interface{}{
+		NetworkID:   networkID,
+		NodeID:      nodeID,
+		MyTime:      myTime,
+		IP:          ip.IP,
+		VersionStr:  myVersion,
+		IPTime:      ip.Time,
+		IPSig:       ip.Signature,
+		Compression: compressionBytes,
+		Formats:     formatBytes,
+	})
+}
+
 // GetPeerList message
 func (m Builder) GetPeerList() (Msg, error) { return m.Pack(GetPeerList, nil) }
 
-// PeerList message
+// PeerList message, as understood by every node
 func (m Builder) PeerList(ipDescs []utils.IPDesc) (Msg, error) {
 	return m.Pack(PeerList, map[Field]interface{}{Peers: ipDescs})
 }
 
-// Ping message
+// SignedPeerList message. It is only sent to peers whose version accepts the
+// optional fields of PeerList.
+func (m Builder) SignedPeerList(peers []SignedIP) (Msg, error) {
+	return m.Pack(PeerList, map[Field]interface{}{
+		Peers:       []utils.IPDesc(nil),
+		SignedPeers: peers,
+	})
+}
+
+// Ping message, as understood by every node
 func (m Builder) Ping() (Msg, error) { return m.Pack(Ping, nil) }
 
-// Pong message
+// PingWithNonce message. It is only sent to peers whose version accepts the
+// optional fields of Ping.
+func (m Builder) PingWithNonce(nonce uint32) (Msg, error) {
+	return m.Pack(Ping, map[Field]interface{}{Nonce: nonce})
+}
+
+// Pong message, as understood by every node
 func (m Builder) Pong() (Msg, error) { return m.Pack(Pong, nil) }
 
+// PongWithNonce message. [nonce] is the nonce of the ping being answered.
+func (m Builder) PongWithNonce(nonce uint32) (Msg, error) {
+	return m.Pack(Pong, map[Field]interface{}{Nonce: nonce})
+}
+
 // GetAcceptedFrontier message
 func (m Builder) GetAcceptedFrontier(chainID ids.ID, requestID uint32, deadline uint64) (Msg, error) {
 	return m.Pack(GetAcceptedFrontier, map[Field]interface{}{
@@ -164,3 +221,68 @@ func (m Builder) Chits(chainID ids.ID, requestID uint32, containerIDs []ids.ID)
 		ContainerIDs: containerIDBytes,
 	})
 }
+
+// AppRequest message
+func (m Builder) AppRequest(chainID ids.ID, requestID uint32, deadline uint64, appRequestBytes []byte) (Msg, error) {
+	return m.Pack(AppRequest, map[Field]interface{}{
+		ChainID:   chainID[:],
+		RequestID: requestID,
+		Deadline:  deadline,
+		AppBytes:  appRequestBytes,
+	})
+}
+
+// AppResponse message
+func (m Builder) AppResponse(chainID ids.ID, requestID uint32, appResponseBytes []byte) (Msg, error) {
+	return m.Pack(AppResponse, map[Field]interface{}{
+		ChainID:   chainID[:],
+		RequestID: requestID,
+		AppBytes:  appResponseBytes,
+	})
+}
+
+// AppGossip message
+func (m Builder) AppGossip(chainID ids.ID, appGossipBytes []byte) (Msg, error) {
+	return m.Pack(AppGossip, map[Field]interface{}{
+		ChainID:  chainID[:],
+		AppBytes: appGossipBytes,
+	})
+}
+
+// GetStateSummary message
+func (m Builder) GetStateSummary(chainID ids.ID, requestID uint32, deadline uint64) (Msg, error) {
+	return m.Pack(GetStateSummary, map[Field]interface{}{
+		ChainID:   chainID[:],
+		RequestID: requestID,
+		Deadline:  deadline,
+	})
+}
+
+// StateSummary message
+func (m Builder) StateSummary(chainID ids.ID, requestID uint32, summary []byte) (Msg, error) {
+	return m.Pack(StateSummary, map[Field]interface{}{
+		ChainID:      chainID[:],
+		RequestID:    requestID,
+		SummaryBytes: summary,
+	})
+}
+
+// GetStateChunk message
+func (m Builder) GetStateChunk(chainID ids.ID, requestID uint32, deadline uint64, summaryID ids.ID, index uint32) (Msg, error) {
+	return m.Pack(GetStateChunk, map[Field]interface{}{
+		ChainID:     chainID[:],
+		RequestID:   requestID,
+		Deadline:    deadline,
+		ContainerID: summaryID[:],
+		ChunkIndex:  index,
+	})
+}
+
+// StateChunk message
+func (m Builder) StateChunk(chainID ids.ID, requestID uint32, chunk []byte) (Msg, error) {
+	return m.Pack(StateChunk, map[Field]interface{}{
+		ChainID:    chainID[:],
+		RequestID:  requestID,
+		ChunkBytes: chunk,
+	})
+}
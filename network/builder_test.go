@@ -11,6 +11,7 @@ import (
 
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/compression"
 )
 
 var (
@@ -64,6 +65,64 @@ func TestBuildVersion(t *testing.T) {
 	assert.Equal(t, myTime, parsedMsg.Get(MyTime))
 	assert.Equal(t, ip, parsedMsg.Get(IP))
 	assert.Equal(t, myVersion, parsedMsg.Get(VersionStr))
+	for _, field := range OptionalFields[Version] {
+		assert.Nil(t, parsedMsg.Get(field))
+	}
+}
+
+func TestBuildVersionWithOptionalFields(t *testing.T) {
+	networkID := uint32(1)
+	nodeID := uint32(3)
+	myTime := uint64(2)
+	ip := SignedIP{
+		IP: utils.IPDesc{
+			IP:   net.IPv6loopback,
+			Port: 12345,
+		},
+		Time:      1,
+		Signature: []byte("signature"),
+	}
+	myVersion := "xD"
+	compressionTypes := []compression.Type{compression.Snappy, compression.Gzip}
+	compressionBytes := []byte{byte(compression.Snappy), byte(compression.Gzip)}
+	formats := []Format{TLVFormat}
+	formatBytes := []byte{byte(TLVFormat)}
+
+	msg, err := TestBuilder.VersionWithOptionalFields(
+		networkID,
+		nodeID,
+		myTime,
+		ip,
+		myVersion,
+		compressionTypes,
+		formats,
+	)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, Version, msg.Op())
+	assert.Equal(t, networkID, msg.Get(NetworkID))
+	assert.Equal(t, nodeID, msg.Get(NodeID))
+	assert.Equal(t, myTime, msg.Get(MyTime))
+	assert.Equal(t, ip.IP, msg.Get(IP))
+	assert.Equal(t, ip.Time, msg.Get(IPTime))
+	assert.Equal(t, ip.Signature, msg.Get(IPSig))
+	assert.Equal(t, myVersion, msg.Get(VersionStr))
+	assert.Equal(t, compressionBytes, msg.Get(Compression))
+	assert.Equal(t, formatBytes, msg.Get(Formats))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, Version, parsedMsg.Op())
+	assert.Equal(t, networkID, parsedMsg.Get(NetworkID))
+	assert.Equal(t, nodeID, parsedMsg.Get(NodeID))
+	assert.Equal(t, myTime, parsedMsg.Get(MyTime))
+	assert.Equal(t, ip.IP, parsedMsg.Get(IP))
+	assert.Equal(t, ip.Time, parsedMsg.Get(IPTime))
+	assert.Equal(t, ip.Signature, parsedMsg.Get(IPSig))
+	assert.Equal(t, myVersion, parsedMsg.Get(VersionStr))
+	assert.Equal(t, compressionBytes, parsedMsg.Get(Compression))
+	assert.Equal(t, formatBytes, parsedMsg.Get(Formats))
 }
 
 func TestBuildGetPeerList(t *testing.T) {
@@ -95,6 +154,99 @@ func TestBuildPeerList(t *testing.T) {
 	assert.NotNil(t, parsedMsg)
 	assert.Equal(t, PeerList, parsedMsg.Op())
 	assert.Equal(t, ips, parsedMsg.Get(Peers))
+	assert.Nil(t, parsedMsg.Get(SignedPeers))
+}
+
+func TestBuildSignedPeerList(t *testing.T) {
+	ips := []SignedIP{
+		{
+			NodeID:    ids.NewShortID([20]byte{1}),
+			Cert:      []byte("cert 1"),
+			IP:        utils.IPDesc{IP: net.IPv6loopback, Port: 12345},
+			Time:      1,
+			Signature: []byte("signature 1"),
+		},
+		{
+			NodeID:    ids.NewShortID([20]byte{2}),
+			Cert:      []byte{},
+			IP:        utils.IPDesc{IP: net.IPv6loopback, Port: 54321},
+			Time:      2,
+			Signature: []byte("signature 2"),
+		},
+	}
+
+	msg, err := TestBuilder.SignedPeerList(ips)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, PeerList, msg.Op())
+	assert.Equal(t, ips, msg.Get(SignedPeers))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, PeerList, parsedMsg.Op())
+	assert.Empty(t, parsedMsg.Get(Peers))
+	assert.Equal(t, ips, parsedMsg.Get(SignedPeers))
+}
+
+func TestBuildPing(t *testing.T) {
+	msg, err := TestBuilder.Ping()
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, Ping, msg.Op())
+	assert.Equal(t, []byte{byte(Ping)}, msg.Bytes())
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, Ping, parsedMsg.Op())
+	assert.Nil(t, parsedMsg.Get(Nonce))
+}
+
+func TestBuildPingWithNonce(t *testing.T) {
+	nonce := uint32(7)
+
+	msg, err := TestBuilder.PingWithNonce(nonce)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, Ping, msg.Op())
+	assert.Equal(t, nonce, msg.Get(Nonce))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, Ping, parsedMsg.Op())
+	assert.Equal(t, nonce, parsedMsg.Get(Nonce))
+}
+
+func TestBuildPong(t *testing.T) {
+	msg, err := TestBuilder.Pong()
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, Pong, msg.Op())
+	assert.Equal(t, []byte{byte(Pong)}, msg.Bytes())
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, Pong, parsedMsg.Op())
+	assert.Nil(t, parsedMsg.Get(Nonce))
+}
+
+func TestBuildPongWithNonce(t *testing.T) {
+	nonce := uint32(7)
+
+	msg, err := TestBuilder.PongWithNonce(nonce)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, Pong, msg.Op())
+	assert.Equal(t, nonce, msg.Get(Nonce))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, Pong, parsedMsg.Op())
+	assert.Equal(t, nonce, parsedMsg.Get(Nonce))
 }
 
 func TestBuildGetAcceptedFrontier(t *testing.T) {
@@ -316,3 +468,226 @@ func TestBuildChits(t *testing.T) {
 	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
 	assert.Equal(t, containerIDs, parsedMsg.Get(ContainerIDs))
 }
+
+func TestBuildAppRequest(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	deadline := uint64(15)
+	appRequestBytes := []byte{2}
+
+	msg, err := TestBuilder.AppRequest(chainID, requestID, deadline, appRequestBytes)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, AppRequest, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, deadline, msg.Get(Deadline))
+	assert.Equal(t, appRequestBytes, msg.Get(AppBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, AppRequest, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, deadline, parsedMsg.Get(Deadline))
+	assert.Equal(t, appRequestBytes, parsedMsg.Get(AppBytes))
+}
+
+func TestBuildAppResponse(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	appResponseBytes := []byte{2}
+
+	msg, err := TestBuilder.AppResponse(chainID, requestID, appResponseBytes)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, AppResponse, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, appResponseBytes, msg.Get(AppBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, AppResponse, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, appResponseBytes, parsedMsg.Get(AppBytes))
+}
+
+func TestBuildAppGossip(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	appGossipBytes := []byte{2}
+
+	msg, err := TestBuilder.AppGossip(chainID, appGossipBytes)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, AppGossip, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, appGossipBytes, msg.Get(AppBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, AppGossip, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, appGossipBytes, parsedMsg.Get(AppBytes))
+}
+
+func TestCompressMsg(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	containerID := ids.Empty.Prefix(1)
+	container := make([]byte, 1024)
+
+	msg, err := TestBuilder.Put(chainID, requestID, containerID, container)
+	assert.NoError(t, err)
+
+	compressors := map[compression.Type]compression.Compressor{
+		compression.None:   compression.NewNoCompressor(),
+		compression.Snappy: compression.NewSnappyCompressor(int64(DefaultMaxMessageSize)),
+	}
+
+	compressedBytes, err := TestBuilder.Compress(msg, compressors[compression.Snappy])
+	assert.NoError(t, err)
+	assert.Equal(t, byte(Put)|compressedFlag, compressedBytes[0])
+	assert.Equal(t, byte(compression.Snappy), compressedBytes[1])
+	assert.Less(t, len(compressedBytes), len(msg.Bytes()))
+
+	msgBytes, err := TestBuilder.Decompress(compressedBytes, compressors)
+	assert.NoError(t, err)
+	assert.Equal(t, msg.Bytes(), msgBytes)
+
+	parsedMsg, err := TestBuilder.Parse(msgBytes)
+	assert.NoError(t, err)
+	assert.Equal(t, Put, parsedMsg.Op())
+	assert.Equal(t, container, parsedMsg.Get(ContainerBytes))
+
+	_, err = TestBuilder.Decompress(compressedBytes, map[compression.Type]compression.Compressor{
+		compression.None: compression.NewNoCompressor(),
+	})
+	assert.Error(t, err, "should have refused an unsupported compression type")
+
+	// Messages sent before the peer knew this node accepts compressed messages
+	// aren't flagged
+	assert.False(t, isCompressed(msg.Bytes()))
+	_, err = TestBuilder.Decompress(msg.Bytes(), compressors)
+	assert.Error(t, err, "should have refused an uncompressed message")
+}
+
+func TestCompressMsgNotSmaller(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	containerID := ids.Empty.Prefix(1)
+
+	msg, err := TestBuilder.Put(chainID, requestID, containerID, []byte{1})
+	assert.NoError(t, err)
+
+	compressedBytes, err := TestBuilder.Compress(msg, compression.NewGzipCompressor(int64(DefaultMaxMessageSize)))
+	assert.NoError(t, err)
+	assert.Equal(t, byte(compression.None), compressedBytes[1])
+	assert.Equal(t, msg.Bytes()[1:], compressedBytes[2:])
+}
+
+func TestCompressMsgNotCompressible(t *testing.T) {
+	msg, err := TestBuilder.Ping()
+	assert.NoError(t, err)
+
+	_, err = TestBuilder.Compress(msg, compression.NewSnappyCompressor(int64(DefaultMaxMessageSize)))
+	assert.Error(t, err)
+}
+
+func TestBuildGetStateSummary(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	deadline := uint64(15)
+
+	msg, err := TestBuilder.GetStateSummary(chainID, requestID, deadline)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, GetStateSummary, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, deadline, msg.Get(Deadline))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, GetStateSummary, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, deadline, parsedMsg.Get(Deadline))
+}
+
+func TestBuildStateSummary(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	summary := []byte{2}
+
+	msg, err := TestBuilder.StateSummary(chainID, requestID, summary)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, StateSummary, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, summary, msg.Get(SummaryBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, StateSummary, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, summary, parsedMsg.Get(SummaryBytes))
+}
+
+func TestBuildGetStateChunk(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	deadline := uint64(15)
+	summaryID := ids.Empty.Prefix(1)
+	index := uint32(3)
+
+	msg, err := TestBuilder.GetStateChunk(chainID, requestID, deadline, summaryID, index)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, GetStateChunk, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, deadline, msg.Get(Deadline))
+	assert.Equal(t, summaryID[:], msg.Get(ContainerID))
+	assert.Equal(t, index, msg.Get(ChunkIndex))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, GetStateChunk, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, deadline, parsedMsg.Get(Deadline))
+	assert.Equal(t, summaryID[:], parsedMsg.Get(ContainerID))
+	assert.Equal(t, index, parsedMsg.Get(ChunkIndex))
+}
+
+func TestBuildStateChunk(t *testing.T) {
+	chainID := ids.Empty.Prefix(0)
+	requestID := uint32(5)
+	chunk := []byte{2}
+
+	msg, err := TestBuilder.StateChunk(chainID, requestID, chunk)
+	assert.NoError(t, err)
+	assert.NotNil(t, msg)
+	assert.Equal(t, StateChunk, msg.Op())
+	assert.Equal(t, chainID[:], msg.Get(ChainID))
+	assert.Equal(t, requestID, msg.Get(RequestID))
+	assert.Equal(t, chunk, msg.Get(ChunkBytes))
+
+	parsedMsg, err := TestBuilder.Parse(msg.Bytes())
+	assert.NoError(t, err)
+	assert.NotNil(t, parsedMsg)
+	assert.Equal(t, StateChunk, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, requestID, parsedMsg.Get(RequestID))
+	assert.Equal(t, chunk, parsedMsg.Get(ChunkBytes))
+}
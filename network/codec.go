@@ -8,19 +8,30 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/liraxapp/avalanchego/utils/compression"
 	"github.com/liraxapp/avalanchego/utils/wrappers"
 )
 
+// compressedFlag is set in the op byte of messages that are prefixed with their
+// compression type
+const compressedFlag byte = 0x40
+
 var (
-	errMissingField = errors.New("message missing field")
-	errBadOp        = errors.New("input field has invalid operation")
+	errMissingField          = errors.New("message missing field")
+	errBadOp                 = errors.New("input field has invalid operation")
+	errNotCompressible       = errors.New("operation can't be compressed")
+	errMissingCompression    = errors.New("compressed message is missing its compression type")
+	errUnsupportedCompressor = errors.New("unsupported compression type")
 )
 
 // Codec defines the serialization and deserialization of network messages
 type Codec struct{}
 
 // Pack attempts to pack a map of fields into a message.
-// The first byte of the message is the opcode of the message.
+// The first byte of the message is the opcode of the message. Optional fields
+// are appended after the other fields, in the order of [OptionalFields]. Since
+// the legacy format is positional, an optional field is only packed if all the
+// optional fields before it are in [fields] too.
 func (Codec) Pack(op Op, fields map[Field]interface{}) (Msg, error) {
 	message, ok := Messages[op]
 	if !ok {
@@ -36,6 +47,13 @@ func (Codec) Pack(op Op, fields map[Field]interface{}) (Msg, error) {
 		}
 		field.Packer()(&p, data)
 	}
+	for _, field := range OptionalFields[op] {
+		data, ok := fields[field]
+		if !ok {
+			break
+		}
+		field.Packer()(&p, data)
+	}
 
 	return &msg{
 		op:     op,
@@ -45,8 +63,17 @@ func (Codec) Pack(op Op, fields map[Field]interface{}) (Msg, error) {
 }
 
 // Parse attempts to convert bytes into a message.
-// The first byte of the message is the opcode of the message.
-func (Codec) Parse(b []byte) (Msg, error) {
+// The first byte of the message is the opcode of the message. Messages in
+// either format are accepted. Optional fields are only unpacked if the message
+// has bytes left for them, so messages of nodes that don't know about the
+// optional fields can be parsed. Unknown fields appended to a Version message
+// by newer nodes are ignored, since Version is always sent in the legacy
+// format.
+func (c Codec) Parse(b []byte) (Msg, error) {
+	if isTLV(b) {
+		return c.parseTLV(b)
+	}
+
 	p := wrappers.Packer{Bytes: b}
 	op := Op(p.UnpackByte())
 	message, ok := Messages[op]
@@ -58,8 +85,14 @@ func (Codec) Parse(b []byte) (Msg, error) {
 	for _, field := range message {
 		fields[field] = field.Unpacker()(&p)
 	}
+	for _, field := range OptionalFields[op] {
+		if p.Offset == len(b) || p.Errored() {
+			break
+		}
+		fields[field] = field.Unpacker()(&p)
+	}
 
-	if p.Offset != len(b) {
+	if p.Offset != len(b) && op != Version {
 		p.Add(fmt.Errorf("expected length %d got %d", len(b), p.Offset))
 	}
 
@@ -69,3 +102,81 @@ func (Codec) Parse(b []byte) (Msg, error) {
 		bytes:  b,
 	}, p.Err
 }
+
+// Compress returns the bytes to send [m] to a peer that accepts compressed
+// messages. The first byte is the op byte of the message, with [compressedFlag]
+// set, and the second byte is the compression type of the remaining bytes. If
+// compressing doesn't make the message smaller, it is sent uncompressed.
+func (Codec) Compress(m Msg, compressor compression.Compressor) ([]byte, error) {
+	op := m.Op()
+	if !op.Compressible() {
+		return nil, errNotCompressible
+	}
+
+	// The same message is often sent to many peers, so only compress it once
+	// per compression type
+	cache, isCacheable := m.(*msg)
+	if isCacheable {
+		cache.compressedLock.Lock()
+		defer cache.compressedLock.Unlock()
+
+		if compressed, ok := cache.compressed[compressor.Type()]; ok {
+			return compressed, nil
+		}
+	}
+
+	payload := m.Bytes()[1:]
+	compressionType := compressor.Type()
+	compressedPayload, err := compressor.Compress(payload)
+	if err != nil {
+		return nil, err
+	}
+	if len(compressedPayload) >= len(payload) {
+		compressionType = compression.None
+		compressedPayload = payload
+	}
+
+	compressed := make([]byte, len(compressedPayload)+2)
+	compressed[0] = m.Bytes()[0] | compressedFlag
+	compressed[1] = byte(compressionType)
+	copy(compressed[2:], compressedPayload)
+
+	if isCacheable {
+		if cache.compressed == nil {
+			cache.compressed = make(map[compression.Type][]byte, 1)
+		}
+		cache.compressed[compressor.Type()] = compressed
+	}
+	return compressed, nil
+}
+
+// Decompress converts bytes produced by Compress back into the bytes of the
+// message, so that they can be passed to Parse. Only compression types in
+// [compressors] are accepted.
+func (Codec) Decompress(b []byte, compressors map[compression.Type]compression.Compressor) ([]byte, error) {
+	if !isCompressed(b) || len(b) < 2 {
+		return nil, errMissingCompression
+	}
+	op := opOf(b[0])
+	if !op.Compressible() {
+		return nil, errNotCompressible
+	}
+	compressionType := compression.Type(b[1])
+	compressor, ok := compressors[compressionType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnsupportedCompressor, compressionType)
+	}
+
+	payload, err := compressor.Decompress(b[2:])
+	if err != nil {
+		return nil, err
+	}
+
+	decompressed := make([]byte, len(payload)+1)
+	decompressed[0] = b[0] &^ compressedFlag
+	copy(decompressed[1:], payload)
+	return decompressed, nil
+}
+
+// isCompressed returns true if [b] are bytes produced by Compress
+func isCompressed(b []byte) bool { return len(b) > 0 && b[0]&compressedFlag != 0 }
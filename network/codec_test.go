@@ -5,9 +5,13 @@ package network
 
 import (
 	"math"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
 )
 
 var (
@@ -33,3 +37,95 @@ func TestCodecParseExtraSpace(t *testing.T) {
 	_, err := TestCodec.Parse([]byte{byte(GetVersion), 0x00})
 	assert.Error(t, err)
 }
+
+// baselineVersion returns a Version message as packed by nodes that don't know
+// about any of its optional fields
+func baselineVersion(ip utils.IPDesc, myVersion string) []byte {
+	p := wrappers.Packer{MaxSize: math.MaxInt32}
+	p.PackByte(byte(Version))
+	p.PackInt(1)         // NetworkID
+	p.PackInt(2)         // NodeID
+	p.PackLong(3)        // MyTime
+	p.PackIP(ip)         // IP
+	p.PackStr(myVersion) // VersionStr
+	return p.Bytes
+}
+
+func TestCodecParseBaselineVersion(t *testing.T) {
+	ip := utils.IPDesc{IP: net.IPv6loopback, Port: 12345}
+
+	msg, err := TestCodec.Parse(baselineVersion(ip, "app/0.1.0"))
+	assert.NoError(t, err)
+	assert.Equal(t, Version, msg.Op())
+	assert.Equal(t, uint32(1), msg.Get(NetworkID))
+	assert.Equal(t, uint32(2), msg.Get(NodeID))
+	assert.Equal(t, uint64(3), msg.Get(MyTime))
+	assert.Equal(t, ip, msg.Get(IP))
+	assert.Equal(t, "app/0.1.0", msg.Get(VersionStr))
+	for _, field := range OptionalFields[Version] {
+		assert.Nil(t, msg.Get(field))
+	}
+
+	// The legacy message is unchanged, so that older nodes can parse it
+	builtMsg, err := TestBuilder.Version(1, 2, 3, ip, "app/0.1.0")
+	assert.NoError(t, err)
+	assert.Equal(t, baselineVersion(ip, "app/0.1.0"), builtMsg.Bytes())
+}
+
+func TestCodecParseBaselinePeerList(t *testing.T) {
+	ips := []utils.IPDesc{
+		{IP: net.IPv6loopback, Port: 12345},
+		{IP: net.IPv4(1, 2, 3, 4), Port: 54321},
+	}
+	p := wrappers.Packer{MaxSize: math.MaxInt32}
+	p.PackByte(byte(PeerList))
+	p.PackIPs(ips) // Peers
+
+	msg, err := TestCodec.Parse(p.Bytes)
+	assert.NoError(t, err)
+	assert.Equal(t, PeerList, msg.Op())
+	assert.Equal(t, ips, msg.Get(Peers))
+	assert.Nil(t, msg.Get(SignedPeers))
+
+	// The legacy message is unchanged, so that older nodes can parse it
+	builtMsg, err := TestBuilder.PeerList(ips)
+	assert.NoError(t, err)
+	assert.Equal(t, p.Bytes, builtMsg.Bytes())
+}
+
+func TestCodecPackOptionalFieldsInOrder(t *testing.T) {
+	ip := utils.IPDesc{IP: net.IPv6loopback, Port: 12345}
+	fields := map[Field]interface{}{
+		NetworkID:  uint32(1),
+		NodeID:     uint32(2),
+		MyTime:     uint64(3),
+		IP:         ip,
+		VersionStr: "app/0.1.0",
+		// IPTime is missing, so none of the following optional fields can be
+		// packed
+		IPSig: []byte("signature"),
+	}
+
+	msg, err := TestCodec.Pack(Version, fields)
+	assert.NoError(t, err)
+	assert.Equal(t, baselineVersion(ip, "app/0.1.0"), msg.Bytes())
+}
+
+func TestCodecParseTruncatedOptionalField(t *testing.T) {
+	ip := utils.IPDesc{IP: net.IPv6loopback, Port: 12345}
+	b := append(baselineVersion(ip, "app/0.1.0"), 0x00)
+
+	_, err := TestCodec.Parse(b)
+	assert.Error(t, err)
+}
+
+func TestParseOp(t *testing.T) {
+	for op := range Messages {
+		parsed, err := ParseOp(op.String())
+		assert.NoError(t, err)
+		assert.Equal(t, op, parsed)
+	}
+
+	_, err := ParseOp("not an op")
+	assert.Error(t, err)
+}
@@ -4,13 +4,19 @@
 package network
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/liraxapp/avalanchego/utils/wrappers"
 )
 
+var errUnknownOp = errors.New("unknown op")
+
 // Field that may be packed into a message
 type Field uint32
 
-// Fields that may be packed. These values are not sent over the wire.
+// Fields that may be packed. These values are sent over the wire to identify
+// fields of messages in the TLV format, so new fields must only be appended.
 const (
 	VersionStr          Field = iota // Used in handshake
 	NetworkID                        // Used in handshake
@@ -25,6 +31,16 @@ const (
 	ContainerBytes                   // Used for gossiping
 	ContainerIDs                     // Used for querying
 	MultiContainerBytes              // Used in MultiPut
+	AppBytes                         // Used in application messages
+	Compression                      // Used in handshake
+	IPTime                           // Used in handshake
+	IPSig                            // Used in handshake
+	Nonce                            // Used in ping/pong
+	Formats                          // Used in handshake
+	SummaryBytes                     // Used in state sync
+	ChunkIndex                       // Used in state sync
+	ChunkBytes                       // Used in state sync
+	SignedPeers                      // Used in handshake
 )
 
 // Packer returns the packer function that can be used to pack this field.
@@ -56,6 +72,26 @@ func (f Field) Packer() func(*wrappers.Packer, interface{}) {
 		return wrappers.TryPackHashes
 	case MultiContainerBytes:
 		return wrappers.TryPack2DBytes
+	case AppBytes:
+		return wrappers.TryPackBytes
+	case Compression:
+		return wrappers.TryPackBytes
+	case IPTime:
+		return wrappers.TryPackLong
+	case IPSig:
+		return wrappers.TryPackBytes
+	case Nonce:
+		return wrappers.TryPackInt
+	case Formats:
+		return wrappers.TryPackBytes
+	case SummaryBytes:
+		return wrappers.TryPackBytes
+	case ChunkIndex:
+		return wrappers.TryPackInt
+	case ChunkBytes:
+		return wrappers.TryPackBytes
+	case SignedPeers:
+		return tryPackSignedIPs
 	default:
 		return nil
 	}
@@ -90,6 +126,26 @@ func (f Field) Unpacker() func(*wrappers.Packer) interface{} {
 		return wrappers.TryUnpackHashes
 	case MultiContainerBytes:
 		return wrappers.TryUnpack2DBytes
+	case AppBytes:
+		return wrappers.TryUnpackBytes
+	case Compression:
+		return wrappers.TryUnpackBytes
+	case IPTime:
+		return wrappers.TryUnpackLong
+	case IPSig:
+		return wrappers.TryUnpackBytes
+	case Nonce:
+		return wrappers.TryUnpackInt
+	case Formats:
+		return wrappers.TryUnpackBytes
+	case SummaryBytes:
+		return wrappers.TryUnpackBytes
+	case ChunkIndex:
+		return wrappers.TryUnpackInt
+	case ChunkBytes:
+		return wrappers.TryUnpackBytes
+	case SignedPeers:
+		return tryUnpackSignedIPs
 	default:
 		return nil
 	}
@@ -123,6 +179,26 @@ func (f Field) String() string {
 		return "Container IDs"
 	case MultiContainerBytes:
 		return "MultiContainerBytes"
+	case AppBytes:
+		return "AppBytes"
+	case Compression:
+		return "Compression"
+	case IPTime:
+		return "IPTime"
+	case IPSig:
+		return "IPSig"
+	case Nonce:
+		return "Nonce"
+	case Formats:
+		return "Formats"
+	case SummaryBytes:
+		return "SummaryBytes"
+	case ChunkIndex:
+		return "ChunkIndex"
+	case ChunkBytes:
+		return "ChunkBytes"
+	case SignedPeers:
+		return "SignedPeers"
 	default:
 		return "Unknown Field"
 	}
@@ -167,11 +243,73 @@ func (op Op) String() string {
 		return "pull_query"
 	case Chits:
 		return "chits"
+	case AppRequest:
+		return "app_request"
+	case AppResponse:
+		return "app_response"
+	case AppGossip:
+		return "app_gossip"
+	case GetStateSummary:
+		return "get_state_summary"
+	case StateSummary:
+		return "state_summary"
+	case GetStateChunk:
+		return "get_state_chunk"
+	case StateChunk:
+		return "state_chunk"
 	default:
 		return "Unknown Op"
 	}
 }
 
+// ParseOp returns the op named [name]
+func ParseOp(name string) (Op, error) {
+	for op := range Messages {
+		if op.String() == name {
+			return op, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", errUnknownOp, name)
+}
+
+// Compressible returns true if messages with this op carry containers or
+// application bytes, and so may be compressed when sent to a peer that
+// supports compression.
+func (op Op) Compressible() bool {
+	switch op {
+	case MultiPut, Put, PushQuery, AppRequest, AppResponse, AppGossip, StateChunk:
+		return true
+	default:
+		return false
+	}
+}
+
+// Bulk returns true if messages with this op may be large and aren't latency
+// sensitive. They are queued separately from other messages sent to a peer, so
+// that they can't starve consensus messages.
+func (op Op) Bulk() bool {
+	switch op {
+	case MultiPut, PeerList, StateChunk:
+		return true
+	default:
+		return false
+	}
+}
+
+// Class returns the class of messages with this op. A multiplexed connection
+// sends the messages of each class on a separate stream.
+func (op Op) Class() Class {
+	switch op {
+	case GetAcceptedFrontier, AcceptedFrontier, GetAccepted, Accepted, GetAncestors, MultiPut,
+		GetStateSummary, StateSummary, GetStateChunk, StateChunk:
+		return BootstrapClass
+	case GetPeerList, PeerList, AppGossip:
+		return GossipClass
+	default:
+		return ConsensusClass
+	}
+}
+
 // Public commands that may be sent between stakers
 const (
 	// Handshake:
@@ -194,6 +332,15 @@ const (
 	PushQuery
 	PullQuery
 	Chits
+	// Application:
+	AppRequest
+	AppResponse
+	AppGossip
+	// State sync:
+	GetStateSummary
+	StateSummary
+	GetStateChunk
+	StateChunk
 )
 
 // Defines the messages that can be sent/received with this network
@@ -219,5 +366,26 @@ var (
 		PushQuery: {ChainID, RequestID, Deadline, ContainerID, ContainerBytes},
 		PullQuery: {ChainID, RequestID, Deadline, ContainerID},
 		Chits:     {ChainID, RequestID, ContainerIDs},
+		// Application:
+		AppRequest:  {ChainID, RequestID, Deadline, AppBytes},
+		AppResponse: {ChainID, RequestID, AppBytes},
+		AppGossip:   {ChainID, AppBytes},
+		// State sync:
+		GetStateSummary: {ChainID, RequestID, Deadline},
+		StateSummary:    {ChainID, RequestID, SummaryBytes},
+		GetStateChunk:   {ChainID, RequestID, Deadline, ContainerID, ChunkIndex},
+		StateChunk:      {ChainID, RequestID, ChunkBytes},
 	}
 )
+
+// OptionalFields are the fields of messages that may be omitted. In the legacy
+// format they are appended after the fields in [Messages], in this order. New
+// fields of existing messages must be added here rather than to [Messages], so
+// that the messages of nodes that don't know about the new fields can still be
+// parsed.
+var OptionalFields = map[Op][]Field{
+	Version:  {IPTime, IPSig, Compression, Formats},
+	PeerList: {SignedPeers},
+	Ping:     {Nonce},
+	Pong:     {Nonce},
+}
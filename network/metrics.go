@@ -9,11 +9,16 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/liraxapp/avalanchego/utils/constants"
+	"github.com/liraxapp/avalanchego/utils/timer"
 	"github.com/liraxapp/avalanchego/utils/wrappers"
 )
 
 type messageMetrics struct {
 	numSent, numFailed, numReceived prometheus.Counter
+
+	// only set for compressible ops
+	uncompressedBytesSent, compressedBytesSent,
+	uncompressedBytesReceived, compressedBytesReceived prometheus.Counter
 }
 
 func (mm *messageMetrics) initialize(msgType Op, registerer prometheus.Registerer) error {
@@ -45,19 +50,72 @@ func (mm *messageMetrics) initialize(msgType Op, registerer prometheus.Registere
 		return fmt.Errorf("failed to register received statistics of %s due to %s",
 			msgType, err)
 	}
+
+	if !msgType.Compressible() {
+		return nil
+	}
+
+	mm.uncompressedBytesSent = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      fmt.Sprintf("%s_uncompressed_bytes_sent", msgType),
+		Help:      fmt.Sprintf("Size of %s messages sent to peers that accept compression, before compression", msgType),
+	})
+	mm.compressedBytesSent = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      fmt.Sprintf("%s_compressed_bytes_sent", msgType),
+		Help:      fmt.Sprintf("Size of %s messages sent to peers that accept compression, after compression", msgType),
+	})
+	mm.uncompressedBytesReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      fmt.Sprintf("%s_uncompressed_bytes_received", msgType),
+		Help:      fmt.Sprintf("Size of compressed %s messages received, after decompression", msgType),
+	})
+	mm.compressedBytesReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      fmt.Sprintf("%s_compressed_bytes_received", msgType),
+		Help:      fmt.Sprintf("Size of compressed %s messages received, before decompression", msgType),
+	})
+
+	if err := registerer.Register(mm.uncompressedBytesSent); err != nil {
+		return fmt.Errorf("failed to register uncompressed bytes sent statistics of %s due to %s",
+			msgType, err)
+	}
+	if err := registerer.Register(mm.compressedBytesSent); err != nil {
+		return fmt.Errorf("failed to register compressed bytes sent statistics of %s due to %s",
+			msgType, err)
+	}
+	if err := registerer.Register(mm.uncompressedBytesReceived); err != nil {
+		return fmt.Errorf("failed to register uncompressed bytes received statistics of %s due to %s",
+			msgType, err)
+	}
+	if err := registerer.Register(mm.compressedBytesReceived); err != nil {
+		return fmt.Errorf("failed to register compressed bytes received statistics of %s due to %s",
+			msgType, err)
+	}
 	return nil
 }
 
 type metrics struct {
 	numPeers prometheus.Gauge
 
+	// round trip times of pings, in milliseconds
+	pingRTT prometheus.Histogram
+	// number of pings that were never answered by a pong
+	pingsLost prometheus.Counter
+	// number of bytes that were delayed because a peer exceeded its bandwidth
+	inboundThrottledBytes, outboundThrottledBytes prometheus.Counter
+
 	getVersion, version,
 	getPeerlist, peerlist,
 	ping, pong,
 	getAcceptedFrontier, acceptedFrontier,
 	getAccepted, accepted,
 	get, getAncestors, put, multiPut,
-	pushQuery, pullQuery, chits messageMetrics
+	pushQuery, pullQuery, chits,
+	appRequest, appResponse, appGossip messageMetrics
+
+	getStateSummary, stateSummary,
+	getStateChunk, stateChunk messageMetrics
 }
 
 func (m *metrics) initialize(registerer prometheus.Registerer) error {
@@ -72,6 +130,44 @@ func (m *metrics) initialize(registerer prometheus.Registerer) error {
 		errs.Add(fmt.Errorf("failed to register peers statistics due to %s",
 			err))
 	}
+
+	m.pingRTT = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: constants.PlatformName,
+		Name:      "ping_rtt",
+		Help:      "Round trip time of pings sent to peers in milliseconds",
+		Buckets:   timer.MillisecondsBuckets,
+	})
+	if err := registerer.Register(m.pingRTT); err != nil {
+		errs.Add(fmt.Errorf("failed to register ping rtt statistics due to %s",
+			err))
+	}
+	m.pingsLost = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "pings_lost",
+		Help:      "Number of pings sent to peers that were never answered",
+	})
+	if err := registerer.Register(m.pingsLost); err != nil {
+		errs.Add(fmt.Errorf("failed to register lost pings statistics due to %s",
+			err))
+	}
+	m.inboundThrottledBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "inbound_throttled_bytes",
+		Help:      "Number of bytes read from peers that were delayed because the peer exceeded its inbound bandwidth",
+	})
+	if err := registerer.Register(m.inboundThrottledBytes); err != nil {
+		errs.Add(fmt.Errorf("failed to register inbound throttled bytes statistics due to %s",
+			err))
+	}
+	m.outboundThrottledBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: constants.PlatformName,
+		Name:      "outbound_throttled_bytes",
+		Help:      "Number of bytes written to peers that were delayed because the peer exceeded its outbound bandwidth",
+	})
+	if err := registerer.Register(m.outboundThrottledBytes); err != nil {
+		errs.Add(fmt.Errorf("failed to register outbound throttled bytes statistics due to %s",
+			err))
+	}
 	errs.Add(
 		m.getVersion.initialize(GetVersion, registerer),
 		m.version.initialize(Version, registerer),
@@ -90,6 +186,13 @@ func (m *metrics) initialize(registerer prometheus.Registerer) error {
 		m.pushQuery.initialize(PushQuery, registerer),
 		m.pullQuery.initialize(PullQuery, registerer),
 		m.chits.initialize(Chits, registerer),
+		m.appRequest.initialize(AppRequest, registerer),
+		m.appResponse.initialize(AppResponse, registerer),
+		m.appGossip.initialize(AppGossip, registerer),
+		m.getStateSummary.initialize(GetStateSummary, registerer),
+		m.stateSummary.initialize(StateSummary, registerer),
+		m.getStateChunk.initialize(GetStateChunk, registerer),
+		m.stateChunk.initialize(StateChunk, registerer),
 	)
 	return errs.Err
 }
@@ -130,6 +233,20 @@ func (m *metrics) message(msgType Op) *messageMetrics {
 		return &m.pullQuery
 	case Chits:
 		return &m.chits
+	case AppRequest:
+		return &m.appRequest
+	case AppResponse:
+		return &m.appResponse
+	case AppGossip:
+		return &m.appGossip
+	case GetStateSummary:
+		return &m.getStateSummary
+	case StateSummary:
+		return &m.stateSummary
+	case GetStateChunk:
+		return &m.getStateChunk
+	case StateChunk:
+		return &m.stateChunk
 	default:
 		return nil
 	}
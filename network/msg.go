@@ -3,6 +3,12 @@
 
 package network
 
+import (
+	"sync"
+
+	"github.com/liraxapp/avalanchego/utils/compression"
+)
+
 // Msg represents a set of fields that can be serialized into a byte stream
 type Msg interface {
 	Op() Op
@@ -14,6 +20,16 @@ type msg struct {
 	op     Op
 	fields map[Field]interface{}
 	bytes  []byte
+
+	// the bytes of this message for each compression type it has been sent
+	// with
+	compressedLock sync.Mutex
+	compressed     map[compression.Type][]byte
+
+	// this message in the TLV format, if it has been sent to a peer that
+	// accepts it
+	tlvLock sync.Mutex
+	tlv     Msg
 }
 
 // Field returns the value of the specified field in this message
@@ -0,0 +1,350 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+// MuxProtocol is the TLS application protocol negotiated by nodes that
+// multiplex their connections
+const MuxProtocol = "avalanche-mux/1"
+
+const (
+	// maxFrameSize is the max number of bytes of a message sent in one frame.
+	// A large message only delays the messages of other streams by the time it
+	// takes to send a frame.
+	maxFrameSize = 16 * 1024
+	// stream, flags and payload length
+	frameHeaderLen = 2*wrappers.ByteLen + wrappers.ShortLen
+	// endOfMessage is set in the flags of the last frame of a message
+	endOfMessage byte = 1
+)
+
+var (
+	errUnknownStream   = errors.New("unknown stream")
+	errMessageTooLarge = errors.New("message is larger than the max message size")
+	errMuxClosed       = errors.New("multiplexed connection is closed")
+)
+
+// Class of a message
+type Class byte
+
+// Classes of messages
+const (
+	// ConsensusClass messages are small and latency sensitive
+	ConsensusClass Class = iota
+	// BootstrapClass messages are sent while bootstrapping and may be large
+	BootstrapClass
+	// GossipClass messages are sent periodically to all peers
+	GossipClass
+
+	numClasses
+)
+
+func (c Class) String() string {
+	switch c {
+	case ConsensusClass:
+		return "consensus"
+	case BootstrapClass:
+		return "bootstrap"
+	case GossipClass:
+		return "gossip"
+	default:
+		return "unknown class"
+	}
+}
+
+// MultiplexedConn is a connection that carries a separate stream of messages
+// for each class
+type MultiplexedConn interface {
+	net.Conn
+
+	// WriteMessage writes [msg] on the stream of [class]. It blocks until the
+	// message is written. Messages that are written concurrently on other
+	// streams are interleaved with it, so a large message doesn't delay the
+	// messages of other classes. Must be thread safe.
+	WriteMessage(class Class, msg []byte) error
+}
+
+type muxUpgrader struct {
+	upgrader       Upgrader
+	maxMessageSize int64
+}
+
+// NewMuxUpgrader returns an Upgrader that multiplexes the messages of each
+// class over the connection upgraded by [upgrader]. If [upgrader] upgrades to
+// TLS, the connection is only multiplexed if [MuxProtocol] was negotiated, so
+// that peers without multiplexing are still supported. Otherwise, peers must
+// also multiplex their connections.
+func NewMuxUpgrader(upgrader Upgrader, maxMessageSize uint32) Upgrader {
+	return muxUpgrader{
+		upgrader:       upgrader,
+		maxMessageSize: int64(maxMessageSize),
+	}
+}
+
+func (u muxUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, *x509.Certificate, error) {
+	id, upgradedConn, cert, err := u.upgrader.Upgrade(conn)
+	if err != nil {
+		return id, upgradedConn, cert, err
+	}
+	if tlsConn, ok := upgradedConn.(*tls.Conn); ok && tlsConn.ConnectionState().NegotiatedProtocol != MuxProtocol {
+		// The peer doesn't multiplex its connections
+		return id, upgradedConn, cert, nil
+	}
+	return id, newMuxConn(upgradedConn, u.maxMessageSize), cert, nil
+}
+
+// muxWrite is a message waiting to be written on a stream
+type muxWrite struct {
+	msg  []byte
+	done chan error
+}
+
+// muxConn splits messages into frames tagged with the stream of the message.
+// Frames of the streams with pending messages are written in turn.
+type muxConn struct {
+	net.Conn
+	maxMessageSize int64
+
+	// messages waiting to be written, for each stream. The writer routine only
+	// takes the next message of a stream once the previous one was written.
+	writes [numClasses]chan *muxWrite
+
+	closeOnce sync.Once
+	closer    chan struct{}
+
+	// the fields below are only accessed by the reader of the connection
+
+	header [frameHeaderLen]byte
+	// bytes of the messages that have only been partially read, for each
+	// stream
+	partial [numClasses][]byte
+	// length prefixed messages that have been fully read, but not returned by
+	// Read yet
+	ready []byte
+}
+
+func newMuxConn(conn net.Conn, maxMessageSize int64) *muxConn {
+	c := &muxConn{
+		Conn:           conn,
+		maxMessageSize: maxMessageSize,
+		closer:         make(chan struct{}),
+	}
+	for i := range c.writes {
+		c.writes[i] = make(chan *muxWrite, 1)
+	}
+	go c.writeFrames()
+	return c
+}
+
+func (c *muxConn) WriteMessage(class Class, msg []byte) error {
+	if class >= numClasses {
+		return errUnknownStream
+	}
+
+	write := &muxWrite{
+		msg:  msg,
+		done: make(chan error, 1),
+	}
+	select {
+	case c.writes[class] <- write:
+	case <-c.closer:
+		return errMuxClosed
+	}
+	select {
+	case err := <-write.done:
+		return err
+	case <-c.closer:
+		// The writer routine may have exited before taking the message
+		return errMuxClosed
+	}
+}
+
+// Write writes [b] as a single message on the consensus stream
+func (c *muxConn) Write(b []byte) (int, error) {
+	if err := c.WriteMessage(ConsensusClass, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Read returns the bytes of the messages of all streams in the order they were
+// fully read. Each message is prefixed with its length, as it would be on a
+// connection that isn't multiplexed.
+func (c *muxConn) Read(b []byte) (int, error) {
+	for len(c.ready) == 0 {
+		if err := c.readFrame(); err != nil {
+			return 0, err
+		}
+	}
+	read := copy(b, c.ready)
+	c.ready = c.ready[read:]
+	return read, nil
+}
+
+func (c *muxConn) Close() error {
+	c.closeOnce.Do(func() { close(c.closer) })
+	return c.Conn.Close()
+}
+
+// writeFrames writes a frame of each stream with a pending message in turn,
+// until the connection is closed or a write fails
+func (c *muxConn) writeFrames() {
+	pending := [numClasses]*muxWrite{}
+	err := error(nil)
+	defer func() {
+		c.closeOnce.Do(func() { close(c.closer) })
+		if err == nil {
+			err = errMuxClosed
+		}
+		for _, write := range pending {
+			if write != nil {
+				write.done <- err
+			}
+		}
+	}()
+
+	for {
+		if !c.accept(&pending) {
+			return
+		}
+
+		for class, write := range pending {
+			if write == nil {
+				continue
+			}
+
+			payload := write.msg
+			flags := byte(0)
+			if len(payload) > maxFrameSize {
+				payload = payload[:maxFrameSize]
+			} else {
+				flags = endOfMessage
+			}
+			if err = c.writeFrame(Class(class), flags, payload); err != nil {
+				return
+			}
+
+			write.msg = write.msg[len(payload):]
+			if flags == endOfMessage {
+				write.done <- nil
+				pending[class] = nil
+			}
+		}
+	}
+}
+
+// accept adds the messages waiting to be written on streams without a pending
+// message to [pending]. If there are no pending messages, it blocks until there
+// is one. Returns false if the connection was closed.
+func (c *muxConn) accept(pending *[numClasses]*muxWrite) bool {
+	block := true
+	for _, write := range pending {
+		if write != nil {
+			block = false
+		}
+	}
+
+	for {
+		writes := [numClasses]chan *muxWrite{}
+		for class, write := range pending {
+			if write == nil {
+				writes[class] = c.writes[class]
+			}
+		}
+
+		var write *muxWrite
+		class := Class(0)
+		if block {
+			select {
+			case write = <-writes[ConsensusClass]:
+				class = ConsensusClass
+			case write = <-writes[BootstrapClass]:
+				class = BootstrapClass
+			case write = <-writes[GossipClass]:
+				class = GossipClass
+			case <-c.closer:
+				return false
+			}
+		} else {
+			select {
+			case write = <-writes[ConsensusClass]:
+				class = ConsensusClass
+			case write = <-writes[BootstrapClass]:
+				class = BootstrapClass
+			case write = <-writes[GossipClass]:
+				class = GossipClass
+			case <-c.closer:
+				return false
+			default:
+				return true
+			}
+		}
+		pending[class] = write
+		block = false
+	}
+}
+
+func (c *muxConn) writeFrame(class Class, flags byte, payload []byte) error {
+	frame := make([]byte, frameHeaderLen+len(payload))
+	frame[0] = byte(class)
+	frame[1] = flags
+	binary.BigEndian.PutUint16(frame[2:], uint16(len(payload)))
+	copy(frame[frameHeaderLen:], payload)
+
+	for len(frame) > 0 {
+		written, err := c.Conn.Write(frame)
+		if err != nil {
+			return err
+		}
+		frame = frame[written:]
+	}
+	return nil
+}
+
+// readFrame reads the next frame from the connection. If it completes a
+// message, the message is added to [ready].
+func (c *muxConn) readFrame() error {
+	if _, err := io.ReadFull(c.Conn, c.header[:]); err != nil {
+		return err
+	}
+	class := Class(c.header[0])
+	flags := c.header[1]
+	length := int(binary.BigEndian.Uint16(c.header[2:]))
+	if class >= numClasses {
+		return errUnknownStream
+	}
+
+	partial := c.partial[class]
+	if int64(len(partial)+length) > c.maxMessageSize {
+		return errMessageTooLarge
+	}
+	start := len(partial)
+	partial = append(partial, make([]byte, length)...)
+	if _, err := io.ReadFull(c.Conn, partial[start:]); err != nil {
+		return err
+	}
+	if flags&endOfMessage == 0 {
+		c.partial[class] = partial
+		return nil
+	}
+
+	c.partial[class] = nil
+	msgLen := [wrappers.IntLen]byte{}
+	binary.BigEndian.PutUint32(msgLen[:], uint32(len(partial)))
+	c.ready = append(c.ready, msgLen[:]...)
+	c.ready = append(c.ready, partial...)
+	return nil
+}
@@ -0,0 +1,148 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/binary"
+	"io"
+	"net"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+// notifyConn closes [written] the first time it is written to
+type notifyConn struct {
+	net.Conn
+	once    sync.Once
+	written chan struct{}
+}
+
+func (c *notifyConn) Write(b []byte) (int, error) {
+	c.once.Do(func() { close(c.written) })
+	return c.Conn.Write(b)
+}
+
+// readMessage reads a length prefixed message from [r]
+func readMessage(t *testing.T, r io.Reader) []byte {
+	msgLen := [wrappers.IntLen]byte{}
+	_, err := io.ReadFull(r, msgLen[:])
+	assert.NoError(t, err)
+	msg := make([]byte, binary.BigEndian.Uint32(msgLen[:]))
+	_, err = io.ReadFull(r, msg)
+	assert.NoError(t, err)
+	return msg
+}
+
+func TestMuxInterleavesStreams(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	notify := &notifyConn{
+		Conn:    clientConn,
+		written: make(chan struct{}),
+	}
+	client := newMuxConn(notify, int64(DefaultMaxMessageSize))
+	server := newMuxConn(serverConn, int64(DefaultMaxMessageSize))
+	defer client.Close()
+	defer server.Close()
+
+	largeMsg := bytes.Repeat([]byte{1}, 64*maxFrameSize)
+	smallMsg := []byte{2}
+
+	errs := make(chan error, 2)
+	go func() { errs <- client.WriteMessage(BootstrapClass, largeMsg) }()
+
+	// The first frame of the large message can't be written until the server
+	// reads it, so the small message is queued while the large one is pending
+	<-notify.written
+	go func() { errs <- client.WriteMessage(ConsensusClass, smallMsg) }()
+	for len(client.writes[ConsensusClass]) == 0 {
+		runtime.Gosched()
+	}
+
+	assert.Equal(t, smallMsg, readMessage(t, server), "the small message shouldn't wait for the large one")
+	assert.Equal(t, largeMsg, readMessage(t, server))
+	assert.NoError(t, <-errs)
+	assert.NoError(t, <-errs)
+}
+
+func TestMuxMessageTooLarge(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	client := newMuxConn(clientConn, int64(DefaultMaxMessageSize))
+	server := newMuxConn(serverConn, maxFrameSize)
+	defer client.Close()
+	defer server.Close()
+
+	go func() { _ = client.WriteMessage(BootstrapClass, make([]byte, 2*maxFrameSize)) }()
+
+	_, err := server.Read(make([]byte, 1))
+	assert.Equal(t, errMessageTooLarge, err)
+}
+
+func TestMuxWriteAfterClose(t *testing.T) {
+	clientConn, _ := net.Pipe()
+	client := newMuxConn(clientConn, int64(DefaultMaxMessageSize))
+	assert.NoError(t, client.Close())
+
+	err := client.WriteMessage(ConsensusClass, []byte{1})
+	assert.Error(t, err)
+}
+
+func TestMuxUpgraderNegotiatesProtocol(t *testing.T) {
+	key, cert := newTestStakingKey(t)
+	newConfig := func(protocols ...string) *tls.Config {
+		// #nosec G402
+		return &tls.Config{
+			Certificates: []tls.Certificate{{
+				Certificate: [][]byte{cert.Raw},
+				PrivateKey:  key,
+			}},
+			ClientAuth:         tls.RequireAnyClientCert,
+			InsecureSkipVerify: true,
+			NextProtos:         protocols,
+		}
+	}
+
+	tests := []struct {
+		name            string
+		clientProtocols []string
+		multiplexed     bool
+	}{
+		{
+			name:            "both multiplex",
+			clientProtocols: []string{MuxProtocol},
+			multiplexed:     true,
+		},
+		{
+			name:        "client doesn't multiplex",
+			multiplexed: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			serverUpgrader := NewMuxUpgrader(NewTLSServerUpgrader(newConfig(MuxProtocol)), DefaultMaxMessageSize)
+			clientUpgrader := NewTLSClientUpgrader(newConfig(test.clientProtocols...))
+
+			clientConn, serverConn := net.Pipe()
+			defer clientConn.Close()
+			defer serverConn.Close()
+
+			go func() {
+				_, _, _, _ = clientUpgrader.Upgrade(clientConn)
+			}()
+
+			_, upgradedConn, peerCert, err := serverUpgrader.Upgrade(serverConn)
+			assert.NoError(t, err)
+			assert.Equal(t, cert.Raw, peerCert.Raw)
+
+			_, multiplexed := upgradedConn.(MultiplexedConn)
+			assert.Equal(t, test.multiplexed, multiplexed)
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package network
 
 import (
+	"crypto"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -15,6 +17,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/liraxapp/avalanchego/api/health"
+	"github.com/liraxapp/avalanchego/database"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/snow"
 	"github.com/liraxapp/avalanchego/snow/networking/router"
@@ -22,8 +25,10 @@ import (
 	"github.com/liraxapp/avalanchego/snow/triggers"
 	"github.com/liraxapp/avalanchego/snow/validators"
 	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/compression"
 	"github.com/liraxapp/avalanchego/utils/constants"
 	"github.com/liraxapp/avalanchego/utils/formatting"
+	"github.com/liraxapp/avalanchego/utils/json"
 	"github.com/liraxapp/avalanchego/utils/logging"
 	"github.com/liraxapp/avalanchego/utils/sampler"
 	"github.com/liraxapp/avalanchego/utils/timer"
@@ -52,11 +57,26 @@ const (
 	defaultConnMeterCacheSize                        = 10000
 )
 
+const (
+	// halflife of the weighted average of a peer's round trip times
+	latencyHalflife = 5 * time.Minute
+	// maximum number of consensus messages sent to a peer in a row while bulk
+	// messages are waiting
+	maxConsecutiveMsgs = 8
+	// peers that haven't been connected to for this long are forgotten
+	knownPeerMaxAge = 14 * 24 * time.Hour
+)
+
 var (
 	errNetworkClosed = errors.New("network closed")
 	errPeerIsMyself  = errors.New("peer is myself")
+	errDeniedIP      = errors.New("peer's IP isn't allowed")
+	errDeniedNodeID  = errors.New("peer's node ID isn't allowed")
 
 	minimumUnmaskedVersion = version.NewDefaultVersion(constants.PlatformName, 1, 1, 0)
+	// Older nodes reject messages with fields they don't know about, so they are
+	// only sent the fields every node knows about
+	minimumOptionalFieldsVersion = version.NewDefaultVersion(constants.PlatformName, 1, 2, 0)
 )
 
 func init() { rand.Seed(time.Now().UnixNano()) }
@@ -95,6 +115,11 @@ type Network interface {
 
 	// Return the IP of the node
 	IP() utils.IPDesc
+
+	// Replace the lists of peers this node may be connected to. Connections to
+	// peers that are no longer allowed are closed. Thread safety must be
+	// managed internally to the network.
+	SetAccessList(config AccessListConfig) error
 }
 
 type network struct {
@@ -177,6 +202,44 @@ type network struct {
 
 	hasMasked        bool
 	maskedValidators ids.ShortSet
+
+	// compression types this node accepts, in order of preference. If empty,
+	// peers never send this node compressed messages.
+	compressionTypes []compression.Type
+	// compressors for every type this node accepts. Always includes
+	// compression.None.
+	compressors map[compression.Type]compression.Compressor
+
+	// message formats, other than the legacy format, that this node accepts.
+	// Peers that accept one of them are sent messages in it.
+	formats []Format
+
+	// staking key this node signs its IP with. If nil, TLS is disabled, so IP
+	// announcements are neither signed nor verified.
+	ipSigner crypto.Signer
+	// this node's IP, signed with [ipSigner]. Only re-signed when the IP
+	// changes.
+	mySignedIP     *SignedIP
+	mySignedIPLock sync.Mutex
+	// newest verified IP announced by each validator. Protected by the
+	// stateLock.
+	latestIPs map[[20]byte]SignedIP
+
+	// bandwidth each peer may use
+	bandwidthConfig BandwidthConfig
+
+	// if true, this node only connects to the node IDs on its access list and
+	// never announces its IP
+	privateNetwork bool
+	// peers this node may be connected to
+	accessList *accessList
+
+	// peers this node has been connected to, persisted across restarts
+	peerTable *peerTable
+
+	// captures the messages exchanged with peers. If nil, messages aren't
+	// captured.
+	recorder *recorder
 }
 
 // NewDefaultNetwork returns a new Network implementation with the provided
@@ -203,6 +266,13 @@ func NewDefaultNetwork(
 	disconnectedCheckFreq time.Duration,
 	disconnectedRestartTimeout time.Duration,
 	apricotPhase0Time time.Time,
+	compressionTypes []compression.Type,
+	ipSigner crypto.Signer,
+	bandwidthConfig BandwidthConfig,
+	privateNetwork bool,
+	peerDB database.Database,
+	recorderConfig RecorderConfig,
+	maxMessageSize uint32,
 ) Network {
 	return NewNetwork(
 		registerer,
@@ -221,7 +291,7 @@ func NewDefaultNetwork(
 		router,
 		defaultInitialReconnectDelay,
 		defaultMaxReconnectDelay,
-		DefaultMaxMessageSize,
+		maxMessageSize,
 		defaultSendQueueSize,
 		defaultMaxNetworkPendingSendBytes,
 		defaultNetworkPendingSendBytesToRateLimit,
@@ -244,10 +314,21 @@ func NewDefaultNetwork(
 		disconnectedCheckFreq,
 		disconnectedRestartTimeout,
 		apricotPhase0Time,
+		compressionTypes,
+		ipSigner,
+		bandwidthConfig,
+		privateNetwork,
+		peerDB,
+		recorderConfig,
 	)
 }
 
 // NewNetwork returns a new Network implementation with the provided parameters.
+// [ipSigner] is the staking key of this node, which must be the key of the
+// certificate used by the upgraders. It should be nil if TLS is disabled.
+// [peerDB] stores the peers this node has been connected to. If nil, they
+// aren't persisted. [recorderConfig] configures the capture of the messages
+// exchanged with peers.
 func NewNetwork(
 	registerer prometheus.Registerer,
 	log logging.Logger,
@@ -288,6 +369,12 @@ func NewNetwork(
 	disconnectedCheckFreq time.Duration,
 	disconnectedRestartTimeout time.Duration,
 	apricotPhase0Time time.Time,
+	compressionTypes []compression.Type,
+	ipSigner crypto.Signer,
+	bandwidthConfig BandwidthConfig,
+	privateNetwork bool,
+	peerDB database.Database,
+	recorderConfig RecorderConfig,
 ) Network {
 	// #nosec G404
 	netw := &network{
@@ -338,11 +425,47 @@ func NewNetwork(
 		connectedMeter:                     timer.TimedMeter{Duration: disconnectedRestartTimeout},
 		restarter:                          restarter,
 		apricotPhase0Time:                  apricotPhase0Time,
+		compressors:                        make(map[compression.Type]compression.Compressor, len(compressionTypes)+1),
+		formats:                            []Format{TLVFormat},
+		ipSigner:                           ipSigner,
+		latestIPs:                          make(map[[20]byte]SignedIP),
+		bandwidthConfig:                    bandwidthConfig,
+		privateNetwork:                     privateNetwork,
+		accessList:                         newAccessList(privateNetwork),
+	}
+
+	// None is always accepted, so that a message that doesn't get smaller can
+	// be sent uncompressed
+	netw.compressors[compression.None] = compression.NewNoCompressor()
+	for _, compressionType := range compressionTypes {
+		if _, ok := netw.compressors[compressionType]; ok {
+			continue
+		}
+		compressor, err := compression.New(compressionType, netw.maxMessageSize)
+		if err != nil {
+			log.Warn("not accepting compression type %s due to: %s", compressionType, err)
+			continue
+		}
+		netw.compressionTypes = append(netw.compressionTypes, compressionType)
+		netw.compressors[compressionType] = compressor
 	}
 
 	if err := netw.initialize(registerer); err != nil {
 		log.Warn("initializing network metrics failed with: %s", err)
 	}
+
+	minLastSeen := netw.clock.Time().Add(-knownPeerMaxAge).Unix()
+	peerTable, err := newPeerTable(peerDB, uint64(minLastSeen))
+	if err != nil {
+		log.Warn("loading known peers failed with: %s", err)
+	}
+	netw.peerTable = peerTable
+
+	recorder, err := newRecorder(recorderConfig, &netw.clock)
+	if err != nil {
+		log.Error("not capturing messages due to: %s", err)
+	}
+	netw.recorder = recorder
 	netw.executor.Initialize()
 	go netw.executor.Dispatch()
 	netw.heartbeat()
@@ -645,6 +768,194 @@ func (n *network) Chits(validatorID ids.ShortID, chainID ids.ID, requestID uint3
 	}
 }
 
+// AppRequest implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) AppRequest(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time, appRequestBytes []byte) {
+	msg, err := n.b.AppRequest(chainID, requestID, uint64(deadline.Sub(n.clock.Time())), appRequestBytes)
+	if err != nil {
+		n.log.Error("failed to build AppRequest(%s, %d): %s",
+			chainID,
+			requestID,
+			err)
+		n.log.Verbo("request:\n%s", formatting.DumpBytes{Bytes: appRequestBytes})
+		for validatorIDKey := range validatorIDs {
+			validatorID := ids.NewShortID(validatorIDKey)
+			n.executor.Add(func() {
+				n.router.AppRequestFailed(validatorID, chainID, requestID)
+			})
+		}
+		return
+	}
+
+	for _, peerElement := range n.getPeers(validatorIDs) {
+		peer := peerElement.peer
+		vID := peerElement.id
+		if peer == nil || !peer.connected.GetValue() || !peer.Send(msg) {
+			n.log.Debug("failed to send AppRequest(%s, %s, %d)",
+				vID,
+				chainID,
+				requestID)
+			n.executor.Add(func() { n.router.AppRequestFailed(vID, chainID, requestID) })
+			n.appRequest.numFailed.Inc()
+		} else {
+			n.appRequest.numSent.Inc()
+		}
+	}
+}
+
+// AppResponse implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) AppResponse(validatorID ids.ShortID, chainID ids.ID, requestID uint32, appResponseBytes []byte) {
+	msg, err := n.b.AppResponse(chainID, requestID, appResponseBytes)
+	if err != nil {
+		n.log.Error("failed to build AppResponse(%s, %d): %s",
+			chainID,
+			requestID,
+			err)
+		n.log.Verbo("response:\n%s", formatting.DumpBytes{Bytes: appResponseBytes})
+		return
+	}
+
+	peer := n.getPeer(validatorID)
+	if peer == nil || !peer.connected.GetValue() || !peer.Send(msg) {
+		n.log.Debug("failed to send AppResponse(%s, %s, %d)",
+			validatorID,
+			chainID,
+			requestID)
+		n.appResponse.numFailed.Inc()
+	} else {
+		n.appResponse.numSent.Inc()
+	}
+}
+
+// GetStateSummary implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) GetStateSummary(validatorIDs ids.ShortSet, chainID ids.ID, requestID uint32, deadline time.Time) {
+	msg, err := n.b.GetStateSummary(chainID, requestID, uint64(deadline.Sub(n.clock.Time())))
+	n.log.AssertNoError(err)
+
+	for _, peerElement := range n.getPeers(validatorIDs) {
+		peer := peerElement.peer
+		vID := peerElement.id
+		if peer == nil || !peer.connected.GetValue() || !peer.Send(msg) {
+			n.log.Debug("failed to send GetStateSummary(%s, %s, %d)",
+				vID,
+				chainID,
+				requestID)
+			n.executor.Add(func() { n.router.GetStateSummaryFailed(vID, chainID, requestID) })
+			n.getStateSummary.numFailed.Inc()
+		} else {
+			n.getStateSummary.numSent.Inc()
+		}
+	}
+}
+
+// StateSummary implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) StateSummary(validatorID ids.ShortID, chainID ids.ID, requestID uint32, summary []byte) {
+	msg, err := n.b.StateSummary(chainID, requestID, summary)
+	if err != nil {
+		n.log.Error("failed to build StateSummary(%s, %d): %s",
+			chainID,
+			requestID,
+			err)
+		return
+	}
+
+	peer := n.getPeer(validatorID)
+	if peer == nil || !peer.connected.GetValue() || !peer.Send(msg) {
+		n.log.Debug("failed to send StateSummary(%s, %s, %d)",
+			validatorID,
+			chainID,
+			requestID)
+		n.stateSummary.numFailed.Inc()
+	} else {
+		n.stateSummary.numSent.Inc()
+	}
+}
+
+// GetStateChunk implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) GetStateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, summaryID ids.ID, index uint32) {
+	msg, err := n.b.GetStateChunk(chainID, requestID, uint64(deadline.Sub(n.clock.Time())), summaryID, index)
+	if err != nil {
+		n.log.Error("failed to build GetStateChunk message: %s", err)
+		return
+	}
+
+	peer := n.getPeer(validatorID)
+	if peer == nil || !peer.connected.GetValue() || !peer.Send(msg) {
+		n.log.Debug("failed to send GetStateChunk(%s, %s, %d, %s, %d)",
+			validatorID,
+			chainID,
+			requestID,
+			summaryID,
+			index)
+		n.executor.Add(func() { n.router.GetStateChunkFailed(validatorID, chainID, requestID) })
+		n.getStateChunk.numFailed.Inc()
+	} else {
+		n.getStateChunk.numSent.Inc()
+	}
+}
+
+// StateChunk implements the Sender interface.
+// assumes the stateLock is not held.
+func (n *network) StateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, chunk []byte) {
+	msg, err := n.b.StateChunk(chainID, requestID, chunk)
+	if err != nil {
+		n.log.Error("failed to build StateChunk message of size %d: %s", len(chunk), err)
+		return
+	}
+
+	peer := n.getPeer(validatorID)
+	if peer == nil || !peer.connected.GetValue() || !peer.Send(msg) {
+		n.log.Debug("failed to send StateChunk(%s, %s, %d, %d)",
+			validatorID,
+			chainID,
+			requestID,
+			len(chunk))
+		n.stateChunk.numFailed.Inc()
+	} else {
+		n.stateChunk.numSent.Inc()
+	}
+}
+
+// AppGossip attempts to gossip the application message to the network
+// assumes the stateLock is not held.
+func (n *network) AppGossip(chainID ids.ID, appGossipBytes []byte) {
+	msg, err := n.b.AppGossip(chainID, appGossipBytes)
+	if err != nil {
+		n.log.Error("failed to build AppGossip(%s): %s", chainID, err)
+		n.log.Verbo("message:\n%s", formatting.DumpBytes{Bytes: appGossipBytes})
+		return
+	}
+
+	allPeers := n.getAllPeers()
+
+	numToGossip := n.gossipSize
+	if numToGossip > len(allPeers) {
+		numToGossip = len(allPeers)
+	}
+
+	s := sampler.NewUniform()
+	if err := s.Initialize(uint64(len(allPeers))); err != nil {
+		n.log.Debug("failed to AppGossip(%s): %s", chainID, err)
+		return
+	}
+	indices, err := s.Sample(numToGossip)
+	if err != nil {
+		n.log.Debug("failed to AppGossip(%s): %s", chainID, err)
+		return
+	}
+	for _, index := range indices {
+		if allPeers[int(index)].Send(msg) {
+			n.appGossip.numSent.Inc()
+		} else {
+			n.appGossip.numFailed.Inc()
+		}
+	}
+}
+
 // Gossip attempts to gossip the container to the network
 // assumes the stateLock is not held.
 func (n *network) Gossip(chainID, containerID ids.ID, container []byte) {
@@ -674,6 +985,7 @@ func (n *network) GetHeartbeat() int64 { return atomic.LoadInt64(&n.lastHeartbea
 // to this node.
 // assumes the stateLock is not held.
 func (n *network) Dispatch() error {
+	n.trackKnownPeers()
 	go n.gossip() // Periodically gossip peers
 	go func() {
 		duration := time.Until(n.apricotPhase0Time)
@@ -753,13 +1065,19 @@ func (n *network) Peers() []PeerID {
 	peers := make([]PeerID, 0, len(n.peers))
 	for _, peer := range n.peers {
 		if peer.connected.GetValue() {
+			lastRTT, latency, pingsLost := peer.pingStats()
 			peers = append(peers, PeerID{
-				IP:           peer.conn.RemoteAddr().String(),
-				PublicIP:     peer.getIP().String(),
-				ID:           peer.id.PrefixedString(constants.NodeIDPrefix),
-				Version:      peer.versionStr.GetValue().(string),
-				LastSent:     time.Unix(atomic.LoadInt64(&peer.lastSent), 0),
-				LastReceived: time.Unix(atomic.LoadInt64(&peer.lastReceived), 0),
+				IP:              peer.conn.RemoteAddr().String(),
+				PublicIP:        peer.getIP().String(),
+				ID:              peer.id.PrefixedString(constants.NodeIDPrefix),
+				Version:         peer.versionStr.GetValue().(string),
+				LastSent:        time.Unix(atomic.LoadInt64(&peer.lastSent), 0),
+				LastReceived:    time.Unix(atomic.LoadInt64(&peer.lastReceived), 0),
+				LastRTT:         json.Uint64(lastRTT),
+				Latency:         json.Uint64(latency),
+				MessagesSent:    json.Uint64(atomic.LoadUint64(&peer.numSent)),
+				MessagesDropped: json.Uint64(atomic.LoadUint64(&peer.numDropped)),
+				PingsLost:       json.Uint64(pingsLost),
 			})
 		}
 	}
@@ -781,6 +1099,9 @@ func (n *network) close() {
 	if err := n.listener.Close(); err != nil {
 		n.log.Debug("closing network listener failed with: %s", err)
 	}
+	if err := n.recorder.Close(); err != nil {
+		n.log.Debug("closing message capture failed with: %s", err)
+	}
 
 	if n.closed.GetValue() {
 		return
@@ -820,6 +1141,36 @@ func (n *network) IP() utils.IPDesc {
 	return n.ip.IP()
 }
 
+// SetAccessList implements the Network interface
+// assumes the stateLock is not held.
+func (n *network) SetAccessList(config AccessListConfig) error {
+	if err := n.accessList.Set(config); err != nil {
+		return err
+	}
+
+	n.stateLock.RLock()
+	deniedPeers := []*peer(nil)
+	for _, peer := range n.peers {
+		if !n.accessList.AllowsNodeID(peer.id) || !n.allowsConn(peer.conn) {
+			deniedPeers = append(deniedPeers, peer)
+		}
+	}
+	n.stateLock.RUnlock()
+
+	for _, peer := range deniedPeers {
+		n.log.Info("disconnecting from %s as it is no longer allowed", peer.id.PrefixedString(constants.NodeIDPrefix))
+		peer.discardIP() // Grabs the stateLock
+	}
+	return nil
+}
+
+// allowsConn returns true if the IP at the other end of [conn] may be connected
+// to.
+func (n *network) allowsConn(conn net.Conn) bool {
+	ip, err := utils.ToIPDesc(conn.RemoteAddr().String())
+	return err != nil || n.accessList.AllowsIP(ip.IP)
+}
+
 // assumes the stateLock is not held.
 func (n *network) gossipContainer(chainID, containerID ids.ID, container []byte) error {
 	msg, err := n.b.Put(chainID, constants.GossipMsgRequestID, containerID, container)
@@ -858,6 +1209,10 @@ func (n *network) track(ip utils.IPDesc) {
 		return
 	}
 
+	if !n.accessList.AllowsIP(ip.IP) {
+		return
+	}
+
 	str := ip.String()
 	if _, ok := n.disconnectedIPs[str]; ok {
 		return
@@ -873,6 +1228,29 @@ func (n *network) track(ip utils.IPDesc) {
 	go n.connectTo(ip)
 }
 
+// assumes the stateLock is not held. Starts connecting to the peers this node
+// was connected to before it restarted. Peers that repeatedly couldn't be
+// reached are retried after a longer delay.
+func (n *network) trackKnownPeers() {
+	knownPeers := n.peerTable.Known()
+
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	for _, p := range knownPeers {
+		if p.ID.Equals(n.id) || !n.accessList.AllowsNodeID(p.ID) {
+			continue
+		}
+		str := p.IP.String()
+		_, isTracked := n.disconnectedIPs[str]
+		n.track(p.IP)
+		if _, ok := n.disconnectedIPs[str]; ok && !isTracked {
+			n.retryDelay[str] = p.backoff(n.initialReconnectDelay, n.maxReconnectDelay)
+		}
+	}
+	n.log.Info("attempting to connect to %d known peers", len(knownPeers))
+}
+
 // assumes the stateLock is not held. Only returns after the network is closed.
 func (n *network) gossip() {
 	t := time.NewTicker(n.peerListGossipSpacing)
@@ -888,30 +1266,11 @@ func (n *network) gossip() {
 			continue
 		}
 
-		ips := make([]utils.IPDesc, 0, len(allPeers))
-		for _, peer := range allPeers {
-			ip := peer.getIP()
-			if peer.connected.GetValue() &&
-				!ip.IsZero() &&
-				n.vdrs.Contains(peer.id) {
-				peerVersion := peer.versionStruct.GetValue().(version.Version)
-				if !peerVersion.Before(minimumUnmaskedVersion) || time.Since(n.apricotPhase0Time) < 0 {
-					ips = append(ips, ip)
-				}
-			}
-		}
-
+		ips := n.validatorIPs()
 		if len(ips) == 0 {
 			n.log.Debug("skipping validator gossiping as no public validators are connected")
 			continue
 		}
-		msg, err := n.b.PeerList(ips)
-		if err != nil {
-			n.log.Error("failed to build peer list to gossip: %s. len(ips): %d",
-				err,
-				len(ips))
-			continue
-		}
 
 		stakers := make([]*peer, 0, len(allPeers))
 		nonStakers := make([]*peer, 0, len(allPeers))
@@ -947,7 +1306,7 @@ func (n *network) gossip() {
 			continue
 		}
 		for _, index := range stakerIndices {
-			stakers[int(index)].Send(msg)
+			stakers[int(index)].PeerList(ips)
 		}
 
 		if err := s.Initialize(uint64(len(nonStakers))); err != nil {
@@ -964,7 +1323,7 @@ func (n *network) gossip() {
 			continue
 		}
 		for _, index := range nonStakerIndices {
-			nonStakers[int(index)].Send(msg)
+			nonStakers[int(index)].PeerList(ips)
 		}
 	}
 }
@@ -999,6 +1358,14 @@ func (n *network) connectTo(ip utils.IPDesc) {
 		_, isMyself := n.myIPs[str]
 		closed := n.closed
 
+		if !n.accessList.AllowsIP(ip.IP) {
+			// If the IP is no longer allowed, we should stop attempting to
+			// connect to it
+			delete(n.disconnectedIPs, str)
+			delete(n.retryDelay, str)
+			isDisconnected = false
+		}
+
 		if !isDisconnected || isConnected || isMyself || closed.GetValue() {
 			// If the IP was discovered by the peer connecting to us, we don't
 			// need to attempt to connect anymore
@@ -1019,6 +1386,9 @@ func (n *network) connectTo(ip utils.IPDesc) {
 		if err == nil {
 			return
 		}
+		if err := n.peerTable.Failed(ip); err != nil {
+			n.log.Warn("failed to record connection failure to %s: %s", ip, err)
+		}
 		n.log.Verbo("error attempting to connect to %s: %s. Reattempting in %s",
 			ip, err, delay)
 	}
@@ -1052,13 +1422,19 @@ func (n *network) attemptConnect(ip utils.IPDesc) error {
 // assumes the stateLock is not held. Returns an error if the peer's connection
 // wasn't able to be upgraded.
 func (n *network) upgrade(p *peer, upgrader Upgrader) error {
+	if !n.allowsConn(p.conn) {
+		_ = p.conn.Close()
+		n.log.Verbo("dropping connection from denied IP %s", p.conn.RemoteAddr())
+		return errDeniedIP
+	}
+
 	if err := p.conn.SetReadDeadline(time.Now().Add(n.readHandshakeTimeout)); err != nil {
 		_ = p.conn.Close()
 		n.log.Verbo("failed to set the read deadline with %s", err)
 		return err
 	}
 
-	id, conn, err := upgrader.Upgrade(p.conn)
+	id, conn, cert, err := upgrader.Upgrade(p.conn)
 	if err != nil {
 		_ = p.conn.Close()
 		n.log.Verbo("failed to upgrade connection with %s", err)
@@ -1071,9 +1447,19 @@ func (n *network) upgrade(p *peer, upgrader Upgrader) error {
 		return err
 	}
 
-	p.sender = make(chan []byte, n.sendQueueSize)
+	if _, ok := conn.(MultiplexedConn); ok {
+		for i := range p.classSenders {
+			p.classSenders[i] = make(chan []byte, n.sendQueueSize)
+		}
+	} else {
+		p.sender = make(chan []byte, n.sendQueueSize)
+		p.bulkSender = make(chan []byte, n.sendQueueSize)
+	}
+	p.inboundThrottler = newBandwidthThrottler(n.bandwidthConfig.PeerInboundBytesPerSec, &n.clock)
+	p.outboundThrottler = newBandwidthThrottler(n.bandwidthConfig.PeerOutboundBytesPerSec, &n.clock)
 	p.id = id
 	p.conn = conn
+	p.cert = cert
 
 	if err := n.tryAddPeer(p); err != nil {
 		_ = p.conn.Close()
@@ -1116,6 +1502,17 @@ func (n *network) tryAddPeer(p *peer) error {
 		return errPeerIsMyself
 	}
 
+	// if this peer isn't allowed, then I should delete the connection and stop
+	// attempting to connect to its IP.
+	if !n.accessList.AllowsNodeID(p.id) {
+		if !ip.IsZero() {
+			str := ip.String()
+			delete(n.disconnectedIPs, str)
+			delete(n.retryDelay, str)
+		}
+		return fmt.Errorf("%w: %s", errDeniedNodeID, p.id.PrefixedString(constants.NodeIDPrefix))
+	}
+
 	// If I am already connected to this peer, then I should close this new
 	// connection.
 	if _, ok := n.peers[key]; ok {
@@ -1133,16 +1530,51 @@ func (n *network) tryAddPeer(p *peer) error {
 	return nil
 }
 
-// assumes the stateLock is not held. Returns the ips of connections that have
-// valid IPs that are marked as validators.
-func (n *network) validatorIPs() []utils.IPDesc {
+// compressor returns the compressor to use when sending messages to a peer that
+// accepts [peerCompressionTypes]. The first of this node's preferred types that
+// the peer accepts is chosen. If there is none, messages are sent uncompressed.
+func (n *network) compressor(peerCompressionTypes []byte) compression.Compressor {
+	for _, compressionType := range n.compressionTypes {
+		for _, peerCompressionType := range peerCompressionTypes {
+			if compressionType == compression.Type(peerCompressionType) {
+				return n.compressors[compressionType]
+			}
+		}
+	}
+	return n.compressors[compression.None]
+}
+
+// acceptsFormat returns true if both this node and the peer, which announced
+// [peerFormats], accept [format]
+func (n *network) acceptsFormat(peerFormats []byte, format Format) bool {
+	for _, myFormat := range n.formats {
+		if myFormat != format {
+			continue
+		}
+		for _, peerFormat := range peerFormats {
+			if Format(peerFormat) == format {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// assumes the stateLock is not held. Returns the signed ips of connections that
+// have valid IPs that are marked as validators.
+func (n *network) validatorIPs() []SignedIP {
+	if n.privateNetwork {
+		// IPs are never gossiped in a private network
+		return nil
+	}
+
 	n.stateLock.RLock()
 	defer n.stateLock.RUnlock()
 
-	ips := make([]utils.IPDesc, 0, len(n.peers))
+	ips := make([]SignedIP, 0, len(n.peers))
 	for _, peer := range n.peers {
-		ip := peer.getIP()
-		if peer.connected.GetValue() && !ip.IsZero() && n.vdrs.Contains(peer.id) {
+		ip, ok := peer.signedIP.GetValue().(SignedIP)
+		if ok && peer.connected.GetValue() && !ip.IP.IsZero() && n.vdrs.Contains(peer.id) {
 			peerVersion := peer.versionStruct.GetValue().(version.Version)
 			if !peerVersion.Before(minimumUnmaskedVersion) || time.Since(n.apricotPhase0Time) < 0 {
 				ips = append(ips, ip)
@@ -1152,10 +1584,156 @@ func (n *network) validatorIPs() []utils.IPDesc {
 	return ips
 }
 
+// assumes the stateLock is not held. Returns this node's IP, signed with its
+// staking key. In a private network, the IP is never announced, so the
+// returned IP is empty.
+func (n *network) signedIP() (SignedIP, error) {
+	ip := n.ip.IP()
+	if n.privateNetwork {
+		ip = utils.IPDesc{}
+	}
+
+	n.mySignedIPLock.Lock()
+	defer n.mySignedIPLock.Unlock()
+
+	if n.mySignedIP != nil && n.mySignedIP.IP.Equal(ip) {
+		return *n.mySignedIP, nil
+	}
+
+	signedIP := SignedIP{
+		NodeID: n.id,
+		IP:     ip,
+		Time:   n.clock.Unix(),
+	}
+	if n.mySignedIP != nil && signedIP.Time <= n.mySignedIP.Time {
+		// Make sure peers consider the new IP to be newer than the old one
+		signedIP.Time = n.mySignedIP.Time + 1
+	}
+	if n.ipSigner != nil {
+		signature, err := signIP(n.ipSigner, signedIP.IP, signedIP.Time)
+		if err != nil {
+			return SignedIP{}, err
+		}
+		signedIP.Signature = signature
+	}
+	n.mySignedIP = &signedIP
+	return signedIP, nil
+}
+
+// assumes the stateLock is not held. Starts tracking the IP announced in
+// [signedIP] if the announcement is signed by a validator and is newer than any
+// other announcement of that validator. If TLS is disabled, announcements can't
+// be verified, so the IP is tracked as is. If the announcement doesn't carry
+// the certificate of the validator, the certificate must already be known from
+// an earlier announcement or from a connection to the validator.
+func (n *network) trackSignedIP(signedIP SignedIP) {
+	ip := signedIP.IP
+	if ip.IsZero() || (!n.allowPrivateIPs && ip.IsPrivate()) {
+		return
+	}
+
+	if n.ipSigner == nil {
+		n.stateLock.Lock()
+		defer n.stateLock.Unlock()
+
+		if !ip.Equal(n.ip.IP()) {
+			n.track(ip)
+		}
+		return
+	}
+
+	nodeID := signedIP.NodeID
+	if nodeID.IsZero() || nodeID.Equals(n.id) || !n.vdrs.Contains(nodeID) || !n.accessList.AllowsNodeID(nodeID) {
+		return
+	}
+
+	// Drop stale announcements before performing any expensive verification
+	n.stateLock.RLock()
+	latestIP, ok := n.latestIPs[nodeID.Key()]
+	var peerCert *x509.Certificate
+	if p, connected := n.peers[nodeID.Key()]; connected {
+		peerCert = p.cert
+	}
+	n.stateLock.RUnlock()
+	if ok && signedIP.Time <= latestIP.Time {
+		return
+	}
+
+	if maxTime := n.clock.Time().Add(n.maxClockDifference).Unix(); signedIP.Time > uint64(maxTime) {
+		n.log.Debug("dropping IP %s announced by %s with a timestamp in the future", ip, nodeID)
+		return
+	}
+
+	switch {
+	case len(signedIP.Cert) > 0:
+		if !certToID(signedIP.Cert).Equals(nodeID) {
+			n.log.Debug("dropping IP %s announced by %s with the certificate of another node", ip, nodeID)
+			return
+		}
+	case ok:
+		signedIP.Cert = latestIP.Cert
+	case peerCert != nil:
+		signedIP.Cert = peerCert.Raw
+	default:
+		n.log.Debug("dropping IP %s announced by %s with an unknown certificate", ip, nodeID)
+		return
+	}
+	cert, err := x509.ParseCertificate(signedIP.Cert)
+	if err != nil {
+		n.log.Debug("dropping IP %s announced with an invalid certificate: %s", ip, err)
+		return
+	}
+	if err := signedIP.Verify(cert); err != nil {
+		n.log.Debug("dropping IP %s announced by %s with an invalid signature: %s", ip, nodeID, err)
+		return
+	}
+
+	n.stateLock.Lock()
+	defer n.stateLock.Unlock()
+
+	if !n.updateLatestIP(nodeID, signedIP) {
+		return
+	}
+	if _, ok := n.peers[nodeID.Key()]; ok {
+		// We are already connected to this node. The new IP will be used if we
+		// disconnect.
+		return
+	}
+	if !ip.Equal(n.ip.IP()) {
+		n.track(ip)
+	}
+}
+
+// assumes the stateLock is held. Records [signedIP] as the IP of [nodeID] if it
+// is newer than the IP currently recorded. Returns true if it was recorded.
+func (n *network) updateLatestIP(nodeID ids.ShortID, signedIP SignedIP) bool {
+	key := nodeID.Key()
+	latestIP, ok := n.latestIPs[key]
+	if ok {
+		if signedIP.Time <= latestIP.Time {
+			return false
+		}
+		if !latestIP.IP.Equal(signedIP.IP) {
+			// The node moved, so stop attempting to connect to its old IP
+			str := latestIP.IP.String()
+			delete(n.disconnectedIPs, str)
+			delete(n.retryDelay, str)
+		}
+	}
+	n.latestIPs[key] = signedIP
+	return true
+}
+
 // should only be called after the peer is marked as connected. Should not be
 // called after disconnected is called with this peer.
 // assumes the stateLock is not held.
 func (n *network) connected(p *peer) {
+	if ip := p.getIP(); !ip.IsZero() {
+		if err := n.peerTable.Connected(p.id, ip, n.clock.Unix()); err != nil {
+			n.log.Warn("failed to record connection to %s: %s", p.id, err)
+		}
+	}
+
 	p.net.stateLock.Lock()
 	defer p.net.stateLock.Unlock()
 
@@ -1199,6 +1777,12 @@ func (n *network) connected(p *peer) {
 // should only be called after the peer is marked as connected.
 // assumes the stateLock is not held.
 func (n *network) disconnected(p *peer) {
+	if p.connected.GetValue() {
+		if err := n.peerTable.Disconnected(p.id, n.clock.Unix()); err != nil {
+			n.log.Warn("failed to record disconnection from %s: %s", p.id, err)
+		}
+	}
+
 	p.net.stateLock.Lock()
 	defer p.net.stateLock.Unlock()
 
@@ -1216,6 +1800,10 @@ func (n *network) disconnected(p *peer) {
 		delete(n.disconnectedIPs, str)
 		delete(n.connectedIPs, str)
 
+		if latestIP, ok := n.latestIPs[key]; ok {
+			// Reconnect through the newest IP the peer announced
+			ip = latestIP.IP
+		}
 		n.track(ip)
 	}
 
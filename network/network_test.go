@@ -11,13 +11,17 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/liraxapp/avalanchego/database/memdb"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/snow/networking/router"
 	"github.com/liraxapp/avalanchego/snow/validators"
 	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/compression"
+	"github.com/liraxapp/avalanchego/utils/constants"
 	"github.com/liraxapp/avalanchego/utils/hashing"
 	"github.com/liraxapp/avalanchego/utils/logging"
 	"github.com/liraxapp/avalanchego/version"
@@ -144,6 +148,7 @@ type testHandler struct {
 	router.Router
 	connected    func(ids.ShortID)
 	disconnected func(ids.ShortID)
+	put          func(ids.ShortID, ids.ID, uint32, ids.ID, []byte)
 }
 
 func (h *testHandler) Connected(id ids.ShortID) {
@@ -156,6 +161,11 @@ func (h *testHandler) Disconnected(id ids.ShortID) {
 		h.disconnected(id)
 	}
 }
+func (h *testHandler) Put(validatorID ids.ShortID, chainID ids.ID, requestID uint32, containerID ids.ID, container []byte) {
+	if h.put != nil {
+		h.put(validatorID, chainID, requestID, containerID, container)
+	}
+}
 
 func TestNewDefaultNetwork(t *testing.T) {
 	log := logging.NoLog{}
@@ -211,6 +221,13 @@ func TestNewDefaultNetwork(t *testing.T) {
 		0,
 		0,
 		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
 	)
 	assert.NotNil(t, net)
 
@@ -324,6 +341,13 @@ func TestEstablishConnection(t *testing.T) {
 		0,
 		0,
 		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
 	)
 	assert.NotNil(t, net0)
 
@@ -349,6 +373,13 @@ func TestEstablishConnection(t *testing.T) {
 		0,
 		0,
 		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
 	)
 	assert.NotNil(t, net1)
 
@@ -474,6 +505,13 @@ func TestDoubleTrack(t *testing.T) {
 		0,
 		0,
 		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
 	)
 	assert.NotNil(t, net0)
 
@@ -499,6 +537,13 @@ func TestDoubleTrack(t *testing.T) {
 		0,
 		0,
 		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
 	)
 	assert.NotNil(t, net1)
 
@@ -625,6 +670,13 @@ func TestDoubleClose(t *testing.T) {
 		0,
 		0,
 		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
 	)
 	assert.NotNil(t, net0)
 
@@ -650,6 +702,13 @@ func TestDoubleClose(t *testing.T) {
 		0,
 		0,
 		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
 	)
 	assert.NotNil(t, net1)
 
@@ -781,6 +840,13 @@ func TestTrackConnected(t *testing.T) {
 		0,
 		0,
 		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
 	)
 	assert.NotNil(t, net0)
 
@@ -806,6 +872,13 @@ func TestTrackConnected(t *testing.T) {
 		0,
 		0,
 		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
 	)
 	assert.NotNil(t, net1)
 
@@ -911,6 +984,13 @@ func TestTrackConnectedRace(t *testing.T) {
 		0,
 		0,
 		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
 	)
 	assert.NotNil(t, net0)
 
@@ -936,6 +1016,13 @@ func TestTrackConnectedRace(t *testing.T) {
 		0,
 		0,
 		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
 	)
 	assert.NotNil(t, net1)
 
@@ -956,3 +1043,633 @@ func TestTrackConnectedRace(t *testing.T) {
 	err = net1.Close()
 	assert.NoError(t, err)
 }
+
+func TestCompressedPut(t *testing.T) {
+	log := logging.NoLog{}
+	networkID := uint32(0)
+	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	versionParser := version.NewDefaultParser()
+
+	ip0 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		0,
+	)
+	id0 := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip0.IP().String())))
+	ip1 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		1,
+	)
+	id1 := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip1.IP().String())))
+	ip2 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		2,
+	)
+	id2 := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip2.IP().String())))
+
+	listeners := make([]*testListener, 3)
+	callers := make([]*testDialer, 3)
+	for i := range listeners {
+		listeners[i] = &testListener{
+			addr: &net.TCPAddr{
+				IP:   net.IPv6loopback,
+				Port: i,
+			},
+			inbound: make(chan net.Conn, 1<<10),
+			closed:  make(chan struct{}),
+		}
+		callers[i] = &testDialer{
+			addr: &net.TCPAddr{
+				IP:   net.IPv6loopback,
+				Port: i,
+			},
+			outbounds: make(map[string]*testListener),
+		}
+	}
+	callers[0].outbounds[ip1.IP().String()] = listeners[1]
+	callers[0].outbounds[ip2.IP().String()] = listeners[2]
+	callers[1].outbounds[ip0.IP().String()] = listeners[0]
+	callers[2].outbounds[ip0.IP().String()] = listeners[0]
+
+	serverUpgrader := NewIPUpgrader()
+	clientUpgrader := NewIPUpgrader()
+
+	vdrs := validators.NewSet()
+
+	chainID := ids.GenerateTestID()
+	containerID := ids.GenerateTestID()
+	container := make([]byte, 1<<16)
+
+	var (
+		connected sync.WaitGroup
+		received  sync.WaitGroup
+	)
+	connected.Add(4)
+	received.Add(4)
+
+	newHandler := func(id ids.ShortID) *testHandler {
+		return &testHandler{
+			connected: func(peerID ids.ShortID) {
+				if !peerID.Equals(id) {
+					connected.Done()
+				}
+			},
+			put: func(_ ids.ShortID, receivedChainID ids.ID, _ uint32, receivedContainerID ids.ID, receivedContainer []byte) {
+				assert.Equal(t, chainID, receivedChainID)
+				assert.Equal(t, containerID, receivedContainerID)
+				assert.Equal(t, container, receivedContainer)
+				received.Done()
+			},
+		}
+	}
+
+	// net0 prefers snappy, net1 only accepts gzip and net2 doesn't accept
+	// compressed messages at all
+	nodeIDs := []ids.ShortID{id0, id1, id2}
+	ips := []utils.DynamicIPDesc{ip0, ip1, ip2}
+	compressionTypes := [][]compression.Type{
+		{compression.Snappy, compression.Gzip},
+		{compression.Gzip},
+		nil,
+	}
+	nets := make([]Network, 3)
+	for i := range nets {
+		nets[i] = NewDefaultNetwork(
+			prometheus.NewRegistry(),
+			log,
+			nodeIDs[i],
+			ips[i],
+			networkID,
+			appVersion,
+			versionParser,
+			listeners[i],
+			callers[i],
+			serverUpgrader,
+			clientUpgrader,
+			vdrs,
+			vdrs,
+			newHandler(nodeIDs[i]),
+			time.Duration(0),
+			0,
+			nil,
+			false,
+			0,
+			0,
+			time.Now(),
+			compressionTypes[i],
+			nil,
+			BandwidthConfig{},
+			false,
+			nil,
+			RecorderConfig{},
+			DefaultMaxMessageSize,
+		)
+		assert.NotNil(t, nets[i])
+
+		net := nets[i]
+		go func() {
+			err := net.Dispatch()
+			assert.Error(t, err)
+		}()
+	}
+
+	nets[0].Track(ip1.IP())
+	nets[0].Track(ip2.IP())
+
+	connected.Wait()
+
+	nets[0].Put(id1, chainID, 0, containerID, container)
+	nets[0].Put(id2, chainID, 0, containerID, container)
+	nets[1].Put(id0, chainID, 0, containerID, container)
+	nets[2].Put(id0, chainID, 0, containerID, container)
+
+	received.Wait()
+
+	net0 := nets[0].(*network)
+	net1 := nets[1].(*network)
+	net2 := nets[2].(*network)
+	assert.Less(t, testutil.ToFloat64(net0.put.compressedBytesSent), testutil.ToFloat64(net0.put.uncompressedBytesSent))
+	assert.Less(t, testutil.ToFloat64(net1.put.compressedBytesReceived), testutil.ToFloat64(net1.put.uncompressedBytesReceived))
+	assert.Zero(t, testutil.ToFloat64(net2.put.compressedBytesReceived))
+
+	for _, net := range nets {
+		err := net.Close()
+		assert.NoError(t, err)
+	}
+}
+
+func TestMultiplexedPut(t *testing.T) {
+	log := logging.NoLog{}
+	networkID := uint32(0)
+	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	versionParser := version.NewDefaultParser()
+
+	ip0 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		0,
+	)
+	id0 := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip0.IP().String())))
+	ip1 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		1,
+	)
+	id1 := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip1.IP().String())))
+
+	listeners := make([]*testListener, 2)
+	callers := make([]*testDialer, 2)
+	for i := range listeners {
+		listeners[i] = &testListener{
+			addr: &net.TCPAddr{
+				IP:   net.IPv6loopback,
+				Port: i,
+			},
+			inbound: make(chan net.Conn, 1<<10),
+			closed:  make(chan struct{}),
+		}
+		callers[i] = &testDialer{
+			addr: &net.TCPAddr{
+				IP:   net.IPv6loopback,
+				Port: i,
+			},
+			outbounds: make(map[string]*testListener),
+		}
+	}
+	callers[0].outbounds[ip1.IP().String()] = listeners[1]
+	callers[1].outbounds[ip0.IP().String()] = listeners[0]
+
+	serverUpgrader := NewMuxUpgrader(NewIPUpgrader(), DefaultMaxMessageSize)
+	clientUpgrader := NewMuxUpgrader(NewIPUpgrader(), DefaultMaxMessageSize)
+
+	vdrs := validators.NewSet()
+
+	chainID := ids.GenerateTestID()
+	containerID := ids.GenerateTestID()
+	container := make([]byte, 1<<20)
+
+	var (
+		connected sync.WaitGroup
+		received  sync.WaitGroup
+	)
+	connected.Add(2)
+	received.Add(2)
+
+	newHandler := func(id ids.ShortID) *testHandler {
+		return &testHandler{
+			connected: func(peerID ids.ShortID) {
+				if !peerID.Equals(id) {
+					connected.Done()
+				}
+			},
+			put: func(_ ids.ShortID, receivedChainID ids.ID, _ uint32, receivedContainerID ids.ID, receivedContainer []byte) {
+				assert.Equal(t, chainID, receivedChainID)
+				assert.Equal(t, containerID, receivedContainerID)
+				assert.Equal(t, container, receivedContainer)
+				received.Done()
+			},
+		}
+	}
+
+	nodeIDs := []ids.ShortID{id0, id1}
+	nets := make([]Network, 2)
+	for i := range nets {
+		nets[i] = NewDefaultNetwork(
+			prometheus.NewRegistry(),
+			log,
+			nodeIDs[i],
+			[]utils.DynamicIPDesc{ip0, ip1}[i],
+			networkID,
+			appVersion,
+			versionParser,
+			listeners[i],
+			callers[i],
+			serverUpgrader,
+			clientUpgrader,
+			vdrs,
+			vdrs,
+			newHandler(nodeIDs[i]),
+			time.Duration(0),
+			0,
+			nil,
+			false,
+			0,
+			0,
+			time.Now(),
+			nil,
+			nil,
+			BandwidthConfig{},
+			false,
+			nil,
+			RecorderConfig{},
+			DefaultMaxMessageSize,
+		)
+		assert.NotNil(t, nets[i])
+
+		net := nets[i]
+		go func() {
+			err := net.Dispatch()
+			assert.Error(t, err)
+		}()
+	}
+
+	nets[0].Track(ip1.IP())
+
+	connected.Wait()
+
+	nets[0].Put(id1, chainID, 0, containerID, container)
+	nets[1].Put(id0, chainID, 0, containerID, container)
+
+	received.Wait()
+
+	for _, net := range nets {
+		err := net.Close()
+		assert.NoError(t, err)
+	}
+}
+
+func TestSetAccessListDisconnects(t *testing.T) {
+	log := logging.NoLog{}
+	networkID := uint32(0)
+	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	versionParser := version.NewDefaultParser()
+
+	ip0 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		0,
+	)
+	id0 := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip0.IP().String())))
+	ip1 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		1,
+	)
+	id1 := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip1.IP().String())))
+
+	listener0 := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller0 := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+	listener1 := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 1,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller1 := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 1,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+
+	caller0.outbounds[ip1.IP().String()] = listener1
+	caller1.outbounds[ip0.IP().String()] = listener0
+
+	serverUpgrader := NewIPUpgrader()
+	clientUpgrader := NewIPUpgrader()
+
+	vdrs := validators.NewSet()
+
+	var (
+		wg0             sync.WaitGroup
+		wg1             sync.WaitGroup
+		disconnectedWG0 sync.WaitGroup
+	)
+	wg0.Add(1)
+	wg1.Add(1)
+	disconnectedWG0.Add(1)
+
+	handler0 := &testHandler{
+		connected: func(id ids.ShortID) {
+			if !id.Equals(id0) {
+				wg0.Done()
+			}
+		},
+		disconnected: func(id ids.ShortID) {
+			if !id.Equals(id0) {
+				disconnectedWG0.Done()
+			}
+		},
+	}
+
+	handler1 := &testHandler{
+		connected: func(id ids.ShortID) {
+			if !id.Equals(id1) {
+				wg1.Done()
+			}
+		},
+	}
+
+	net0 := NewDefaultNetwork(
+		prometheus.NewRegistry(),
+		log,
+		id0,
+		ip0,
+		networkID,
+		appVersion,
+		versionParser,
+		listener0,
+		caller0,
+		serverUpgrader,
+		clientUpgrader,
+		vdrs,
+		vdrs,
+		handler0,
+		time.Duration(0),
+		0,
+		nil,
+		false,
+		0,
+		0,
+		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
+	)
+	assert.NotNil(t, net0)
+
+	net1 := NewDefaultNetwork(
+		prometheus.NewRegistry(),
+		log,
+		id1,
+		ip1,
+		networkID,
+		appVersion,
+		versionParser,
+		listener1,
+		caller1,
+		serverUpgrader,
+		clientUpgrader,
+		vdrs,
+		vdrs,
+		handler1,
+		time.Duration(0),
+		0,
+		nil,
+		false,
+		0,
+		0,
+		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
+	)
+	assert.NotNil(t, net1)
+
+	go func() {
+		err := net0.Dispatch()
+		assert.Error(t, err)
+	}()
+	go func() {
+		err := net1.Dispatch()
+		assert.Error(t, err)
+	}()
+
+	net0.Track(ip1.IP())
+
+	wg0.Wait()
+	wg1.Wait()
+
+	err := net0.SetAccessList(AccessListConfig{
+		DeniedNodeIDs: []string{id1.PrefixedString(constants.NodeIDPrefix)},
+	})
+	assert.NoError(t, err)
+
+	disconnectedWG0.Wait()
+
+	assert.Empty(t, net0.Peers(), "denied peer is still connected")
+
+	err = net0.Close()
+	assert.NoError(t, err)
+
+	err = net1.Close()
+	assert.NoError(t, err)
+}
+
+func TestReconnectToKnownPeers(t *testing.T) {
+	log := logging.NoLog{}
+	networkID := uint32(0)
+	appVersion := version.NewDefaultVersion("app", 0, 1, 0)
+	versionParser := version.NewDefaultParser()
+
+	ip0 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		0,
+	)
+	id0 := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip0.IP().String())))
+	ip1 := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		1,
+	)
+	id1 := ids.NewShortID(hashing.ComputeHash160Array([]byte(ip1.IP().String())))
+
+	listener0 := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller0 := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+	listener1 := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 1,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller1 := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 1,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+
+	caller0.outbounds[ip1.IP().String()] = listener1
+	caller1.outbounds[ip0.IP().String()] = listener0
+
+	serverUpgrader := NewIPUpgrader()
+	clientUpgrader := NewIPUpgrader()
+
+	vdrs := validators.NewSet()
+
+	// net0 was connected to net1 before it restarted
+	peerDB := memdb.New()
+	table, err := newPeerTable(peerDB, 0)
+	assert.NoError(t, err)
+	err = table.Connected(id1, ip1.IP(), uint64(time.Now().Unix()))
+	assert.NoError(t, err)
+
+	var (
+		wg0 sync.WaitGroup
+		wg1 sync.WaitGroup
+	)
+	wg0.Add(1)
+	wg1.Add(1)
+
+	handler0 := &testHandler{
+		connected: func(id ids.ShortID) {
+			if !id.Equals(id0) {
+				wg0.Done()
+			}
+		},
+	}
+
+	handler1 := &testHandler{
+		connected: func(id ids.ShortID) {
+			if !id.Equals(id1) {
+				wg1.Done()
+			}
+		},
+	}
+
+	net0 := NewDefaultNetwork(
+		prometheus.NewRegistry(),
+		log,
+		id0,
+		ip0,
+		networkID,
+		appVersion,
+		versionParser,
+		listener0,
+		caller0,
+		serverUpgrader,
+		clientUpgrader,
+		vdrs,
+		vdrs,
+		handler0,
+		time.Duration(0),
+		0,
+		nil,
+		false,
+		0,
+		0,
+		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		peerDB,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
+	)
+	assert.NotNil(t, net0)
+
+	net1 := NewDefaultNetwork(
+		prometheus.NewRegistry(),
+		log,
+		id1,
+		ip1,
+		networkID,
+		appVersion,
+		versionParser,
+		listener1,
+		caller1,
+		serverUpgrader,
+		clientUpgrader,
+		vdrs,
+		vdrs,
+		handler1,
+		time.Duration(0),
+		0,
+		nil,
+		false,
+		0,
+		0,
+		time.Now(),
+		nil,
+		nil,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
+	)
+	assert.NotNil(t, net1)
+
+	go func() {
+		err := net0.Dispatch()
+		assert.Error(t, err)
+	}()
+	go func() {
+		err := net1.Dispatch()
+		assert.Error(t, err)
+	}()
+
+	wg0.Wait()
+	wg1.Wait()
+
+	err = net0.Close()
+	assert.NoError(t, err)
+
+	err = net1.Close()
+	assert.NoError(t, err)
+}
@@ -4,6 +4,7 @@
 package network
 
 import (
+	"crypto/x509"
 	"encoding/binary"
 	"math"
 	"net"
@@ -13,7 +14,9 @@ import (
 
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/compression"
 	"github.com/liraxapp/avalanchego/utils/formatting"
+	safemath "github.com/liraxapp/avalanchego/utils/math"
 	"github.com/liraxapp/avalanchego/utils/wrappers"
 	"github.com/liraxapp/avalanchego/version"
 )
@@ -42,11 +45,22 @@ type peer struct {
 	// number of bytes currently in the send queue.
 	pendingBytes int64
 
-	// lock to ensure that closing of the sender queue is handled safely
+	// lock to ensure that closing of the sender queues is handled safely
 	senderLock sync.Mutex
-	// queue of messages this connection is attempting to send the peer. Is
-	// closed when the connection is closed.
-	sender chan []byte
+	// queues of messages this connection is attempting to send the peer. Bulk
+	// messages are queued in [bulkSender], all others in [sender]. If the
+	// connection is multiplexed, messages are instead queued in the
+	// [classSenders] of their class, each of which is written by its own
+	// routine. Are closed when the connection is closed.
+	sender, bulkSender chan []byte
+	classSenders       [numClasses]chan []byte
+	// number of messages sent from [sender] since a message was last sent from
+	// [bulkSender]. Is only accessed on the connection's writer routine.
+	consecutiveMsgs int
+
+	// limit the bandwidth used by the connection. A nil throttler doesn't
+	// limit the bandwidth.
+	inboundThrottler, outboundThrottler *bandwidthThrottler
 
 	// ip may or may not be set when the peer is first started. is only modified
 	// on the connection's reader routine.
@@ -59,12 +73,61 @@ type peer struct {
 	// the connection object that is used to read/write messages from
 	conn net.Conn
 
+	// cert is the certificate the peer authenticated with. Is nil if TLS is
+	// disabled. Should be set when the peer is first created.
+	cert *x509.Certificate
+
+	// IP the peer announced during the handshake, if it is the IP we are
+	// connected to the peer through. Only set IPs are gossiped to other nodes.
+	signedIP utils.AtomicInterface
+
 	// version that the peer reported during the handshake
 	versionStruct, versionStr utils.AtomicInterface
 
+	// true if the peer's version accepts the optional fields of messages.
+	// Until then, only the fields every node knows about are sent to the peer.
+	// Is only modified on the connection's reader routine.
+	acceptsOptionalFields utils.AtomicBool
+
+	// true if the optional fields of the peer's Version have been received. Is
+	// only modified on the connection's reader routine.
+	gotVersionFields utils.AtomicBool
+
+	// IDs of the nodes whose certificates were sent to the peer in a PeerList,
+	// so that they are only sent once per connection
+	sentCertsLock sync.Mutex
+	sentCerts     ids.ShortSet
+
+	// compressor used for compressible messages sent to this peer. Is unset if
+	// the peer doesn't accept compressed messages. Is only modified on the
+	// connection's reader routine.
+	compressor utils.AtomicInterface
+
+	// true if messages are sent to the peer in the TLV format. Is only
+	// modified on the connection's reader routine.
+	tlv utils.AtomicBool
+
 	// unix time of the last message sent and received respectively
 	lastSent, lastReceived int64
 
+	// number of messages queued to be sent to the peer and number of messages
+	// dropped instead, respectively
+	numSent, numDropped uint64
+
+	// pingLock protects the round trip time tracking below
+	pingLock sync.Mutex
+	// nonce of the last ping sent to the peer
+	pingNonce uint32
+	// time the last ping was sent at. Is zero if no ping is awaiting a pong.
+	pingSent time.Time
+	// round trip time of the last answered ping
+	lastRTT time.Duration
+	// exponentially weighted moving average of the round trip times, in
+	// nanoseconds. Is nil until the first pong is received.
+	latency safemath.Averager
+	// number of pings that were never answered
+	numPingsLost uint64
+
 	tickerCloser chan struct{}
 
 	// ticker processes
@@ -185,6 +248,34 @@ func (p *peer) ReadMessages() {
 			return
 		}
 
+		// delay reading more bytes from the connection if the peer is using
+		// more than its inbound bandwidth
+		msgLen := wrappers.IntLen + len(msgBytes)
+		if delay := p.inboundThrottler.Acquire(msgLen); delay > 0 {
+			p.net.inboundThrottledBytes.Add(float64(msgLen))
+			if !p.wait(delay) {
+				return
+			}
+		}
+
+		// Compressed messages are flagged, so that they can be told apart from
+		// the messages sent before the peer learned that this node accepts
+		// compressed messages
+		if isCompressed(msgBytes) {
+			compressedLen := len(msgBytes)
+			msgBytes, err = p.net.b.Decompress(msgBytes, p.net.compressors)
+			if err != nil {
+				p.net.log.Debug("failed to decompress new message from %s: %s",
+					p.id,
+					err)
+				return
+			}
+
+			msgMetrics := p.net.message(opOf(msgBytes[0]))
+			msgMetrics.compressedBytesReceived.Add(float64(compressedLen))
+			msgMetrics.uncompressedBytesReceived.Add(float64(len(msgBytes)))
+		}
+
 		p.net.log.Verbo("parsing new message from %s:\n%s",
 			p.id,
 			formatting.DumpBytes{Bytes: msgBytes})
@@ -198,6 +289,7 @@ func (p *peer) ReadMessages() {
 			return
 		}
 
+		p.net.recorder.Record(Inbound, p.id, msg)
 		p.handle(msg)
 	}
 }
@@ -208,13 +300,19 @@ func (p *peer) WriteMessages() {
 
 	p.Version()
 
-	for msg := range p.sender {
-		p.net.log.Verbo("sending new message to %s:\n%s",
-			p.id,
-			formatting.DumpBytes{Bytes: msg})
+	if conn, ok := p.conn.(MultiplexedConn); ok {
+		p.writeClasses(conn)
+		return
+	}
 
-		atomic.AddInt64(&p.pendingBytes, -int64(len(msg)))
-		atomic.AddInt64(&p.net.pendingBytes, -int64(len(msg)))
+	for {
+		msg, ok := p.nextMessage()
+		if !ok {
+			return
+		}
+		if !p.prepareWrite(msg) {
+			return
+		}
 
 		msgb := [wrappers.IntLen]byte{}
 		binary.BigEndian.PutUint32(msgb[:], uint32(len(msg)))
@@ -233,8 +331,113 @@ func (p *peer) WriteMessages() {
 	}
 }
 
-// send assumes that the stateLock is not held.
+// writeClasses writes the messages of each class on its own routine, so that a
+// large message is interleaved with the messages of the other classes rather
+// than delaying them. Returns once the queue of the first class is closed.
+func (p *peer) writeClasses(conn MultiplexedConn) {
+	for _, lane := range p.classSenders[1:] {
+		go p.writeMultiplexed(conn, lane)
+	}
+	p.writeMultiplexed(conn, p.classSenders[0])
+}
+
+// writeMultiplexed writes the messages queued in [lane] on the stream of their
+// class until the peer is closed
+func (p *peer) writeMultiplexed(conn MultiplexedConn, lane chan []byte) {
+	defer p.Close()
+
+	for msg := range lane {
+		if !p.prepareWrite(msg) {
+			return
+		}
+		if err := conn.WriteMessage(opOf(msg[0]).Class(), msg); err != nil {
+			p.net.log.Verbo("error writing to %s at %s due to: %s", p.id, p.getIP(), err)
+			return
+		}
+		p.tickerOnce.Do(p.StartTicker)
+		atomic.StoreInt64(&p.lastSent, p.net.clock.Time().Unix())
+	}
+}
+
+// prepareWrite waits until [msg] may be sent under the outbound bandwidth
+// limit and removes it from the pending bytes. Returns false if the peer was
+// closed in the meantime.
+func (p *peer) prepareWrite(msg []byte) bool {
+	msgLen := wrappers.IntLen + len(msg)
+	if delay := p.outboundThrottler.Acquire(msgLen); delay > 0 {
+		p.net.outboundThrottledBytes.Add(float64(msgLen))
+		if !p.wait(delay) {
+			return false
+		}
+	}
+
+	p.net.log.Verbo("sending new message to %s:\n%s",
+		p.id,
+		formatting.DumpBytes{Bytes: msg})
+
+	atomic.AddInt64(&p.pendingBytes, -int64(len(msg)))
+	atomic.AddInt64(&p.net.pendingBytes, -int64(len(msg)))
+	return true
+}
+
+// nextMessage blocks until there is a message to send to the peer. Messages in
+// the consensus lane are preferred, but a waiting bulk message is sent after
+// every [maxConsecutiveMsgs] consensus messages, so that bulk messages can't be
+// starved either. Returns false if the peer was closed.
+func (p *peer) nextMessage() ([]byte, bool) {
+	if p.consecutiveMsgs < maxConsecutiveMsgs {
+		select {
+		case msg, ok := <-p.sender:
+			p.consecutiveMsgs++
+			return msg, ok
+		default:
+		}
+	}
+
+	select {
+	case msg, ok := <-p.bulkSender:
+		p.consecutiveMsgs = 0
+		return msg, ok
+	default:
+	}
+
+	select {
+	case msg, ok := <-p.sender:
+		p.consecutiveMsgs++
+		return msg, ok
+	case msg, ok := <-p.bulkSender:
+		p.consecutiveMsgs = 0
+		return msg, ok
+	}
+}
+
+// wait blocks for [delay]. Returns false if the peer was closed in the
+// meantime.
+func (p *peer) wait(delay time.Duration) bool {
+	delayTimer := time.NewTimer(delay)
+	defer delayTimer.Stop()
+
+	select {
+	case <-delayTimer.C:
+		return true
+	case <-p.tickerCloser:
+		return false
+	}
+}
+
+// Send assumes that the stateLock is not held.
 func (p *peer) Send(msg Msg) bool {
+	if p.send(msg) {
+		atomic.AddUint64(&p.numSent, 1)
+		p.net.recorder.Record(Outbound, p.id, msg)
+		return true
+	}
+	atomic.AddUint64(&p.numDropped, 1)
+	return false
+}
+
+// send assumes that the stateLock is not held.
+func (p *peer) send(msg Msg) bool {
 	p.senderLock.Lock()
 	defer p.senderLock.Unlock()
 
@@ -251,7 +454,11 @@ func (p *peer) Send(msg Msg) bool {
 		return false
 	}
 
-	msgBytes := msg.Bytes()
+	msgBytes, compressed, err := p.encode(msg)
+	if err != nil {
+		p.net.log.Debug("dropping message to %s due to a failed encoding: %s", p.id, err)
+		return false
+	}
 	msgBytesLen := int64(len(msgBytes))
 
 	// lets assume send will be successful, we add to the network pending bytes
@@ -267,8 +474,13 @@ func (p *peer) Send(msg Msg) bool {
 	}
 
 	select {
-	case p.sender <- msgBytes:
+	case p.lane(msg.Op()) <- msgBytes:
 		atomic.AddInt64(&p.pendingBytes, msgBytesLen)
+		if compressed {
+			msgMetrics := p.net.message(msg.Op())
+			msgMetrics.uncompressedBytesSent.Add(float64(len(msg.Bytes())))
+			msgMetrics.compressedBytesSent.Add(float64(msgBytesLen))
+		}
 		return true
 	default:
 		// we never sent the message, remove from pending totals
@@ -278,6 +490,42 @@ func (p *peer) Send(msg Msg) bool {
 	}
 }
 
+// lane returns the queue that messages with [op] are sent from. Assumes the
+// senderLock is held.
+func (p *peer) lane(op Op) chan []byte {
+	switch {
+	case p.classSenders[0] != nil:
+		return p.classSenders[op.Class()]
+	case op.Bulk():
+		return p.bulkSender
+	default:
+		return p.sender
+	}
+}
+
+// encode returns the bytes to send [msg] with, in the format the peer accepts.
+// If the message was encoded with the peer's compressor, true is returned.
+func (p *peer) encode(msg Msg) ([]byte, bool, error) {
+	// Version is always sent in the legacy format, since the peer's formats
+	// aren't known until its Version is received
+	if p.tlv.GetValue() && msg.Op() != Version {
+		tlvMsg, err := p.net.b.ToTLV(msg)
+		if err != nil {
+			return nil, false, err
+		}
+		msg = tlvMsg
+	}
+	if !msg.Op().Compressible() {
+		return msg.Bytes(), false, nil
+	}
+	compressor, ok := p.compressor.GetValue().(compression.Compressor)
+	if !ok {
+		return msg.Bytes(), false, nil
+	}
+	msgBytes, err := p.net.b.Compress(msg, compressor)
+	return msgBytes, true, err
+}
+
 // assumes the stateLock is not held
 func (p *peer) handle(msg Msg) {
 	p.net.heartbeat()
@@ -338,7 +586,13 @@ func (p *peer) handle(msg Msg) {
 		return
 	}
 
-	switch op {
+	p.route(msg)
+}
+
+// route passes a consensus or application message to the router
+// assumes the stateLock is not held
+func (p *peer) route(msg Msg) {
+	switch op := msg.Op(); op {
 	case GetAcceptedFrontier:
 		p.getAcceptedFrontier(msg)
 	case AcceptedFrontier:
@@ -361,6 +615,20 @@ func (p *peer) handle(msg Msg) {
 		p.pullQuery(msg)
 	case Chits:
 		p.chits(msg)
+	case AppRequest:
+		p.appRequest(msg)
+	case AppResponse:
+		p.appResponse(msg)
+	case AppGossip:
+		p.appGossip(msg)
+	case GetStateSummary:
+		p.getStateSummary(msg)
+	case StateSummary:
+		p.stateSummary(msg)
+	case GetStateChunk:
+		p.getStateChunk(msg)
+	case StateChunk:
+		p.stateChunk(msg)
 	default:
 		p.net.log.Debug("dropping an unknown message from %s with op %s", p.id, op.String())
 	}
@@ -394,8 +662,13 @@ func (p *peer) close() {
 
 	p.senderLock.Lock()
 	// The locks guarantee here that the sender routine will read that the peer
-	// has been closed and will therefore not attempt to write on this channel.
-	close(p.sender)
+	// has been closed and will therefore not attempt to write on these
+	// channels.
+	for _, lane := range append([]chan []byte{p.sender, p.bulkSender}, p.classSenders[:]...) {
+		if lane != nil {
+			close(lane)
+		}
+	}
 	p.senderLock.Unlock()
 
 	p.net.disconnected(p)
@@ -410,13 +683,36 @@ func (p *peer) GetVersion() {
 
 // assumes the stateLock is not held
 func (p *peer) Version() {
+	if !p.acceptsOptionalFields.GetValue() {
+		p.net.stateLock.RLock()
+		msg, err := p.net.b.Version(
+			p.net.networkID,
+			p.net.nodeID,
+			p.net.clock.Unix(),
+			p.net.ip.IP(),
+			p.net.version.String(),
+		)
+		p.net.stateLock.RUnlock()
+		p.net.log.AssertNoError(err)
+		p.Send(msg)
+		return
+	}
+
+	signedIP, err := p.net.signedIP()
+	if err != nil {
+		p.net.log.Error("failed to sign my IP due to %s", err)
+		return
+	}
+
 	p.net.stateLock.RLock()
-	msg, err := p.net.b.Version(
+	msg, err := p.net.b.VersionWithOptionalFields(
 		p.net.networkID,
 		p.net.nodeID,
 		p.net.clock.Unix(),
-		p.net.ip.IP(),
+		signedIP,
 		p.net.version.String(),
+		p.net.compressionTypes,
+		p.net.formats,
 	)
 	p.net.stateLock.RUnlock()
 	p.net.log.AssertNoError(err)
@@ -436,30 +732,97 @@ func (p *peer) SendPeerList() {
 	p.PeerList(ips)
 }
 
-// assumes the stateLock is not held
-func (p *peer) PeerList(peers []utils.IPDesc) {
-	msg, err := p.net.b.PeerList(peers)
+// assumes the stateLock is not held. Peers whose version doesn't accept signed
+// IPs are sent the IPs without their signatures. Otherwise, the certificate of
+// each node is only sent the first time one of its IPs is sent to the peer.
+func (p *peer) PeerList(peers []SignedIP) {
+	if !p.acceptsOptionalFields.GetValue() {
+		ips := make([]utils.IPDesc, len(peers))
+		for i, peer := range peers {
+			ips[i] = peer.IP
+		}
+		msg, err := p.net.b.PeerList(ips)
+		if err != nil {
+			p.net.log.Warn("failed to send PeerList message due to %s", err)
+			return
+		}
+		p.Send(msg)
+		return
+	}
+
+	p.sentCertsLock.Lock()
+	defer p.sentCertsLock.Unlock()
+
+	signedIPs := make([]SignedIP, 0, len(peers))
+	newCerts := []ids.ShortID(nil)
+	for _, peer := range peers {
+		if peer.NodeID.Equals(p.id) {
+			continue
+		}
+		if p.net.ipSigner != nil && len(peer.Signature) == 0 {
+			// The peer would drop the IP, since it can't be verified
+			continue
+		}
+		if p.sentCerts.Contains(peer.NodeID) {
+			peer.Cert = nil
+		} else if len(peer.Cert) > 0 {
+			newCerts = append(newCerts, peer.NodeID)
+		}
+		signedIPs = append(signedIPs, peer)
+	}
+
+	msg, err := p.net.b.SignedPeerList(signedIPs)
 	if err != nil {
 		p.net.log.Warn("failed to send PeerList message due to %s", err)
 		return
 	}
-	p.Send(msg)
+	if p.Send(msg) {
+		p.sentCerts.Add(newCerts...)
+	}
 }
 
 // assumes the stateLock is not held
 func (p *peer) Ping() {
-	msg, err := p.net.b.Ping()
+	p.pingLock.Lock()
+	defer p.pingLock.Unlock()
+
+	if !p.pingSent.IsZero() {
+		p.numPingsLost++
+		p.net.pingsLost.Inc()
+	}
+	p.pingNonce++
+
+	var (
+		msg Msg
+		err error
+	)
+	if p.acceptsOptionalFields.GetValue() {
+		msg, err = p.net.b.PingWithNonce(p.pingNonce)
+	} else {
+		msg, err = p.net.b.Ping()
+	}
 	p.net.log.AssertNoError(err)
 	if p.Send(msg) {
+		p.pingSent = p.net.clock.Time()
 		p.net.ping.numSent.Inc()
 	} else {
+		p.pingSent = time.Time{}
 		p.net.ping.numFailed.Inc()
 	}
 }
 
-// assumes the stateLock is not held
-func (p *peer) Pong() {
-	msg, err := p.net.b.Pong()
+// assumes the stateLock is not held. [nonce] is the nonce of the ping being
+// answered, or nil if the ping didn't have one.
+func (p *peer) Pong(nonce interface{}) {
+	var (
+		msg Msg
+		err error
+	)
+	if nonce, ok := nonce.(uint32); ok {
+		msg, err = p.net.b.PongWithNonce(nonce)
+	} else {
+		msg, err = p.net.b.Pong()
+	}
 	p.net.log.AssertNoError(err)
 	if p.Send(msg) {
 		p.net.pong.numSent.Inc()
@@ -474,7 +837,13 @@ func (p *peer) getVersion(_ Msg) { p.Version() }
 // assumes the stateLock is not held
 func (p *peer) version(msg Msg) {
 	if p.gotVersion.GetValue() {
-		p.net.log.Verbo("dropping duplicated version message from %s", p.id)
+		// The optional fields are sent in a later Version message if the peer
+		// didn't know this node's version when it sent its first one
+		if msg.Get(IPTime) == nil || p.gotVersionFields.GetValue() || !p.acceptsOptionalFields.GetValue() {
+			p.net.log.Verbo("dropping duplicated version message from %s", p.id)
+			return
+		}
+		p.versionFields(msg)
 		return
 	}
 
@@ -549,7 +918,6 @@ func (p *peer) version(msg Msg) {
 	if ip.IsZero() {
 		// we only care about the claimed IP if we don't know the IP yet
 		peerIP := msg.Get(IP).(utils.IPDesc)
-
 		addr := p.conn.RemoteAddr()
 		localPeerIP, err := utils.ToIPDesc(addr.String())
 		if err == nil {
@@ -562,15 +930,77 @@ func (p *peer) version(msg Msg) {
 		}
 	}
 
-	p.SendPeerList()
+	// Until the peer's signed IP is received, the IP we know the peer by is
+	// only gossiped to nodes that don't verify IPs
+	p.signedIP.SetValue(SignedIP{NodeID: p.id, IP: p.getIP()})
 
 	p.versionStruct.SetValue(peerVersion)
 	p.versionStr.SetValue(peerVersion.String())
 	p.gotVersion.SetValue(true)
 
+	if !peerVersion.Before(minimumOptionalFieldsVersion) {
+		p.acceptsOptionalFields.SetValue(true)
+
+		// The Version sent before the peer's version was known didn't include
+		// the optional fields
+		p.Version()
+	}
+	if msg.Get(IPTime) != nil && !p.versionFields(msg) {
+		return
+	}
+
+	p.SendPeerList()
+
 	p.tryMarkConnected()
 }
 
+// versionFields handles the optional fields of the peer's Version message.
+// Returns false if the peer was discarded.
+// assumes the stateLock is not held
+func (p *peer) versionFields(msg Msg) bool {
+	p.gotVersionFields.SetValue(true)
+
+	ipSig, _ := msg.Get(IPSig).([]byte)
+	peerIP := SignedIP{
+		NodeID:    p.id,
+		IP:        msg.Get(IP).(utils.IPDesc),
+		Time:      msg.Get(IPTime).(uint64),
+		Signature: ipSig,
+	}
+	if p.cert != nil {
+		if peerIP.Time > msg.Get(MyTime).(uint64) {
+			p.net.log.Debug("peer %s signed its IP in the future", p.id)
+
+			p.discardIP()
+			return false
+		}
+		if err := peerIP.Verify(p.cert); err != nil {
+			p.net.log.Debug("peer %s's IP signature couldn't be verified due to %s", p.id, err)
+
+			p.discardIP()
+			return false
+		}
+		peerIP.Cert = p.cert.Raw
+
+		if ip := p.getIP(); !ip.IsZero() && ip.Equal(peerIP.IP) {
+			p.signedIP.SetValue(peerIP)
+
+			p.net.stateLock.Lock()
+			if p.net.vdrs.Contains(p.id) {
+				p.net.updateLatestIP(p.id, peerIP)
+			}
+			p.net.stateLock.Unlock()
+		}
+	}
+
+	if peerCompressionTypes, _ := msg.Get(Compression).([]byte); len(peerCompressionTypes) > 0 {
+		p.compressor.SetValue(p.net.compressor(peerCompressionTypes))
+	}
+	peerFormats, _ := msg.Get(Formats).([]byte)
+	p.tlv.SetValue(p.net.acceptsFormat(peerFormats, TLVFormat))
+	return true
+}
+
 // assumes the stateLock is not held
 func (p *peer) getPeerList(_ Msg) {
 	if p.gotVersion.GetValue() {
@@ -580,28 +1010,65 @@ func (p *peer) getPeerList(_ Msg) {
 
 // assumes the stateLock is not held
 func (p *peer) peerList(msg Msg) {
-	ips := msg.Get(Peers).([]utils.IPDesc)
-
 	p.gotPeerList.SetValue(true)
 	p.tryMarkConnected()
 
-	for _, ip := range ips {
-		p.net.stateLock.Lock()
-		if !ip.Equal(p.net.ip.IP()) &&
-			!ip.IsZero() &&
-			(p.net.allowPrivateIPs || !ip.IsPrivate()) {
-			// TODO: only try to connect once
-			p.net.track(ip)
-		}
-		p.net.stateLock.Unlock()
+	// IPs without signatures are only tracked if TLS is disabled, since their
+	// owners can't be verified
+	for _, ip := range msg.Get(Peers).([]utils.IPDesc) {
+		// TODO: only try to connect once
+		p.net.trackSignedIP(SignedIP{IP: ip})
+	}
+	signedIPs, _ := msg.Get(SignedPeers).([]SignedIP)
+	for _, ip := range signedIPs {
+		// TODO: only try to connect once
+		p.net.trackSignedIP(ip)
 	}
 }
 
 // assumes the stateLock is not held
-func (p *peer) ping(_ Msg) { p.Pong() }
+func (p *peer) ping(msg Msg) { p.Pong(msg.Get(Nonce)) }
 
-// assumes the stateLock is not held
-func (p *peer) pong(_ Msg) {}
+// assumes the stateLock is not held. Pongs of peers that don't send nonces are
+// assumed to answer the last ping sent to the peer.
+func (p *peer) pong(msg Msg) {
+	currentTime := p.net.clock.Time()
+
+	p.pingLock.Lock()
+	defer p.pingLock.Unlock()
+
+	if p.pingSent.IsZero() {
+		p.net.log.Verbo("dropping unexpected pong from %s", p.id)
+		return
+	}
+	if nonce, ok := msg.Get(Nonce).(uint32); ok && nonce != p.pingNonce {
+		p.net.log.Verbo("dropping unexpected pong with nonce %d from %s", nonce, p.id)
+		return
+	}
+
+	rtt := currentTime.Sub(p.pingSent)
+	p.pingSent = time.Time{}
+	p.lastRTT = rtt
+	if p.latency == nil {
+		p.latency = safemath.NewAverager(float64(rtt), latencyHalflife, currentTime)
+	} else {
+		p.latency.Observe(float64(rtt), currentTime)
+	}
+	p.net.pingRTT.Observe(float64(rtt) / float64(time.Millisecond))
+}
+
+// pingStats returns the round trip time of the last answered ping, the weighted
+// average of the round trip times and the number of pings that were never
+// answered. The round trip times are zero if no ping has been answered yet.
+func (p *peer) pingStats() (time.Duration, time.Duration, uint64) {
+	p.pingLock.Lock()
+	defer p.pingLock.Unlock()
+
+	if p.latency == nil {
+		return 0, 0, p.numPingsLost
+	}
+	return p.lastRTT, time.Duration(p.latency.Read()), p.numPingsLost
+}
 
 // assumes the stateLock is not held
 func (p *peer) getAcceptedFrontier(msg Msg) {
@@ -788,6 +1255,79 @@ func (p *peer) chits(msg Msg) {
 	p.net.router.Chits(p.id, chainID, requestID, containerIDs)
 }
 
+// assumes the stateLock is not held
+func (p *peer) appRequest(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	deadline := p.net.clock.Time().Add(time.Duration(msg.Get(Deadline).(uint64)))
+	appRequestBytes := msg.Get(AppBytes).([]byte)
+
+	p.net.router.AppRequest(p.id, chainID, requestID, deadline, appRequestBytes)
+}
+
+// assumes the stateLock is not held
+func (p *peer) appResponse(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	appResponseBytes := msg.Get(AppBytes).([]byte)
+
+	p.net.router.AppResponse(p.id, chainID, requestID, appResponseBytes)
+}
+
+// assumes the stateLock is not held
+func (p *peer) appGossip(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	appGossipBytes := msg.Get(AppBytes).([]byte)
+
+	p.net.router.AppGossip(p.id, chainID, appGossipBytes)
+}
+
+// assumes the stateLock is not held
+func (p *peer) getStateSummary(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	deadline := p.net.clock.Time().Add(time.Duration(msg.Get(Deadline).(uint64)))
+
+	p.net.router.GetStateSummary(p.id, chainID, requestID, deadline)
+}
+
+// assumes the stateLock is not held
+func (p *peer) stateSummary(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	summary := msg.Get(SummaryBytes).([]byte)
+
+	p.net.router.StateSummary(p.id, chainID, requestID, summary)
+}
+
+// assumes the stateLock is not held
+func (p *peer) getStateChunk(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	deadline := p.net.clock.Time().Add(time.Duration(msg.Get(Deadline).(uint64)))
+	summaryID, err := ids.ToID(msg.Get(ContainerID).([]byte))
+	p.net.log.AssertNoError(err)
+	index := msg.Get(ChunkIndex).(uint32)
+
+	p.net.router.GetStateChunk(p.id, chainID, requestID, deadline, summaryID, index)
+}
+
+// assumes the stateLock is not held
+func (p *peer) stateChunk(msg Msg) {
+	chainID, err := ids.ToID(msg.Get(ChainID).([]byte))
+	p.net.log.AssertNoError(err)
+	requestID := msg.Get(RequestID).(uint32)
+	chunk := msg.Get(ChunkBytes).([]byte)
+
+	p.net.router.StateChunk(p.id, chainID, requestID, chunk)
+}
+
 // assumes the stateLock is held
 func (p *peer) tryMarkConnected() {
 	if !p.connected.GetValue() && // not already connected
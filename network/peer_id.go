@@ -5,6 +5,8 @@ package network
 
 import (
 	"time"
+
+	"github.com/liraxapp/avalanchego/utils/json"
 )
 
 // PeerID ...
@@ -15,4 +17,15 @@ type PeerID struct {
 	Version      string    `json:"version"`
 	LastSent     time.Time `json:"lastSent"`
 	LastReceived time.Time `json:"lastReceived"`
+	// Round trip time of the last ping answered by the peer, and the
+	// exponentially weighted moving average of the round trip times, in
+	// nanoseconds. Zero if the peer hasn't answered a ping yet.
+	LastRTT json.Uint64 `json:"lastRTT"`
+	Latency json.Uint64 `json:"latency"`
+	// Number of messages queued to be sent to the peer, and number of messages
+	// dropped instead because the connection was closed or overloaded
+	MessagesSent    json.Uint64 `json:"messagesSent"`
+	MessagesDropped json.Uint64 `json:"messagesDropped"`
+	// Number of pings the peer never answered
+	PingsLost json.Uint64 `json:"pingsLost"`
 }
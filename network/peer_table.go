@@ -0,0 +1,197 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+// length of a known peer when written to the database
+const knownPeerLen = net.IPv6len + wrappers.ShortLen + wrappers.LongLen + 2*wrappers.IntLen
+
+// knownPeer is a peer this node has been connected to in the past
+type knownPeer struct {
+	ID ids.ShortID
+	// IP the peer was last connected to at
+	IP utils.IPDesc
+	// Unix time the peer was last connected to
+	LastSeen uint64
+	// Number of times the peer was connected to
+	NumSuccesses uint32
+	// Number of failed attempts to connect to the peer since it was last
+	// connected to
+	NumFailures uint32
+}
+
+// backoff returns how long to wait before attempting to connect to the peer.
+// The delay doubles with each consecutive failure, up to [maxDelay].
+func (p *knownPeer) backoff(initialDelay, maxDelay time.Duration) time.Duration {
+	if p.NumFailures == 0 {
+		return 0
+	}
+	delay := initialDelay
+	for i := uint32(1); i < p.NumFailures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+func (p *knownPeer) Bytes() []byte {
+	packer := wrappers.Packer{Bytes: make([]byte, knownPeerLen)}
+	packer.PackIP(p.IP)
+	packer.PackLong(p.LastSeen)
+	packer.PackInt(p.NumSuccesses)
+	packer.PackInt(p.NumFailures)
+	return packer.Bytes
+}
+
+func parseKnownPeer(key, value []byte) (*knownPeer, error) {
+	id, err := ids.ToShortID(key)
+	if err != nil {
+		return nil, err
+	}
+	packer := wrappers.Packer{Bytes: value}
+	p := &knownPeer{
+		ID:           id,
+		IP:           packer.UnpackIP(),
+		LastSeen:     packer.UnpackLong(),
+		NumSuccesses: packer.UnpackInt(),
+		NumFailures:  packer.UnpackInt(),
+	}
+	if packer.Offset != len(value) {
+		packer.Add(fmt.Errorf("expected length %d got %d", len(value), packer.Offset))
+	}
+	return p, packer.Err
+}
+
+// peerTable records the peers this node has been connected to, so that they
+// can be reconnected to after a restart without relying on the bootstrap IPs.
+// It is safe to use concurrently.
+type peerTable struct {
+	// if nil, the table isn't persisted
+	db database.Database
+
+	lock sync.Mutex
+	// node ID -> peer
+	peers map[[20]byte]*knownPeer
+	// IP -> peer last connected to at that IP
+	ips map[string]*knownPeer
+}
+
+// newPeerTable loads the peers stored in [db]. Peers that haven't been
+// connected to since [minLastSeen] are removed from the table.
+func newPeerTable(db database.Database, minLastSeen uint64) (*peerTable, error) {
+	t := &peerTable{
+		db:    db,
+		peers: make(map[[20]byte]*knownPeer),
+		ips:   make(map[string]*knownPeer),
+	}
+	if db == nil {
+		return t, nil
+	}
+
+	stalePeers := [][]byte(nil)
+	it := db.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		p, err := parseKnownPeer(key, it.Value())
+		if err != nil || p.LastSeen < minLastSeen {
+			stalePeers = append(stalePeers, append([]byte(nil), key...))
+			continue
+		}
+		t.peers[p.ID.Key()] = p
+		t.ips[p.IP.String()] = p
+	}
+	if err := it.Error(); err != nil {
+		return t, err
+	}
+
+	errs := wrappers.Errs{}
+	for _, key := range stalePeers {
+		errs.Add(db.Delete(key))
+	}
+	return t, errs.Err
+}
+
+// Known returns the peers in the table
+func (t *peerTable) Known() []knownPeer {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	peers := make([]knownPeer, 0, len(t.peers))
+	for _, p := range t.peers {
+		peers = append(peers, *p)
+	}
+	return peers
+}
+
+// Connected records that the peer [nodeID] was connected to at [ip] at unix
+// time [now]
+func (t *peerTable) Connected(nodeID ids.ShortID, ip utils.IPDesc, now uint64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	key := nodeID.Key()
+	p, ok := t.peers[key]
+	if !ok {
+		p = &knownPeer{ID: nodeID}
+		t.peers[key] = p
+	}
+	if oldIP := p.IP.String(); t.ips[oldIP] == p {
+		delete(t.ips, oldIP)
+	}
+	p.IP = ip
+	p.LastSeen = now
+	p.NumSuccesses++
+	p.NumFailures = 0
+	t.ips[ip.String()] = p
+	return t.put(p)
+}
+
+// Disconnected records that the peer [nodeID] was last seen at unix time [now]
+func (t *peerTable) Disconnected(nodeID ids.ShortID, now uint64) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	p, ok := t.peers[nodeID.Key()]
+	if !ok {
+		return nil
+	}
+	p.LastSeen = now
+	return t.put(p)
+}
+
+// Failed records that an attempt to connect to [ip] failed
+func (t *peerTable) Failed(ip utils.IPDesc) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	p, ok := t.ips[ip.String()]
+	if !ok {
+		return nil
+	}
+	p.NumFailures++
+	return t.put(p)
+}
+
+// assumes the lock is held
+func (t *peerTable) put(p *knownPeer) error {
+	if t.db == nil {
+		return nil
+	}
+	return t.db.Put(p.ID.Bytes(), p.Bytes())
+}
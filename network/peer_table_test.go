@@ -0,0 +1,111 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liraxapp/avalanchego/database/memdb"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils"
+)
+
+func TestPeerTablePersists(t *testing.T) {
+	db := memdb.New()
+	id0 := ids.NewShortID([20]byte{1})
+	id1 := ids.NewShortID([20]byte{2})
+	ip0 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	ip1 := utils.IPDesc{IP: net.IPv4(5, 6, 7, 8), Port: 9651}
+
+	table, err := newPeerTable(db, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, table.Known())
+
+	assert.NoError(t, table.Connected(id0, ip0, 100))
+	assert.NoError(t, table.Connected(id1, ip1, 100))
+	assert.NoError(t, table.Disconnected(id1, 200))
+	assert.NoError(t, table.Failed(ip1))
+	assert.NoError(t, table.Failed(ip1))
+
+	table, err = newPeerTable(db, 0)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []knownPeer{
+		{
+			ID:           id0,
+			IP:           ip0,
+			LastSeen:     100,
+			NumSuccesses: 1,
+		},
+		{
+			ID:           id1,
+			IP:           ip1,
+			LastSeen:     200,
+			NumSuccesses: 1,
+			NumFailures:  2,
+		},
+	}, table.Known())
+}
+
+func TestPeerTableRemovesStalePeers(t *testing.T) {
+	db := memdb.New()
+	id0 := ids.NewShortID([20]byte{1})
+	id1 := ids.NewShortID([20]byte{2})
+	ip0 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	ip1 := utils.IPDesc{IP: net.IPv4(5, 6, 7, 8), Port: 9651}
+
+	table, err := newPeerTable(db, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, table.Connected(id0, ip0, 100))
+	assert.NoError(t, table.Connected(id1, ip1, 200))
+
+	table, err = newPeerTable(db, 150)
+	assert.NoError(t, err)
+	known := table.Known()
+	if assert.Len(t, known, 1) {
+		assert.Equal(t, id1, known[0].ID)
+	}
+
+	has, err := db.Has(id0.Bytes())
+	assert.NoError(t, err)
+	assert.False(t, has, "stale peer wasn't removed from the database")
+}
+
+func TestPeerTableMovedPeer(t *testing.T) {
+	id := ids.NewShortID([20]byte{1})
+	ip0 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	ip1 := utils.IPDesc{IP: net.IPv4(5, 6, 7, 8), Port: 9651}
+
+	table, err := newPeerTable(nil, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, table.Connected(id, ip0, 100))
+	assert.NoError(t, table.Failed(ip0))
+	assert.NoError(t, table.Connected(id, ip1, 200))
+
+	// Failures at the old IP aren't attributed to the peer anymore
+	assert.NoError(t, table.Failed(ip0))
+	known := table.Known()
+	if assert.Len(t, known, 1) {
+		assert.Equal(t, ip1, known[0].IP)
+		assert.Equal(t, uint32(2), known[0].NumSuccesses)
+		assert.Zero(t, known[0].NumFailures)
+	}
+}
+
+func TestKnownPeerBackoff(t *testing.T) {
+	p := knownPeer{}
+	assert.Zero(t, p.backoff(time.Second, time.Minute))
+
+	p.NumFailures = 1
+	assert.Equal(t, time.Second, p.backoff(time.Second, time.Minute))
+
+	p.NumFailures = 4
+	assert.Equal(t, 8*time.Second, p.backoff(time.Second, time.Minute))
+
+	p.NumFailures = 100
+	assert.Equal(t, time.Minute, p.backoff(time.Second, time.Minute))
+}
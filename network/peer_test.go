@@ -0,0 +1,297 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/logging"
+)
+
+func TestPeerRoundTripTime(t *testing.T) {
+	n := &network{
+		log:                                logging.NoLog{},
+		maxMessageSize:                     int64(DefaultMaxMessageSize),
+		maxNetworkPendingSendBytes:         defaultMaxNetworkPendingSendBytes,
+		networkPendingSendBytesToRateLimit: defaultNetworkPendingSendBytesToRateLimit,
+	}
+	err := n.initialize(prometheus.NewRegistry())
+	assert.NoError(t, err)
+	p := &peer{
+		net:    n,
+		sender: make(chan []byte, 10),
+	}
+	p.acceptsOptionalFields.SetValue(true)
+
+	startTime := time.Unix(1000, 0)
+	n.clock.Set(startTime)
+
+	lastRTT, latency, pingsLost := p.pingStats()
+	assert.Zero(t, lastRTT)
+	assert.Zero(t, latency)
+	assert.Zero(t, pingsLost)
+
+	p.Ping()
+	pong, err := n.b.PongWithNonce(p.pingNonce)
+	assert.NoError(t, err)
+
+	n.clock.Set(startTime.Add(100 * time.Millisecond))
+	p.pong(pong)
+
+	lastRTT, latency, pingsLost = p.pingStats()
+	assert.Equal(t, 100*time.Millisecond, lastRTT)
+	assert.Equal(t, 100*time.Millisecond, latency)
+	assert.Zero(t, pingsLost)
+
+	// A pong answering an old ping must be ignored
+	n.clock.Set(startTime.Add(time.Second))
+	p.Ping()
+	stalePong, err := n.b.PongWithNonce(p.pingNonce - 1)
+	assert.NoError(t, err)
+	p.pong(stalePong)
+
+	lastRTT, _, _ = p.pingStats()
+	assert.Equal(t, 100*time.Millisecond, lastRTT)
+
+	// The previous ping wasn't answered before the next one was sent
+	n.clock.Set(startTime.Add(2 * time.Second))
+	p.Ping()
+	pong, err = n.b.PongWithNonce(p.pingNonce)
+	assert.NoError(t, err)
+
+	n.clock.Set(startTime.Add(2*time.Second + 300*time.Millisecond))
+	p.pong(pong)
+
+	lastRTT, latency, pingsLost = p.pingStats()
+	assert.Equal(t, 300*time.Millisecond, lastRTT)
+	assert.Greater(t, int64(latency), int64(100*time.Millisecond))
+	assert.Less(t, int64(latency), int64(300*time.Millisecond))
+	assert.Equal(t, uint64(1), pingsLost)
+
+	assert.Equal(t, uint64(3), p.numSent)
+	assert.Zero(t, p.numDropped)
+	assert.Equal(t, float64(1), testutil.ToFloat64(n.pingsLost))
+}
+
+func TestPeerRoundTripTimeWithoutNonce(t *testing.T) {
+	n := &network{
+		log:                                logging.NoLog{},
+		maxMessageSize:                     int64(DefaultMaxMessageSize),
+		maxNetworkPendingSendBytes:         defaultMaxNetworkPendingSendBytes,
+		networkPendingSendBytesToRateLimit: defaultNetworkPendingSendBytesToRateLimit,
+	}
+	err := n.initialize(prometheus.NewRegistry())
+	assert.NoError(t, err)
+	p := &peer{
+		net:    n,
+		sender: make(chan []byte, 10),
+	}
+
+	startTime := time.Unix(1000, 0)
+	n.clock.Set(startTime)
+
+	// Older peers are sent pings without nonces
+	p.Ping()
+	ping, err := n.b.Parse(<-p.sender)
+	assert.NoError(t, err)
+	assert.Nil(t, ping.Get(Nonce))
+
+	pong, err := n.b.Pong()
+	assert.NoError(t, err)
+
+	n.clock.Set(startTime.Add(100 * time.Millisecond))
+	p.pong(pong)
+
+	lastRTT, latency, pingsLost := p.pingStats()
+	assert.Equal(t, 100*time.Millisecond, lastRTT)
+	assert.Equal(t, 100*time.Millisecond, latency)
+	assert.Zero(t, pingsLost)
+
+	// A second pong doesn't answer any ping
+	n.clock.Set(startTime.Add(time.Second))
+	p.pong(pong)
+
+	lastRTT, _, _ = p.pingStats()
+	assert.Equal(t, 100*time.Millisecond, lastRTT)
+}
+
+func TestPeerSendLanes(t *testing.T) {
+	n := &network{
+		log:                                logging.NoLog{},
+		maxMessageSize:                     int64(DefaultMaxMessageSize),
+		maxNetworkPendingSendBytes:         defaultMaxNetworkPendingSendBytes,
+		networkPendingSendBytesToRateLimit: defaultNetworkPendingSendBytesToRateLimit,
+	}
+	err := n.initialize(prometheus.NewRegistry())
+	assert.NoError(t, err)
+	p := &peer{
+		net:        n,
+		sender:     make(chan []byte, 2*maxConsecutiveMsgs),
+		bulkSender: make(chan []byte, 2),
+	}
+
+	bulkMsg, err := n.b.PeerList(nil)
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		assert.True(t, p.Send(bulkMsg))
+	}
+
+	chitsMsg, err := n.b.Chits(ids.Empty, 0, nil)
+	assert.NoError(t, err)
+	for i := 0; i < 2*maxConsecutiveMsgs; i++ {
+		assert.True(t, p.Send(chitsMsg))
+	}
+
+	// The bulk lane is full, but consensus messages can still be queued
+	assert.False(t, p.Send(bulkMsg))
+	assert.Equal(t, uint64(1), p.numDropped)
+
+	// Consensus messages are preferred, but bulk messages aren't starved
+	for lane := 0; lane < 2; lane++ {
+		for i := 0; i < maxConsecutiveMsgs; i++ {
+			msg, ok := p.nextMessage()
+			assert.True(t, ok)
+			assert.Equal(t, chitsMsg.Bytes(), msg)
+		}
+		msg, ok := p.nextMessage()
+		assert.True(t, ok)
+		assert.Equal(t, bulkMsg.Bytes(), msg)
+	}
+}
+
+func TestPeerListSendsCertsOnce(t *testing.T) {
+	key, cert := newTestStakingKey(t)
+	n := &network{
+		log:                                logging.NoLog{},
+		maxMessageSize:                     int64(DefaultMaxMessageSize),
+		maxNetworkPendingSendBytes:         defaultMaxNetworkPendingSendBytes,
+		networkPendingSendBytesToRateLimit: defaultNetworkPendingSendBytesToRateLimit,
+		ipSigner:                           key,
+	}
+	err := n.initialize(prometheus.NewRegistry())
+	assert.NoError(t, err)
+	p := &peer{
+		net:        n,
+		id:         ids.NewShortID([20]byte{1}),
+		sender:     make(chan []byte, 10),
+		bulkSender: make(chan []byte, 10),
+	}
+	p.acceptsOptionalFields.SetValue(true)
+
+	signedIP := newTestSignedIP(t, key, cert, utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}, 10)
+	unsignedIP := SignedIP{
+		NodeID: ids.NewShortID([20]byte{2}),
+		IP:     utils.IPDesc{IP: net.IPv4(5, 6, 7, 8), Port: 9651},
+	}
+	ips := []SignedIP{signedIP, unsignedIP}
+
+	p.PeerList(ips)
+	p.PeerList(ips)
+
+	msg, err := n.b.Parse(<-p.bulkSender)
+	assert.NoError(t, err)
+	assert.Equal(t, []SignedIP{signedIP}, msg.Get(SignedPeers), "the unsigned IP can't be verified by the peer")
+
+	signedIP.Cert = []byte{}
+	msg, err = n.b.Parse(<-p.bulkSender)
+	assert.NoError(t, err)
+	assert.Equal(t, []SignedIP{signedIP}, msg.Get(SignedPeers), "the certificate was sent twice")
+}
+
+func TestPeerListToOlderPeer(t *testing.T) {
+	key, cert := newTestStakingKey(t)
+	n := &network{
+		log:                                logging.NoLog{},
+		maxMessageSize:                     int64(DefaultMaxMessageSize),
+		maxNetworkPendingSendBytes:         defaultMaxNetworkPendingSendBytes,
+		networkPendingSendBytesToRateLimit: defaultNetworkPendingSendBytesToRateLimit,
+		ipSigner:                           key,
+	}
+	err := n.initialize(prometheus.NewRegistry())
+	assert.NoError(t, err)
+	p := &peer{
+		net:        n,
+		id:         ids.NewShortID([20]byte{1}),
+		sender:     make(chan []byte, 10),
+		bulkSender: make(chan []byte, 10),
+	}
+
+	signedIP := newTestSignedIP(t, key, cert, utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}, 10)
+	unsignedIP := SignedIP{
+		NodeID: ids.NewShortID([20]byte{2}),
+		IP:     utils.IPDesc{IP: net.IPv4(5, 6, 7, 8), Port: 9651},
+	}
+	p.PeerList([]SignedIP{signedIP, unsignedIP})
+
+	expectedMsg, err := n.b.PeerList([]utils.IPDesc{signedIP.IP, unsignedIP.IP})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedMsg.Bytes(), <-p.bulkSender)
+}
+
+// blockingMuxConn blocks writes of [blocked] until [release] is closed
+type blockingMuxConn struct {
+	net.Conn
+	blocked Class
+	release chan struct{}
+	written chan []byte
+}
+
+func (c *blockingMuxConn) WriteMessage(class Class, msg []byte) error {
+	if class == c.blocked {
+		<-c.release
+	}
+	c.written <- msg
+	return nil
+}
+
+func TestPeerWritesClassesIndependently(t *testing.T) {
+	n := &network{
+		log:                                logging.NoLog{},
+		maxMessageSize:                     int64(DefaultMaxMessageSize),
+		maxNetworkPendingSendBytes:         defaultMaxNetworkPendingSendBytes,
+		networkPendingSendBytesToRateLimit: defaultNetworkPendingSendBytesToRateLimit,
+	}
+	err := n.initialize(prometheus.NewRegistry())
+	assert.NoError(t, err)
+	conn := &blockingMuxConn{
+		blocked: GossipClass,
+		release: make(chan struct{}),
+		written: make(chan []byte, 2),
+	}
+	p := &peer{
+		net:  n,
+		conn: conn,
+	}
+	for i := range p.classSenders {
+		p.classSenders[i] = make(chan []byte, 1)
+	}
+	// Don't start the ping routines
+	p.tickerOnce.Do(func() {})
+
+	// Gossip and consensus messages share a lane on connections that aren't
+	// multiplexed, but are written by separate routines here
+	peerListMsg, err := n.b.PeerList(nil)
+	assert.NoError(t, err)
+	assert.True(t, p.Send(peerListMsg))
+	getPeerListMsg, err := n.b.GetPeerList()
+	assert.NoError(t, err)
+	assert.False(t, p.Send(getPeerListMsg), "the gossip queue should be full")
+	chitsMsg, err := n.b.Chits(ids.Empty, 0, nil)
+	assert.NoError(t, err)
+	assert.True(t, p.Send(chitsMsg))
+
+	go p.writeClasses(conn)
+
+	assert.Equal(t, chitsMsg.Bytes(), <-conn.written, "the consensus message should be written while the gossip is blocked")
+	close(conn.release)
+	assert.Equal(t, peerListMsg.Bytes(), <-conn.written)
+}
@@ -0,0 +1,369 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils/hashing"
+	"github.com/liraxapp/avalanchego/utils/timer"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+const (
+	// extension of the files messages are captured to
+	captureFileExt = ".capture"
+	// length of a record before its message bytes
+	recordHeaderLen = wrappers.LongLen + wrappers.ByteLen + hashing.AddrLen + wrappers.IntLen
+	// number of records waiting to be written before new records are dropped
+	recordQueueSize = 1024
+)
+
+var errBadDirection = errors.New("record has an invalid direction")
+
+// Direction a captured message was sent in
+type Direction byte
+
+// Directions a message may be captured in
+const (
+	Inbound Direction = iota
+	Outbound
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Inbound:
+		return "inbound"
+	case Outbound:
+		return "outbound"
+	default:
+		return "unknown direction"
+	}
+}
+
+// RecorderConfig configures the capture of the messages exchanged with peers
+type RecorderConfig struct {
+	// Directory the capture files are written to. If empty, messages aren't
+	// captured.
+	Dir string `json:"dir"`
+	// A new file is started once the current one exceeds this many bytes
+	MaxFileSize int `json:"maxFileSize"`
+	// Number of files kept. The oldest file is deleted when a new one is
+	// started. If 0, files are never deleted.
+	MaxFiles int `json:"maxFiles"`
+	// If non-empty, only messages about these chains are captured. Messages
+	// that aren't about a chain, such as handshake messages, are dropped.
+	ChainIDs []ids.ID `json:"chainIDs"`
+	// If non-empty, only messages with these ops are captured
+	Ops []Op `json:"ops"`
+}
+
+// Record is a message captured from, or to, a peer
+type Record struct {
+	Time      time.Time
+	Direction Direction
+	NodeID    ids.ShortID
+	// Uncompressed bytes of the message
+	Msg []byte
+}
+
+// recorder writes the messages exchanged with peers to rotating capture files.
+// Records are written by a separate routine, so that capturing never delays
+// the peers. A nil recorder is valid and doesn't capture anything.
+type recorder struct {
+	config   RecorderConfig
+	clock    *timer.Clock
+	chainIDs ids.Set
+	ops      map[Op]struct{}
+
+	// queueLock ensures that records aren't queued after [queue] is closed
+	queueLock sync.RWMutex
+	closed    bool
+	// records waiting to be written. If it is full, new records are dropped.
+	queue chan []byte
+	// closed once the writer routine wrote all the queued records
+	done chan struct{}
+
+	// The following fields are only accessed by the writer routine, or after
+	// it returned.
+	file   *os.File
+	writer *bufio.Writer
+	// number of bytes written to [file]
+	size int
+	// index of [file]. Indices increase with each new file.
+	index int
+	// set if writing to the capture files failed. No more messages are
+	// captured afterwards.
+	err error
+}
+
+// newRecorder returns a recorder writing to [config.Dir]. Returns nil if
+// messages shouldn't be captured, or if the capture files couldn't be opened.
+func newRecorder(config RecorderConfig, clock *timer.Clock) (*recorder, error) {
+	if config.Dir == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(config.Dir, 0700); err != nil {
+		return nil, err
+	}
+
+	files, err := CaptureFiles(config.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &recorder{
+		config:   config,
+		clock:    clock,
+		chainIDs: ids.Set{},
+		ops:      make(map[Op]struct{}, len(config.Ops)),
+		queue:    make(chan []byte, recordQueueSize),
+		done:     make(chan struct{}),
+	}
+	r.chainIDs.Add(config.ChainIDs...)
+	for _, op := range config.Ops {
+		r.ops[op] = struct{}{}
+	}
+	if len(files) > 0 {
+		// Don't overwrite the capture from before the restart
+		lastIndex, err := captureFileIndex(files[len(files)-1])
+		if err != nil {
+			return nil, err
+		}
+		r.index = lastIndex + 1
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	go r.writeRecords()
+	return r, nil
+}
+
+// Record [msg] if it passes the filters
+func (r *recorder) Record(direction Direction, nodeID ids.ShortID, msg Msg) {
+	if r == nil || !r.captures(msg) {
+		return
+	}
+
+	msgBytes := msg.Bytes()
+	p := wrappers.Packer{Bytes: make([]byte, recordHeaderLen+len(msgBytes))}
+	p.PackLong(uint64(r.clock.Time().UnixNano()))
+	p.PackByte(byte(direction))
+	p.PackFixedBytes(nodeID.Bytes())
+	p.PackBytes(msgBytes)
+
+	r.queueLock.RLock()
+	defer r.queueLock.RUnlock()
+
+	if r.closed {
+		return
+	}
+	select {
+	case r.queue <- p.Bytes:
+	default:
+		// The writer routine is falling behind. Dropping the record is
+		// preferred to delaying the peer.
+	}
+}
+
+// writeRecords writes the queued records until the recorder is closed. The
+// buffered records are flushed whenever the queue is empty.
+func (r *recorder) writeRecords() {
+	defer close(r.done)
+
+	for recordBytes := range r.queue {
+		if r.err != nil {
+			continue
+		}
+		r.err = r.write(recordBytes)
+		if r.err == nil && len(r.queue) == 0 {
+			r.err = r.writer.Flush()
+		}
+	}
+}
+
+// captures returns true if [msg] passes the filters
+func (r *recorder) captures(msg Msg) bool {
+	if len(r.ops) > 0 {
+		if _, ok := r.ops[msg.Op()]; !ok {
+			return false
+		}
+	}
+	if r.chainIDs.Len() > 0 {
+		chainIDBytes, ok := msg.Get(ChainID).([]byte)
+		if !ok {
+			return false
+		}
+		chainID, err := ids.ToID(chainIDBytes)
+		if err != nil || !r.chainIDs.Contains(chainID) {
+			return false
+		}
+	}
+	return true
+}
+
+// assumes only the writer routine calls this
+func (r *recorder) write(recordBytes []byte) error {
+	if r.config.MaxFileSize > 0 && r.size > 0 && r.size+len(recordBytes) > r.config.MaxFileSize {
+		if err := r.rotate(); err != nil {
+			return err
+		}
+	}
+	written, err := r.writer.Write(recordBytes)
+	r.size += written
+	return err
+}
+
+// assumes only the writer routine calls this
+func (r *recorder) rotate() error {
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+	r.index++
+	if r.config.MaxFiles > 0 {
+		if staleIndex := r.index - r.config.MaxFiles; staleIndex >= 0 {
+			err := os.Remove(r.fileName(staleIndex))
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return r.open()
+}
+
+// assumes only the writer routine calls this, or that it isn't running
+func (r *recorder) open() error {
+	file, err := os.OpenFile(r.fileName(r.index), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	r.file = file
+	r.writer = bufio.NewWriter(file)
+	r.size = 0
+	return nil
+}
+
+// closeFile flushes and closes the current capture file, if it is open
+func (r *recorder) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	errs := wrappers.Errs{}
+	errs.Add(
+		r.writer.Flush(),
+		r.file.Close(),
+	)
+	r.file = nil
+	r.writer = nil
+	return errs.Err
+}
+
+func (r *recorder) fileName(index int) string {
+	return filepath.Join(r.config.Dir, fmt.Sprintf("%d%s", index, captureFileExt))
+}
+
+// Close writes the queued records and closes the current capture file
+func (r *recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+
+	r.queueLock.Lock()
+	if r.closed {
+		r.queueLock.Unlock()
+		return nil
+	}
+	r.closed = true
+	close(r.queue)
+	r.queueLock.Unlock()
+
+	<-r.done
+	return r.closeFile()
+}
+
+// CaptureFiles returns the capture files in [dir], oldest first
+func CaptureFiles(dir string) ([]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string(nil)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != captureFileExt {
+			continue
+		}
+		if _, err := captureFileIndex(entry.Name()); err != nil {
+			continue
+		}
+		files = append(files, filepath.Join(dir, entry.Name()))
+	}
+	sort.Slice(files, func(i, j int) bool {
+		iIndex, _ := captureFileIndex(files[i])
+		jIndex, _ := captureFileIndex(files[j])
+		return iIndex < jIndex
+	})
+	return files, nil
+}
+
+func captureFileIndex(file string) (int, error) {
+	return strconv.Atoi(strings.TrimSuffix(filepath.Base(file), captureFileExt))
+}
+
+// CaptureReader reads the records of a capture file
+type CaptureReader struct {
+	r *bufio.Reader
+}
+
+// NewCaptureReader returns a reader of the records in [r]
+func NewCaptureReader(r io.Reader) *CaptureReader {
+	return &CaptureReader{r: bufio.NewReader(r)}
+}
+
+// Next returns the next record. Returns io.EOF once all the records were read.
+func (c *CaptureReader) Next() (Record, error) {
+	header := make([]byte, recordHeaderLen)
+	if _, err := io.ReadFull(c.r, header); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			// The node may have stopped while writing the record
+			return Record{}, io.EOF
+		}
+		return Record{}, err
+	}
+
+	p := wrappers.Packer{Bytes: header}
+	unixNano := p.UnpackLong()
+	direction := Direction(p.UnpackByte())
+	nodeID, _ := ids.ToShortID(p.UnpackFixedBytes(hashing.AddrLen))
+	msgLen := p.UnpackInt()
+	if direction != Inbound && direction != Outbound {
+		return Record{}, errBadDirection
+	}
+
+	msgBytes := make([]byte, msgLen)
+	if _, err := io.ReadFull(c.r, msgBytes); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return Record{}, io.EOF
+		}
+		return Record{}, err
+	}
+	return Record{
+		Time:      time.Unix(0, int64(unixNano)),
+		Direction: direction,
+		NodeID:    nodeID,
+		Msg:       msgBytes,
+	}, nil
+}
@@ -0,0 +1,257 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow"
+	"github.com/liraxapp/avalanchego/snow/engine/common"
+	"github.com/liraxapp/avalanchego/snow/networking/benchlist"
+	"github.com/liraxapp/avalanchego/snow/networking/router"
+	"github.com/liraxapp/avalanchego/snow/networking/timeout"
+	"github.com/liraxapp/avalanchego/snow/validators"
+	"github.com/liraxapp/avalanchego/utils/logging"
+	"github.com/liraxapp/avalanchego/utils/timer"
+)
+
+func readCapture(t *testing.T, files ...string) []Record {
+	records := []Record(nil)
+	for _, file := range files {
+		f, err := os.Open(file)
+		assert.NoError(t, err)
+
+		capture := NewCaptureReader(f)
+		for {
+			record, err := capture.Next()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+			records = append(records, record)
+		}
+		assert.NoError(t, f.Close())
+	}
+	return records
+}
+
+func TestRecorderFilters(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	chainID0 := ids.ID{1}
+	chainID1 := ids.ID{2}
+	nodeID := ids.NewShortID([20]byte{1})
+	b := Builder{}
+
+	clock := timer.Clock{}
+	clock.Set(time.Unix(1000, 0))
+	r, err := newRecorder(RecorderConfig{
+		Dir:      dir,
+		ChainIDs: []ids.ID{chainID0},
+		Ops:      []Op{Put, PushQuery},
+	}, &clock)
+	assert.NoError(t, err)
+
+	put0, err := b.Put(chainID0, 1, ids.ID{3}, []byte{4})
+	assert.NoError(t, err)
+	put1, err := b.Put(chainID1, 1, ids.ID{3}, []byte{4})
+	assert.NoError(t, err)
+	get0, err := b.Get(chainID0, 1, 0, ids.ID{3})
+	assert.NoError(t, err)
+	ping, err := b.PingWithNonce(1)
+	assert.NoError(t, err)
+	pushQuery0, err := b.PushQuery(chainID0, 2, 0, ids.ID{3}, []byte{4})
+	assert.NoError(t, err)
+
+	r.Record(Inbound, nodeID, put0)
+	r.Record(Inbound, nodeID, put1)
+	r.Record(Outbound, nodeID, get0)
+	r.Record(Outbound, nodeID, ping)
+	r.Record(Outbound, nodeID, pushQuery0)
+	assert.NoError(t, r.Close())
+
+	files, err := CaptureFiles(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "0.capture")}, files)
+
+	assert.Equal(t, []Record{
+		{
+			Time:      time.Unix(1000, 0),
+			Direction: Inbound,
+			NodeID:    nodeID,
+			Msg:       put0.Bytes(),
+		},
+		{
+			Time:      time.Unix(1000, 0),
+			Direction: Outbound,
+			NodeID:    nodeID,
+			Msg:       pushQuery0.Bytes(),
+		},
+	}, readCapture(t, files...))
+}
+
+func TestRecorderRotates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	nodeID := ids.NewShortID([20]byte{1})
+	b := Builder{}
+	msgs := make([]Msg, 5)
+	for i := range msgs {
+		msgs[i], err = b.PingWithNonce(uint32(i))
+		assert.NoError(t, err)
+	}
+	recordLen := recordHeaderLen + len(msgs[0].Bytes())
+
+	config := RecorderConfig{
+		Dir:         dir,
+		MaxFileSize: 2 * recordLen,
+		MaxFiles:    2,
+	}
+	r, err := newRecorder(config, &timer.Clock{})
+	assert.NoError(t, err)
+	for _, msg := range msgs {
+		r.Record(Inbound, nodeID, msg)
+	}
+	assert.NoError(t, r.Close())
+
+	// The first file was deleted when the third one was started
+	files, err := CaptureFiles(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "1.capture"),
+		filepath.Join(dir, "2.capture"),
+	}, files)
+
+	records := readCapture(t, files...)
+	if assert.Len(t, records, 3) {
+		for i, record := range records {
+			assert.Equal(t, msgs[i+2].Bytes(), record.Msg)
+		}
+	}
+
+	// A restarted node starts a new file rather than overwriting the capture
+	r, err = newRecorder(config, &timer.Clock{})
+	assert.NoError(t, err)
+	r.Record(Inbound, nodeID, msgs[0])
+	assert.NoError(t, r.Close())
+
+	files, err = CaptureFiles(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "3.capture"), files[len(files)-1])
+}
+
+func TestReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := snow.DefaultContextTest()
+	nodeID := ids.NewShortID([20]byte{1})
+	containerID := ids.ID{3}
+	container := []byte{4}
+	b := Builder{}
+
+	r, err := newRecorder(RecorderConfig{Dir: dir}, &timer.Clock{})
+	assert.NoError(t, err)
+	pushQuery, err := b.PushQuery(ctx.ChainID, 1, uint64(time.Minute), containerID, container)
+	assert.NoError(t, err)
+	chits, err := b.Chits(ctx.ChainID, 2, []ids.ID{containerID})
+	assert.NoError(t, err)
+	ping, err := b.PingWithNonce(1)
+	assert.NoError(t, err)
+	r.Record(Inbound, nodeID, ping)
+	r.Record(Inbound, nodeID, pushQuery)
+	r.Record(Outbound, nodeID, chits)
+	assert.NoError(t, r.Close())
+
+	tm := timeout.Manager{}
+	err = tm.Initialize(&timer.AdaptiveTimeoutConfig{
+		InitialTimeout: time.Millisecond,
+		MinimumTimeout: time.Millisecond,
+		MaximumTimeout: 10 * time.Second,
+		TimeoutInc:     2 * time.Millisecond,
+		TimeoutDec:     time.Millisecond,
+		Namespace:      "",
+		Registerer:     prometheus.NewRegistry(),
+	}, benchlist.NewNoBenchlist())
+	assert.NoError(t, err)
+	go tm.Dispatch()
+
+	chainRouter := &router.ChainRouter{}
+	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil)
+
+	engine := common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.Context { return ctx }
+
+	received := make(chan ids.ID, 1)
+	engine.PushQueryF = func(validatorID ids.ShortID, requestID uint32, containerID ids.ID, container []byte) error {
+		assert.Equal(t, nodeID, validatorID)
+		assert.Equal(t, uint32(1), requestID)
+		received <- containerID
+		return nil
+	}
+
+	vdrs := validators.NewSet()
+	err = vdrs.AddWeight(nodeID, 1)
+	assert.NoError(t, err)
+
+	handler := &router.Handler{}
+	handler.Initialize(
+		&engine,
+		vdrs,
+		nil,
+		16,
+		router.DefaultMaxNonStakerPendingMsgs,
+		router.DefaultStakerPortion,
+		router.DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+	go handler.Dispatch()
+	chainRouter.AddChain(handler)
+	defer chainRouter.Shutdown()
+
+	files, err := CaptureFiles(dir)
+	assert.NoError(t, err)
+
+	replayer := NewReplayer(logging.NoLog{}, chainRouter)
+	numReplayed, err := replayer.ReplayFiles(files...)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, numReplayed, "only the inbound consensus message should be replayed")
+
+	select {
+	case replayedID := <-received:
+		assert.Equal(t, containerID, replayedID)
+	case <-time.After(time.Second):
+		t.Fatal("replayed message never reached the engine")
+	}
+}
+
+func TestRecorderOpenFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "capture")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// The capture directory can't be created under a regular file
+	file := filepath.Join(dir, "file")
+	assert.NoError(t, ioutil.WriteFile(file, nil, 0600))
+
+	r, err := newRecorder(RecorderConfig{Dir: filepath.Join(file, "capture")}, &timer.Clock{})
+	assert.Error(t, err)
+	assert.Nil(t, r)
+}
@@ -0,0 +1,95 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"io"
+	"os"
+
+	"github.com/liraxapp/avalanchego/snow/networking/router"
+	"github.com/liraxapp/avalanchego/utils/logging"
+)
+
+// Replayer feeds captured messages back into a router, so that the behaviour
+// of the engines can be reproduced offline. Messages are replayed as fast as
+// the router accepts them. Deadlines are relative to the time a message is
+// replayed.
+type Replayer struct {
+	// used to route messages as if they were received from a peer
+	net *network
+}
+
+// NewReplayer returns a replayer that sends messages to [router]
+func NewReplayer(log logging.Logger, router router.Router) *Replayer {
+	return &Replayer{
+		net: &network{
+			log:    log,
+			router: router,
+		},
+	}
+}
+
+// ReplayFiles replays the capture files [files], in order. Returns the number
+// of messages replayed.
+func (r *Replayer) ReplayFiles(files ...string) (int, error) {
+	numReplayed := 0
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return numReplayed, err
+		}
+		n, err := r.Replay(f)
+		numReplayed += n
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return numReplayed, err
+		}
+	}
+	return numReplayed, nil
+}
+
+// Replay the capture read from [reader]. Only inbound messages about a chain
+// are replayed. Outbound messages were produced by the engines, and handshake
+// messages don't reach the router. Returns the number of messages replayed.
+func (r *Replayer) Replay(reader io.Reader) (int, error) {
+	capture := NewCaptureReader(reader)
+	numReplayed := 0
+	for {
+		record, err := capture.Next()
+		if err == io.EOF {
+			return numReplayed, nil
+		}
+		if err != nil {
+			return numReplayed, err
+		}
+		if r.ReplayRecord(record) {
+			numReplayed++
+		}
+	}
+}
+
+// ReplayRecord sends the message in [record] to the router. Returns false if
+// the message wasn't replayed.
+func (r *Replayer) ReplayRecord(record Record) bool {
+	if record.Direction != Inbound {
+		return false
+	}
+	msg, err := r.net.b.Parse(record.Msg)
+	if err != nil {
+		r.net.log.Debug("failed to parse captured message from %s: %s", record.NodeID, err)
+		return false
+	}
+	if _, ok := msg.Get(ChainID).([]byte); !ok {
+		return false
+	}
+
+	p := &peer{
+		net: r.net,
+		id:  record.NodeID,
+	}
+	p.route(msg)
+	return true
+}
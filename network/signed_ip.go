@@ -0,0 +1,111 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"net"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/hashing"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+// length of the bytes that are signed to announce an IP
+const signedIPBytesLen = net.IPv6len + wrappers.ShortLen + wrappers.LongLen
+
+var (
+	errUnsupportedKeyAlgorithm = errors.New("certificate has an unsupported public key algorithm")
+	errWrongFieldType          = errors.New("wrong type passed")
+)
+
+// SignedIP is an IP that a node claims to be reachable at. The claim is signed
+// with the node's staking key so that any node can verify that the IP was
+// announced by the owner of the staking certificate, rather than by the peer
+// that relayed it.
+type SignedIP struct {
+	// ID of the node that announced the IP
+	NodeID ids.ShortID
+	// Raw staking certificate of the node that announced the IP. Empty in
+	// Version messages, where the certificate is known from the TLS handshake,
+	// and in PeerList messages to peers that were already sent the
+	// certificate.
+	Cert []byte
+	IP   utils.IPDesc
+	// Unix time the IP was signed at. Only the newest announcement of a node
+	// is kept.
+	Time      uint64
+	Signature []byte
+}
+
+// Verify returns nil if [Signature] is a valid signature of [IP] and [Time] by
+// the key of [cert].
+func (ip *SignedIP) Verify(cert *x509.Certificate) error {
+	var algorithm x509.SignatureAlgorithm
+	switch cert.PublicKeyAlgorithm {
+	case x509.RSA:
+		algorithm = x509.SHA256WithRSA
+	case x509.ECDSA:
+		algorithm = x509.ECDSAWithSHA256
+	default:
+		return errUnsupportedKeyAlgorithm
+	}
+	return cert.CheckSignature(algorithm, signedIPBytes(ip.IP, ip.Time), ip.Signature)
+}
+
+// signIP returns the signature of [ip] at [time] by [signer]
+func signIP(signer crypto.Signer, ip utils.IPDesc, time uint64) ([]byte, error) {
+	hash := hashing.ComputeHash256(signedIPBytes(ip, time))
+	return signer.Sign(rand.Reader, hash, crypto.SHA256)
+}
+
+func signedIPBytes(ip utils.IPDesc, time uint64) []byte {
+	p := wrappers.Packer{Bytes: make([]byte, signedIPBytesLen)}
+	p.PackIP(ip)
+	p.PackLong(time)
+	return p.Bytes
+}
+
+// tryPackSignedIPs attempts to pack the value as a signed ip list
+func tryPackSignedIPs(p *wrappers.Packer, valIntf interface{}) {
+	ips, ok := valIntf.([]SignedIP)
+	if !ok {
+		p.Add(errWrongFieldType)
+		return
+	}
+
+	p.PackInt(uint32(len(ips)))
+	for i := 0; i < len(ips) && !p.Errored(); i++ {
+		nodeID := ips[i].NodeID
+		if nodeID.IsZero() {
+			nodeID = ids.ShortEmpty
+		}
+		p.PackFixedBytes(nodeID.Bytes())
+		p.PackBytes(ips[i].Cert)
+		p.PackIP(ips[i].IP)
+		p.PackLong(ips[i].Time)
+		p.PackBytes(ips[i].Signature)
+	}
+}
+
+// tryUnpackSignedIPs attempts to unpack the value as a signed ip list
+func tryUnpackSignedIPs(p *wrappers.Packer) interface{} {
+	sliceSize := p.UnpackInt()
+	ips := []SignedIP(nil)
+	for i := uint32(0); i < sliceSize && !p.Errored(); i++ {
+		nodeID, _ := ids.ToShortID(p.UnpackFixedBytes(hashing.AddrLen))
+		ips = append(ips, SignedIP{
+			NodeID:    nodeID,
+			Cert:      p.UnpackBytes(),
+			IP:        p.UnpackIP(),
+			Time:      p.UnpackLong(),
+			Signature: p.UnpackBytes(),
+		})
+	}
+	return ips
+}
@@ -0,0 +1,236 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liraxapp/avalanchego/snow/validators"
+	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/logging"
+	"github.com/liraxapp/avalanchego/version"
+)
+
+func newTestStakingKey(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	certTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(0),
+		NotBefore:             time.Date(2000, time.January, 0, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Now().AddDate(100, 0, 0),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageDataEncipherment,
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, certTemplate, certTemplate, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	assert.NoError(t, err)
+	return key, cert
+}
+
+func newTestSignedIP(t *testing.T, key *rsa.PrivateKey, cert *x509.Certificate, ip utils.IPDesc, time uint64) SignedIP {
+	signature, err := signIP(key, ip, time)
+	assert.NoError(t, err)
+	return SignedIP{
+		NodeID:    certToID(cert.Raw),
+		Cert:      cert.Raw,
+		IP:        ip,
+		Time:      time,
+		Signature: signature,
+	}
+}
+
+func TestSignedIPVerify(t *testing.T) {
+	key, cert := newTestStakingKey(t)
+	_, otherCert := newTestStakingKey(t)
+
+	ip := utils.IPDesc{
+		IP:   net.IPv4(1, 2, 3, 4),
+		Port: 9651,
+	}
+	signedIP := newTestSignedIP(t, key, cert, ip, 10)
+	assert.NoError(t, signedIP.Verify(cert))
+	assert.Error(t, signedIP.Verify(otherCert), "signature verified with the wrong certificate")
+
+	movedIP := signedIP
+	movedIP.IP.Port++
+	assert.Error(t, movedIP.Verify(cert), "signature verified for a different IP")
+
+	replayedIP := signedIP
+	replayedIP.Time++
+	assert.Error(t, replayedIP.Verify(cert), "signature verified for a different time")
+}
+
+func TestTrackSignedIP(t *testing.T) {
+	log := logging.NoLog{}
+	ip := utils.NewDynamicIPDesc(
+		net.IPv6loopback,
+		0,
+	)
+	myKey, myCert := newTestStakingKey(t)
+	peerKey, peerCert := newTestStakingKey(t)
+	nonValidatorKey, nonValidatorCert := newTestStakingKey(t)
+	otherKey, otherCert := newTestStakingKey(t)
+	peerID := certToID(peerCert.Raw)
+
+	vdrs := validators.NewSet()
+	err := vdrs.AddWeight(peerID, 1)
+	assert.NoError(t, err)
+	err = vdrs.AddWeight(certToID(otherCert.Raw), 1)
+	assert.NoError(t, err)
+
+	listener := &testListener{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		inbound: make(chan net.Conn, 1<<10),
+		closed:  make(chan struct{}),
+	}
+	caller := &testDialer{
+		addr: &net.TCPAddr{
+			IP:   net.IPv6loopback,
+			Port: 0,
+		},
+		outbounds: make(map[string]*testListener),
+	}
+
+	netIntf := NewDefaultNetwork(
+		prometheus.NewRegistry(),
+		log,
+		certToID(myCert.Raw),
+		ip,
+		0,
+		version.NewDefaultVersion("app", 0, 1, 0),
+		version.NewDefaultParser(),
+		listener,
+		caller,
+		NewIPUpgrader(),
+		NewIPUpgrader(),
+		vdrs,
+		vdrs,
+		&testHandler{},
+		time.Duration(0),
+		0,
+		nil,
+		false,
+		0,
+		0,
+		time.Now(),
+		nil,
+		myKey,
+		BandwidthConfig{},
+		false,
+		nil,
+		RecorderConfig{},
+		DefaultMaxMessageSize,
+	)
+	n := netIntf.(*network)
+
+	ip0 := utils.IPDesc{IP: net.IPv4(1, 2, 3, 4), Port: 9651}
+	ip1 := utils.IPDesc{IP: net.IPv4(5, 6, 7, 8), Port: 9651}
+	now := n.clock.Unix()
+
+	isTracked := func(ip utils.IPDesc) bool {
+		n.stateLock.RLock()
+		defer n.stateLock.RUnlock()
+
+		_, ok := n.disconnectedIPs[ip.String()]
+		return ok
+	}
+	latestIP := func() SignedIP {
+		n.stateLock.RLock()
+		defer n.stateLock.RUnlock()
+
+		return n.latestIPs[peerID.Key()]
+	}
+
+	signedIP0 := newTestSignedIP(t, peerKey, peerCert, ip0, now-10)
+	n.trackSignedIP(signedIP0)
+	assert.True(t, isTracked(ip0))
+	assert.Equal(t, signedIP0, latestIP())
+
+	staleIP := newTestSignedIP(t, peerKey, peerCert, ip1, now-20)
+	n.trackSignedIP(staleIP)
+	assert.False(t, isTracked(ip1), "tracked an IP older than the latest announcement")
+	assert.Equal(t, signedIP0, latestIP())
+
+	forgedIP := signedIP0
+	forgedIP.IP = ip1
+	forgedIP.Time = now
+	n.trackSignedIP(forgedIP)
+	assert.False(t, isTracked(ip1), "tracked an IP with an invalid signature")
+	assert.Equal(t, signedIP0, latestIP())
+
+	futureIP := newTestSignedIP(t, peerKey, peerCert, ip1, now+uint64(time.Hour/time.Second))
+	n.trackSignedIP(futureIP)
+	assert.False(t, isTracked(ip1), "tracked an IP signed in the future")
+
+	nonValidatorIP := newTestSignedIP(t, nonValidatorKey, nonValidatorCert, ip1, now)
+	n.trackSignedIP(nonValidatorIP)
+	assert.False(t, isTracked(ip1), "tracked an IP announced by a non-validator")
+
+	impersonatedIP := newTestSignedIP(t, otherKey, otherCert, ip1, now)
+	impersonatedIP.NodeID = peerID
+	n.trackSignedIP(impersonatedIP)
+	assert.False(t, isTracked(ip1), "tracked an IP announced with the certificate of another node")
+
+	unknownCertIP := newTestSignedIP(t, otherKey, otherCert, ip1, now)
+	unknownCertIP.Cert = nil
+	n.trackSignedIP(unknownCertIP)
+	assert.False(t, isTracked(ip1), "tracked an IP announced by a node whose certificate isn't known")
+
+	signedIP1 := newTestSignedIP(t, peerKey, peerCert, ip1, now)
+	n.trackSignedIP(signedIP1)
+	assert.True(t, isTracked(ip1))
+	assert.False(t, isTracked(ip0), "kept tracking the old IP of a node that moved")
+	assert.Equal(t, signedIP1, latestIP())
+
+	// The certificate of a node is known once one of its IPs was tracked
+	signedIP2 := newTestSignedIP(t, peerKey, peerCert, ip0, now+1)
+	signedIP2.Cert = nil
+	n.trackSignedIP(signedIP2)
+	assert.True(t, isTracked(ip0))
+	signedIP2.Cert = peerCert.Raw
+	assert.Equal(t, signedIP2, latestIP())
+
+	err = n.Close()
+	assert.NoError(t, err)
+}
+
+func TestMySignedIP(t *testing.T) {
+	key, cert := newTestStakingKey(t)
+
+	n := &network{
+		ip:       utils.NewDynamicIPDesc(net.IPv4(1, 2, 3, 4), 9651),
+		ipSigner: key,
+	}
+	n.clock.Set(time.Unix(1000, 0))
+
+	signedIP0, err := n.signedIP()
+	assert.NoError(t, err)
+	assert.NoError(t, signedIP0.Verify(cert))
+	assert.Equal(t, uint64(1000), signedIP0.Time)
+
+	n.ip.Update(utils.IPDesc{IP: net.IPv4(5, 6, 7, 8), Port: 9651})
+
+	signedIP1, err := n.signedIP()
+	assert.NoError(t, err)
+	assert.NoError(t, signedIP1.Verify(cert))
+	assert.Greater(t, signedIP1.Time, signedIP0.Time, "a new IP must be announced as newer than the old one")
+
+	cachedIP, err := n.signedIP()
+	assert.NoError(t, err)
+	assert.Equal(t, signedIP1, cachedIP)
+}
@@ -0,0 +1,184 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+// tlvFlag is set in the op byte of messages in the TLV format
+const tlvFlag byte = 0x80
+
+var errDuplicateField = errors.New("message contains a duplicated field")
+
+// Format of the bytes of a message
+type Format byte
+
+// Formats a message may be packed in
+const (
+	// LegacyFormat packs the fields of a message in a fixed order. It is
+	// understood by every node.
+	LegacyFormat Format = iota
+	// TLVFormat packs each field of a message with its tag and length, so that
+	// fields added by newer nodes can be skipped by older nodes. It is only
+	// sent to peers that announced support for it in their Version message.
+	TLVFormat
+)
+
+func (f Format) String() string {
+	switch f {
+	case LegacyFormat:
+		return "legacy"
+	case TLVFormat:
+		return "tlv"
+	default:
+		return "unknown format"
+	}
+}
+
+// opOf returns the op of the message whose first byte is [b]
+func opOf(b byte) Op { return Op(b &^ (tlvFlag | compressedFlag)) }
+
+// isTLV returns true if [b] are the bytes of a message in the TLV format
+func isTLV(b []byte) bool { return len(b) > 0 && b[0]&tlvFlag != 0 }
+
+// PackTLV attempts to pack a map of fields into a message in the TLV format.
+// The first byte of the message is the opcode of the message, with [tlvFlag]
+// set. It is followed by the tag, length and value of each field. Optional
+// fields are only packed if they are in [fields].
+func (Codec) PackTLV(op Op, fields map[Field]interface{}) (Msg, error) {
+	message, ok := Messages[op]
+	if !ok {
+		return nil, errBadOp
+	}
+
+	p := wrappers.Packer{MaxSize: math.MaxInt32}
+	p.PackByte(byte(op) | tlvFlag)
+	for _, field := range message {
+		data, ok := fields[field]
+		if !ok {
+			return nil, errMissingField
+		}
+		packTLVField(&p, field, data)
+	}
+	for _, field := range OptionalFields[op] {
+		if data, ok := fields[field]; ok {
+			packTLVField(&p, field, data)
+		}
+	}
+
+	return &msg{
+		op:     op,
+		fields: fields,
+		bytes:  p.Bytes,
+	}, p.Err
+}
+
+func packTLVField(p *wrappers.Packer, field Field, data interface{}) {
+	value := wrappers.Packer{MaxSize: math.MaxInt32}
+	field.Packer()(&value, data)
+	if value.Errored() {
+		p.Add(value.Err)
+		return
+	}
+	p.PackShort(uint16(field))
+	p.PackBytes(value.Bytes)
+}
+
+// parseTLV attempts to convert bytes in the TLV format into a message. Fields
+// that aren't known for the op of the message are skipped.
+func (Codec) parseTLV(b []byte) (Msg, error) {
+	p := wrappers.Packer{Bytes: b}
+	op := opOf(p.UnpackByte())
+	message, ok := Messages[op]
+	if !ok {
+		return nil, errBadOp
+	}
+
+	knownFields := make(map[Field]struct{}, len(message)+len(OptionalFields[op]))
+	for _, field := range message {
+		knownFields[field] = struct{}{}
+	}
+	for _, field := range OptionalFields[op] {
+		knownFields[field] = struct{}{}
+	}
+
+	fields := make(map[Field]interface{}, len(message))
+	for p.Offset < len(b) && !p.Errored() {
+		field := Field(p.UnpackShort())
+		valueBytes := p.UnpackBytes()
+		if p.Errored() {
+			break
+		}
+		if _, ok := knownFields[field]; !ok {
+			// The field was added by a newer node
+			continue
+		}
+		if _, ok := fields[field]; ok {
+			p.Add(fmt.Errorf("%w: %s", errDuplicateField, field))
+			break
+		}
+
+		value := wrappers.Packer{Bytes: valueBytes}
+		fields[field] = field.Unpacker()(&value)
+		if value.Offset != len(valueBytes) {
+			value.Add(fmt.Errorf("expected length %d got %d for field %s", len(valueBytes), value.Offset, field))
+		}
+		p.Add(value.Err)
+	}
+	for _, field := range message {
+		if _, ok := fields[field]; !ok {
+			p.Add(fmt.Errorf("%w: %s", errMissingField, field))
+			break
+		}
+	}
+
+	return &msg{
+		op:     op,
+		fields: fields,
+		bytes:  b,
+	}, p.Err
+}
+
+// ToTLV returns [m] in the TLV format. The same message is often sent to many
+// peers, so it is only converted once.
+func (c Codec) ToTLV(m Msg) (Msg, error) {
+	if isTLV(m.Bytes()) {
+		return m, nil
+	}
+
+	cache, isCacheable := m.(*msg)
+	if isCacheable {
+		cache.tlvLock.Lock()
+		defer cache.tlvLock.Unlock()
+
+		if cache.tlv != nil {
+			return cache.tlv, nil
+		}
+	}
+
+	op := m.Op()
+	fields := make(map[Field]interface{}, len(Messages[op]))
+	for _, field := range Messages[op] {
+		fields[field] = m.Get(field)
+	}
+	for _, field := range OptionalFields[op] {
+		if data := m.Get(field); data != nil {
+			fields[field] = data
+		}
+	}
+
+	tlvMsg, err := c.PackTLV(op, fields)
+	if err != nil {
+		return nil, err
+	}
+	if isCacheable {
+		cache.tlv = tlvMsg
+	}
+	return tlvMsg, nil
+}
@@ -0,0 +1,157 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"math"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/compression"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+func TestTLVRoundTrip(t *testing.T) {
+	chainID := ids.ID{1}
+	containerID := ids.ID{2}
+	container := []byte{3, 4, 5}
+
+	legacyMsg, err := TestBuilder.PushQuery(chainID, 6, 7, containerID, container)
+	assert.NoError(t, err)
+
+	tlvMsg, err := TestCodec.ToTLV(legacyMsg)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(PushQuery)|tlvFlag, tlvMsg.Bytes()[0])
+	assert.NotEqual(t, legacyMsg.Bytes(), tlvMsg.Bytes())
+
+	cachedMsg, err := TestCodec.ToTLV(legacyMsg)
+	assert.NoError(t, err)
+	assert.Equal(t, tlvMsg, cachedMsg, "the conversion should be cached")
+
+	parsedMsg, err := TestCodec.Parse(tlvMsg.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, PushQuery, parsedMsg.Op())
+	assert.Equal(t, chainID[:], parsedMsg.Get(ChainID))
+	assert.Equal(t, uint32(6), parsedMsg.Get(RequestID))
+	assert.Equal(t, uint64(7), parsedMsg.Get(Deadline))
+	assert.Equal(t, containerID[:], parsedMsg.Get(ContainerID))
+	assert.Equal(t, container, parsedMsg.Get(ContainerBytes))
+}
+
+func TestTLVSkipsUnknownFields(t *testing.T) {
+	tlvMsg, err := TestCodec.PackTLV(Ping, map[Field]interface{}{Nonce: uint32(1)})
+	assert.NoError(t, err)
+
+	// A newer node may send a field this node doesn't know about
+	p := wrappers.Packer{MaxSize: math.MaxInt32, Bytes: tlvMsg.Bytes(), Offset: len(tlvMsg.Bytes())}
+	p.PackShort(math.MaxUint16)
+	p.PackBytes([]byte("new field"))
+	assert.NoError(t, p.Err)
+
+	parsedMsg, err := TestCodec.Parse(p.Bytes)
+	assert.NoError(t, err)
+	assert.Equal(t, Ping, parsedMsg.Op())
+	assert.Equal(t, uint32(1), parsedMsg.Get(Nonce))
+}
+
+func TestTLVOptionalFields(t *testing.T) {
+	optionalFields := OptionalFields[Ping]
+	OptionalFields[Ping] = append(optionalFields[:len(optionalFields):len(optionalFields)], AppBytes)
+	defer func() { OptionalFields[Ping] = optionalFields }()
+
+	withoutOptional, err := TestCodec.PackTLV(Ping, map[Field]interface{}{Nonce: uint32(1)})
+	assert.NoError(t, err)
+	parsedMsg, err := TestCodec.Parse(withoutOptional.Bytes())
+	assert.NoError(t, err)
+	assert.Nil(t, parsedMsg.Get(AppBytes))
+
+	withOptional, err := TestCodec.PackTLV(Ping, map[Field]interface{}{
+		Nonce:    uint32(1),
+		AppBytes: []byte{2},
+	})
+	assert.NoError(t, err)
+	parsedMsg, err = TestCodec.Parse(withOptional.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2}, parsedMsg.Get(AppBytes))
+
+	// Optional fields are appended in the legacy format
+	legacyMsg, err := TestCodec.Pack(Ping, map[Field]interface{}{
+		Nonce:    uint32(1),
+		AppBytes: []byte{2},
+	})
+	assert.NoError(t, err)
+	parsedMsg, err = TestCodec.Parse(legacyMsg.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{2}, parsedMsg.Get(AppBytes))
+}
+
+func TestTLVMissingField(t *testing.T) {
+	_, err := TestCodec.PackTLV(Get, make(map[Field]interface{}))
+	assert.Error(t, err)
+
+	_, err = TestCodec.Parse([]byte{byte(Get) | tlvFlag})
+	assert.Error(t, err)
+}
+
+func TestTLVDuplicateField(t *testing.T) {
+	tlvMsg, err := TestCodec.PackTLV(Ping, map[Field]interface{}{Nonce: uint32(1)})
+	assert.NoError(t, err)
+
+	b := append(tlvMsg.Bytes(), tlvMsg.Bytes()[1:]...)
+	_, err = TestCodec.Parse(b)
+	assert.Error(t, err)
+}
+
+func TestTLVTruncatedField(t *testing.T) {
+	tlvMsg, err := TestCodec.PackTLV(Ping, map[Field]interface{}{Nonce: uint32(1)})
+	assert.NoError(t, err)
+
+	b := tlvMsg.Bytes()
+	_, err = TestCodec.Parse(b[:len(b)-1])
+	assert.Error(t, err)
+}
+
+func TestTLVCompression(t *testing.T) {
+	container := make([]byte, 1024)
+	legacyMsg, err := TestBuilder.Put(ids.ID{1}, 2, ids.ID{3}, container)
+	assert.NoError(t, err)
+	tlvMsg, err := TestCodec.ToTLV(legacyMsg)
+	assert.NoError(t, err)
+
+	compressor := compression.NewGzipCompressor(math.MaxInt32)
+	compressors := map[compression.Type]compression.Compressor{
+		compression.Gzip: compressor,
+	}
+	compressed, err := TestCodec.Compress(tlvMsg, compressor)
+	assert.NoError(t, err)
+	assert.Equal(t, tlvMsg.Bytes()[0]|compressedFlag, compressed[0])
+
+	decompressed, err := TestCodec.Decompress(compressed, compressors)
+	assert.NoError(t, err)
+	assert.Equal(t, tlvMsg.Bytes(), decompressed)
+}
+
+func TestVersionIgnoresAppendedFields(t *testing.T) {
+	ip := SignedIP{IP: utils.IPDesc{IP: net.IPv6loopback, Port: 12345}}
+	msg, err := TestBuilder.VersionWithOptionalFields(1, 2, 3, ip, "app/0.1.0", nil, nil)
+	assert.NoError(t, err)
+
+	b := append(msg.Bytes(), "new field"...)
+	parsedMsg, err := TestCodec.Parse(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "app/0.1.0", parsedMsg.Get(VersionStr))
+}
+
+func TestAcceptsFormat(t *testing.T) {
+	n := &network{formats: []Format{TLVFormat}}
+	assert.True(t, n.acceptsFormat([]byte{byte(TLVFormat)}, TLVFormat))
+	assert.False(t, n.acceptsFormat(nil, TLVFormat))
+
+	n.formats = nil
+	assert.False(t, n.acceptsFormat([]byte{byte(TLVFormat)}, TLVFormat))
+}
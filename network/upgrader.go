@@ -5,6 +5,7 @@ package network
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"net"
 
@@ -18,8 +19,10 @@ var (
 
 // Upgrader ...
 type Upgrader interface {
-	// Must be thread safe
-	Upgrade(net.Conn) (ids.ShortID, net.Conn, error)
+	// Must be thread safe. Returns the ID of the peer, the upgraded connection
+	// and the certificate the peer authenticated with. The certificate is nil
+	// if the connection isn't authenticated.
+	Upgrade(net.Conn) (ids.ShortID, net.Conn, *x509.Certificate, error)
 }
 
 type ipUpgrader struct{}
@@ -27,11 +30,11 @@ type ipUpgrader struct{}
 // NewIPUpgrader ...
 func NewIPUpgrader() Upgrader { return ipUpgrader{} }
 
-func (ipUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, error) {
+func (ipUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, *x509.Certificate, error) {
 	addr := conn.RemoteAddr()
 	str := addr.String()
 	id := ids.NewShortID(hashing.ComputeHash160Array([]byte(str)))
-	return id, conn, nil
+	return id, conn, nil, nil
 }
 
 type tlsServerUpgrader struct {
@@ -45,21 +48,18 @@ func NewTLSServerUpgrader(config *tls.Config) Upgrader {
 	}
 }
 
-func (t tlsServerUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, error) {
+func (t tlsServerUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, *x509.Certificate, error) {
 	encConn := tls.Server(conn, t.config)
 	if err := encConn.Handshake(); err != nil {
-		return ids.ShortID{}, nil, err
+		return ids.ShortID{}, nil, nil, err
 	}
 
 	connState := encConn.ConnectionState()
 	if len(connState.PeerCertificates) == 0 {
-		return ids.ShortID{}, nil, errNoCert
+		return ids.ShortID{}, nil, nil, errNoCert
 	}
 	peerCert := connState.PeerCertificates[0]
-	id := ids.NewShortID(
-		hashing.ComputeHash160Array(
-			hashing.ComputeHash256(peerCert.Raw)))
-	return id, encConn, nil
+	return certToID(peerCert.Raw), encConn, peerCert, nil
 }
 
 type tlsClientUpgrader struct {
@@ -73,19 +73,24 @@ func NewTLSClientUpgrader(config *tls.Config) Upgrader {
 	}
 }
 
-func (t tlsClientUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, error) {
+func (t tlsClientUpgrader) Upgrade(conn net.Conn) (ids.ShortID, net.Conn, *x509.Certificate, error) {
 	encConn := tls.Client(conn, t.config)
 	if err := encConn.Handshake(); err != nil {
-		return ids.ShortID{}, nil, err
+		return ids.ShortID{}, nil, nil, err
 	}
 
 	connState := encConn.ConnectionState()
 	if len(connState.PeerCertificates) == 0 {
-		return ids.ShortID{}, nil, errNoCert
+		return ids.ShortID{}, nil, nil, errNoCert
 	}
 	peerCert := connState.PeerCertificates[0]
-	id := ids.NewShortID(
+	return certToID(peerCert.Raw), encConn, peerCert, nil
+}
+
+// certToID returns the node ID of the node that authenticates with the
+// certificate [certBytes]
+func certToID(certBytes []byte) ids.ShortID {
+	return ids.NewShortID(
 		hashing.ComputeHash160Array(
-			hashing.ComputeHash256(peerCert.Raw)))
-	return id, encConn, nil
+			hashing.ComputeHash256(certBytes)))
 }
@@ -7,13 +7,17 @@ import (
 	"time"
 
 	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/compressdb"
+	"github.com/liraxapp/avalanchego/database/encdb"
 	"github.com/liraxapp/avalanchego/genesis"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/nat"
+	"github.com/liraxapp/avalanchego/network"
 	"github.com/liraxapp/avalanchego/snow/consensus/avalanche"
 	"github.com/liraxapp/avalanchego/snow/networking/benchlist"
 	"github.com/liraxapp/avalanchego/snow/networking/router"
 	"github.com/liraxapp/avalanchego/utils"
+	"github.com/liraxapp/avalanchego/utils/compression"
 	"github.com/liraxapp/avalanchego/utils/dynamicip"
 	"github.com/liraxapp/avalanchego/utils/logging"
 	"github.com/liraxapp/avalanchego/utils/timer"
@@ -41,6 +45,18 @@ type Config struct {
 	// Database to use for the node
 	DB database.Database
 
+	// Verify the accepted state of every chain on startup
+	VerifyDB bool
+
+	// Compression algorithm to use for the databases of newly created chains,
+	// keyed by chain ID or alias, and the size below which values are stored
+	// uncompressed
+	DBCompression          map[string]compressdb.Algorithm
+	DBCompressionThreshold int
+
+	// Number of bytes the caches of all chains may use
+	CacheMemoryBudget uint64
+
 	// Staking configuration
 	StakingIP               utils.DynamicIPDesc
 	EnableP2PTLS            bool
@@ -61,6 +77,10 @@ type Config struct {
 	// Bootstrapping configuration
 	BootstrapPeers []*Peer
 
+	// Sync the state of chains that support it, rather than executing every
+	// block since genesis
+	StateSyncEnabled bool
+
 	// HTTP configuration
 	HTTPHost string
 	HTTPPort uint16
@@ -78,15 +98,26 @@ type Config struct {
 	MetricsAPIEnabled  bool
 	HealthAPIEnabled   bool
 
+	// Supplies the keys the data of keystore users is encrypted with. If nil,
+	// the keys are derived from the users' passwords.
+	KeystoreKeyProvider encdb.KeyProvider
+
 	// Logging configuration
 	LoggingConfig logging.Config
 
 	// Plugin directory
 	PluginDir string
 
+	// Plugins in [PluginDir] to register as VMs, besides the evm plugin
+	PluginVMs []PluginVM
+
 	// Consensus configuration
 	ConsensusParams avalanche.Parameters
 
+	// Consensus configuration of chains in specific subnets, keyed by subnet
+	// ID. Chains in other subnets use [ConsensusParams].
+	SubnetConsensusParams map[ids.ID]avalanche.Parameters
+
 	// Throughput configuration
 	ThroughputPort          uint16
 	ThroughputServerEnabled bool
@@ -110,6 +141,29 @@ type Config struct {
 	ConnMeterResetDuration time.Duration
 	ConnMeterMaxConns      int
 
+	// Compression types peers may use for messages sent to this node, in order
+	// of preference
+	NetworkCompression []compression.Type
+
+	// Bandwidth each peer may use
+	NetworkBandwidth network.BandwidthConfig
+
+	// Path to the file listing the peers this node may be connected to
+	NetworkAccessListFile string
+
+	// If true, only connect to allowed node IDs and never gossip this node's IP
+	NetworkPrivate bool
+
+	// Capture of the messages exchanged with peers
+	NetworkRecorder network.RecorderConfig
+
+	// If true, the messages of each class are multiplexed over the connection
+	// to a peer
+	NetworkMultiplex bool
+
+	// Maximum number of bytes of a message exchanged with a peer
+	NetworkMaxMessageSize uint32
+
 	// Subnet Whitelist
 	WhitelistedSubnets ids.Set
 
@@ -121,3 +175,13 @@ type Config struct {
 	// Coreth
 	CorethConfig string
 }
+
+// PluginVM is a VM served by a plugin in the plugin directory
+type PluginVM struct {
+	// ID of the VM
+	ID ids.ID
+	// Name of the plugin's executable
+	Name string
+	// True if the plugin serves a DAG VM rather than a chain VM
+	DAG bool
+}
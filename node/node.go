@@ -4,8 +4,10 @@
 package node
 
 import (
+	"crypto"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -23,9 +25,11 @@ import (
 	"github.com/liraxapp/avalanchego/api/info"
 	"github.com/liraxapp/avalanchego/api/keystore"
 	"github.com/liraxapp/avalanchego/api/metrics"
+	"github.com/liraxapp/avalanchego/cache"
 	"github.com/liraxapp/avalanchego/chains"
 	"github.com/liraxapp/avalanchego/chains/atomic"
 	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/journal"
 	"github.com/liraxapp/avalanchego/database/meterdb"
 	"github.com/liraxapp/avalanchego/database/prefixdb"
 	"github.com/liraxapp/avalanchego/genesis"
@@ -67,9 +71,11 @@ var (
 	genesisHashKey = []byte("genesisID")
 
 	// Version is the version of this code
-	Version                 = version.NewDefaultVersion(constants.PlatformName, 1, 1, 0)
+	Version                 = version.NewDefaultVersion(constants.PlatformName, 1, 2, 0)
 	versionParser           = version.NewDefaultParser()
 	beaconConnectionTimeout = 1 * time.Minute
+
+	errInvalidStakingKey = errors.New("staking key can't be used to sign messages")
 )
 
 // Node is an instance of an Avalanche node.
@@ -151,13 +157,23 @@ func (n *Node) initNetworking() error {
 	}
 	dialer := network.NewDialer(TCP)
 
-	var serverUpgrader, clientUpgrader network.Upgrader
+	var (
+		serverUpgrader, clientUpgrader network.Upgrader
+		ipSigner                       crypto.Signer
+	)
 	if n.Config.EnableP2PTLS {
 		cert, err := tls.LoadX509KeyPair(n.Config.StakingCertFile, n.Config.StakingKeyFile)
 		if err != nil {
 			return err
 		}
 
+		// The staking key signs the IP this node announces to its peers
+		signer, ok := cert.PrivateKey.(crypto.Signer)
+		if !ok {
+			return errInvalidStakingKey
+		}
+		ipSigner = signer
+
 		// #nosec G402
 		tlsConfig := &tls.Config{
 			Certificates: []tls.Certificate{cert},
@@ -170,6 +186,9 @@ func (n *Node) initNetworking() error {
 			// and confirmed to be safe and correct.
 			InsecureSkipVerify: true,
 		}
+		if n.Config.NetworkMultiplex {
+			tlsConfig.NextProtos = []string{network.MuxProtocol}
+		}
 
 		serverUpgrader = network.NewTLSServerUpgrader(tlsConfig)
 		clientUpgrader = network.NewTLSClientUpgrader(tlsConfig)
@@ -177,6 +196,10 @@ func (n *Node) initNetworking() error {
 		serverUpgrader = network.NewIPUpgrader()
 		clientUpgrader = network.NewIPUpgrader()
 	}
+	if n.Config.NetworkMultiplex {
+		serverUpgrader = network.NewMuxUpgrader(serverUpgrader, n.Config.NetworkMaxMessageSize)
+		clientUpgrader = network.NewMuxUpgrader(clientUpgrader, n.Config.NetworkMaxMessageSize)
+	}
 
 	// Initialize validator manager and primary network's validator set
 	primaryNetworkValidators := validators.NewSet()
@@ -245,7 +268,17 @@ func (n *Node) initNetworking() error {
 		n.Config.DisconnectedCheckFreq,
 		n.Config.DisconnectedRestartTimeout,
 		n.Config.ApricotPhase0Time,
+		n.Config.NetworkCompression,
+		ipSigner,
+		n.Config.NetworkBandwidth,
+		n.Config.NetworkPrivate,
+		prefixdb.New([]byte("peers"), n.DB),
+		n.Config.NetworkRecorder,
+		n.Config.NetworkMaxMessageSize,
 	)
+	if err := n.reloadAccessList(); err != nil {
+		return err
+	}
 
 	n.nodeCloser = utils.HandleSignals(func(os.Signal) {
 		// errors are already logged internally if they are meaningful
@@ -255,6 +288,25 @@ func (n *Node) initNetworking() error {
 	return nil
 }
 
+// reloadAccessList reads the peer access list file, if one was provided, and
+// applies it to the network
+func (n *Node) reloadAccessList() error {
+	config := network.AccessListConfig{}
+	if n.Config.NetworkAccessListFile != "" {
+		configBytes, err := ioutil.ReadFile(n.Config.NetworkAccessListFile)
+		if err != nil {
+			return fmt.Errorf("couldn't read access list file: %w", err)
+		}
+		if err := json.Unmarshal(configBytes, &config); err != nil {
+			return fmt.Errorf("couldn't parse access list file: %w", err)
+		}
+	}
+	if n.Config.NetworkPrivate && len(config.AllowedNodeIDs) == 0 {
+		n.Log.Warn("private network mode is enabled but no node IDs are allowed. This node won't connect to any peers")
+	}
+	return n.Net.SetAccessList(config)
+}
+
 type insecureValidatorManager struct {
 	router.Router
 	vdrs   validators.Set
@@ -551,9 +603,15 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		DecisionEvents:          n.DecisionDispatcher,
 		ConsensusEvents:         n.ConsensusDispatcher,
 		DB:                      n.DB,
+		VerifyDB:                n.Config.VerifyDB,
+		StateSyncEnabled:        n.Config.StateSyncEnabled,
+		DBCompression:           n.Config.DBCompression,
+		DBCompressionThreshold:  n.Config.DBCompressionThreshold,
+		CacheBudget:             cache.NewBudget(int(n.Config.CacheMemoryBudget)),
 		Router:                  n.Config.ConsensusRouter,
 		Net:                     n.Net,
 		ConsensusParams:         n.Config.ConsensusParams,
+		SubnetConsensusParams:   n.Config.SubnetConsensusParams,
 		Validators:              n.vdrs,
 		NodeID:                  n.ID,
 		NetworkID:               n.Config.NetworkID,
@@ -609,6 +667,12 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		n.vmManager.RegisterVMFactory(nftfx.ID, &nftfx.Factory{}),
 		n.vmManager.RegisterVMFactory(propertyfx.ID, &propertyfx.Factory{}),
 	)
+	for _, pluginVM := range n.Config.PluginVMs {
+		errs.Add(n.vmManager.RegisterVMFactory(pluginVM.ID, &rpcchainvm.Factory{
+			Path: filepath.Join(n.Config.PluginDir, pluginVM.Name),
+			DAG:  pluginVM.DAG,
+		}))
+	}
 	if errs.Errored() {
 		return errs.Err
 	}
@@ -621,8 +685,25 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 // initSharedMemory initializes the shared memory for cross chain interation
 func (n *Node) initSharedMemory() error {
 	n.Log.Info("initializing SharedMemory")
+
+	// Commits that were interrupted by a crash must be finished before any
+	// chain reads its state
+	journalDB := prefixdb.New([]byte("journal"), n.DB)
+	j := journal.New(journalDB, n.DB)
+	replayed, rolledBack, err := j.Recover()
+	if err != nil {
+		return fmt.Errorf("couldn't recover database journal: %w", err)
+	}
+	if replayed > 0 || rolledBack > 0 {
+		n.Log.Info("recovered database journal: replayed %d and rolled back %d incomplete commits", replayed, rolledBack)
+	}
+
 	sharedMemoryDB := prefixdb.New([]byte("shared memory"), n.DB)
-	return n.sharedMemory.Initialize(n.Log, sharedMemoryDB)
+	if err := n.sharedMemory.Initialize(n.Log, sharedMemoryDB); err != nil {
+		return err
+	}
+	n.sharedMemory.SetJournal(j)
+	return nil
 }
 
 // initKeystoreAPI initializes the keystore service, which is an on-node wallet.
@@ -630,7 +711,7 @@ func (n *Node) initSharedMemory() error {
 func (n *Node) initKeystoreAPI() error {
 	n.Log.Info("initializing keystore")
 	keystoreDB := prefixdb.New([]byte("keystore"), n.DB)
-	if err := n.keystoreServer.Initialize(n.Log, keystoreDB); err != nil {
+	if err := n.keystoreServer.InitializeWithKeyProvider(n.Log, keystoreDB, n.Config.KeystoreKeyProvider); err != nil {
 		return err
 	}
 	keystoreHandler, err := n.keystoreServer.CreateHandler()
@@ -677,7 +758,7 @@ func (n *Node) initAdminAPI() error {
 		return nil
 	}
 	n.Log.Info("initializing admin API")
-	service, err := admin.NewService(n.Log, n.chainManager, &n.APIServer)
+	service, err := admin.NewService(n.Log, n.chainManager, &n.APIServer, n.reloadAccessList)
 	if err != nil {
 		return err
 	}
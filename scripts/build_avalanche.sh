@@ -15,3 +15,7 @@ GIT_COMMIT=$( git rev-list -1 HEAD )
 # Build aVALANCHE
 echo "Building Avalanche..."
 go build -ldflags "-X main.GitCommit=$GIT_COMMIT" -o "$BUILD_DIR/avalanchego" "$AVALANCHE_PATH/main/"*.go
+
+# Build the offline database tool
+echo "Building dbtool..."
+go build -o "$BUILD_DIR/dbtool" "$AVALANCHE_PATH/dbtool/"*.go
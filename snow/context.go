@@ -12,6 +12,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/liraxapp/avalanchego/cache"
 	"github.com/liraxapp/avalanchego/chains/atomic"
 	"github.com/liraxapp/avalanchego/database"
 	"github.com/liraxapp/avalanchego/ids"
@@ -46,6 +47,22 @@ type SubnetLookup interface {
 	SubnetID(chainID ids.ID) (ids.ID, error)
 }
 
+// AppSender sends application level messages to the VMs running the same
+// chain on other nodes
+type AppSender interface {
+	// SendAppRequest sends [appRequestBytes] to every node in [nodeIDs]. The
+	// VM of each of them is expected to answer with an AppResponse carrying
+	// [requestID]. If a node doesn't answer in time, AppRequestFailed is called
+	// on this VM instead.
+	SendAppRequest(nodeIDs ids.ShortSet, requestID uint32, appRequestBytes []byte) error
+
+	// SendAppResponse answers the AppRequest with ID [requestID] from [nodeID]
+	SendAppResponse(nodeID ids.ShortID, requestID uint32, appResponseBytes []byte) error
+
+	// SendAppGossip gossips [appGossipBytes] to a random sample of peers
+	SendAppGossip(appGossipBytes []byte) error
+}
+
 // Context is information about the current execution.
 // [NetworkID] is the ID of the network this context exists within.
 // [ChainID] is the ID of the chain this context exists within.
@@ -67,11 +84,16 @@ type Context struct {
 	SharedMemory        atomic.SharedMemory
 	BCLookup            AliasLookup
 	SNLookup            SubnetLookup
+	AppSender           AppSender
 
 	// Non-zero iff this chain bootstrapped. Should only be accessed atomically.
 	bootstrapped uint32
 	Namespace    string
 	Metrics      prometheus.Registerer
+
+	// Memory budget of this chain's caches. May be nil, in which case caches
+	// use their default sizes.
+	CacheBudget *cache.Budget
 }
 
 // IsBootstrapped returns true iff this chain is done bootstrapping
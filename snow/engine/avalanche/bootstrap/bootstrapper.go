@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/liraxapp/avalanchego/cache"
+	"github.com/liraxapp/avalanchego/cache/metercacher"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/snow"
 	"github.com/liraxapp/avalanchego/snow/choices"
@@ -59,7 +60,7 @@ type Bootstrapper struct {
 	needToFetch ids.Set
 
 	// Contains IDs of vertices that have recently been processed
-	processedCache *cache.LRU
+	processedCache cache.Cacher
 }
 
 // Initialize this engine.
@@ -73,12 +74,20 @@ func (b *Bootstrapper) Initialize(
 	b.TxBlocked = config.TxBlocked
 	b.Manager = config.Manager
 	b.VM = config.VM
-	b.processedCache = &cache.LRU{Size: cacheSize}
 	b.OnFinished = onFinished
 
 	if err := b.metrics.Initialize(namespace, registerer); err != nil {
 		return err
 	}
+	if err := b.Tracker.Initialize(namespace, registerer); err != nil {
+		return err
+	}
+
+	processedCache, err := metercacher.New(namespace, "processed_cache", registerer, &cache.LRU{Size: cacheSize})
+	if err != nil {
+		return err
+	}
+	b.processedCache = processedCache
 
 	b.VtxBlocked.SetParser(&vtxParser{
 		log:         config.Ctx.Log,
@@ -134,19 +143,41 @@ func (b *Bootstrapper) fetch(vtxIDs ...ids.ID) error {
 			continue
 		}
 
-		validators, err := b.Beacons.Sample(1) // validator to send request to
-		if err != nil {
-			return fmt.Errorf("dropping request for %s as there are no validators", vtxID)
+		if err := b.request(vtxID, ids.ShortID{}); err != nil {
+			return err
 		}
-		validatorID := validators[0].ID()
-		b.RequestID++
-
-		b.OutstandingRequests.Add(validatorID, b.RequestID, vtxID)
-		b.Sender.GetAncestors(validatorID, b.RequestID, vtxID) // request vertex and ancestors
 	}
 	return b.finish()
 }
 
+// Request [vtxID] and its ancestors from a beacon other than [avoid], if
+// another beacon is connected
+func (b *Bootstrapper) request(vtxID ids.ID, avoid ids.ShortID) error {
+	validatorID, err := b.Tracker.Select(b.Beacons, avoid) // validator to send request to
+	if err != nil {
+		return fmt.Errorf("dropping request for %s as there are no validators", vtxID)
+	}
+	b.RequestID++
+
+	b.OutstandingRequests.Add(validatorID, b.RequestID, vtxID)
+	b.Tracker.Sent(validatorID, b.RequestID)
+	b.Sender.GetAncestors(validatorID, b.RequestID, vtxID) // request vertex and ancestors
+	return nil
+}
+
+// Retry the request [requestID] to [vdr] for [vtxID], which failed, on a
+// different beacon
+func (b *Bootstrapper) retry(vdr ids.ShortID, requestID uint32, vtxID ids.ID) error {
+	b.Tracker.Failed(vdr, requestID)
+
+	if _, err := b.Manager.GetVertex(vtxID); err != nil && !b.OutstandingRequests.Contains(vtxID) {
+		if err := b.request(vtxID, vdr); err != nil {
+			return err
+		}
+	}
+	return b.fetch()
+}
+
 // Process the vertices in [vtxs].
 func (b *Bootstrapper) process(vtxs ...avalanche.Vertex) error {
 	// Vertices that we need to process. Store them in a heap for deduplication
@@ -252,14 +283,17 @@ func (b *Bootstrapper) MultiPut(vdr ids.ShortID, requestID uint32, vtxs [][]byte
 
 		b.Ctx.Log.Debug("failed to parse requested vertex %s: %s", requestedVtxID, err)
 		b.Ctx.Log.Verbo("vertex: %s", formatting.DumpBytes{Bytes: vtxs[0]})
-		return b.fetch(requestedVtxID)
+		return b.retry(vdr, requestID, requestedVtxID)
 	}
 
 	vtxID := vtx.ID()
 	// If the vertex is neither the requested vertex nor a needed vertex, return early and re-fetch if necessary
 	if requested && requestedVtxID != vtxID {
 		b.Ctx.Log.Debug("received incorrect vertex from %s with vertexID %s", vdr, vtxID)
-		return b.fetch(requestedVtxID)
+		return b.retry(vdr, requestID, requestedVtxID)
+	}
+	if requested {
+		b.Tracker.Received(vdr, requestID, len(vtxs))
 	}
 	if !requested && !b.OutstandingRequests.Contains(vtxID) && !b.needToFetch.Contains(vtxID) {
 		b.Ctx.Log.Debug("received un-needed vertex from %s with vertexID %s", vdr, vtxID)
@@ -317,8 +351,8 @@ func (b *Bootstrapper) GetAncestorsFailed(vdr ids.ShortID, requestID uint32) err
 		b.Ctx.Log.Debug("GetAncestorsFailed(%s, %d) called but there was no outstanding request to this validator with this ID", vdr, requestID)
 		return nil
 	}
-	// Send another request for the vertex
-	return b.fetch(vtxID)
+	// Send another request for the vertex, to a different beacon
+	return b.retry(vdr, requestID, vtxID)
 }
 
 // ForceAccepted starts bootstrapping. Process the vertices in [accepterContainerIDs].
@@ -399,6 +433,7 @@ func (b *Bootstrapper) Connected(validatorID ids.ShortID) error {
 	if connector, ok := b.VM.(validators.Connector); ok {
 		connector.Connected(validatorID)
 	}
+	b.Tracker.Connected(validatorID)
 	return b.Bootstrapper.Connected(validatorID)
 }
 
@@ -407,5 +442,67 @@ func (b *Bootstrapper) Disconnected(validatorID ids.ShortID) error {
 	if connector, ok := b.VM.(validators.Connector); ok {
 		connector.Disconnected(validatorID)
 	}
+	b.Tracker.Disconnected(validatorID)
 	return b.Bootstrapper.Disconnected(validatorID)
 }
+
+// AppRequest implements the Engine interface by passing the request on to the
+// VM
+func (b *Bootstrapper) AppRequest(validatorID ids.ShortID, requestID uint32, request []byte) error {
+	return b.VM.AppRequest(validatorID, requestID, request)
+}
+
+// AppResponse implements the Engine interface by passing the response on to
+// the VM
+func (b *Bootstrapper) AppResponse(validatorID ids.ShortID, requestID uint32, response []byte) error {
+	return b.VM.AppResponse(validatorID, requestID, response)
+}
+
+// AppRequestFailed implements the Engine interface by notifying the VM
+func (b *Bootstrapper) AppRequestFailed(validatorID ids.ShortID, requestID uint32) error {
+	return b.VM.AppRequestFailed(validatorID, requestID)
+}
+
+// AppGossip implements the Engine interface by passing the message on to the
+// VM
+func (b *Bootstrapper) AppGossip(validatorID ids.ShortID, msg []byte) error {
+	return b.VM.AppGossip(validatorID, msg)
+}
+
+// GetStateSummary implements the Engine interface. Avalanche chains don't
+// support state sync, so the summary is empty.
+func (b *Bootstrapper) GetStateSummary(validatorID ids.ShortID, requestID uint32) error {
+	b.Sender.StateSummary(validatorID, requestID, nil)
+	return nil
+}
+
+// StateSummary implements the Engine interface
+func (b *Bootstrapper) StateSummary(validatorID ids.ShortID, requestID uint32, summary []byte) error {
+	b.Ctx.Log.Debug("dropping StateSummary(%s, %d) as avalanche chains don't support state sync",
+		validatorID, requestID)
+	return nil
+}
+
+// GetStateSummaryFailed implements the Engine interface
+func (b *Bootstrapper) GetStateSummaryFailed(validatorID ids.ShortID, requestID uint32) error {
+	return nil
+}
+
+// GetStateChunk implements the Engine interface
+func (b *Bootstrapper) GetStateChunk(validatorID ids.ShortID, requestID uint32, summaryID ids.ID, index uint32) error {
+	b.Ctx.Log.Debug("dropping GetStateChunk(%s, %d) as avalanche chains don't support state sync",
+		validatorID, requestID)
+	return nil
+}
+
+// StateChunk implements the Engine interface
+func (b *Bootstrapper) StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte) error {
+	b.Ctx.Log.Debug("dropping StateChunk(%s, %d) as avalanche chains don't support state sync",
+		validatorID, requestID)
+	return nil
+}
+
+// GetStateChunkFailed implements the Engine interface
+func (b *Bootstrapper) GetStateChunkFailed(validatorID ids.ShortID, requestID uint32) error {
+	return nil
+}
@@ -320,6 +320,130 @@ func TestBootstrapperByzantineResponses(t *testing.T) {
 	}
 }
 
+// Outstanding requests are spread across the beacons, and a failed request is
+// retried on a different beacon
+func TestBootstrapperSpreadsRequests(t *testing.T) {
+	config, peerID, sender, manager, vm := newConfig(t)
+
+	otherPeerID := ids.GenerateTestShortID()
+	if err := config.Beacons.AddWeight(otherPeerID, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	vtxID0 := ids.Empty.Prefix(0)
+	vtxID1 := ids.Empty.Prefix(1)
+
+	vtxBytes0 := []byte{0}
+	vtxBytes1 := []byte{1}
+
+	vtx0 := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     vtxID0,
+			StatusV: choices.Unknown,
+		},
+		HeightV: 0,
+		BytesV:  vtxBytes0,
+	}
+	vtx1 := &avalanche.TestVertex{
+		TestDecidable: choices.TestDecidable{
+			IDV:     vtxID1,
+			StatusV: choices.Unknown,
+		},
+		HeightV: 0,
+		BytesV:  vtxBytes1,
+	}
+
+	bs := Bootstrapper{}
+	finished := new(bool)
+	err := bs.Initialize(
+		config,
+		func() error { *finished = true; return nil },
+		fmt.Sprintf("%s_%s_bs", constants.PlatformName, config.Ctx.ChainID),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager.GetVertexF = func(vtxID ids.ID) (avalanche.Vertex, error) {
+		switch {
+		case vtxID == vtxID0 && vtx0.StatusV != choices.Unknown:
+			return vtx0, nil
+		case vtxID == vtxID1 && vtx1.StatusV != choices.Unknown:
+			return vtx1, nil
+		}
+		return nil, errUnknownVertex
+	}
+	manager.ParseVertexF = func(vtxBytes []byte) (avalanche.Vertex, error) {
+		switch {
+		case bytes.Equal(vtxBytes, vtxBytes0):
+			vtx0.StatusV = choices.Processing
+			return vtx0, nil
+		case bytes.Equal(vtxBytes, vtxBytes1):
+			vtx1.StatusV = choices.Processing
+			return vtx1, nil
+		}
+		t.Fatal(errParsedUnknownVertex)
+		return nil, errParsedUnknownVertex
+	}
+
+	type request struct {
+		vdr   ids.ShortID
+		reqID uint32
+		vtxID ids.ID
+	}
+	requests := []request(nil)
+	sender.GetAncestorsF = func(vdr ids.ShortID, reqID uint32, vtxID ids.ID) {
+		requests = append(requests, request{vdr: vdr, reqID: reqID, vtxID: vtxID})
+	}
+	vm.CantBootstrapping = false
+
+	if err := bs.ForceAccepted([]ids.ID{vtxID0, vtxID1}); err != nil { // should request vtx0 and vtx1
+		t.Fatal(err)
+	} else if len(requests) != 2 {
+		t.Fatalf("should have requested 2 vertices but requested %d", len(requests))
+	} else if requests[0].vdr.Equals(requests[1].vdr) {
+		t.Fatalf("should have requested the vertices from different beacons")
+	}
+	for _, req := range requests {
+		if !req.vdr.Equals(peerID) && !req.vdr.Equals(otherPeerID) {
+			t.Fatalf("should have requested %s from a beacon", req.vtxID)
+		}
+	}
+
+	failed := requests[0]
+	if err := bs.GetAncestorsFailed(failed.vdr, failed.reqID); err != nil { // should request the vertex again
+		t.Fatal(err)
+	} else if len(requests) != 3 || requests[2].vtxID != failed.vtxID {
+		t.Fatalf("should have requested %s again", failed.vtxID)
+	} else if requests[2].vdr.Equals(failed.vdr) {
+		t.Fatalf("should have retried on a different beacon than %s", failed.vdr)
+	}
+
+	vm.CantBootstrapped = false
+
+	for _, req := range requests[1:] {
+		vtxBytes := vtxBytes0
+		if req.vtxID == vtxID1 {
+			vtxBytes = vtxBytes1
+		}
+		if err := bs.MultiPut(req.vdr, req.reqID, [][]byte{vtxBytes}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	switch {
+	case len(requests) != 3:
+		t.Fatalf("shouldn't have requested any more vertices")
+	case !*finished:
+		t.Fatalf("Bootstrapping should have finished")
+	case vtx0.Status() != choices.Accepted:
+		t.Fatalf("Vertex should be accepted")
+	case vtx1.Status() != choices.Accepted:
+		t.Fatalf("Vertex should be accepted")
+	}
+}
+
 // Vertex has a dependency and tx has a dependency
 func TestBootstrapperTxDependencies(t *testing.T) {
 	config, peerID, sender, manager, vm := newConfig(t)
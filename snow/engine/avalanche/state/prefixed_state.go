@@ -4,7 +4,10 @@
 package state
 
 import (
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/liraxapp/avalanchego/cache"
+	"github.com/liraxapp/avalanchego/cache/metercacher"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/snow/choices"
 )
@@ -26,13 +29,30 @@ type prefixedState struct {
 	uniqueVtx   cache.Deduplicator
 }
 
-func newPrefixedState(state *state, idCacheSizes int) *prefixedState {
+func newPrefixedState(
+	state *state,
+	idCacheSizes int,
+	namespace string,
+	registerer prometheus.Registerer,
+) (*prefixedState, error) {
+	vtxCache, err := metercacher.New(namespace, "vertex_id_cache", registerer, &cache.LRU{Size: idCacheSizes})
+	if err != nil {
+		return nil, err
+	}
+	statusCache, err := metercacher.New(namespace, "vertex_status_id_cache", registerer, &cache.LRU{Size: idCacheSizes})
+	if err != nil {
+		return nil, err
+	}
+	uniqueVtxCache, err := metercacher.NewDeduplicator(namespace, "unique_vertex_cache", registerer, &cache.EvictableLRU{Size: idCacheSizes})
+	if err != nil {
+		return nil, err
+	}
 	return &prefixedState{
 		state:     state,
-		vtx:       &cache.LRU{Size: idCacheSizes},
-		status:    &cache.LRU{Size: idCacheSizes},
-		uniqueVtx: &cache.EvictableLRU{Size: idCacheSizes},
-	}
+		vtx:       vtxCache,
+		status:    statusCache,
+		uniqueVtx: uniqueVtxCache,
+	}, nil
 }
 
 func (s *prefixedState) UniqueVertex(vtx *uniqueVertex) *uniqueVertex {
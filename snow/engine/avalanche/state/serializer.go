@@ -20,10 +20,11 @@ import (
 	"github.com/liraxapp/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/liraxapp/avalanchego/utils/hashing"
 	"github.com/liraxapp/avalanchego/utils/math"
+	"github.com/liraxapp/avalanchego/utils/units"
 )
 
 const (
-	dbCacheSize = 10000
+	dbCacheSize = 32 * units.MiB
 	idCacheSize = 1000
 )
 
@@ -42,21 +43,29 @@ type Serializer struct {
 }
 
 // Initialize implements the avalanche.State interface
-func (s *Serializer) Initialize(ctx *snow.Context, vm vertex.DAGVM, db database.Database) {
+func (s *Serializer) Initialize(ctx *snow.Context, vm vertex.DAGVM, db database.Database) error {
 	s.ctx = ctx
 	s.vm = vm
 
 	vdb := versiondb.New(db)
-	dbCache := &cache.LRU{Size: dbCacheSize}
+	dbCache, err := cache.NewSizedLRU(ctx.Namespace, "vertex_db_cache", ctx.Metrics, dbCacheSize, cache.BytesSize)
+	if err != nil {
+		return err
+	}
+	ctx.CacheBudget.Track(1, dbCache)
 	rawState := &state{
 		serializer: s,
 		dbCache:    dbCache,
 		db:         vdb,
 	}
-	s.state = newPrefixedState(rawState, idCacheSize)
+	s.state, err = newPrefixedState(rawState, idCacheSize, ctx.Namespace, ctx.Metrics)
+	if err != nil {
+		return err
+	}
 	s.db = vdb
 
 	s.edge.Add(s.state.Edge()...)
+	return nil
 }
 
 // ParseVertex implements the avalanche.State interface
@@ -25,7 +25,9 @@ func newSerializer(t *testing.T, parseTx func([]byte) (snowstorm.Tx, error)) *Se
 	baseDB := memdb.New()
 	ctx := snow.DefaultContextTest()
 	s := &Serializer{}
-	s.Initialize(ctx, &vm, baseDB)
+	if err := s.Initialize(ctx, &vm, baseDB); err != nil {
+		t.Fatal(err)
+	}
 	return s
 }
 
@@ -36,6 +36,8 @@ type ExternalHandler interface {
 	AcceptedHandler
 	FetchHandler
 	QueryHandler
+	AppHandler
+	StateSyncHandler
 }
 
 // FrontierHandler defines how a consensus engine reacts to frontier messages
@@ -276,6 +278,114 @@ type QueryHandler interface {
 	QueryFailed(validatorID ids.ShortID, requestID uint32) error
 }
 
+// AppHandler defines how a consensus engine, and the VM it passes them on to,
+// reacts to application level messages from other nodes. Application messages
+// carry bytes that only the VM interprets. Functions only return fatal errors
+// if they occur.
+type AppHandler interface {
+	// Notify this engine of an application level request.
+	//
+	// This function can be called by any node. It is not safe to assume this
+	// message is utilizing a unique requestID or that [appRequestBytes] is
+	// well formed. However, the validatorID is assumed to be authenticated.
+	//
+	// The VM may respond with an AppResponse carrying the same requestID.
+	AppRequest(validatorID ids.ShortID, requestID uint32, appRequestBytes []byte) error
+
+	// Notify this engine of a response to an application level request.
+	//
+	// This function can be called by any node. It is not safe to assume this
+	// message is in response to an AppRequest sent by this node or that
+	// [appResponseBytes] is well formed. However, the validatorID is assumed
+	// to be authenticated.
+	AppResponse(validatorID ids.ShortID, requestID uint32, appResponseBytes []byte) error
+
+	// Notify this engine that an application level request it issued has
+	// failed.
+	//
+	// This function will be called if the VM sent an AppRequest that is not
+	// anticipated to be responded to. This could be because the recipient of
+	// the message is unknown or if the message request has timed out.
+	//
+	// The validatorID and requestID are assumed to be the same as those sent
+	// in the AppRequest.
+	AppRequestFailed(validatorID ids.ShortID, requestID uint32) error
+
+	// Notify this engine of an application level gossip message.
+	//
+	// This function can be called by any node. It is not safe to assume that
+	// [appGossipBytes] is well formed. However, the validatorID is assumed to
+	// be authenticated.
+	AppGossip(validatorID ids.ShortID, appGossipBytes []byte) error
+}
+
+// StateSyncHandler defines how a consensus engine reacts to state sync
+// messages from other validators. Functions only return fatal errors if they
+// occur.
+type StateSyncHandler interface {
+	// Notify this engine of a request for the summary of its most recent
+	// state.
+	//
+	// This function can be called by any validator. It is not safe to assume
+	// this message is utilizing a unique requestID. However, the validatorID is
+	// assumed to be authenticated.
+	//
+	// This engine should respond with a StateSummary message with the same
+	// requestID. If this engine's VM doesn't support state sync, the summary
+	// should be empty.
+	GetStateSummary(validatorID ids.ShortID, requestID uint32) error
+
+	// Notify this engine of the summary of the state of another validator.
+	//
+	// This function can be called by any validator. It is not safe to assume
+	// this message is in response to a GetStateSummary message or that
+	// [summary] is well formed. However, the validatorID is assumed to be
+	// authenticated. An empty [summary] means the validator has no state to
+	// sync from.
+	StateSummary(validatorID ids.ShortID, requestID uint32, summary []byte) error
+
+	// Notify this engine that a GetStateSummary request it issued has failed.
+	//
+	// This function will be called if the engine sent a GetStateSummary
+	// message that is not anticipated to be responded to. This could be
+	// because the recipient of the message is unknown or if the message
+	// request has timed out.
+	//
+	// The validatorID and requestID are assumed to be the same as those sent
+	// in the GetStateSummary message.
+	GetStateSummaryFailed(validatorID ids.ShortID, requestID uint32) error
+
+	// Notify this engine of a request for the chunk at [index] of the state
+	// summarized by [summaryID].
+	//
+	// This function can be called by any validator. It is not safe to assume
+	// this message is utilizing a unique requestID or that the summary or the
+	// chunk exist. However, the validatorID is assumed to be authenticated.
+	//
+	// This engine should respond with a StateChunk message with the same
+	// requestID if the chunk is locally available. Otherwise, the message can
+	// be safely dropped.
+	GetStateChunk(validatorID ids.ShortID, requestID uint32, summaryID ids.ID, index uint32) error
+
+	// Notify this engine of a chunk of state, along with its proof.
+	//
+	// This function can be called by any validator. It is not safe to assume
+	// this message is in response to a GetStateChunk message or that [chunk]
+	// is valid. However, the validatorID is assumed to be authenticated.
+	StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte) error
+
+	// Notify this engine that a GetStateChunk request it issued has failed.
+	//
+	// This function will be called if the engine sent a GetStateChunk message
+	// that is not anticipated to be responded to. This could be because the
+	// recipient of the message is unknown or if the message request has timed
+	// out.
+	//
+	// The validatorID and requestID are assumed to be the same as those sent
+	// in the GetStateChunk message.
+	GetStateChunkFailed(validatorID ids.ShortID, requestID uint32) error
+}
+
 // InternalHandler defines how this consensus engine reacts to messages from
 // other components of this validator. Functions only return fatal errors if
 // they occur.
@@ -0,0 +1,209 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/validators"
+	"github.com/liraxapp/avalanchego/utils/math"
+	"github.com/liraxapp/avalanchego/utils/timer"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+const (
+	// fetchThroughputHalflife is the halflife of the estimate of how many
+	// containers per second each peer serves
+	fetchThroughputHalflife = 30 * time.Second
+
+	// minFetchDuration is the minimum duration a request is considered to have
+	// taken, so that the throughput of a near instant response isn't unbounded
+	minFetchDuration = time.Millisecond
+)
+
+// fetchPeer is the fetching history of a peer
+type fetchPeer struct {
+	// number of requests sent to the peer that haven't been responded to or
+	// failed
+	outstanding int
+	// containers per second the peer has served. nil until a request to the
+	// peer has been responded to or has failed.
+	throughput math.Averager
+}
+
+// FetchTracker decides which beacon to send each request for containers to,
+// so that outstanding requests are spread across the beacons in proportion
+// to how quickly they serve them. Beacons that haven't been measured yet are
+// tried before slower ones, and a request that failed is retried on a
+// different beacon.
+type FetchTracker struct {
+	clock timer.Clock
+
+	// validator ID -> fetching history of the validator
+	peers map[[20]byte]*fetchPeer
+	// request ID -> time the request was sent
+	sent map[uint32]time.Time
+	// beacons that have disconnected and haven't reconnected since
+	disconnected ids.ShortSet
+
+	fetchRate   prometheus.Gauge
+	fetchedFrom *prometheus.CounterVec
+}
+
+// Initialize the tracker and register its metrics
+func (t *FetchTracker) Initialize(namespace string, registerer prometheus.Registerer) error {
+	t.peers = make(map[[20]byte]*fetchPeer)
+	t.sent = make(map[uint32]time.Time)
+
+	t.fetchRate = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "fetch_rate",
+		Help:      "Estimated number of containers per second the beacons serve during bootstrapping",
+	})
+	t.fetchedFrom = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "fetched_from",
+		Help:      "Number of containers received from each beacon during bootstrapping",
+	}, []string{"peer"})
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(t.fetchRate),
+		registerer.Register(t.fetchedFrom),
+	)
+	return errs.Err
+}
+
+// Select the beacon to send the next request to. Connected beacons with the
+// most throughput to spare are preferred. [avoid] is only selected if no
+// other beacon is connected.
+func (t *FetchTracker) Select(beacons validators.Set, avoid ids.ShortID) (ids.ShortID, error) {
+	// Peers that haven't been measured yet are assumed to be as fast as the
+	// fastest peer, so that they're tried
+	optimistic := 1.
+	for _, peer := range t.peers {
+		if peer.throughput != nil && peer.throughput.Read() > optimistic {
+			optimistic = peer.throughput.Read()
+		}
+	}
+
+	var (
+		best      ids.ShortID
+		bestScore float64
+		found     bool
+	)
+	for _, vdr := range beacons.List() {
+		vdrID := vdr.ID()
+		if t.disconnected.Contains(vdrID) || (!avoid.IsZero() && vdrID.Equals(avoid)) {
+			continue
+		}
+
+		throughput := optimistic
+		outstanding := 0
+		if peer, ok := t.peers[vdrID.Key()]; ok {
+			if peer.throughput != nil {
+				throughput = peer.throughput.Read()
+			}
+			outstanding = peer.outstanding
+		}
+		// The throughput the peer would give each of its requests if it were
+		// sent another one
+		score := throughput / float64(outstanding+1)
+		if !found || score > bestScore {
+			best = vdrID
+			bestScore = score
+			found = true
+		}
+	}
+	if found {
+		return best, nil
+	}
+
+	// No other beacon is connected, so fall back to any beacon
+	vdrs, err := beacons.Sample(1)
+	if err != nil {
+		return ids.ShortID{}, err
+	}
+	return vdrs[0].ID(), nil
+}
+
+// Sent marks that request [requestID] was sent to [vdr]
+func (t *FetchTracker) Sent(vdr ids.ShortID, requestID uint32) {
+	t.peer(vdr).outstanding++
+	t.sent[requestID] = t.clock.Time()
+}
+
+// Received marks that [vdr] responded to request [requestID] with
+// [numContainers] containers
+func (t *FetchTracker) Received(vdr ids.ShortID, requestID uint32, numContainers int) {
+	t.done(vdr, requestID, numContainers)
+	t.fetchedFrom.WithLabelValues(vdr.String()).Add(float64(numContainers))
+}
+
+// Failed marks that request [requestID] to [vdr] failed or timed out
+func (t *FetchTracker) Failed(vdr ids.ShortID, requestID uint32) {
+	t.done(vdr, requestID, 0)
+}
+
+// Connected marks that [vdr] is connected
+func (t *FetchTracker) Connected(vdr ids.ShortID) { t.disconnected.Remove(vdr) }
+
+// Disconnected marks that [vdr] is disconnected, so requests aren't sent to it
+// unless no other beacon is connected
+func (t *FetchTracker) Disconnected(vdr ids.ShortID) { t.disconnected.Add(vdr) }
+
+// Throughput returns the estimated number of containers per second [vdr]
+// serves, and false if no request to [vdr] has finished yet
+func (t *FetchTracker) Throughput(vdr ids.ShortID) (float64, bool) {
+	peer, ok := t.peers[vdr.Key()]
+	if !ok || peer.throughput == nil {
+		return 0, false
+	}
+	return peer.throughput.Read(), true
+}
+
+// done records the throughput of a finished request
+func (t *FetchTracker) done(vdr ids.ShortID, requestID uint32, numContainers int) {
+	sentTime, ok := t.sent[requestID]
+	if !ok {
+		return
+	}
+	delete(t.sent, requestID)
+
+	peer := t.peer(vdr)
+	peer.outstanding--
+
+	currentTime := t.clock.Time()
+	duration := currentTime.Sub(sentTime)
+	if duration < minFetchDuration {
+		duration = minFetchDuration
+	}
+	throughput := float64(numContainers) / duration.Seconds()
+	if peer.throughput == nil {
+		peer.throughput = math.NewAverager(throughput, fetchThroughputHalflife, currentTime)
+	} else {
+		peer.throughput.Observe(throughput, currentTime)
+	}
+
+	fetchRate := 0.
+	for _, peer := range t.peers {
+		if peer.throughput != nil {
+			fetchRate += peer.throughput.Read()
+		}
+	}
+	t.fetchRate.Set(fetchRate)
+}
+
+func (t *FetchTracker) peer(vdr ids.ShortID) *fetchPeer {
+	key := vdr.Key()
+	peer, ok := t.peers[key]
+	if !ok {
+		peer = &fetchPeer{}
+		t.peers[key] = peer
+	}
+	return peer
+}
@@ -0,0 +1,106 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/validators"
+)
+
+func newFetchTracker(t *testing.T, numBeacons int) (*FetchTracker, validators.Set, []ids.ShortID) {
+	tracker := &FetchTracker{}
+	err := tracker.Initialize("", prometheus.NewRegistry())
+	assert.NoError(t, err)
+	tracker.clock.Set(time.Unix(0, 0))
+
+	beacons := validators.NewSet()
+	vdrIDs := make([]ids.ShortID, numBeacons)
+	for i := range vdrIDs {
+		vdrIDs[i] = ids.GenerateTestShortID()
+		err := beacons.AddWeight(vdrIDs[i], 1)
+		assert.NoError(t, err)
+	}
+	return tracker, beacons, vdrIDs
+}
+
+func TestFetchTrackerSpreadsRequests(t *testing.T) {
+	tracker, beacons, vdrIDs := newFetchTracker(t, 3)
+
+	selected := ids.ShortSet{}
+	for requestID := uint32(0); requestID < 3; requestID++ {
+		vdrID, err := tracker.Select(beacons, ids.ShortID{})
+		assert.NoError(t, err)
+		tracker.Sent(vdrID, requestID)
+		selected.Add(vdrID)
+	}
+	assert.Equal(t, len(vdrIDs), selected.Len(), "should have sent a request to each beacon")
+}
+
+func TestFetchTrackerPrefersFasterPeers(t *testing.T) {
+	tracker, beacons, vdrIDs := newFetchTracker(t, 2)
+	fast, slow := vdrIDs[0], vdrIDs[1]
+
+	tracker.Sent(fast, 0)
+	tracker.Sent(slow, 1)
+	tracker.clock.Set(time.Unix(1, 0))
+	tracker.Received(fast, 0, 100)
+	tracker.Received(slow, 1, 15)
+
+	throughput, ok := tracker.Throughput(fast)
+	assert.True(t, ok)
+	assert.Equal(t, 100., throughput)
+	throughput, ok = tracker.Throughput(slow)
+	assert.True(t, ok)
+	assert.Equal(t, 15., throughput)
+
+	// The fast peer should be sent requests until it has enough outstanding
+	// that the slow peer would serve a request faster
+	for requestID := uint32(2); requestID < 8; requestID++ {
+		vdrID, err := tracker.Select(beacons, ids.ShortID{})
+		assert.NoError(t, err)
+		assert.Equal(t, fast, vdrID, "should have sent request %d to the fast peer", requestID)
+		tracker.Sent(vdrID, requestID)
+	}
+	vdrID, err := tracker.Select(beacons, ids.ShortID{})
+	assert.NoError(t, err)
+	assert.Equal(t, slow, vdrID, "should have sent the request to the slow peer")
+}
+
+func TestFetchTrackerRetriesOnDifferentPeer(t *testing.T) {
+	tracker, beacons, vdrIDs := newFetchTracker(t, 2)
+
+	vdrID, err := tracker.Select(beacons, ids.ShortID{})
+	assert.NoError(t, err)
+	tracker.Sent(vdrID, 0)
+	tracker.Failed(vdrID, 0)
+
+	throughput, ok := tracker.Throughput(vdrID)
+	assert.True(t, ok)
+	assert.Equal(t, 0., throughput)
+
+	retryID, err := tracker.Select(beacons, vdrID)
+	assert.NoError(t, err)
+	assert.NotEqual(t, vdrID, retryID, "should have retried on a different peer")
+
+	// A disconnected peer is only selected if no other peer is connected
+	other := vdrIDs[0]
+	if other.Equals(vdrID) {
+		other = vdrIDs[1]
+	}
+	tracker.Disconnected(other)
+	retryID, err = tracker.Select(beacons, vdrID)
+	assert.NoError(t, err)
+	assert.True(t, retryID.Equals(vdrID) || retryID.Equals(other))
+
+	tracker.Connected(other)
+	retryID, err = tracker.Select(beacons, vdrID)
+	assert.NoError(t, err)
+	assert.Equal(t, other, retryID)
+}
@@ -11,6 +11,9 @@ type Fetcher struct {
 	// tracks which validators were asked for which containers in which requests
 	OutstandingRequests Requests
 
+	// decides which beacon to send each request to
+	Tracker FetchTracker
+
 	// Called when bootstrapping is done
 	OnFinished func() error
 }
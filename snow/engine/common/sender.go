@@ -5,6 +5,7 @@ package common
 
 import (
 	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow"
 )
 
 // Sender defines how a consensus engine sends messages and requests to other
@@ -15,6 +16,8 @@ type Sender interface {
 	FetchSender
 	QuerySender
 	Gossiper
+	StateSyncSender
+	snow.AppSender
 }
 
 // FrontierSender defines how a consensus engine sends frontier messages to
@@ -86,3 +89,23 @@ type Gossiper interface {
 	// Gossip gossips the provided container throughout the network
 	Gossip(containerID ids.ID, container []byte)
 }
+
+// StateSyncSender defines how a consensus engine sends state sync messages to
+// other validators
+type StateSyncSender interface {
+	// GetStateSummary requests that every validator in [validatorIDs] sends a
+	// StateSummary message.
+	GetStateSummary(validatorIDs ids.ShortSet, requestID uint32)
+
+	// StateSummary responds to a GetStateSummary message with the summary of
+	// this engine's most recent state.
+	StateSummary(validatorID ids.ShortID, requestID uint32, summary []byte)
+
+	// GetStateChunk requests that the validator with ID [validatorID] sends
+	// the chunk at [index] of the state summarized by [summaryID].
+	GetStateChunk(validatorID ids.ShortID, requestID uint32, summaryID ids.ID, index uint32)
+
+	// StateChunk responds to a GetStateChunk message with the requested chunk
+	// of state and its proof.
+	StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte)
+}
@@ -44,6 +44,18 @@ type EngineTest struct {
 	CantQueryFailed,
 	CantChits,
 
+	CantAppRequest,
+	CantAppResponse,
+	CantAppRequestFailed,
+	CantAppGossip,
+
+	CantGetStateSummary,
+	CantStateSummary,
+	CantGetStateSummaryFailed,
+	CantGetStateChunk,
+	CantStateChunk,
+	CantGetStateChunkFailed,
+
 	CantConnected,
 	CantDisconnected,
 
@@ -61,6 +73,13 @@ type EngineTest struct {
 	QueryFailedF, GetAcceptedFrontierFailedF, GetAcceptedFailedF func(validatorID ids.ShortID, requestID uint32) error
 	ConnectedF, DisconnectedF func(validatorID ids.ShortID) error
 	HealthF                   func() (interface{}, error)
+	AppRequestF, AppResponseF func(validatorID ids.ShortID, requestID uint32, msg []byte) error
+	AppRequestFailedF         func(validatorID ids.ShortID, requestID uint32) error
+	AppGossipF                func(validatorID ids.ShortID, msg []byte) error
+	GetStateSummaryF, GetStateSummaryFailedF,
+	GetStateChunkFailedF func(validatorID ids.ShortID, requestID uint32) error
+	StateSummaryF, StateChunkF func(validatorID ids.ShortID, requestID uint32, msg []byte) error
+	GetStateChunkF             func(validatorID ids.ShortID, requestID uint32, summaryID ids.ID, index uint32) error
 }
 
 var _ Engine = &EngineTest{}
@@ -97,6 +116,18 @@ func (e *EngineTest) Default(cant bool) {
 	e.CantQueryFailed = cant
 	e.CantChits = cant
 
+	e.CantAppRequest = cant
+	e.CantAppResponse = cant
+	e.CantAppRequestFailed = cant
+	e.CantAppGossip = cant
+
+	e.CantGetStateSummary = cant
+	e.CantStateSummary = cant
+	e.CantGetStateSummaryFailed = cant
+	e.CantGetStateChunk = cant
+	e.CantStateChunk = cant
+	e.CantGetStateChunkFailed = cant
+
 	e.CantConnected = cant
 	e.CantDisconnected = cant
 
@@ -444,3 +475,143 @@ func (e *EngineTest) Health() (interface{}, error) {
 	}
 	return nil, errors.New("unexpectedly called Health")
 }
+
+// AppRequest ...
+func (e *EngineTest) AppRequest(validatorID ids.ShortID, requestID uint32, request []byte) error {
+	if e.AppRequestF != nil {
+		return e.AppRequestF(validatorID, requestID, request)
+	}
+	if !e.CantAppRequest {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called AppRequest")
+	}
+	return errors.New("unexpectedly called AppRequest")
+}
+
+// AppResponse ...
+func (e *EngineTest) AppResponse(validatorID ids.ShortID, requestID uint32, response []byte) error {
+	if e.AppResponseF != nil {
+		return e.AppResponseF(validatorID, requestID, response)
+	}
+	if !e.CantAppResponse {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called AppResponse")
+	}
+	return errors.New("unexpectedly called AppResponse")
+}
+
+// AppRequestFailed ...
+func (e *EngineTest) AppRequestFailed(validatorID ids.ShortID, requestID uint32) error {
+	if e.AppRequestFailedF != nil {
+		return e.AppRequestFailedF(validatorID, requestID)
+	}
+	if !e.CantAppRequestFailed {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called AppRequestFailed")
+	}
+	return errors.New("unexpectedly called AppRequestFailed")
+}
+
+// AppGossip ...
+func (e *EngineTest) AppGossip(validatorID ids.ShortID, msg []byte) error {
+	if e.AppGossipF != nil {
+		return e.AppGossipF(validatorID, msg)
+	}
+	if !e.CantAppGossip {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called AppGossip")
+	}
+	return errors.New("unexpectedly called AppGossip")
+}
+
+// GetStateSummary ...
+func (e *EngineTest) GetStateSummary(validatorID ids.ShortID, requestID uint32) error {
+	if e.GetStateSummaryF != nil {
+		return e.GetStateSummaryF(validatorID, requestID)
+	}
+	if !e.CantGetStateSummary {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called GetStateSummary")
+	}
+	return errors.New("unexpectedly called GetStateSummary")
+}
+
+// StateSummary ...
+func (e *EngineTest) StateSummary(validatorID ids.ShortID, requestID uint32, summary []byte) error {
+	if e.StateSummaryF != nil {
+		return e.StateSummaryF(validatorID, requestID, summary)
+	}
+	if !e.CantStateSummary {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called StateSummary")
+	}
+	return errors.New("unexpectedly called StateSummary")
+}
+
+// GetStateSummaryFailed ...
+func (e *EngineTest) GetStateSummaryFailed(validatorID ids.ShortID, requestID uint32) error {
+	if e.GetStateSummaryFailedF != nil {
+		return e.GetStateSummaryFailedF(validatorID, requestID)
+	}
+	if !e.CantGetStateSummaryFailed {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called GetStateSummaryFailed")
+	}
+	return errors.New("unexpectedly called GetStateSummaryFailed")
+}
+
+// GetStateChunk ...
+func (e *EngineTest) GetStateChunk(validatorID ids.ShortID, requestID uint32, summaryID ids.ID, index uint32) error {
+	if e.GetStateChunkF != nil {
+		return e.GetStateChunkF(validatorID, requestID, summaryID, index)
+	}
+	if !e.CantGetStateChunk {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called GetStateChunk")
+	}
+	return errors.New("unexpectedly called GetStateChunk")
+}
+
+// StateChunk ...
+func (e *EngineTest) StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte) error {
+	if e.StateChunkF != nil {
+		return e.StateChunkF(validatorID, requestID, chunk)
+	}
+	if !e.CantStateChunk {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called StateChunk")
+	}
+	return errors.New("unexpectedly called StateChunk")
+}
+
+// GetStateChunkFailed ...
+func (e *EngineTest) GetStateChunkFailed(validatorID ids.ShortID, requestID uint32) error {
+	if e.GetStateChunkFailedF != nil {
+		return e.GetStateChunkFailedF(validatorID, requestID)
+	}
+	if !e.CantGetStateChunkFailed {
+		return nil
+	}
+	if e.T != nil {
+		e.T.Fatalf("Unexpectedly called GetStateChunkFailed")
+	}
+	return errors.New("unexpectedly called GetStateChunkFailed")
+}
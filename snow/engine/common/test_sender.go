@@ -4,6 +4,7 @@
 package common
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/liraxapp/avalanchego/ids"
@@ -17,7 +18,10 @@ type SenderTest struct {
 	CantGetAccepted, CantAccepted,
 	CantGet, CantGetAncestors, CantPut, CantMultiPut,
 	CantPullQuery, CantPushQuery, CantChits,
-	CantGossip bool
+	CantGossip,
+	CantSendAppRequest, CantSendAppResponse, CantSendAppGossip,
+	CantGetStateSummary, CantStateSummary,
+	CantGetStateChunk, CantStateChunk bool
 
 	GetAcceptedFrontierF func(ids.ShortSet, uint32)
 	AcceptedFrontierF    func(ids.ShortID, uint32, []ids.ID)
@@ -31,6 +35,13 @@ type SenderTest struct {
 	PullQueryF           func(ids.ShortSet, uint32, ids.ID)
 	ChitsF               func(ids.ShortID, uint32, []ids.ID)
 	GossipF              func(ids.ID, []byte)
+	SendAppRequestF      func(ids.ShortSet, uint32, []byte) error
+	SendAppResponseF     func(ids.ShortID, uint32, []byte) error
+	SendAppGossipF       func([]byte) error
+	GetStateSummaryF     func(ids.ShortSet, uint32)
+	StateSummaryF        func(ids.ShortID, uint32, []byte)
+	GetStateChunkF       func(ids.ShortID, uint32, ids.ID, uint32)
+	StateChunkF          func(ids.ShortID, uint32, []byte)
 }
 
 // Default set the default callable value to [cant]
@@ -47,6 +58,13 @@ func (s *SenderTest) Default(cant bool) {
 	s.CantPushQuery = cant
 	s.CantChits = cant
 	s.CantGossip = cant
+	s.CantSendAppRequest = cant
+	s.CantSendAppResponse = cant
+	s.CantSendAppGossip = cant
+	s.CantGetStateSummary = cant
+	s.CantStateSummary = cant
+	s.CantGetStateChunk = cant
+	s.CantStateChunk = cant
 }
 
 // GetAcceptedFrontier calls GetAcceptedFrontierF if it was initialized. If it
@@ -180,3 +198,86 @@ func (s *SenderTest) Gossip(containerID ids.ID, container []byte) {
 		s.T.Fatalf("Unexpectedly called Gossip")
 	}
 }
+
+// SendAppRequest calls SendAppRequestF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *SenderTest) SendAppRequest(validatorIDs ids.ShortSet, requestID uint32, appRequestBytes []byte) error {
+	switch {
+	case s.SendAppRequestF != nil:
+		return s.SendAppRequestF(validatorIDs, requestID, appRequestBytes)
+	case s.CantSendAppRequest && s.T != nil:
+		s.T.Fatalf("Unexpectedly called SendAppRequest")
+	}
+	return errors.New("unexpectedly called SendAppRequest")
+}
+
+// SendAppResponse calls SendAppResponseF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *SenderTest) SendAppResponse(validatorID ids.ShortID, requestID uint32, appResponseBytes []byte) error {
+	switch {
+	case s.SendAppResponseF != nil:
+		return s.SendAppResponseF(validatorID, requestID, appResponseBytes)
+	case s.CantSendAppResponse && s.T != nil:
+		s.T.Fatalf("Unexpectedly called SendAppResponse")
+	}
+	return errors.New("unexpectedly called SendAppResponse")
+}
+
+// SendAppGossip calls SendAppGossipF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *SenderTest) SendAppGossip(appGossipBytes []byte) error {
+	switch {
+	case s.SendAppGossipF != nil:
+		return s.SendAppGossipF(appGossipBytes)
+	case s.CantSendAppGossip && s.T != nil:
+		s.T.Fatalf("Unexpectedly called SendAppGossip")
+	}
+	return errors.New("unexpectedly called SendAppGossip")
+}
+
+// GetStateSummary calls GetStateSummaryF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *SenderTest) GetStateSummary(validatorIDs ids.ShortSet, requestID uint32) {
+	if s.GetStateSummaryF != nil {
+		s.GetStateSummaryF(validatorIDs, requestID)
+	} else if s.CantGetStateSummary && s.T != nil {
+		s.T.Fatalf("Unexpectedly called GetStateSummary")
+	}
+}
+
+// StateSummary calls StateSummaryF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *SenderTest) StateSummary(validatorID ids.ShortID, requestID uint32, summary []byte) {
+	if s.StateSummaryF != nil {
+		s.StateSummaryF(validatorID, requestID, summary)
+	} else if s.CantStateSummary && s.T != nil {
+		s.T.Fatalf("Unexpectedly called StateSummary")
+	}
+}
+
+// GetStateChunk calls GetStateChunkF if it was initialized. If it wasn't
+// initialized and this function shouldn't be called and testing was
+// initialized, then testing will fail.
+func (s *SenderTest) GetStateChunk(validatorID ids.ShortID, requestID uint32, summaryID ids.ID, index uint32) {
+	if s.GetStateChunkF != nil {
+		s.GetStateChunkF(validatorID, requestID, summaryID, index)
+	} else if s.CantGetStateChunk && s.T != nil {
+		s.T.Fatalf("Unexpectedly called GetStateChunk")
+	}
+}
+
+// StateChunk calls StateChunkF if it was initialized. If it wasn't initialized
+// and this function shouldn't be called and testing was initialized, then
+// testing will fail.
+func (s *SenderTest) StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte) {
+	if s.StateChunkF != nil {
+		s.StateChunkF(validatorID, requestID, chunk)
+	} else if s.CantStateChunk && s.T != nil {
+		s.T.Fatalf("Unexpectedly called StateChunk")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/snow"
 )
 
@@ -21,13 +22,17 @@ type TestVM struct {
 
 	CantInitialize, CantBootstrapping, CantBootstrapped,
 	CantShutdown, CantCreateHandlers, CantCreateStaticHandlers,
-	CantHealth bool
+	CantHealth,
+	CantAppRequest, CantAppResponse, CantAppRequestFailed, CantAppGossip bool
 
 	InitializeF                              func(*snow.Context, database.Database, []byte, chan<- Message, []*Fx) error
 	BootstrappingF, BootstrappedF, ShutdownF func() error
 	CreateHandlersF                          func() map[string]*HTTPHandler
 	CreateStaticHandlersF                    func() map[string]*HTTPHandler
 	HealthF                                  func() (interface{}, error)
+	AppRequestF, AppResponseF                func(validatorID ids.ShortID, requestID uint32, msg []byte) error
+	AppRequestFailedF                        func(validatorID ids.ShortID, requestID uint32) error
+	AppGossipF                               func(validatorID ids.ShortID, msg []byte) error
 }
 
 // Default ...
@@ -39,6 +44,10 @@ func (vm *TestVM) Default(cant bool) {
 	vm.CantCreateHandlers = cant
 	vm.CantCreateStaticHandlers = cant
 	vm.CantHealth = cant
+	vm.CantAppRequest = cant
+	vm.CantAppResponse = cant
+	vm.CantAppRequestFailed = cant
+	vm.CantAppGossip = cant
 }
 
 // Initialize ...
@@ -123,3 +132,59 @@ func (vm *TestVM) Health() (interface{}, error) {
 	}
 	return nil, errors.New("Unexpectedly called Health")
 }
+
+// AppRequest ...
+func (vm *TestVM) AppRequest(validatorID ids.ShortID, requestID uint32, request []byte) error {
+	if vm.AppRequestF != nil {
+		return vm.AppRequestF(validatorID, requestID, request)
+	}
+	if !vm.CantAppRequest {
+		return nil
+	}
+	if vm.T != nil {
+		vm.T.Fatalf("Unexpectedly called AppRequest")
+	}
+	return errors.New("unexpectedly called AppRequest")
+}
+
+// AppResponse ...
+func (vm *TestVM) AppResponse(validatorID ids.ShortID, requestID uint32, response []byte) error {
+	if vm.AppResponseF != nil {
+		return vm.AppResponseF(validatorID, requestID, response)
+	}
+	if !vm.CantAppResponse {
+		return nil
+	}
+	if vm.T != nil {
+		vm.T.Fatalf("Unexpectedly called AppResponse")
+	}
+	return errors.New("unexpectedly called AppResponse")
+}
+
+// AppRequestFailed ...
+func (vm *TestVM) AppRequestFailed(validatorID ids.ShortID, requestID uint32) error {
+	if vm.AppRequestFailedF != nil {
+		return vm.AppRequestFailedF(validatorID, requestID)
+	}
+	if !vm.CantAppRequestFailed {
+		return nil
+	}
+	if vm.T != nil {
+		vm.T.Fatalf("Unexpectedly called AppRequestFailed")
+	}
+	return errors.New("unexpectedly called AppRequestFailed")
+}
+
+// AppGossip ...
+func (vm *TestVM) AppGossip(validatorID ids.ShortID, msg []byte) error {
+	if vm.AppGossipF != nil {
+		return vm.AppGossipF(validatorID, msg)
+	}
+	if !vm.CantAppGossip {
+		return nil
+	}
+	if vm.T != nil {
+		vm.T.Fatalf("Unexpectedly called AppGossip")
+	}
+	return errors.New("unexpectedly called AppGossip")
+}
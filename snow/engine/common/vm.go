@@ -10,6 +10,10 @@ import (
 
 // VM describes the interface that all consensus VMs must implement
 type VM interface {
+	// Handles application level messages from the VMs of other nodes. Any
+	// messages this VM sends to them go through [ctx.AppSender].
+	AppHandler
+
 	// Initialize this VM.
 	// [ctx]: Metadata about this VM.
 	//     [ctx.networkID]: The ID of the network this VM's chain is running on.
@@ -19,6 +23,8 @@ type VM interface {
 	//     [ctx.Lock]: A Read/Write lock shared by this VM and the consensus
 	//                 engine that manages this VM. The write lock is held
 	//                 whenever code in the consensus engine calls the VM.
+	//     [ctx.AppSender]: Sends application level messages to the VMs of
+	//                      other nodes.
 	// [db]: The database this VM will persist data to.
 	// [genesisBytes]: The byte-encoding of the genesis information of this
 	//                 VM. The VM uses it to initialize its state. For
@@ -0,0 +1,66 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"github.com/liraxapp/avalanchego/ids"
+)
+
+// StateSummary summarizes the state of a chain as of an accepted block. A node
+// that syncs the state of a summary doesn't need to execute the blocks before
+// it.
+type StateSummary interface {
+	// ID uniquely identifies this summary. Nodes that summarize the same state
+	// must agree on the ID.
+	ID() ids.ID
+
+	// Height of the block this summary is the state as of
+	Height() uint64
+
+	// BlockID is the ID of the block this summary is the state as of
+	BlockID() ids.ID
+
+	// NumChunks is the number of chunks the state is split into
+	NumChunks() uint32
+
+	// Bytes is the byte representation of this summary
+	Bytes() []byte
+}
+
+// StateSyncableVM is a ChainVM that can sync its state from a summary, rather
+// than executing every block since genesis. The state is fetched in chunks,
+// each of which carries a proof that it is part of the state of the summary.
+type StateSyncableVM interface {
+	ChainVM
+
+	// GetStateSummary returns the summary of the state as of a recently
+	// accepted block.
+	GetStateSummary() (StateSummary, error)
+
+	// ParseStateSummary attempts to parse a summary from a stream of bytes.
+	ParseStateSummary([]byte) (StateSummary, error)
+
+	// ShouldSyncState returns true if the state of [summary] should be synced.
+	// For example, a VM may not want to sync a summary that isn't far beyond
+	// its last accepted block.
+	ShouldSyncState(summary StateSummary) (bool, error)
+
+	// GetStateChunk returns the chunk at [index] of the state summarized by
+	// [summaryID], along with its proof. If the summary or the chunk isn't
+	// available, an error should be returned.
+	GetStateChunk(summaryID ids.ID, index uint32) ([]byte, error)
+
+	// SyncStateChunk verifies the proof of [chunk], the chunk at [index] of
+	// the state of [summary], and stores the chunk. If the chunk is invalid,
+	// an error should be returned. The stored chunks must not modify the state
+	// until StateSynced is called, as state sync may be abandoned before every
+	// chunk was synced.
+	SyncStateChunk(summary StateSummary, index uint32, chunk []byte) error
+
+	// StateSynced is called once every chunk of [summary] was synced. The VM
+	// should then replace its state with the stored chunks and mark the block
+	// of [summary] as its last accepted block, so that only the blocks after
+	// it are fetched and executed.
+	StateSynced(summary StateSummary) error
+}
@@ -0,0 +1,140 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package block
+
+import (
+	"errors"
+
+	"github.com/liraxapp/avalanchego/ids"
+)
+
+var (
+	errGetStateSummary   = errors.New("unexpectedly called GetStateSummary")
+	errParseStateSummary = errors.New("unexpectedly called ParseStateSummary")
+	errShouldSyncState   = errors.New("unexpectedly called ShouldSyncState")
+	errGetStateChunk     = errors.New("unexpectedly called GetStateChunk")
+	errSyncStateChunk    = errors.New("unexpectedly called SyncStateChunk")
+	errStateSynced       = errors.New("unexpectedly called StateSynced")
+)
+
+// TestStateSummary ...
+type TestStateSummary struct {
+	IDV        ids.ID
+	HeightV    uint64
+	BlockIDV   ids.ID
+	NumChunksV uint32
+	BytesV     []byte
+}
+
+// ID ...
+func (s *TestStateSummary) ID() ids.ID { return s.IDV }
+
+// Height ...
+func (s *TestStateSummary) Height() uint64 { return s.HeightV }
+
+// BlockID ...
+func (s *TestStateSummary) BlockID() ids.ID { return s.BlockIDV }
+
+// NumChunks ...
+func (s *TestStateSummary) NumChunks() uint32 { return s.NumChunksV }
+
+// Bytes ...
+func (s *TestStateSummary) Bytes() []byte { return s.BytesV }
+
+// TestStateSyncableVM ...
+type TestStateSyncableVM struct {
+	TestVM
+
+	CantGetStateSummary,
+	CantParseStateSummary,
+	CantShouldSyncState,
+	CantGetStateChunk,
+	CantSyncStateChunk,
+	CantStateSynced bool
+
+	GetStateSummaryF   func() (StateSummary, error)
+	ParseStateSummaryF func([]byte) (StateSummary, error)
+	ShouldSyncStateF   func(StateSummary) (bool, error)
+	GetStateChunkF     func(ids.ID, uint32) ([]byte, error)
+	SyncStateChunkF    func(StateSummary, uint32, []byte) error
+	StateSyncedF       func(StateSummary) error
+}
+
+// Default ...
+func (vm *TestStateSyncableVM) Default(cant bool) {
+	vm.TestVM.Default(cant)
+
+	vm.CantGetStateSummary = cant
+	vm.CantParseStateSummary = cant
+	vm.CantShouldSyncState = cant
+	vm.CantGetStateChunk = cant
+	vm.CantSyncStateChunk = cant
+	vm.CantStateSynced = cant
+}
+
+// GetStateSummary ...
+func (vm *TestStateSyncableVM) GetStateSummary() (StateSummary, error) {
+	if vm.GetStateSummaryF != nil {
+		return vm.GetStateSummaryF()
+	}
+	if vm.CantGetStateSummary && vm.T != nil {
+		vm.T.Fatal(errGetStateSummary)
+	}
+	return nil, errGetStateSummary
+}
+
+// ParseStateSummary ...
+func (vm *TestStateSyncableVM) ParseStateSummary(b []byte) (StateSummary, error) {
+	if vm.ParseStateSummaryF != nil {
+		return vm.ParseStateSummaryF(b)
+	}
+	if vm.CantParseStateSummary && vm.T != nil {
+		vm.T.Fatal(errParseStateSummary)
+	}
+	return nil, errParseStateSummary
+}
+
+// ShouldSyncState ...
+func (vm *TestStateSyncableVM) ShouldSyncState(summary StateSummary) (bool, error) {
+	if vm.ShouldSyncStateF != nil {
+		return vm.ShouldSyncStateF(summary)
+	}
+	if vm.CantShouldSyncState && vm.T != nil {
+		vm.T.Fatal(errShouldSyncState)
+	}
+	return false, errShouldSyncState
+}
+
+// GetStateChunk ...
+func (vm *TestStateSyncableVM) GetStateChunk(summaryID ids.ID, index uint32) ([]byte, error) {
+	if vm.GetStateChunkF != nil {
+		return vm.GetStateChunkF(summaryID, index)
+	}
+	if vm.CantGetStateChunk && vm.T != nil {
+		vm.T.Fatal(errGetStateChunk)
+	}
+	return nil, errGetStateChunk
+}
+
+// SyncStateChunk ...
+func (vm *TestStateSyncableVM) SyncStateChunk(summary StateSummary, index uint32, chunk []byte) error {
+	if vm.SyncStateChunkF != nil {
+		return vm.SyncStateChunkF(summary, index, chunk)
+	}
+	if vm.CantSyncStateChunk && vm.T != nil {
+		vm.T.Fatal(errSyncStateChunk)
+	}
+	return errSyncStateChunk
+}
+
+// StateSynced ...
+func (vm *TestStateSyncableVM) StateSynced(summary StateSummary) error {
+	if vm.StateSyncedF != nil {
+		return vm.StateSyncedF(summary)
+	}
+	if vm.CantStateSynced && vm.T != nil {
+		vm.T.Fatal(errStateSynced)
+	}
+	return errStateSynced
+}
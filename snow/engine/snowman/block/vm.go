@@ -55,3 +55,26 @@ type ChainVM interface {
 	// returned.
 	LastAccepted() ids.ID
 }
+
+// HeightIndexedChainVM is a ChainVM that indexes its accepted blocks by height,
+// so that an accepted block can be looked up without walking back from the
+// last accepted block.
+type HeightIndexedChainVM interface {
+	ChainVM
+
+	// GetBlockIDAtHeight returns the ID of the accepted block at [height].
+	//
+	// If no block has been accepted at [height], an error should be returned.
+	GetBlockIDAtHeight(height uint64) (ids.ID, error)
+}
+
+// Negotiator is a ChainVM whose optional interfaces, such as
+// StateSyncableVM, are only known once it's initialized. For example, a VM
+// that runs in another process.
+type Negotiator interface {
+	ChainVM
+
+	// Negotiated returns this VM as a ChainVM that implements exactly the
+	// optional interfaces the VM supports. It must be called after Initialize.
+	Negotiated() ChainVM
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/liraxapp/avalanchego/cache"
+	"github.com/liraxapp/avalanchego/cache/metercacher"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/snow/choices"
 	"github.com/liraxapp/avalanchego/snow/consensus/snowman"
@@ -18,6 +20,12 @@ import (
 	"github.com/liraxapp/avalanchego/utils/formatting"
 )
 
+const (
+	// Number of processed blocks whose IDs are cached, so that the ancestors of
+	// a block aren't traversed again when its descendants are processed
+	cacheSize = 100000
+)
+
 // Config ...
 type Config struct {
 	common.Config
@@ -28,6 +36,11 @@ type Config struct {
 	VM block.ChainVM
 
 	Bootstrapped func()
+
+	// StateSync is true if the state of the chain should be synced from a
+	// summary agreed on by the beacons, if the VM supports it, rather than
+	// executing every block since genesis
+	StateSync bool
 }
 
 // Bootstrapper ...
@@ -43,8 +56,14 @@ type Bootstrapper struct {
 
 	Bootstrapped func()
 
+	// tracks the progress of syncing the state, if it is enabled
+	sync stateSync
+
 	// true if all of the vertices in the original accepted frontier have been processed
 	processedStartingAcceptedFrontier bool
+
+	// Contains IDs of blocks that have recently been processed
+	processedCache cache.Cacher
 }
 
 // Initialize this engine.
@@ -58,10 +77,20 @@ func (b *Bootstrapper) Initialize(
 	b.VM = config.VM
 	b.Bootstrapped = config.Bootstrapped
 	b.OnFinished = onFinished
+	b.sync.enabled = config.StateSync
 
 	if err := b.metrics.Initialize(namespace, registerer); err != nil {
 		return err
 	}
+	if err := b.Tracker.Initialize(namespace, registerer); err != nil {
+		return err
+	}
+
+	processedCache, err := metercacher.New(namespace, "processed_cache", registerer, &cache.LRU{Size: cacheSize})
+	if err != nil {
+		return err
+	}
+	b.processedCache = processedCache
 
 	b.Blocked.SetParser(&parser{
 		log:         config.Ctx.Log,
@@ -98,12 +127,21 @@ func (b *Bootstrapper) ForceAccepted(acceptedContainerIDs []ids.ID) error {
 			err)
 	}
 
+	if vm, ok := b.VM.(block.StateSyncableVM); ok && b.sync.enabled {
+		return b.startStateSync(vm, acceptedContainerIDs)
+	}
+	return b.fetchAccepted(acceptedContainerIDs)
+}
+
+// fetchAccepted fetches and processes [acceptedContainerIDs] and their
+// ancestors that haven't been accepted yet
+func (b *Bootstrapper) fetchAccepted(acceptedContainerIDs []ids.ID) error {
 	for _, blkID := range acceptedContainerIDs {
 		if blk, err := b.VM.GetBlock(blkID); err == nil {
 			if err := b.process(blk); err != nil {
 				return err
 			}
-		} else if err := b.fetch(blkID); err != nil {
+		} else if err := b.fetch(blkID, ids.ShortID{}); err != nil {
 			return err
 		}
 	}
@@ -115,8 +153,9 @@ func (b *Bootstrapper) ForceAccepted(acceptedContainerIDs []ids.ID) error {
 	return nil
 }
 
-// Get block [blkID] and its ancestors from a validator
-func (b *Bootstrapper) fetch(blkID ids.ID) error {
+// Get block [blkID] and its ancestors from a beacon other than [avoid], if
+// another beacon is connected
+func (b *Bootstrapper) fetch(blkID ids.ID, avoid ids.ShortID) error {
 	// Make sure we haven't already requested this block
 	if b.OutstandingRequests.Contains(blkID) {
 		return nil
@@ -130,14 +169,14 @@ func (b *Bootstrapper) fetch(blkID ids.ID) error {
 		return nil
 	}
 
-	validators, err := b.Beacons.Sample(1) // validator to send request to
+	validatorID, err := b.Tracker.Select(b.Beacons, avoid) // validator to send request to
 	if err != nil {
 		return fmt.Errorf("dropping request for %s as there are no validators", blkID)
 	}
-	validatorID := validators[0].ID()
 	b.RequestID++
 
 	b.OutstandingRequests.Add(validatorID, b.RequestID, blkID)
+	b.Tracker.Sent(validatorID, b.RequestID)
 	b.Sender.GetAncestors(validatorID, b.RequestID, blkID) // request block and ancestors
 	return nil
 }
@@ -165,20 +204,38 @@ func (b *Bootstrapper) MultiPut(vdr ids.ShortID, requestID uint32, blks [][]byte
 	wantedBlk, err := b.VM.ParseBlock(blks[0]) // the block we requested
 	if err != nil {
 		b.Ctx.Log.Debug("Failed to parse requested block %s: %s", wantedBlkID, err)
-		return b.fetch(wantedBlkID)
+		b.Tracker.Failed(vdr, requestID)
+		return b.fetch(wantedBlkID, vdr)
 	} else if actualID := wantedBlk.ID(); actualID != wantedBlkID {
 		b.Ctx.Log.Debug("expected the first block to be the requested block, %s, but is %s",
 			wantedBlk, actualID)
-		return b.fetch(wantedBlkID)
+		b.Tracker.Failed(vdr, requestID)
+		return b.fetch(wantedBlkID, vdr)
 	}
-
-	for _, blkBytes := range blks {
-		if _, err := b.VM.ParseBlock(blkBytes); err != nil { // persists the block
+	b.Tracker.Received(vdr, requestID, len(blks))
+
+	// the parent of the oldest block in [blks] that is an ancestor of
+	// [wantedBlk]
+	oldestParent := wantedBlk.Parent()
+	for _, blkBytes := range blks[1:] {
+		blk, err := b.VM.ParseBlock(blkBytes) // persists the block
+		if err != nil {
 			b.Ctx.Log.Debug("Failed to parse block: %s", err)
 			b.Ctx.Log.Verbo("block: %s", formatting.DumpBytes{Bytes: blkBytes})
+			continue
+		}
+		if blk.ID() == oldestParent.ID() {
+			oldestParent = blk.Parent()
 		}
 	}
 
+	// Request the ancestors of the oldest block before processing the blocks,
+	// so that the next response is on its way while they're processed
+	if oldestParent.Status() == choices.Unknown {
+		if err := b.fetch(oldestParent.ID(), ids.ShortID{}); err != nil {
+			return err
+		}
+	}
 	return b.process(wantedBlk)
 }
 
@@ -190,8 +247,10 @@ func (b *Bootstrapper) GetAncestorsFailed(vdr ids.ShortID, requestID uint32) err
 			vdr, requestID)
 		return nil
 	}
-	// Send another request for this
-	return b.fetch(blkID)
+	b.Tracker.Failed(vdr, requestID)
+
+	// Send another request for this, to a different beacon
+	return b.fetch(blkID, vdr)
 }
 
 // process a block
@@ -199,6 +258,10 @@ func (b *Bootstrapper) process(blk snowman.Block) error {
 	status := blk.Status()
 	blkID := blk.ID()
 	for status == choices.Processing {
+		if _, ok := b.processedCache.Get(blkID); ok {
+			// The ancestors of [blk] were already processed
+			break
+		}
 		if err := b.Blocked.Push(&blockJob{
 			numAccepted: b.numAccepted,
 			numDropped:  b.numDropped,
@@ -214,6 +277,7 @@ func (b *Bootstrapper) process(blk snowman.Block) error {
 		if err := b.Blocked.Commit(); err != nil {
 			return err
 		}
+		b.processedCache.Put(blkID, nil)
 
 		// Process this block's parent
 		blk = blk.Parent()
@@ -223,7 +287,7 @@ func (b *Bootstrapper) process(blk snowman.Block) error {
 
 	switch status := blk.Status(); status {
 	case choices.Unknown:
-		if err := b.fetch(blkID); err != nil {
+		if err := b.fetch(blkID, ids.ShortID{}); err != nil {
 			return err
 		}
 	case choices.Rejected: // Should never happen
@@ -290,6 +354,7 @@ func (b *Bootstrapper) Connected(validatorID ids.ShortID) error {
 	if connector, ok := b.VM.(validators.Connector); ok {
 		connector.Connected(validatorID)
 	}
+	b.Tracker.Connected(validatorID)
 	return b.Bootstrapper.Connected(validatorID)
 }
 
@@ -298,5 +363,29 @@ func (b *Bootstrapper) Disconnected(validatorID ids.ShortID) error {
 	if connector, ok := b.VM.(validators.Connector); ok {
 		connector.Disconnected(validatorID)
 	}
+	b.Tracker.Disconnected(validatorID)
 	return b.Bootstrapper.Disconnected(validatorID)
 }
+
+// AppRequest implements the Engine interface by passing the request on to the
+// VM
+func (b *Bootstrapper) AppRequest(validatorID ids.ShortID, requestID uint32, request []byte) error {
+	return b.VM.AppRequest(validatorID, requestID, request)
+}
+
+// AppResponse implements the Engine interface by passing the response on to
+// the VM
+func (b *Bootstrapper) AppResponse(validatorID ids.ShortID, requestID uint32, response []byte) error {
+	return b.VM.AppResponse(validatorID, requestID, response)
+}
+
+// AppRequestFailed implements the Engine interface by notifying the VM
+func (b *Bootstrapper) AppRequestFailed(validatorID ids.ShortID, requestID uint32) error {
+	return b.VM.AppRequestFailed(validatorID, requestID)
+}
+
+// AppGossip implements the Engine interface by passing the message on to the
+// VM
+func (b *Bootstrapper) AppGossip(validatorID ids.ShortID, msg []byte) error {
+	return b.VM.AppGossip(validatorID, msg)
+}
@@ -585,6 +585,159 @@ func TestBootstrapperMultiPut(t *testing.T) {
 	}
 }
 
+// A failed request is retried on a different beacon, and the ancestors of a
+// response are requested from the beacon with the most throughput to spare
+func TestBootstrapperRetryOnDifferentBeacon(t *testing.T) {
+	config, peerID, sender, vm := newConfig(t)
+
+	otherPeerID := ids.GenerateTestShortID()
+	if err := config.Beacons.AddWeight(otherPeerID, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	blkID0 := ids.Empty.Prefix(0)
+	blkID1 := ids.Empty.Prefix(1)
+	blkID2 := ids.Empty.Prefix(2)
+	blkID3 := ids.Empty.Prefix(3)
+
+	blkBytes1 := []byte{1}
+	blkBytes2 := []byte{2}
+	blkBytes3 := []byte{3}
+
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID0,
+			StatusV: choices.Accepted,
+		},
+		HeightV: 0,
+	}
+	blk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID1,
+			StatusV: choices.Unknown,
+		},
+		ParentV: blk0,
+		HeightV: 1,
+		BytesV:  blkBytes1,
+	}
+	blk2 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID2,
+			StatusV: choices.Unknown,
+		},
+		ParentV: blk1,
+		HeightV: 2,
+		BytesV:  blkBytes2,
+	}
+	blk3 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID3,
+			StatusV: choices.Processing,
+		},
+		ParentV: blk2,
+		HeightV: 3,
+		BytesV:  blkBytes3,
+	}
+
+	vm.CantBootstrapping = false
+
+	finished := new(bool)
+	bs := Bootstrapper{}
+	err := bs.Initialize(
+		config,
+		func() error { *finished = true; return nil },
+		fmt.Sprintf("%s_%s", constants.PlatformName, config.Ctx.ChainID),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		switch {
+		case blkID == blkID0:
+			return blk0, nil
+		case blkID == blkID1 && blk1.StatusV != choices.Unknown:
+			return blk1, nil
+		case blkID == blkID2 && blk2.StatusV != choices.Unknown:
+			return blk2, nil
+		case blkID == blkID3:
+			return blk3, nil
+		}
+		return nil, errUnknownBlock
+	}
+	vm.ParseBlockF = func(blkBytes []byte) (snowman.Block, error) {
+		switch {
+		case bytes.Equal(blkBytes, blkBytes1):
+			blk1.StatusV = choices.Processing
+			return blk1, nil
+		case bytes.Equal(blkBytes, blkBytes2):
+			blk2.StatusV = choices.Processing
+			return blk2, nil
+		case bytes.Equal(blkBytes, blkBytes3):
+			return blk3, nil
+		}
+		t.Fatal(errUnknownBlock)
+		return nil, errUnknownBlock
+	}
+
+	type request struct {
+		vdr   ids.ShortID
+		reqID uint32
+		blkID ids.ID
+	}
+	requests := []request(nil)
+	sender.GetAncestorsF = func(vdr ids.ShortID, reqID uint32, blkID ids.ID) {
+		requests = append(requests, request{vdr: vdr, reqID: reqID, blkID: blkID})
+	}
+
+	if err := bs.ForceAccepted([]ids.ID{blkID3}); err != nil { // should request blk2
+		t.Fatal(err)
+	} else if len(requests) != 1 || requests[0].blkID != blkID2 {
+		t.Fatalf("should have requested blk2")
+	}
+
+	failed := requests[0]
+	if err := bs.GetAncestorsFailed(failed.vdr, failed.reqID); err != nil { // should request blk2 again
+		t.Fatal(err)
+	}
+	if len(requests) != 2 || requests[1].blkID != blkID2 {
+		t.Fatalf("should have requested blk2 again")
+	}
+	retry := requests[1]
+	switch {
+	case retry.vdr.Equals(failed.vdr):
+		t.Fatalf("should have retried on a different beacon than %s", failed.vdr)
+	case !retry.vdr.Equals(peerID) && !retry.vdr.Equals(otherPeerID):
+		t.Fatalf("should have retried on a beacon")
+	}
+
+	if err := bs.MultiPut(retry.vdr, retry.reqID, [][]byte{blkBytes2}); err != nil { // should request blk1
+		t.Fatal(err)
+	} else if len(requests) != 3 || requests[2].blkID != blkID1 {
+		t.Fatalf("should have requested blk1")
+	} else if throughput, ok := bs.Tracker.Throughput(retry.vdr); !ok || throughput == 0 {
+		t.Fatalf("should have measured the throughput of %s", retry.vdr)
+	}
+
+	vm.CantBootstrapped = false
+
+	if err := bs.MultiPut(requests[2].vdr, requests[2].reqID, [][]byte{blkBytes1}); err != nil {
+		t.Fatal(err)
+	}
+
+	switch {
+	case !*finished:
+		t.Fatalf("Bootstrapping should have finished")
+	case blk1.Status() != choices.Accepted:
+		t.Fatalf("Block should be accepted")
+	case blk2.Status() != choices.Accepted:
+		t.Fatalf("Block should be accepted")
+	case blk3.Status() != choices.Accepted:
+		t.Fatalf("Block should be accepted")
+	}
+}
+
 func TestBootstrapperAcceptedFrontier(t *testing.T) {
 	config, _, _, vm := newConfig(t)
 
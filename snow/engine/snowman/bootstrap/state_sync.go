@@ -0,0 +1,298 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"fmt"
+
+	stdmath "math"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/engine/common"
+	"github.com/liraxapp/avalanchego/snow/engine/snowman/block"
+	"github.com/liraxapp/avalanchego/utils/math"
+)
+
+// maxChunkAttempts is the number of times a chunk is requested before state
+// sync is abandoned in favor of executing every block
+const maxChunkAttempts = 8
+
+// chunkRequest is an outstanding request for a chunk of state
+type chunkRequest struct {
+	vdr   ids.ShortID
+	index uint32
+}
+
+// stateSync tracks the progress of syncing the state of a chain from a summary
+// before its blocks are fetched
+type stateSync struct {
+	enabled bool
+
+	vm block.StateSyncableVM
+
+	// accepted frontier to fetch once the state is synced
+	acceptedFrontier []ids.ID
+
+	// IDs of beacons we have requested a summary from but haven't received a
+	// reply from
+	pendingSummaries ids.ShortSet
+	summaries        map[ids.ID]block.StateSummary
+	summaryVotes     map[ids.ID]uint64
+	summaryVoters    map[ids.ID][]ids.ShortID
+
+	// summary being synced
+	summary block.StateSummary
+	// beacons that voted for [summary], which chunks are requested from in turn
+	sources    []ids.ShortID
+	nextSource int
+
+	// chunks that haven't been requested or synced yet
+	missingChunks []uint32
+	// chunk index -> number of times the chunk was requested
+	chunkAttempts map[uint32]int
+	// request ID -> outstanding chunk request
+	chunkRequests map[uint32]chunkRequest
+	numSynced     uint32
+}
+
+// startStateSync requests the summary of the state of each beacon. Once every
+// beacon has replied, the state of the summary with enough weight behind it is
+// synced and then the blocks in [acceptedFrontier] after it are fetched.
+func (b *Bootstrapper) startStateSync(vm block.StateSyncableVM, acceptedFrontier []ids.ID) error {
+	b.sync.vm = vm
+	b.sync.acceptedFrontier = acceptedFrontier
+	b.sync.summaries = make(map[ids.ID]block.StateSummary)
+	b.sync.summaryVotes = make(map[ids.ID]uint64)
+	b.sync.summaryVoters = make(map[ids.ID][]ids.ShortID)
+	for _, vdr := range b.Beacons.List() {
+		b.sync.pendingSummaries.Add(vdr.ID())
+	}
+
+	if b.sync.pendingSummaries.Len() == 0 {
+		return b.fetchAccepted(acceptedFrontier)
+	}
+
+	vdrs := ids.ShortSet{}
+	vdrs.Union(b.sync.pendingSummaries)
+
+	b.RequestID++
+	b.Sender.GetStateSummary(vdrs, b.RequestID)
+	return nil
+}
+
+// GetStateSummary implements the Engine interface by responding with the
+// summary of the VM's state. If the VM doesn't support state sync, the summary
+// is empty.
+func (b *Bootstrapper) GetStateSummary(vdr ids.ShortID, requestID uint32) error {
+	summaryBytes := []byte(nil)
+	if vm, ok := b.VM.(block.StateSyncableVM); ok {
+		summary, err := vm.GetStateSummary()
+		if err != nil {
+			b.Ctx.Log.Debug("couldn't get the state summary for GetStateSummary(%s, %d): %s",
+				vdr, requestID, err)
+		} else {
+			summaryBytes = summary.Bytes()
+		}
+	}
+	b.Sender.StateSummary(vdr, requestID, summaryBytes)
+	return nil
+}
+
+// GetStateSummaryFailed implements the Engine interface
+func (b *Bootstrapper) GetStateSummaryFailed(vdr ids.ShortID, requestID uint32) error {
+	// If we can't get a response from [vdr], act as though they have no state
+	// to sync from
+	return b.StateSummary(vdr, requestID, nil)
+}
+
+// StateSummary implements the Engine interface
+func (b *Bootstrapper) StateSummary(vdr ids.ShortID, requestID uint32, summaryBytes []byte) error {
+	if !b.sync.pendingSummaries.Contains(vdr) {
+		b.Ctx.Log.Debug("received an unexpected StateSummary from %s with ID %d",
+			vdr, requestID)
+		return nil
+	}
+	// Mark that we received a response from [vdr]
+	b.sync.pendingSummaries.Remove(vdr)
+
+	if len(summaryBytes) > 0 {
+		if summary, err := b.sync.vm.ParseStateSummary(summaryBytes); err != nil {
+			b.Ctx.Log.Debug("failed to parse the state summary from %s: %s", vdr, err)
+		} else {
+			summaryID := summary.ID()
+			weight := uint64(0)
+			if w, ok := b.Beacons.GetWeight(vdr); ok {
+				weight = w
+			}
+			newWeight, err := math.Add64(weight, b.sync.summaryVotes[summaryID])
+			if err != nil {
+				newWeight = stdmath.MaxUint64
+			}
+			b.sync.summaries[summaryID] = summary
+			b.sync.summaryVotes[summaryID] = newWeight
+			b.sync.summaryVoters[summaryID] = append(b.sync.summaryVoters[summaryID], vdr)
+		}
+	}
+
+	if b.sync.pendingSummaries.Len() != 0 {
+		return nil
+	}
+
+	// We've received a summary from every beacon. Sync the state of the summary
+	// with the most weight behind it, if the weight is sufficient.
+	var (
+		summary block.StateSummary
+		weight  uint64
+	)
+	for summaryID, votes := range b.sync.summaryVotes {
+		if s := b.sync.summaries[summaryID]; summary == nil || votes > weight ||
+			(votes == weight && s.Height() > summary.Height()) {
+			summary = s
+			weight = votes
+		}
+	}
+	if summary == nil || weight < b.Alpha {
+		b.Ctx.Log.Info("no state summary has enough weight to be synced. bootstrapping from the last accepted block")
+		return b.fetchAccepted(b.sync.acceptedFrontier)
+	}
+
+	shouldSync, err := b.sync.vm.ShouldSyncState(summary)
+	if err != nil {
+		return fmt.Errorf("failed to check if state summary %s should be synced: %w",
+			summary.ID(), err)
+	}
+	if !shouldSync {
+		b.Ctx.Log.Info("skipping state sync to height %d. bootstrapping from the last accepted block",
+			summary.Height())
+		return b.fetchAccepted(b.sync.acceptedFrontier)
+	}
+
+	b.Ctx.Log.Info("syncing %d chunks of state at height %d",
+		summary.NumChunks(), summary.Height())
+	b.sync.summary = summary
+	b.sync.sources = b.sync.summaryVoters[summary.ID()]
+	b.sync.chunkRequests = make(map[uint32]chunkRequest)
+	b.sync.chunkAttempts = make(map[uint32]int)
+	b.sync.missingChunks = make([]uint32, summary.NumChunks())
+	for i := range b.sync.missingChunks {
+		b.sync.missingChunks[i] = summary.NumChunks() - uint32(i) - 1
+	}
+	if summary.NumChunks() == 0 {
+		return b.finishStateSync()
+	}
+	b.fetchChunks()
+	return nil
+}
+
+// fetchChunks requests missing chunks from the beacons that voted for the
+// summary in turn, until the max number of requests are outstanding
+func (b *Bootstrapper) fetchChunks() {
+	for len(b.sync.chunkRequests) < common.MaxOutstandingRequests && len(b.sync.missingChunks) > 0 {
+		index := b.sync.missingChunks[len(b.sync.missingChunks)-1]
+		b.sync.missingChunks = b.sync.missingChunks[:len(b.sync.missingChunks)-1]
+
+		vdr := b.sync.sources[b.sync.nextSource%len(b.sync.sources)]
+		b.sync.nextSource++
+		b.sync.chunkAttempts[index]++
+
+		b.RequestID++
+		b.sync.chunkRequests[b.RequestID] = chunkRequest{
+			vdr:   vdr,
+			index: index,
+		}
+		b.Sender.GetStateChunk(vdr, b.RequestID, b.sync.summary.ID(), index)
+	}
+}
+
+// GetStateChunk implements the Engine interface by responding with the
+// requested chunk, if the VM has it
+func (b *Bootstrapper) GetStateChunk(vdr ids.ShortID, requestID uint32, summaryID ids.ID, index uint32) error {
+	vm, ok := b.VM.(block.StateSyncableVM)
+	if !ok {
+		b.Ctx.Log.Debug("dropping GetStateChunk(%s, %d) as the VM doesn't support state sync",
+			vdr, requestID)
+		return nil
+	}
+	chunk, err := vm.GetStateChunk(summaryID, index)
+	if err != nil {
+		b.Ctx.Log.Debug("dropping GetStateChunk(%s, %d, %s, %d): %s",
+			vdr, requestID, summaryID, index, err)
+		return nil
+	}
+	b.Sender.StateChunk(vdr, requestID, chunk)
+	return nil
+}
+
+// StateChunk implements the Engine interface
+func (b *Bootstrapper) StateChunk(vdr ids.ShortID, requestID uint32, chunk []byte) error {
+	req, ok := b.sync.chunkRequests[requestID]
+	if !ok || req.vdr != vdr {
+		b.Ctx.Log.Debug("received an unexpected StateChunk from %s with ID %d",
+			vdr, requestID)
+		return nil
+	}
+	delete(b.sync.chunkRequests, requestID)
+
+	if err := b.sync.vm.SyncStateChunk(b.sync.summary, req.index, chunk); err != nil {
+		b.Ctx.Log.Debug("failed to sync chunk %d from %s: %s", req.index, vdr, err)
+		return b.retryChunk(req.index)
+	}
+
+	b.sync.numSynced++
+	if b.sync.numSynced%common.StatusUpdateFrequency == 0 { // Periodically print progress
+		b.Ctx.Log.Info("synced %d chunks of state", b.sync.numSynced)
+	}
+	if b.sync.numSynced == b.sync.summary.NumChunks() {
+		return b.finishStateSync()
+	}
+	b.fetchChunks()
+	return nil
+}
+
+// GetStateChunkFailed implements the Engine interface
+func (b *Bootstrapper) GetStateChunkFailed(vdr ids.ShortID, requestID uint32) error {
+	req, ok := b.sync.chunkRequests[requestID]
+	if !ok || req.vdr != vdr {
+		b.Ctx.Log.Debug("GetStateChunkFailed(%s, %d) called but there was no outstanding request to this validator with this ID",
+			vdr, requestID)
+		return nil
+	}
+	delete(b.sync.chunkRequests, requestID)
+
+	return b.retryChunk(req.index)
+}
+
+// retryChunk requests the chunk at [index] again, from the next beacon. If the
+// chunk was already requested [maxChunkAttempts] times, state sync is
+// abandoned and the blocks after the last accepted block are fetched instead.
+// The VM only applies the synced chunks once every chunk was synced, so its
+// state is left as it was.
+func (b *Bootstrapper) retryChunk(index uint32) error {
+	if b.sync.chunkAttempts[index] < maxChunkAttempts {
+		b.sync.missingChunks = append(b.sync.missingChunks, index)
+		b.fetchChunks()
+		return nil
+	}
+
+	b.Ctx.Log.Warn("failed to sync chunk %d of state at height %d after %d attempts. bootstrapping from the last accepted block",
+		index, b.sync.summary.Height(), maxChunkAttempts)
+	// Responses to the outstanding requests are dropped
+	b.sync.summary = nil
+	b.sync.chunkRequests = nil
+	b.sync.chunkAttempts = nil
+	b.sync.missingChunks = nil
+	return b.fetchAccepted(b.sync.acceptedFrontier)
+}
+
+// finishStateSync marks the block of the synced summary as accepted, and then
+// fetches the blocks after it
+func (b *Bootstrapper) finishStateSync() error {
+	if err := b.sync.vm.StateSynced(b.sync.summary); err != nil {
+		return fmt.Errorf("failed to mark state summary %s as synced: %w",
+			b.sync.summary.ID(), err)
+	}
+	b.Ctx.Log.Info("synced state at height %d. fetching the blocks after it...",
+		b.sync.summary.Height())
+	return b.fetchAccepted(b.sync.acceptedFrontier)
+}
@@ -0,0 +1,410 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bootstrap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/choices"
+	"github.com/liraxapp/avalanchego/snow/consensus/snowman"
+	"github.com/liraxapp/avalanchego/snow/engine/common"
+	"github.com/liraxapp/avalanchego/snow/engine/snowman/block"
+	"github.com/liraxapp/avalanchego/utils/constants"
+)
+
+var errInvalidChunk = errors.New("invalid chunk")
+
+func newStateSyncConfig(t *testing.T) (Config, ids.ShortID, *common.SenderTest, *block.TestStateSyncableVM) {
+	config, peerID, sender, _ := newConfig(t)
+
+	vm := &block.TestStateSyncableVM{}
+	vm.T = t
+	vm.Default(true)
+
+	config.VM = vm
+	config.StateSync = true
+	return config, peerID, sender, vm
+}
+
+// The state of the summary agreed on by the beacons is synced, and then only
+// the blocks after it are fetched
+func TestBootstrapperStateSync(t *testing.T) {
+	config, peerID, sender, vm := newStateSyncConfig(t)
+
+	blkID0 := ids.Empty.Prefix(0)
+	blkID1 := ids.Empty.Prefix(1)
+	blkID2 := ids.Empty.Prefix(2)
+
+	blkBytes2 := []byte{2}
+
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID0,
+			StatusV: choices.Accepted,
+		},
+		HeightV: 0,
+	}
+	blk1 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID1,
+			StatusV: choices.Unknown,
+		},
+		ParentV: blk0,
+		HeightV: 1,
+	}
+	blk2 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID2,
+			StatusV: choices.Unknown,
+		},
+		ParentV: blk1,
+		HeightV: 2,
+		BytesV:  blkBytes2,
+	}
+
+	summaryBytes := []byte{1, 2, 3}
+	summary := &block.TestStateSummary{
+		IDV:        ids.Empty.Prefix(100),
+		HeightV:    1,
+		BlockIDV:   blkID1,
+		NumChunksV: 2,
+		BytesV:     summaryBytes,
+	}
+
+	finished := new(bool)
+	bs := Bootstrapper{}
+	err := bs.Initialize(
+		config,
+		func() error { *finished = true; return nil },
+		fmt.Sprintf("%s_%s", constants.PlatformName, config.Ctx.ChainID),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		switch {
+		case blkID == blkID0:
+			return blk0, nil
+		case blkID == blkID1 && blk1.StatusV != choices.Unknown:
+			return blk1, nil
+		case blkID == blkID2 && blk2.StatusV != choices.Unknown:
+			return blk2, nil
+		}
+		return nil, errUnknownBlock
+	}
+	vm.ParseBlockF = func(blkBytes []byte) (snowman.Block, error) {
+		if bytes.Equal(blkBytes, blkBytes2) {
+			blk2.StatusV = choices.Processing
+			return blk2, nil
+		}
+		t.Fatal(errUnknownBlock)
+		return nil, errUnknownBlock
+	}
+	vm.ParseStateSummaryF = func(b []byte) (block.StateSummary, error) {
+		if !bytes.Equal(b, summaryBytes) {
+			t.Fatalf("parsed the wrong summary")
+		}
+		return summary, nil
+	}
+	vm.ShouldSyncStateF = func(block.StateSummary) (bool, error) { return true, nil }
+	synced := map[uint32]bool{}
+	vm.SyncStateChunkF = func(s block.StateSummary, index uint32, chunk []byte) error {
+		if s.ID() != summary.ID() {
+			t.Fatalf("synced a chunk of the wrong summary")
+		}
+		if !bytes.Equal(chunk, []byte{byte(index)}) {
+			return errInvalidChunk
+		}
+		synced[index] = true
+		return nil
+	}
+	vm.StateSyncedF = func(s block.StateSummary) error {
+		if len(synced) != int(summary.NumChunks()) {
+			t.Fatalf("marked the state as synced before every chunk was synced")
+		}
+		blk1.StatusV = choices.Accepted
+		return nil
+	}
+
+	summaryRequestID := new(uint32)
+	sender.GetStateSummaryF = func(vdrs ids.ShortSet, reqID uint32) {
+		if !vdrs.Contains(peerID) {
+			t.Fatalf("should have requested a summary from %s", peerID)
+		}
+		*summaryRequestID = reqID
+	}
+	chunkRequests := map[uint32]uint32{} // chunk index -> request ID
+	sender.GetStateChunkF = func(vdr ids.ShortID, reqID uint32, summaryID ids.ID, index uint32) {
+		if !vdr.Equals(peerID) {
+			t.Fatalf("should have requested the chunk from %s, requested from %s", peerID, vdr)
+		}
+		if summaryID != summary.ID() {
+			t.Fatalf("requested a chunk of the wrong summary")
+		}
+		chunkRequests[index] = reqID
+	}
+	ancestorsRequestID := new(uint32)
+	sender.GetAncestorsF = func(vdr ids.ShortID, reqID uint32, blkID ids.ID) {
+		if blkID != blkID2 {
+			t.Fatalf("should only have requested blocks after the synced block")
+		}
+		*ancestorsRequestID = reqID
+	}
+
+	vm.CantBootstrapping = false
+
+	if err := bs.ForceAccepted([]ids.ID{blkID2}); err != nil { // should request the summary
+		t.Fatal(err)
+	}
+	if err := bs.StateSummary(peerID, *summaryRequestID, summaryBytes); err != nil { // should request the chunks
+		t.Fatal(err)
+	} else if len(chunkRequests) != 2 {
+		t.Fatalf("should have requested %d chunks, requested %d", 2, len(chunkRequests))
+	}
+
+	firstRequestID := chunkRequests[0]
+	if err := bs.StateChunk(peerID, firstRequestID, []byte{9}); err != nil { // invalid chunk
+		t.Fatal(err)
+	} else if chunkRequests[0] == firstRequestID {
+		t.Fatalf("should have requested the invalid chunk again")
+	}
+	if err := bs.StateChunk(peerID, chunkRequests[0], []byte{0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bs.StateChunk(peerID, chunkRequests[1], []byte{1}); err != nil { // should request blk2
+		t.Fatal(err)
+	} else if blk1.Status() != choices.Accepted {
+		t.Fatalf("the synced block should be accepted")
+	}
+
+	vm.CantBootstrapped = false
+
+	if err := bs.MultiPut(peerID, *ancestorsRequestID, [][]byte{blkBytes2}); err != nil {
+		t.Fatal(err)
+	}
+
+	switch {
+	case !*finished:
+		t.Fatalf("Bootstrapping should have finished")
+	case blk2.Status() != choices.Accepted:
+		t.Fatalf("Block should be accepted")
+	}
+}
+
+// If no summary has enough weight behind it, every block is fetched
+func TestBootstrapperStateSyncNoSummary(t *testing.T) {
+	config, peerID, sender, vm := newStateSyncConfig(t)
+
+	blkID0 := ids.Empty.Prefix(0)
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID0,
+			StatusV: choices.Accepted,
+		},
+		HeightV: 0,
+	}
+
+	finished := new(bool)
+	bs := Bootstrapper{}
+	err := bs.Initialize(
+		config,
+		func() error { *finished = true; return nil },
+		fmt.Sprintf("%s_%s", constants.PlatformName, config.Ctx.ChainID),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		if blkID == blkID0 {
+			return blk0, nil
+		}
+		return nil, errUnknownBlock
+	}
+	summaryRequestID := new(uint32)
+	sender.GetStateSummaryF = func(vdrs ids.ShortSet, reqID uint32) {
+		*summaryRequestID = reqID
+	}
+
+	vm.CantBootstrapping = false
+
+	if err := bs.ForceAccepted([]ids.ID{blkID0}); err != nil {
+		t.Fatal(err)
+	}
+
+	vm.CantBootstrapped = false
+
+	if err := bs.GetStateSummaryFailed(peerID, *summaryRequestID); err != nil {
+		t.Fatal(err)
+	} else if !*finished {
+		t.Fatalf("Bootstrapping should have finished")
+	}
+}
+
+// If a chunk can't be synced, state sync is abandoned and every block after the
+// last accepted block is fetched
+func TestBootstrapperStateSyncAbandoned(t *testing.T) {
+	config, peerID, sender, vm := newStateSyncConfig(t)
+
+	blkID0 := ids.Empty.Prefix(0)
+	blk0 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     blkID0,
+			StatusV: choices.Accepted,
+		},
+		HeightV: 0,
+	}
+
+	summaryBytes := []byte{1, 2, 3}
+	summary := &block.TestStateSummary{
+		IDV:        ids.Empty.Prefix(100),
+		HeightV:    1,
+		BlockIDV:   ids.Empty.Prefix(1),
+		NumChunksV: 1,
+		BytesV:     summaryBytes,
+	}
+
+	finished := new(bool)
+	bs := Bootstrapper{}
+	err := bs.Initialize(
+		config,
+		func() error { *finished = true; return nil },
+		fmt.Sprintf("%s_%s", constants.PlatformName, config.Ctx.ChainID),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		if blkID == blkID0 {
+			return blk0, nil
+		}
+		return nil, errUnknownBlock
+	}
+	vm.ParseStateSummaryF = func([]byte) (block.StateSummary, error) { return summary, nil }
+	vm.ShouldSyncStateF = func(block.StateSummary) (bool, error) { return true, nil }
+	vm.SyncStateChunkF = func(block.StateSummary, uint32, []byte) error { return errInvalidChunk }
+
+	summaryRequestID := new(uint32)
+	sender.GetStateSummaryF = func(vdrs ids.ShortSet, reqID uint32) {
+		*summaryRequestID = reqID
+	}
+	numChunkRequests := 0
+	chunkRequestID := new(uint32)
+	sender.GetStateChunkF = func(vdr ids.ShortID, reqID uint32, summaryID ids.ID, index uint32) {
+		numChunkRequests++
+		*chunkRequestID = reqID
+	}
+
+	vm.CantBootstrapping = false
+
+	if err := bs.ForceAccepted([]ids.ID{blkID0}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bs.StateSummary(peerID, *summaryRequestID, summaryBytes); err != nil {
+		t.Fatal(err)
+	}
+
+	vm.CantBootstrapped = false
+
+	for i := 0; i < maxChunkAttempts; i++ {
+		if *finished {
+			t.Fatalf("Bootstrapping finished after %d attempts, expected %d", i, maxChunkAttempts)
+		}
+		if i%2 == 0 {
+			err = bs.StateChunk(peerID, *chunkRequestID, []byte{9})
+		} else {
+			err = bs.GetStateChunkFailed(peerID, *chunkRequestID)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	switch {
+	case numChunkRequests != maxChunkAttempts:
+		t.Fatalf("should have requested the chunk %d times, requested %d", maxChunkAttempts, numChunkRequests)
+	case !*finished:
+		t.Fatalf("Bootstrapping should have finished")
+	}
+
+	// A late response is dropped
+	if err := bs.StateChunk(peerID, *chunkRequestID, []byte{0}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBootstrapperGetStateSummary(t *testing.T) {
+	config, peerID, sender, vm := newStateSyncConfig(t)
+
+	bs := Bootstrapper{}
+	err := bs.Initialize(
+		config,
+		nil,
+		fmt.Sprintf("%s_%s", constants.PlatformName, config.Ctx.ChainID),
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	summaryBytes := []byte{1, 2, 3}
+	vm.GetStateSummaryF = func() (block.StateSummary, error) {
+		return &block.TestStateSummary{BytesV: summaryBytes}, nil
+	}
+	sent := new(bool)
+	sender.StateSummaryF = func(vdr ids.ShortID, reqID uint32, summary []byte) {
+		*sent = true
+		switch {
+		case !vdr.Equals(peerID):
+			t.Fatalf("sent the summary to the wrong validator")
+		case reqID != 5:
+			t.Fatalf("sent the summary with the wrong request ID")
+		case !bytes.Equal(summary, summaryBytes):
+			t.Fatalf("sent the wrong summary")
+		}
+	}
+
+	if err := bs.GetStateSummary(peerID, 5); err != nil {
+		t.Fatal(err)
+	} else if !*sent {
+		t.Fatalf("should have sent the summary")
+	}
+
+	chunk := []byte{4, 5, 6}
+	vm.GetStateChunkF = func(summaryID ids.ID, index uint32) ([]byte, error) {
+		if index != 1 {
+			return nil, errInvalidChunk
+		}
+		return chunk, nil
+	}
+	sentChunk := new(bool)
+	sender.StateChunkF = func(vdr ids.ShortID, reqID uint32, c []byte) {
+		*sentChunk = true
+		if !bytes.Equal(c, chunk) {
+			t.Fatalf("sent the wrong chunk")
+		}
+	}
+
+	if err := bs.GetStateChunk(peerID, 6, ids.Empty, 0); err != nil { // unknown chunk is dropped
+		t.Fatal(err)
+	} else if *sentChunk {
+		t.Fatalf("shouldn't have sent an unknown chunk")
+	}
+	if err := bs.GetStateChunk(peerID, 7, ids.Empty, 1); err != nil {
+		t.Fatal(err)
+	} else if !*sentChunk {
+		t.Fatalf("should have sent the chunk")
+	}
+}
@@ -0,0 +1,328 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/prefixdb"
+	"github.com/liraxapp/avalanchego/database/versiondb"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/choices"
+	"github.com/liraxapp/avalanchego/snow/engine/snowman/block"
+	"github.com/liraxapp/avalanchego/snow/validators"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+)
+
+var (
+	heightPrefix = []byte("height")
+	blockPrefix  = []byte("block")
+	tipKey       = []byte("tip")
+
+	_ block.HeightIndexedChainVM = &HeightIndexer{}
+	_ block.HeightIndexedChainVM = &stateSyncableHeightIndexer{}
+	_ block.StateSyncableVM      = &stateSyncableHeightIndexer{}
+	_ validators.Connector       = &HeightIndexer{}
+)
+
+// heighter is implemented by blocks that know their height
+type heighter interface {
+	Height() uint64
+}
+
+// HeightIndexer wraps a ChainVM that doesn't index its accepted blocks by
+// height, and indexes them in [db]. The index is brought up to date with the
+// last accepted block of the VM when it is queried and once the VM is
+// bootstrapped. Blocks accepted before the indexer was first used are indexed
+// by walking back from the last accepted block, so the index can be built
+// retroactively for an existing chain.
+//
+// Like the other methods of the VM, the methods of the indexer must not be
+// called concurrently.
+type HeightIndexer struct {
+	block.ChainVM
+
+	db *versiondb.Database
+	// height -> ID of the accepted block at that height
+	heightDB database.Database
+	// block ID -> height of the accepted block
+	blockDB database.Database
+
+	// ID of the last accepted block when the index was last brought up to date
+	lastIndexedID ids.ID
+	// height of the highest indexed block, if [hasTip] is true
+	tip    uint64
+	hasTip bool
+}
+
+// NewHeightIndexer returns a new HeightIndexer of the accepted blocks of [vm].
+// [db] should be dedicated to the index.
+func NewHeightIndexer(vm block.ChainVM, db database.Database) (*HeightIndexer, error) {
+	vdb := versiondb.New(db)
+	hi := &HeightIndexer{
+		ChainVM:  vm,
+		db:       vdb,
+		heightDB: prefixdb.New(heightPrefix, vdb),
+		blockDB:  prefixdb.New(blockPrefix, vdb),
+	}
+
+	tipBytes, err := vdb.Get(tipKey)
+	switch err {
+	case nil:
+		hi.tip = binary.BigEndian.Uint64(tipBytes)
+		hi.hasTip = true
+	case database.ErrNotFound:
+	default:
+		return nil, err
+	}
+	return hi, nil
+}
+
+// NewHeightIndexedVM returns [vm] wrapped in a HeightIndexer that indexes its
+// accepted blocks in [db]. If [vm] syncs its state, so does the returned VM,
+// and the block a synced state is of is indexed at the height of the summary.
+func NewHeightIndexedVM(vm block.ChainVM, db database.Database) (block.HeightIndexedChainVM, error) {
+	hi, err := NewHeightIndexer(vm, db)
+	if err != nil {
+		return nil, err
+	}
+	svm, ok := vm.(block.StateSyncableVM)
+	if !ok {
+		return hi, nil
+	}
+	return &stateSyncableHeightIndexer{
+		HeightIndexer: hi,
+		vm:            svm,
+	}, nil
+}
+
+// Bootstrapped implements the common.VM interface. Once the VM is
+// bootstrapped, the blocks accepted while bootstrapping are indexed.
+func (hi *HeightIndexer) Bootstrapped() error {
+	if err := hi.ChainVM.Bootstrapped(); err != nil {
+		return err
+	}
+	return hi.Index()
+}
+
+// Connected implements the validators.Connector interface. It is forwarded to
+// the VM if the VM tracks its connections.
+func (hi *HeightIndexer) Connected(id ids.ShortID) {
+	if connector, ok := hi.ChainVM.(validators.Connector); ok {
+		connector.Connected(id)
+	}
+}
+
+// Disconnected implements the validators.Connector interface. It is forwarded
+// to the VM if the VM tracks its connections.
+func (hi *HeightIndexer) Disconnected(id ids.ShortID) {
+	if connector, ok := hi.ChainVM.(validators.Connector); ok {
+		connector.Disconnected(id)
+	}
+}
+
+// GetBlockIDAtHeight implements the block.HeightIndexedChainVM interface
+func (hi *HeightIndexer) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	if err := hi.Index(); err != nil {
+		return ids.ID{}, err
+	}
+	blkIDBytes, err := hi.heightDB.Get(heightKey(height))
+	if err != nil {
+		return ids.ID{}, fmt.Errorf("couldn't get the accepted block at height %d: %w", height, err)
+	}
+	return ids.ToID(blkIDBytes)
+}
+
+// Index indexes the blocks accepted since the index was last brought up to
+// date. If the last accepted block of the VM was moved back, the blocks that
+// are no longer accepted are removed from the index.
+func (hi *HeightIndexer) Index() error {
+	lastAcceptedID := hi.ChainVM.LastAccepted()
+	if lastAcceptedID == hi.lastIndexedID {
+		return nil
+	}
+
+	if err := hi.index(lastAcceptedID); err != nil {
+		hi.db.Abort()
+		return err
+	}
+	hi.lastIndexedID = lastAcceptedID
+	return nil
+}
+
+func (hi *HeightIndexer) index(lastAcceptedID ids.ID) error {
+	// IDs of the blocks that aren't indexed yet, from the last accepted block
+	// towards genesis
+	path := []ids.ID(nil)
+	// height of the last block in [path]
+	nextHeight := uint64(0)
+
+	blkID := lastAcceptedID
+	for {
+		heightBytes, err := hi.blockDB.Get(blkID[:])
+		if err == nil {
+			nextHeight = binary.BigEndian.Uint64(heightBytes) + 1
+			break
+		}
+		if err != database.ErrNotFound {
+			return err
+		}
+
+		blk, err := hi.ChainVM.GetBlock(blkID)
+		if err != nil {
+			return fmt.Errorf("couldn't get accepted block %s: %w", blkID, err)
+		}
+		path = append(path, blkID)
+
+		parent := blk.Parent()
+		if parent == nil || parent.Status() != choices.Accepted {
+			// [blk] is the oldest accepted block the VM has. Unless the block
+			// knows its height, it must be the genesis block.
+			if blkHeight, ok := blk.(heighter); ok {
+				nextHeight = blkHeight.Height()
+			}
+			break
+		}
+		blkID = parent.ID()
+	}
+
+	// [path] is only empty if the last accepted block is already indexed
+	newTip := nextHeight + uint64(len(path)) - 1
+
+	// The blocks indexed at the heights of the blocks in [path], or above them,
+	// are no longer accepted. This happens if the chain was truncated.
+	if hi.hasTip {
+		for height := nextHeight; height <= hi.tip; height++ {
+			if err := hi.remove(height); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range path {
+		blkID := path[i][:]
+		height := heightKey(newTip - uint64(i))
+		if err := hi.heightDB.Put(height, blkID); err != nil {
+			return err
+		}
+		if err := hi.blockDB.Put(blkID, height); err != nil {
+			return err
+		}
+	}
+	if err := hi.db.Put(tipKey, heightKey(newTip)); err != nil {
+		return err
+	}
+	if err := hi.db.Commit(); err != nil {
+		return err
+	}
+
+	hi.tip = newTip
+	hi.hasTip = true
+	return nil
+}
+
+// indexSynced restarts the index at the block [blkID] a state was synced to,
+// which is at [height]. The blocks between the previous tip and it were
+// skipped by the sync, so they aren't indexed.
+func (hi *HeightIndexer) indexSynced(blkID ids.ID, height uint64) error {
+	if err := hi.reindex(blkID, height); err != nil {
+		hi.db.Abort()
+		return err
+	}
+	hi.lastIndexedID = blkID
+	return nil
+}
+
+func (hi *HeightIndexer) reindex(blkID ids.ID, height uint64) error {
+	if hi.hasTip {
+		for h := height; h <= hi.tip; h++ {
+			if err := hi.remove(h); err != nil {
+				return err
+			}
+		}
+	}
+
+	key := heightKey(height)
+	if err := hi.heightDB.Put(key, blkID[:]); err != nil {
+		return err
+	}
+	if err := hi.blockDB.Put(blkID[:], key); err != nil {
+		return err
+	}
+	if err := hi.db.Put(tipKey, key); err != nil {
+		return err
+	}
+	if err := hi.db.Commit(); err != nil {
+		return err
+	}
+
+	hi.tip = height
+	hi.hasTip = true
+	return nil
+}
+
+// remove the block indexed at [height], if there is one, from the index
+func (hi *HeightIndexer) remove(height uint64) error {
+	key := heightKey(height)
+	blkID, err := hi.heightDB.Get(key)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		return nil
+	default:
+		return err
+	}
+	if err := hi.blockDB.Delete(blkID); err != nil {
+		return err
+	}
+	return hi.heightDB.Delete(key)
+}
+
+func heightKey(height uint64) []byte {
+	key := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(key, height)
+	return key
+}
+
+// stateSyncableHeightIndexer is a HeightIndexer of a VM that syncs its state
+type stateSyncableHeightIndexer struct {
+	*HeightIndexer
+
+	vm block.StateSyncableVM
+}
+
+// GetStateSummary implements the block.StateSyncableVM interface
+func (hi *stateSyncableHeightIndexer) GetStateSummary() (block.StateSummary, error) {
+	return hi.vm.GetStateSummary()
+}
+
+// ParseStateSummary implements the block.StateSyncableVM interface
+func (hi *stateSyncableHeightIndexer) ParseStateSummary(summaryBytes []byte) (block.StateSummary, error) {
+	return hi.vm.ParseStateSummary(summaryBytes)
+}
+
+// ShouldSyncState implements the block.StateSyncableVM interface
+func (hi *stateSyncableHeightIndexer) ShouldSyncState(summary block.StateSummary) (bool, error) {
+	return hi.vm.ShouldSyncState(summary)
+}
+
+// GetStateChunk implements the block.StateSyncableVM interface
+func (hi *stateSyncableHeightIndexer) GetStateChunk(summaryID ids.ID, index uint32) ([]byte, error) {
+	return hi.vm.GetStateChunk(summaryID, index)
+}
+
+// SyncStateChunk implements the block.StateSyncableVM interface
+func (hi *stateSyncableHeightIndexer) SyncStateChunk(summary block.StateSummary, index uint32, chunk []byte) error {
+	return hi.vm.SyncStateChunk(summary, index, chunk)
+}
+
+// StateSynced implements the block.StateSyncableVM interface
+func (hi *stateSyncableHeightIndexer) StateSynced(summary block.StateSummary) error {
+	if err := hi.vm.StateSynced(summary); err != nil {
+		return err
+	}
+	return hi.indexSynced(summary.BlockID(), summary.Height())
+}
@@ -0,0 +1,232 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"testing"
+
+	"github.com/liraxapp/avalanchego/database/memdb"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/choices"
+	"github.com/liraxapp/avalanchego/snow/consensus/snowman"
+	"github.com/liraxapp/avalanchego/snow/engine/snowman/block"
+)
+
+// newTestChain returns a VM with [length] accepted blocks, and those blocks
+// from genesis to the last accepted block
+func newTestChain(t *testing.T, length int) (*block.TestVM, []*snowman.TestBlock) {
+	genesis := &snowman.TestBlock{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Accepted,
+	}}
+	blks := []*snowman.TestBlock{genesis}
+	for i := 1; i < length; i++ {
+		blks = append(blks, &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Accepted,
+			},
+			ParentV: blks[i-1],
+			HeightV: uint64(i),
+		})
+	}
+
+	vm := &block.TestVM{}
+	vm.T = t
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID() == blkID {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+	vm.LastAcceptedF = func() ids.ID { return blks[len(blks)-1].ID() }
+	return vm, blks
+}
+
+func TestHeightIndexerRetroactive(t *testing.T) {
+	vm, blks := newTestChain(t, 5)
+
+	hi, err := NewHeightIndexer(vm, memdb.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for height, blk := range blks {
+		if blkID, err := hi.GetBlockIDAtHeight(uint64(height)); err != nil {
+			t.Fatal(err)
+		} else if blkID != blk.ID() {
+			t.Fatalf("wrong block at height %d", height)
+		}
+	}
+	if _, err := hi.GetBlockIDAtHeight(uint64(len(blks))); err == nil {
+		t.Fatalf("shouldn't have a block above the last accepted block")
+	}
+}
+
+func TestHeightIndexerAccept(t *testing.T) {
+	vm, blks := newTestChain(t, 3)
+	db := memdb.New()
+
+	hi, err := NewHeightIndexer(vm, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hi.Index(); err != nil {
+		t.Fatal(err)
+	}
+
+	blk3 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		ParentV: blks[2],
+		HeightV: 3,
+	}
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		if blkID != blk3.ID() {
+			t.Fatalf("should only have fetched the newly accepted block")
+		}
+		return blk3, nil
+	}
+	vm.LastAcceptedF = func() ids.ID { return blk3.ID() }
+	blks = append(blks, blk3)
+
+	if blkID, err := hi.GetBlockIDAtHeight(3); err != nil {
+		t.Fatal(err)
+	} else if blkID != blk3.ID() {
+		t.Fatalf("wrong block at height 3")
+	}
+
+	// The index should be persisted
+	vm.GetBlockF = func(ids.ID) (snowman.Block, error) {
+		t.Fatalf("shouldn't have fetched an indexed block")
+		return nil, errUnknownBlock
+	}
+	hi, err = NewHeightIndexer(vm, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for height, blk := range blks {
+		if blkID, err := hi.GetBlockIDAtHeight(uint64(height)); err != nil {
+			t.Fatal(err)
+		} else if blkID != blk.ID() {
+			t.Fatalf("wrong block at height %d", height)
+		}
+	}
+}
+
+func TestHeightIndexerTruncate(t *testing.T) {
+	vm, blks := newTestChain(t, 4)
+
+	hi, err := NewHeightIndexer(vm, memdb.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hi.Index(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Truncate the chain to height 1
+	vm.LastAcceptedF = func() ids.ID { return blks[1].ID() }
+	if _, err := hi.GetBlockIDAtHeight(2); err == nil {
+		t.Fatalf("shouldn't have a block above the last accepted block")
+	}
+
+	// Accept a different block at height 2
+	blk2 := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		ParentV: blks[1],
+		HeightV: 2,
+	}
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		if blkID == blk2.ID() {
+			return blk2, nil
+		}
+		return nil, errUnknownBlock
+	}
+	vm.LastAcceptedF = func() ids.ID { return blk2.ID() }
+
+	if blkID, err := hi.GetBlockIDAtHeight(2); err != nil {
+		t.Fatal(err)
+	} else if blkID != blk2.ID() {
+		t.Fatalf("wrong block at height 2")
+	}
+	if _, err := hi.GetBlockIDAtHeight(3); err == nil {
+		t.Fatalf("shouldn't have a block above the last accepted block")
+	}
+}
+
+func TestHeightIndexedVMStateSynced(t *testing.T) {
+	vm, blks := newTestChain(t, 2)
+
+	hivm, err := NewHeightIndexedVM(vm, memdb.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := hivm.(block.StateSyncableVM); ok {
+		t.Fatalf("shouldn't sync the state of a VM that doesn't sync its state")
+	}
+
+	svm := &block.TestStateSyncableVM{TestVM: *vm}
+	hivm, err = NewHeightIndexedVM(svm, memdb.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hivm.(*stateSyncableHeightIndexer).Index(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Sync to a block whose ancestors the VM doesn't have
+	synced := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV: 100,
+	}
+	summary := &block.TestStateSummary{
+		IDV:      ids.GenerateTestID(),
+		HeightV:  synced.Height(),
+		BlockIDV: synced.ID(),
+	}
+	svm.StateSyncedF = func(block.StateSummary) error {
+		svm.LastAcceptedF = func() ids.ID { return synced.ID() }
+		return nil
+	}
+	svm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		if blkID == synced.ID() {
+			return synced, nil
+		}
+		return nil, errUnknownBlock
+	}
+
+	ssvm, ok := hivm.(block.StateSyncableVM)
+	if !ok {
+		t.Fatalf("should sync the state of a VM that syncs its state")
+	}
+	if err := ssvm.StateSynced(summary); err != nil {
+		t.Fatal(err)
+	}
+	if blkID, err := hivm.GetBlockIDAtHeight(synced.Height()); err != nil {
+		t.Fatal(err)
+	} else if blkID != synced.ID() {
+		t.Fatalf("wrong block at height %d", synced.Height())
+	}
+	// The blocks accepted before the sync are still on the chain
+	for height, blk := range blks {
+		if blkID, err := hivm.GetBlockIDAtHeight(uint64(height)); err != nil {
+			t.Fatal(err)
+		} else if blkID != blk.ID() {
+			t.Fatalf("wrong block at height %d", height)
+		}
+	}
+	if _, err := hivm.GetBlockIDAtHeight(synced.Height() - 1); err == nil {
+		t.Fatalf("shouldn't have a block that was skipped by the sync")
+	}
+}
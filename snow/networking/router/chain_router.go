@@ -389,6 +389,164 @@ func (sr *ChainRouter) QueryFailed(validatorID ids.ShortID, chainID ids.ID, requ
 	}
 }
 
+// AppRequest routes an incoming AppRequest from the validator with ID
+// [validatorID] to the consensus engine working on the chain with ID [chainID]
+func (sr *ChainRouter) AppRequest(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, appRequestBytes []byte) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	if chain, exists := sr.chains[chainID]; exists {
+		chain.AppRequest(validatorID, requestID, deadline, appRequestBytes)
+	} else {
+		sr.log.Debug("AppRequest(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		sr.log.Verbo("request:\n%s", formatting.DumpBytes{Bytes: appRequestBytes})
+	}
+}
+
+// AppResponse routes an incoming AppResponse from the validator with ID
+// [validatorID] to the consensus engine working on the chain with ID [chainID]
+func (sr *ChainRouter) AppResponse(validatorID ids.ShortID, chainID ids.ID, requestID uint32, appResponseBytes []byte) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	// This message came in response to an AppRequest from this node, and when
+	// we sent that request we set a timeout. Since we got a response, cancel
+	// the timeout.
+	if chain, exists := sr.chains[chainID]; exists {
+		if chain.AppResponse(validatorID, requestID, appResponseBytes) {
+			sr.timeouts.CancelApp(validatorID, chainID, requestID)
+		}
+	} else {
+		sr.log.Debug("AppResponse(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+		sr.log.Verbo("response:\n%s", formatting.DumpBytes{Bytes: appResponseBytes})
+	}
+}
+
+// AppRequestFailed routes an incoming AppRequestFailed message from the
+// validator with ID [validatorID] to the consensus engine working on the chain
+// with ID [chainID]
+func (sr *ChainRouter) AppRequestFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	sr.timeouts.CancelApp(validatorID, chainID, requestID)
+	if chain, exists := sr.chains[chainID]; exists {
+		chain.AppRequestFailed(validatorID, requestID)
+	} else {
+		sr.log.Error("AppRequestFailed(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+	}
+}
+
+// AppGossip routes an incoming AppGossip message from the validator with ID
+// [validatorID] to the consensus engine working on the chain with ID [chainID]
+func (sr *ChainRouter) AppGossip(validatorID ids.ShortID, chainID ids.ID, appGossipBytes []byte) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	if chain, exists := sr.chains[chainID]; exists {
+		chain.AppGossip(validatorID, appGossipBytes)
+	} else {
+		sr.log.Verbo("AppGossip(%s, %s) dropped due to unknown chain. Message:\n%s",
+			validatorID, chainID, formatting.DumpBytes{Bytes: appGossipBytes},
+		)
+	}
+}
+
+// GetStateSummary routes an incoming GetStateSummary request from the
+// validator with ID [validatorID] to the consensus engine working on the chain
+// with ID [chainID]
+func (sr *ChainRouter) GetStateSummary(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	if chain, exists := sr.chains[chainID]; exists {
+		chain.GetStateSummary(validatorID, requestID, deadline)
+	} else {
+		sr.log.Debug("GetStateSummary(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+	}
+}
+
+// StateSummary routes an incoming StateSummary message from the validator with
+// ID [validatorID] to the consensus engine working on the chain with ID
+// [chainID]
+func (sr *ChainRouter) StateSummary(validatorID ids.ShortID, chainID ids.ID, requestID uint32, summary []byte) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	// This message came in response to a GetStateSummary message from this
+	// node, and when we sent that message we set a timeout. Since we got a
+	// response, cancel the timeout.
+	if chain, exists := sr.chains[chainID]; exists {
+		if chain.StateSummary(validatorID, requestID, summary) {
+			sr.timeouts.Cancel(validatorID, chainID, requestID)
+		}
+	} else {
+		sr.log.Debug("StateSummary(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+	}
+}
+
+// GetStateSummaryFailed routes an incoming GetStateSummaryFailed message from
+// the validator with ID [validatorID] to the consensus engine working on the
+// chain with ID [chainID]
+func (sr *ChainRouter) GetStateSummaryFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	sr.timeouts.Cancel(validatorID, chainID, requestID)
+	if chain, exists := sr.chains[chainID]; exists {
+		chain.GetStateSummaryFailed(validatorID, requestID)
+	} else {
+		sr.log.Error("GetStateSummaryFailed(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+	}
+}
+
+// GetStateChunk routes an incoming GetStateChunk request from the validator
+// with ID [validatorID] to the consensus engine working on the chain with ID
+// [chainID]
+func (sr *ChainRouter) GetStateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, deadline time.Time, summaryID ids.ID, index uint32) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	if chain, exists := sr.chains[chainID]; exists {
+		chain.GetStateChunk(validatorID, requestID, deadline, summaryID, index)
+	} else {
+		sr.log.Debug("GetStateChunk(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+	}
+}
+
+// StateChunk routes an incoming StateChunk message from the validator with ID
+// [validatorID] to the consensus engine working on the chain with ID [chainID]
+func (sr *ChainRouter) StateChunk(validatorID ids.ShortID, chainID ids.ID, requestID uint32, chunk []byte) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	// This message came in response to a GetStateChunk message from this node,
+	// and when we sent that message we set a timeout. Since we got a response,
+	// cancel the timeout.
+	if chain, exists := sr.chains[chainID]; exists {
+		if chain.StateChunk(validatorID, requestID, chunk) {
+			sr.timeouts.Cancel(validatorID, chainID, requestID)
+		}
+	} else {
+		sr.log.Debug("StateChunk(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+	}
+}
+
+// GetStateChunkFailed routes an incoming GetStateChunkFailed message from the
+// validator with ID [validatorID] to the consensus engine working on the chain
+// with ID [chainID]
+func (sr *ChainRouter) GetStateChunkFailed(validatorID ids.ShortID, chainID ids.ID, requestID uint32) {
+	sr.lock.RLock()
+	defer sr.lock.RUnlock()
+
+	sr.timeouts.Cancel(validatorID, chainID, requestID)
+	if chain, exists := sr.chains[chainID]; exists {
+		chain.GetStateChunkFailed(validatorID, requestID)
+	} else {
+		sr.log.Error("GetStateChunkFailed(%s, %s, %d) dropped due to unknown chain", validatorID, chainID, requestID)
+	}
+}
+
 // Connected routes an incoming notification that a validator was just connected
 func (sr *ChainRouter) Connected(validatorID ids.ShortID) {
 	sr.lock.Lock()
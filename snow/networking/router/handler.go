@@ -454,6 +454,122 @@ func (h *Handler) QueryFailed(validatorID ids.ShortID, requestID uint32) {
 	})
 }
 
+// AppRequest passes an AppRequest message received from the network to the
+// consensus engine.
+func (h *Handler) AppRequest(validatorID ids.ShortID, requestID uint32, deadline time.Time, appRequestBytes []byte) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.AppRequestMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		deadline:    deadline,
+		appBytes:    appRequestBytes,
+		received:    h.clock.Time(),
+	})
+}
+
+// AppResponse passes an AppResponse message received from the network to the
+// consensus engine.
+func (h *Handler) AppResponse(validatorID ids.ShortID, requestID uint32, appResponseBytes []byte) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.AppResponseMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		appBytes:    appResponseBytes,
+		received:    h.clock.Time(),
+	})
+}
+
+// AppRequestFailed passes an AppRequestFailed message to the consensus engine.
+func (h *Handler) AppRequestFailed(validatorID ids.ShortID, requestID uint32) {
+	h.sendReliableMsg(message{
+		messageType: constants.AppRequestFailedMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+	})
+}
+
+// AppGossip passes an AppGossip message received from the network to the
+// consensus engine.
+func (h *Handler) AppGossip(validatorID ids.ShortID, appGossipBytes []byte) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.AppGossipMsg,
+		validatorID: validatorID,
+		requestID:   constants.GossipMsgRequestID,
+		appBytes:    appGossipBytes,
+		received:    h.clock.Time(),
+	})
+}
+
+// GetStateSummary passes a GetStateSummary message received from the network
+// to the consensus engine.
+func (h *Handler) GetStateSummary(validatorID ids.ShortID, requestID uint32, deadline time.Time) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.GetStateSummaryMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		deadline:    deadline,
+		received:    h.clock.Time(),
+	})
+}
+
+// StateSummary passes a StateSummary message received from the network to the
+// consensus engine.
+func (h *Handler) StateSummary(validatorID ids.ShortID, requestID uint32, summary []byte) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.StateSummaryMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		container:   summary,
+		received:    h.clock.Time(),
+	})
+}
+
+// GetStateSummaryFailed passes a GetStateSummaryFailed message to the
+// consensus engine.
+func (h *Handler) GetStateSummaryFailed(validatorID ids.ShortID, requestID uint32) {
+	h.sendReliableMsg(message{
+		messageType: constants.GetStateSummaryFailedMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+	})
+}
+
+// GetStateChunk passes a GetStateChunk message received from the network to
+// the consensus engine.
+func (h *Handler) GetStateChunk(validatorID ids.ShortID, requestID uint32, deadline time.Time, summaryID ids.ID, index uint32) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.GetStateChunkMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		deadline:    deadline,
+		containerID: summaryID,
+		index:       index,
+		received:    h.clock.Time(),
+	})
+}
+
+// StateChunk passes a StateChunk message received from the network to the
+// consensus engine.
+func (h *Handler) StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte) bool {
+	return h.serviceQueue.PushMessage(message{
+		messageType: constants.StateChunkMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+		container:   chunk,
+		received:    h.clock.Time(),
+	})
+}
+
+// GetStateChunkFailed passes a GetStateChunkFailed message to the consensus
+// engine.
+func (h *Handler) GetStateChunkFailed(validatorID ids.ShortID, requestID uint32) {
+	h.sendReliableMsg(message{
+		messageType: constants.GetStateChunkFailedMsg,
+		validatorID: validatorID,
+		requestID:   requestID,
+	})
+}
+
 // Connected passes a new connection notification to the consensus engine
 func (h *Handler) Connected(validatorID ids.ShortID) {
 	h.sendReliableMsg(message{
@@ -550,6 +666,26 @@ func (h *Handler) handleValidatorMsg(msg message, startTime time.Time) error {
 		err = h.engine.QueryFailed(msg.validatorID, msg.requestID)
 	case constants.ChitsMsg:
 		err = h.engine.Chits(msg.validatorID, msg.requestID, msg.containerIDs)
+	case constants.AppRequestMsg:
+		err = h.engine.AppRequest(msg.validatorID, msg.requestID, msg.appBytes)
+	case constants.AppResponseMsg:
+		err = h.engine.AppResponse(msg.validatorID, msg.requestID, msg.appBytes)
+	case constants.AppRequestFailedMsg:
+		err = h.engine.AppRequestFailed(msg.validatorID, msg.requestID)
+	case constants.AppGossipMsg:
+		err = h.engine.AppGossip(msg.validatorID, msg.appBytes)
+	case constants.GetStateSummaryMsg:
+		err = h.engine.GetStateSummary(msg.validatorID, msg.requestID)
+	case constants.StateSummaryMsg:
+		err = h.engine.StateSummary(msg.validatorID, msg.requestID, msg.container)
+	case constants.GetStateSummaryFailedMsg:
+		err = h.engine.GetStateSummaryFailed(msg.validatorID, msg.requestID)
+	case constants.GetStateChunkMsg:
+		err = h.engine.GetStateChunk(msg.validatorID, msg.requestID, msg.containerID, msg.index)
+	case constants.StateChunkMsg:
+		err = h.engine.StateChunk(msg.validatorID, msg.requestID, msg.container)
+	case constants.GetStateChunkFailedMsg:
+		err = h.engine.GetStateChunkFailed(msg.validatorID, msg.requestID)
 	case constants.ConnectedMsg:
 		err = h.engine.Connected(msg.validatorID)
 	case constants.DisconnectedMsg:
@@ -14,6 +14,7 @@ import (
 	"github.com/liraxapp/avalanchego/snow"
 	"github.com/liraxapp/avalanchego/snow/engine/common"
 	"github.com/liraxapp/avalanchego/snow/validators"
+	"github.com/liraxapp/avalanchego/utils/constants"
 )
 
 func TestHandlerDropsTimedOutMessages(t *testing.T) {
@@ -185,3 +186,137 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 	case <-closed:
 	}
 }
+
+func TestHandlerDispatchesAppMessages(t *testing.T) {
+	engine := common.EngineTest{T: t}
+	engine.Default(true)
+	engine.ContextF = snow.DefaultContextTest
+
+	called := make(chan constants.MsgType, 4)
+
+	engine.AppRequestF = func(validatorID ids.ShortID, requestID uint32, msg []byte) error {
+		called <- constants.AppRequestMsg
+		return nil
+	}
+	engine.AppResponseF = func(validatorID ids.ShortID, requestID uint32, msg []byte) error {
+		called <- constants.AppResponseMsg
+		return nil
+	}
+	engine.AppRequestFailedF = func(validatorID ids.ShortID, requestID uint32) error {
+		called <- constants.AppRequestFailedMsg
+		return nil
+	}
+	engine.AppGossipF = func(validatorID ids.ShortID, msg []byte) error {
+		called <- constants.AppGossipMsg
+		return nil
+	}
+
+	handler := &Handler{}
+	handler.Initialize(
+		&engine,
+		validators.NewSet(),
+		nil,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+	handler.clock.Set(time.Now())
+
+	vdr := ids.GenerateTestShortID()
+	handler.AppRequest(vdr, 1, time.Now().Add(time.Second), []byte{1})
+	handler.AppResponse(vdr, 2, []byte{2})
+	handler.AppRequestFailed(vdr, 3)
+	handler.AppGossip(vdr, []byte{3})
+
+	go handler.Dispatch()
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	received := map[constants.MsgType]bool{}
+	for len(received) < 4 {
+		select {
+		case <-ticker.C:
+			t.Fatalf("Calling engine function timed out")
+		case msgType := <-called:
+			received[msgType] = true
+		}
+	}
+}
+
+func TestHandlerDispatchesStateSyncMessages(t *testing.T) {
+	engine := common.EngineTest{T: t}
+	engine.Default(true)
+	engine.ContextF = snow.DefaultContextTest
+
+	called := make(chan constants.MsgType, 6)
+
+	engine.GetStateSummaryF = func(validatorID ids.ShortID, requestID uint32) error {
+		called <- constants.GetStateSummaryMsg
+		return nil
+	}
+	engine.StateSummaryF = func(validatorID ids.ShortID, requestID uint32, summary []byte) error {
+		called <- constants.StateSummaryMsg
+		return nil
+	}
+	engine.GetStateSummaryFailedF = func(validatorID ids.ShortID, requestID uint32) error {
+		called <- constants.GetStateSummaryFailedMsg
+		return nil
+	}
+	engine.GetStateChunkF = func(validatorID ids.ShortID, requestID uint32, summaryID ids.ID, index uint32) error {
+		if index != 7 {
+			t.Fatalf("Wrong chunk index %d", index)
+		}
+		called <- constants.GetStateChunkMsg
+		return nil
+	}
+	engine.StateChunkF = func(validatorID ids.ShortID, requestID uint32, chunk []byte) error {
+		called <- constants.StateChunkMsg
+		return nil
+	}
+	engine.GetStateChunkFailedF = func(validatorID ids.ShortID, requestID uint32) error {
+		called <- constants.GetStateChunkFailedMsg
+		return nil
+	}
+
+	handler := &Handler{}
+	handler.Initialize(
+		&engine,
+		validators.NewSet(),
+		nil,
+		16,
+		DefaultMaxNonStakerPendingMsgs,
+		DefaultStakerPortion,
+		DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+	handler.clock.Set(time.Now())
+
+	vdr := ids.GenerateTestShortID()
+	deadline := time.Now().Add(time.Second)
+	handler.GetStateSummary(vdr, 1, deadline)
+	handler.StateSummary(vdr, 2, []byte{2})
+	handler.GetStateSummaryFailed(vdr, 3)
+	handler.GetStateChunk(vdr, 4, deadline, ids.Empty, 7)
+	handler.StateChunk(vdr, 5, []byte{5})
+	handler.GetStateChunkFailed(vdr, 6)
+
+	go handler.Dispatch()
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	received := map[constants.MsgType]bool{}
+	for len(received) < 6 {
+		select {
+		case <-ticker.C:
+			t.Fatalf("Calling engine function timed out")
+		case msgType := <-called:
+			received[msgType] = true
+		}
+	}
+}
@@ -21,6 +21,8 @@ type message struct {
 	container    []byte
 	containers   [][]byte
 	containerIDs []ids.ID
+	appBytes     []byte
+	index        uint32
 	notification common.Message
 	received     time.Time // Time this message was received
 	deadline     time.Time // Time this message must be responded to
@@ -45,6 +47,13 @@ func (m message) String() string {
 		sb.WriteString(fmt.Sprintf("\n    containerID: %s", m.containerID))
 	case constants.MultiPutMsg:
 		sb.WriteString(fmt.Sprintf("\n    numContainers: %d", len(m.containers)))
+	case constants.AppRequestMsg, constants.AppResponseMsg, constants.AppGossipMsg:
+		sb.WriteString(fmt.Sprintf("\n    appBytesLen: %d", len(m.appBytes)))
+	case constants.StateSummaryMsg, constants.StateChunkMsg:
+		sb.WriteString(fmt.Sprintf("\n    containerLen: %d", len(m.container)))
+	case constants.GetStateChunkMsg:
+		sb.WriteString(fmt.Sprintf("\n    summaryID: %s", m.containerID))
+		sb.WriteString(fmt.Sprintf("\n    index: %d", m.index))
 	case constants.NotifyMsg:
 		sb.WriteString(fmt.Sprintf("\n    notification: %s", m.notification))
 	}
@@ -42,6 +42,9 @@ type metrics struct {
 	getAncestors, multiPut, getAncestorsFailed,
 	get, put, getFailed,
 	pushQuery, pullQuery, chits, queryFailed,
+	appRequest, appResponse, appRequestFailed, appGossip,
+	getStateSummary, stateSummary, getStateSummaryFailed,
+	getStateChunk, stateChunk, getStateChunkFailed,
 	connected, disconnected,
 	notify,
 	gossip,
@@ -108,6 +111,16 @@ func (m *metrics) Initialize(namespace string, registerer prometheus.Registerer)
 	m.pullQuery = initHistogram(namespace, "pull_query", registerer, &errs)
 	m.chits = initHistogram(namespace, "chits", registerer, &errs)
 	m.queryFailed = initHistogram(namespace, "query_failed", registerer, &errs)
+	m.appRequest = initHistogram(namespace, "app_request", registerer, &errs)
+	m.appResponse = initHistogram(namespace, "app_response", registerer, &errs)
+	m.appRequestFailed = initHistogram(namespace, "app_request_failed", registerer, &errs)
+	m.appGossip = initHistogram(namespace, "app_gossip", registerer, &errs)
+	m.getStateSummary = initHistogram(namespace, "get_state_summary", registerer, &errs)
+	m.stateSummary = initHistogram(namespace, "state_summary", registerer, &errs)
+	m.getStateSummaryFailed = initHistogram(namespace, "get_state_summary_failed", registerer, &errs)
+	m.getStateChunk = initHistogram(namespace, "get_state_chunk", registerer, &errs)
+	m.stateChunk = initHistogram(namespace, "state_chunk", registerer, &errs)
+	m.getStateChunkFailed = initHistogram(namespace, "get_state_chunk_failed", registerer, &errs)
 	m.connected = initHistogram(namespace, "connected", registerer, &errs)
 	m.disconnected = initHistogram(namespace, "disconnected", registerer, &errs)
 	m.notify = initHistogram(namespace, "notify", registerer, &errs)
@@ -193,6 +206,26 @@ func (m *metrics) getMSGHistogram(msg constants.MsgType) prometheus.Histogram {
 		return m.queryFailed
 	case constants.ChitsMsg:
 		return m.chits
+	case constants.AppRequestMsg:
+		return m.appRequest
+	case constants.AppResponseMsg:
+		return m.appResponse
+	case constants.AppRequestFailedMsg:
+		return m.appRequestFailed
+	case constants.AppGossipMsg:
+		return m.appGossip
+	case constants.GetStateSummaryMsg:
+		return m.getStateSummary
+	case constants.StateSummaryMsg:
+		return m.stateSummary
+	case constants.GetStateSummaryFailedMsg:
+		return m.getStateSummaryFailed
+	case constants.GetStateChunkMsg:
+		return m.getStateChunk
+	case constants.StateChunkMsg:
+		return m.stateChunk
+	case constants.GetStateChunkFailedMsg:
+		return m.getStateChunkFailed
 	case constants.ConnectedMsg:
 		return m.connected
 	case constants.DisconnectedMsg:
@@ -243,3 +243,119 @@ func (s *Sender) Gossip(containerID ids.ID, container []byte) {
 	s.ctx.Log.Verbo("Gossiping %s", containerID)
 	s.sender.Gossip(s.ctx.ChainID, containerID, container)
 }
+
+// SendAppRequest sends an AppRequest message to the VMs running this chain on
+// the specified validators. If a validator doesn't respond before the request
+// times out, the VM is sent an AppRequestFailed message.
+func (s *Sender) SendAppRequest(validatorIDs ids.ShortSet, requestID uint32, appRequestBytes []byte) error {
+	s.ctx.Log.Verbo("Sending AppRequest to validators %v. RequestID: %d", validatorIDs, requestID)
+
+	// Copy the set so that removing this node below doesn't modify the VM's set
+	vdrIDs := ids.ShortSet{}
+	vdrIDs.Union(validatorIDs)
+
+	currentDeadline := time.Time{}
+	for validatorIDKey := range vdrIDs {
+		validatorID := ids.NewShortID(validatorIDKey)
+		deadline := s.timeouts.RegisterApp(validatorID, s.ctx.ChainID, requestID, func() {
+			s.router.AppRequestFailed(validatorID, s.ctx.ChainID, requestID)
+		})
+		if deadline.After(currentDeadline) {
+			currentDeadline = deadline
+		}
+	}
+
+	// If one of the validators in [vdrIDs] is myself, send this message
+	// directly to my own router rather than sending it over the network
+	if vdrIDs.Contains(s.ctx.NodeID) {
+		vdrIDs.Remove(s.ctx.NodeID)
+		go s.router.AppRequest(s.ctx.NodeID, s.ctx.ChainID, requestID, currentDeadline, appRequestBytes)
+	}
+
+	s.sender.AppRequest(vdrIDs, s.ctx.ChainID, requestID, currentDeadline, appRequestBytes)
+	return nil
+}
+
+// SendAppResponse sends an AppResponse message to the VM running this chain on
+// the specified validator
+func (s *Sender) SendAppResponse(validatorID ids.ShortID, requestID uint32, appResponseBytes []byte) error {
+	s.ctx.Log.Verbo("Sending AppResponse to validator %s. RequestID: %d", validatorID, requestID)
+	if validatorID.Equals(s.ctx.NodeID) {
+		go s.router.AppResponse(validatorID, s.ctx.ChainID, requestID, appResponseBytes)
+	} else {
+		s.sender.AppResponse(validatorID, s.ctx.ChainID, requestID, appResponseBytes)
+	}
+	return nil
+}
+
+// SendAppGossip gossips the provided application level message
+func (s *Sender) SendAppGossip(appGossipBytes []byte) error {
+	s.ctx.Log.Verbo("Gossiping application message of length %d", len(appGossipBytes))
+	s.sender.AppGossip(s.ctx.ChainID, appGossipBytes)
+	return nil
+}
+
+// GetStateSummary sends a GetStateSummary message to the specified validators.
+// The GetStateSummary message signifies that this consensus engine would like
+// each validator to send the summary of its most recent state.
+func (s *Sender) GetStateSummary(validatorIDs ids.ShortSet, requestID uint32) {
+	s.ctx.Log.Verbo("Sending GetStateSummary to validators %v. RequestID: %d", validatorIDs, requestID)
+
+	currentDeadline := time.Time{}
+	for validatorIDKey := range validatorIDs {
+		validatorID := ids.NewShortID(validatorIDKey)
+		deadline, ok := s.timeouts.Register(validatorID, s.ctx.ChainID, requestID, true, constants.GetStateSummaryMsg, func() {
+			s.router.GetStateSummaryFailed(validatorID, s.ctx.ChainID, requestID)
+		})
+		if deadline.After(currentDeadline) {
+			currentDeadline = deadline
+		}
+		if !ok {
+			validatorIDs.Remove(validatorID)
+		}
+	}
+
+	if validatorIDs.Contains(s.ctx.NodeID) {
+		validatorIDs.Remove(s.ctx.NodeID)
+		go s.router.GetStateSummary(s.ctx.NodeID, s.ctx.ChainID, requestID, currentDeadline)
+	}
+
+	s.sender.GetStateSummary(validatorIDs, s.ctx.ChainID, requestID, currentDeadline)
+}
+
+// StateSummary sends a StateSummary message to the specified validator
+func (s *Sender) StateSummary(validatorID ids.ShortID, requestID uint32, summary []byte) {
+	s.ctx.Log.Verbo("Sending StateSummary to validator %s. RequestID: %d. SummaryLen: %d", validatorID, requestID, len(summary))
+	if validatorID.Equals(s.ctx.NodeID) {
+		go s.router.StateSummary(validatorID, s.ctx.ChainID, requestID, summary)
+	} else {
+		s.sender.StateSummary(validatorID, s.ctx.ChainID, requestID, summary)
+	}
+}
+
+// GetStateChunk sends a GetStateChunk message to the specified validator. The
+// GetStateChunk message signifies that this consensus engine would like the
+// recipient to send the chunk at [index] of the state summarized by
+// [summaryID].
+func (s *Sender) GetStateChunk(validatorID ids.ShortID, requestID uint32, summaryID ids.ID, index uint32) {
+	s.ctx.Log.Verbo("Sending GetStateChunk to validator %s. RequestID: %d. SummaryID: %s. Index: %d", validatorID, requestID, summaryID, index)
+	// Sending a GetStateChunk to myself will always fail
+	if validatorID.Equals(s.ctx.NodeID) {
+		go s.router.GetStateChunkFailed(validatorID, s.ctx.ChainID, requestID)
+		return
+	}
+
+	deadline, ok := s.timeouts.Register(validatorID, s.ctx.ChainID, requestID, false, constants.GetStateChunkMsg, func() {
+		s.router.GetStateChunkFailed(validatorID, s.ctx.ChainID, requestID)
+	})
+	if !ok {
+		return
+	}
+	s.sender.GetStateChunk(validatorID, s.ctx.ChainID, requestID, deadline, summaryID, index)
+}
+
+// StateChunk sends a StateChunk message to the specified validator
+func (s *Sender) StateChunk(validatorID ids.ShortID, requestID uint32, chunk []byte) {
+	s.ctx.Log.Verbo("Sending StateChunk to validator %s. RequestID: %d. ChunkLen: %d", validatorID, requestID, len(chunk))
+	s.sender.StateChunk(validatorID, s.ctx.ChainID, requestID, chunk)
+}
@@ -265,3 +265,74 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		<-await
 	}
 }
+
+func TestAppTimeout(t *testing.T) {
+	vdrs := validators.NewSet()
+	benchlist := benchlist.NewNoBenchlist()
+	tm := timeout.Manager{}
+	err := tm.Initialize(&timer.AdaptiveTimeoutConfig{
+		InitialTimeout: time.Millisecond,
+		MinimumTimeout: time.Millisecond,
+		MaximumTimeout: 10 * time.Second,
+		TimeoutInc:     2 * time.Millisecond,
+		TimeoutDec:     time.Millisecond,
+		Namespace:      "",
+		Registerer:     prometheus.NewRegistry(),
+	}, benchlist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go tm.Dispatch()
+
+	chainRouter := router.ChainRouter{}
+	chainRouter.Initialize(ids.ShortEmpty, logging.NoLog{}, &tm, time.Hour, time.Second, ids.Set{}, nil)
+
+	sender := Sender{}
+	sender.Initialize(snow.DefaultContextTest(), &ExternalSenderTest{}, &chainRouter, &tm)
+
+	engine := common.EngineTest{T: t}
+	engine.Default(true)
+	engine.CantConnected = false
+
+	engine.ContextF = snow.DefaultContextTest
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	failedVDRs := ids.ShortSet{}
+	engine.AppRequestFailedF = func(validatorID ids.ShortID, _ uint32) error {
+		failedVDRs.Add(validatorID)
+		wg.Done()
+		return nil
+	}
+
+	handler := router.Handler{}
+	handler.Initialize(
+		&engine,
+		vdrs,
+		nil,
+		1,
+		router.DefaultMaxNonStakerPendingMsgs,
+		router.DefaultStakerPortion,
+		router.DefaultStakerPortion,
+		"",
+		prometheus.NewRegistry(),
+	)
+	go handler.Dispatch()
+
+	chainRouter.AddChain(&handler)
+
+	vdrIDs := ids.ShortSet{}
+	vdrIDs.Add(ids.NewShortID([20]byte{255}))
+	vdrIDs.Add(ids.NewShortID([20]byte{254}))
+
+	if err := sender.SendAppRequest(vdrIDs, 0, []byte{1}); err != nil {
+		t.Fatal(err)
+	}
+
+	wg.Wait()
+
+	if !failedVDRs.Equals(vdrIDs) {
+		t.Fatalf("Timeouts should have fired")
+	}
+}
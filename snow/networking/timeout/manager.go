@@ -15,6 +15,10 @@ import (
 	"github.com/liraxapp/avalanchego/utils/wrappers"
 )
 
+// Prefixed to the keys of application level requests so that they can't collide
+// with the keys of the engine's requests
+var appRequestPrefix = []byte("app")
+
 // Manager registers and fires timeouts for the snow API.
 type Manager struct {
 	tm        timer.AdaptiveTimeoutManager
@@ -61,9 +65,29 @@ func (m *Manager) Cancel(validatorID ids.ShortID, chainID ids.ID, requestID uint
 	m.tm.Remove(createRequestID(validatorID, chainID, requestID))
 }
 
+// RegisterApp registers the timeout of an application level request. The IDs of
+// these requests are chosen by the VM rather than the consensus engine, so they
+// are tracked separately from the engine's requests. Unanswered application
+// requests never benchlist a validator.
+func (m *Manager) RegisterApp(validatorID ids.ShortID, chainID ids.ID, requestID uint32, timeout func()) time.Time {
+	return m.tm.Put(createAppRequestID(validatorID, chainID, requestID), timeout)
+}
+
+// CancelApp cancels the timeout of an application level request.
+func (m *Manager) CancelApp(validatorID ids.ShortID, chainID ids.ID, requestID uint32) {
+	m.tm.Remove(createAppRequestID(validatorID, chainID, requestID))
+}
+
 func createRequestID(validatorID ids.ShortID, chainID ids.ID, requestID uint32) ids.ID {
 	p := wrappers.Packer{Bytes: make([]byte, wrappers.IntLen)}
 	p.PackInt(requestID)
 
 	return hashing.ByteArraysToHash256Array(validatorID.Bytes(), chainID[:], p.Bytes)
 }
+
+func createAppRequestID(validatorID ids.ShortID, chainID ids.ID, requestID uint32) ids.ID {
+	p := wrappers.Packer{Bytes: make([]byte, wrappers.IntLen)}
+	p.PackInt(requestID)
+
+	return hashing.ByteArraysToHash256Array(appRequestPrefix, validatorID.Bytes(), chainID[:], p.Bytes)
+}
@@ -74,3 +74,40 @@ func TestManagerCancel(t *testing.T) {
 		t.Fatalf("Should have cancelled the function")
 	}
 }
+
+func TestManagerCancelApp(t *testing.T) {
+	manager := Manager{}
+	benchlist := benchlist.NewNoBenchlist()
+	err := manager.Initialize(&timer.AdaptiveTimeoutConfig{
+		InitialTimeout: time.Millisecond,
+		MinimumTimeout: time.Millisecond,
+		MaximumTimeout: 10 * time.Second,
+		TimeoutInc:     2 * time.Millisecond,
+		TimeoutDec:     time.Millisecond,
+		Namespace:      "",
+		Registerer:     prometheus.NewRegistry(),
+	}, benchlist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	go manager.Dispatch()
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+
+	fired := new(bool)
+
+	// An application request and an engine request may share a request ID
+	manager.RegisterApp(ids.NewShortID([20]byte{}), ids.ID{}, 0, func() { *fired = true })
+	manager.Register(ids.NewShortID([20]byte{}), ids.ID{}, 0, true, 0, wg.Done)
+
+	manager.CancelApp(ids.NewShortID([20]byte{}), ids.ID{}, 0)
+
+	manager.RegisterApp(ids.NewShortID([20]byte{}), ids.ID{}, 1, wg.Done)
+
+	wg.Wait()
+
+	if *fired {
+		t.Fatalf("Should have cancelled the function")
+	}
+}
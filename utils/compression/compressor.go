@@ -0,0 +1,88 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	errUnknownType = errors.New("unknown compression type")
+
+	// ErrDecompressedMsgTooLarge is returned when the decompressed bytes would
+	// be larger than the compressor's maximum size
+	ErrDecompressedMsgTooLarge = errors.New("decompressed message exceeds maximum size")
+)
+
+// Type of compression. Types are sent over the wire, so existing values must
+// never be changed.
+type Type byte
+
+// Supported compression types
+const (
+	None Type = iota
+	Gzip
+	Snappy
+)
+
+// Types lists the supported compression types
+var Types = []Type{None, Gzip, Snappy}
+
+func (t Type) String() string {
+	switch t {
+	case None:
+		return "none"
+	case Gzip:
+		return "gzip"
+	case Snappy:
+		return "snappy"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseType returns the compression type named [name]
+func ParseType(name string) (Type, error) {
+	for _, t := range Types {
+		if t.String() == name {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", errUnknownType, name)
+}
+
+// Compressor compresses and decompresses messages. Decompressing a message must
+// never produce more than the maximum size the compressor was created with, so
+// that a small malicious message can't exhaust our memory.
+type Compressor interface {
+	Type() Type
+	Compress([]byte) ([]byte, error)
+	Decompress([]byte) ([]byte, error)
+}
+
+// New returns a compressor of type [t] that refuses to decompress messages
+// larger than [maxSize] bytes
+func New(t Type, maxSize int64) (Compressor, error) {
+	switch t {
+	case None:
+		return NewNoCompressor(), nil
+	case Gzip:
+		return NewGzipCompressor(maxSize), nil
+	case Snappy:
+		return NewSnappyCompressor(maxSize), nil
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnknownType, t)
+	}
+}
+
+// noCompressor passes messages through unchanged
+type noCompressor struct{}
+
+// NewNoCompressor returns a compressor that doesn't compress
+func NewNoCompressor() Compressor { return &noCompressor{} }
+
+func (*noCompressor) Type() Type                            { return None }
+func (*noCompressor) Compress(msg []byte) ([]byte, error)   { return msg, nil }
+func (*noCompressor) Decompress(msg []byte) ([]byte, error) { return msg, nil }
@@ -0,0 +1,78 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+const maxTestSize = 1 << 10
+
+func TestCompressDecompress(t *testing.T) {
+	msg := bytes.Repeat([]byte{1, 2, 3, 4}, maxTestSize/4)
+	for _, compressionType := range Types {
+		compressor, err := New(compressionType, maxTestSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if compressor.Type() != compressionType {
+			t.Fatalf("%s compressor reported type %s", compressionType, compressor.Type())
+		}
+
+		compressed, err := compressor.Compress(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if compressionType != None && len(compressed) >= len(msg) {
+			t.Fatalf("%s didn't compress a repetitive message", compressionType)
+		}
+
+		decompressed, err := compressor.Decompress(compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(msg, decompressed) {
+			t.Fatalf("%s returned the wrong message", compressionType)
+		}
+	}
+}
+
+func TestDecompressTooLarge(t *testing.T) {
+	msg := make([]byte, maxTestSize+1)
+	for _, compressionType := range []Type{Gzip, Snappy} {
+		largeCompressor, err := New(compressionType, 2*maxTestSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		compressor, err := New(compressionType, maxTestSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		compressed, err := largeCompressor.Compress(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := compressor.Decompress(compressed); !errors.Is(err, ErrDecompressedMsgTooLarge) {
+			t.Fatalf("%s should have refused to decompress an oversized message but returned: %v", compressionType, err)
+		}
+	}
+}
+
+func TestParseType(t *testing.T) {
+	for _, compressionType := range Types {
+		parsed, err := ParseType(compressionType.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parsed != compressionType {
+			t.Fatalf("parsed %s as %s", compressionType, parsed)
+		}
+	}
+	if _, err := ParseType("lz4"); err == nil {
+		t.Fatalf("should have failed to parse an unknown type")
+	}
+}
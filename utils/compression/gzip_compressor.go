@@ -0,0 +1,53 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+)
+
+// gzipCompressor compresses messages with gzip
+type gzipCompressor struct {
+	maxSize int64
+}
+
+// NewGzipCompressor returns a gzip compressor that refuses to decompress
+// messages larger than [maxSize] bytes
+func NewGzipCompressor(maxSize int64) Compressor {
+	return &gzipCompressor{maxSize: maxSize}
+}
+
+func (*gzipCompressor) Type() Type { return Gzip }
+
+func (*gzipCompressor) Compress(msg []byte) ([]byte, error) {
+	buf := bytes.Buffer{}
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(msg); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (g *gzipCompressor) Decompress(msg []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(msg))
+	if err != nil {
+		return nil, err
+	}
+	// Read one byte more than allowed so that an oversized message can be
+	// detected without ever inflating more than that
+	decompressed, err := ioutil.ReadAll(io.LimitReader(reader, g.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decompressed)) > g.maxSize {
+		return nil, ErrDecompressedMsgTooLarge
+	}
+	return decompressed, reader.Close()
+}
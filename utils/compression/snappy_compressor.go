@@ -0,0 +1,38 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"github.com/golang/snappy"
+)
+
+// snappyCompressor compresses messages with snappy
+type snappyCompressor struct {
+	maxSize int64
+}
+
+// NewSnappyCompressor returns a snappy compressor that refuses to decompress
+// messages larger than [maxSize] bytes
+func NewSnappyCompressor(maxSize int64) Compressor {
+	return &snappyCompressor{maxSize: maxSize}
+}
+
+func (*snappyCompressor) Type() Type { return Snappy }
+
+func (*snappyCompressor) Compress(msg []byte) ([]byte, error) {
+	return snappy.Encode(nil, msg), nil
+}
+
+func (s *snappyCompressor) Decompress(msg []byte) ([]byte, error) {
+	// The decoded length is stored in the header, so an oversized message is
+	// rejected before anything is allocated for it
+	decodedLen, err := snappy.DecodedLen(msg)
+	if err != nil {
+		return nil, err
+	}
+	if int64(decodedLen) > s.maxSize {
+		return nil, ErrDecompressedMsgTooLarge
+	}
+	return snappy.Decode(nil, msg)
+}
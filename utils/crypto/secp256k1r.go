@@ -43,7 +43,11 @@ const (
 )
 
 // FactorySECP256K1R ...
-type FactorySECP256K1R struct{ Cache cache.LRU }
+type FactorySECP256K1R struct {
+	// Cache of recovered public keys, keyed by the hash of the message hash
+	// and signature they were recovered from. If nil, keys aren't cached.
+	Cache cache.Cacher
+}
 
 // NewPrivateKey implements the Factory interface
 func (*FactorySECP256K1R) NewPrivateKey() (PrivateKey, error) {
@@ -79,8 +83,10 @@ func (f *FactorySECP256K1R) RecoverHashPublicKey(hash, sig []byte) (PublicKey, e
 	copy(cacheBytes, hash)
 	copy(cacheBytes[len(hash):], sig)
 	id := hashing.ComputeHash256Array(cacheBytes)
-	if cachedPublicKey, ok := f.Cache.Get(id); ok {
-		return cachedPublicKey.(*PublicKeySECP256K1R), nil
+	if f.Cache != nil {
+		if cachedPublicKey, ok := f.Cache.Get(id); ok {
+			return cachedPublicKey.(*PublicKeySECP256K1R), nil
+		}
 	}
 
 	if err := verifySECP256K1RSignatureFormat(sig); err != nil {
@@ -102,7 +108,9 @@ func (f *FactorySECP256K1R) RecoverHashPublicKey(hash, sig []byte) (PublicKey, e
 	}
 
 	pubkey := &PublicKeySECP256K1R{pk: rawPubkey}
-	f.Cache.Put(id, pubkey)
+	if f.Cache != nil {
+		f.Cache.Put(id, pubkey)
+	}
 	return pubkey, nil
 }
 
@@ -32,7 +32,7 @@ func TestRecover(t *testing.T) {
 }
 
 func TestCachedRecover(t *testing.T) {
-	f := FactorySECP256K1R{Cache: cache.LRU{Size: 1}}
+	f := FactorySECP256K1R{Cache: &cache.LRU{Size: 1}}
 	key, _ := f.NewPrivateKey()
 
 	msg := []byte{1, 2, 3}
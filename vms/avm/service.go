@@ -173,7 +173,14 @@ func (service *Service) GetUTXOs(r *http.Request, args *api.GetUTXOsArgs, reply
 		err       error
 	)
 	if sourceChain == service.vm.ctx.ChainID {
-		utxos, endAddr, endUTXOID, err = service.vm.GetUTXOs(
+		state, db, snapshotErr := service.vm.snapshotState()
+		if snapshotErr != nil {
+			return snapshotErr
+		}
+		defer db.Close()
+
+		utxos, endAddr, endUTXOID, err = service.vm.getUTXOs(
+			state,
 			addrSet,
 			startAddr,
 			startUTXO,
@@ -288,7 +295,13 @@ func (service *Service) GetBalance(r *http.Request, args *GetBalanceArgs, reply
 	addrSet := ids.ShortSet{}
 	addrSet.Add(addr)
 
-	utxos, _, _, err := service.vm.GetUTXOs(addrSet, ids.ShortEmpty, ids.Empty, -1, false)
+	state, db, err := service.vm.snapshotState()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	utxos, _, _, err := service.vm.getUTXOs(state, addrSet, ids.ShortEmpty, ids.Empty, -1, false)
 	if err != nil {
 		return fmt.Errorf("problem retrieving UTXOs: %w", err)
 	}
@@ -339,7 +352,13 @@ func (service *Service) GetAllBalances(r *http.Request, args *api.JSONAddress, r
 	addrSet := ids.ShortSet{}
 	addrSet.Add(address)
 
-	utxos, _, _, err := service.vm.GetUTXOs(addrSet, ids.ShortEmpty, ids.Empty, -1, false)
+	state, db, err := service.vm.snapshotState()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	utxos, _, _, err := service.vm.getUTXOs(state, addrSet, ids.ShortEmpty, ids.Empty, -1, false)
 	if err != nil {
 		return fmt.Errorf("couldn't get address's UTXOs: %w", err)
 	}
@@ -7,6 +7,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/liraxapp/avalanchego/cache"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/snow/choices"
 	"github.com/liraxapp/avalanchego/utils/crypto"
@@ -426,3 +427,51 @@ func TestStateTXs(t *testing.T) {
 		t.Fatalf("Should have errored when reading tx")
 	}
 }
+
+func TestStateCacheSize(t *testing.T) {
+	_, _, vm, _ := GenesisVM(t)
+	ctx := vm.ctx
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+
+	state := vm.state.state
+	stateCache := state.Cache.(*cache.SizedLRU)
+	stateCache.Flush()
+
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{
+			TxID:        ids.Empty,
+			OutputIndex: 1,
+		},
+		Asset: avax.Asset{ID: ids.Empty},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{keys[0].PublicKey().Address()},
+			},
+		},
+	}
+	utxoBytes, err := vm.codec.Marshal(codecVersion, utxo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := state.SetUTXO(ids.Empty, utxo); err != nil {
+		t.Fatal(err)
+	}
+	if size := stateCache.CurrentSize(); size != cache.EntryOverhead+len(utxoBytes) {
+		t.Fatalf("Wrong size: Returned: %d ; Expected: %d", size, cache.EntryOverhead+len(utxoBytes))
+	}
+
+	statusID := ids.Empty.Prefix(1)
+	if err := state.SetStatus(statusID, choices.Accepted); err != nil {
+		t.Fatal(err)
+	}
+	if size := stateCache.CurrentSize(); size != 2*cache.EntryOverhead+len(utxoBytes)+len(choices.Accepted.Bytes()) {
+		t.Fatalf("Wrong size: Returned: %d ; Expected: %d", size, 2*cache.EntryOverhead+len(utxoBytes)+len(choices.Accepted.Bytes()))
+	}
+}
@@ -15,7 +15,9 @@ import (
 	"github.com/gorilla/rpc/v2"
 
 	"github.com/liraxapp/avalanchego/cache"
+	"github.com/liraxapp/avalanchego/cache/metercacher"
 	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/snapshotdb"
 	"github.com/liraxapp/avalanchego/database/versiondb"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/snow"
@@ -29,6 +31,7 @@ import (
 	"github.com/liraxapp/avalanchego/utils/formatting"
 	"github.com/liraxapp/avalanchego/utils/logging"
 	"github.com/liraxapp/avalanchego/utils/timer"
+	"github.com/liraxapp/avalanchego/utils/units"
 	"github.com/liraxapp/avalanchego/utils/wrappers"
 	"github.com/liraxapp/avalanchego/vms/components/avax"
 	"github.com/liraxapp/avalanchego/vms/components/verify"
@@ -42,7 +45,7 @@ import (
 const (
 	batchTimeout       = time.Second
 	batchSize          = 30
-	stateCacheSize     = 30000
+	stateCacheSize     = 32 * units.MiB
 	idCacheSize        = 30000
 	txCacheSize        = 30000
 	assetToFxCacheSize = 1024
@@ -59,7 +62,8 @@ var (
 	errBootstrapping             = errors.New("chain is currently bootstrapping")
 	errInsufficientFunds         = errors.New("insufficient funds")
 
-	_ vertex.DAGVM = &VM{}
+	_ vertex.DAGVM          = &VM{}
+	_ secp256k1fx.MeteredVM = &VM{}
 )
 
 // VM implements the avalanche.DAGVM interface
@@ -91,7 +95,7 @@ type VM struct {
 	txFee uint64
 
 	// Asset ID --> Bit set with fx IDs the asset supports
-	assetToFxCache *cache.LRU
+	assetToFxCache cache.Cacher
 
 	// Transaction issuing
 	timer        *timer.Timer
@@ -128,7 +132,6 @@ func (vm *VM) Initialize(
 	vm.db = versiondb.New(db)
 	vm.typeToFxIndex = map[reflect.Type]int{}
 	vm.Aliaser.Initialize()
-	vm.assetToFxCache = &cache.LRU{Size: assetToFxCacheSize}
 
 	vm.pubsub = cjson.NewPubSubServer(ctx)
 
@@ -187,19 +190,47 @@ func (vm *VM) Initialize(
 		}
 	}
 
+	stateCache, err := cache.NewSizedLRU(ctx.Namespace, "state_cache", ctx.Metrics, stateCacheSize, vm.stateValueSize)
+	if err != nil {
+		return err
+	}
+	ctx.CacheBudget.Track(1, stateCache)
+
+	assetToFxCache, err := metercacher.New(ctx.Namespace, "asset_to_fx_cache", ctx.Metrics, &cache.LRU{Size: assetToFxCacheSize})
+	if err != nil {
+		return err
+	}
+	txCache, err := metercacher.New(ctx.Namespace, "tx_id_cache", ctx.Metrics, &cache.LRU{Size: idCacheSize})
+	if err != nil {
+		return err
+	}
+	utxoCache, err := metercacher.New(ctx.Namespace, "utxo_id_cache", ctx.Metrics, &cache.LRU{Size: idCacheSize})
+	if err != nil {
+		return err
+	}
+	txStatusCache, err := metercacher.New(ctx.Namespace, "tx_status_id_cache", ctx.Metrics, &cache.LRU{Size: idCacheSize})
+	if err != nil {
+		return err
+	}
+	uniqueTxCache, err := metercacher.NewDeduplicator(ctx.Namespace, "unique_tx_cache", ctx.Metrics, &cache.EvictableLRU{Size: txCacheSize})
+	if err != nil {
+		return err
+	}
+
+	vm.assetToFxCache = assetToFxCache
 	vm.state = &prefixedState{
 		state: &state{State: avax.State{
-			Cache:        &cache.LRU{Size: stateCacheSize},
+			Cache:        stateCache,
 			DB:           vm.db,
 			GenesisCodec: vm.genesisCodec,
 			Codec:        vm.codec,
 		}},
 
-		tx:       &cache.LRU{Size: idCacheSize},
-		utxo:     &cache.LRU{Size: idCacheSize},
-		txStatus: &cache.LRU{Size: idCacheSize},
+		tx:       txCache,
+		utxo:     utxoCache,
+		txStatus: txStatusCache,
 
-		uniqueTx: &cache.EvictableLRU{Size: txCacheSize},
+		uniqueTx: uniqueTxCache,
 	}
 
 	if err := vm.initAliases(genesisBytes); err != nil {
@@ -270,6 +301,32 @@ func (vm *VM) Shutdown() error {
 	return vm.baseDB.Close()
 }
 
+// AppRequest implements the avalanche.DAGVM interface. The AVM doesn't use
+// application level messages, so the request is dropped.
+func (vm *VM) AppRequest(validatorID ids.ShortID, requestID uint32, request []byte) error {
+	vm.ctx.Log.Verbo("dropping AppRequest(%s, %d)", validatorID, requestID)
+	return nil
+}
+
+// AppResponse implements the avalanche.DAGVM interface. The AVM never sends
+// application level requests, so the response is dropped.
+func (vm *VM) AppResponse(validatorID ids.ShortID, requestID uint32, response []byte) error {
+	vm.ctx.Log.Verbo("dropping AppResponse(%s, %d)", validatorID, requestID)
+	return nil
+}
+
+// AppRequestFailed implements the avalanche.DAGVM interface
+func (vm *VM) AppRequestFailed(validatorID ids.ShortID, requestID uint32) error {
+	return nil
+}
+
+// AppGossip implements the avalanche.DAGVM interface. The AVM doesn't use
+// application level messages, so the message is dropped.
+func (vm *VM) AppGossip(validatorID ids.ShortID, msg []byte) error {
+	vm.ctx.Log.Verbo("dropping AppGossip from %s", validatorID)
+	return nil
+}
+
 // CreateHandlers implements the avalanche.DAGVM interface
 func (vm *VM) CreateHandlers() map[string]*common.HTTPHandler {
 	vm.metrics.numCreateHandlersCalls.Inc()
@@ -439,18 +496,58 @@ func (vm *VM) GetUTXOs(
 	startUTXOID ids.ID,
 	limit int,
 	paginate bool,
+) ([]*avax.UTXO, ids.ShortID, ids.ID, error) {
+	return vm.getUTXOs(vm.state, addrs, startAddr, startUTXOID, limit, paginate)
+}
+
+// snapshotState returns a read-only view of the state as of this call, so that
+// multi-step reads don't observe a partially committed block. The returned
+// database must be closed once the view is no longer used.
+func (vm *VM) snapshotState() (*prefixedState, database.Database, error) {
+	snapshot, err := vm.db.NewSnapshot()
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't snapshot the database: %w", err)
+	}
+	db := snapshotdb.New(snapshot)
+	return &prefixedState{
+		state: &state{State: avax.State{
+			Cache:        &cache.LRU{Size: maxUTXOsToFetch},
+			DB:           db,
+			GenesisCodec: vm.genesisCodec,
+			Codec:        vm.codec,
+		}},
+
+		// The unique IDs don't depend on the database, so they can be shared
+		tx:       vm.state.tx,
+		utxo:     vm.state.utxo,
+		txStatus: vm.state.txStatus,
+
+		uniqueTx: vm.state.uniqueTx,
+	}, db, nil
+}
+
+// getUTXOs is GetUTXOs, reading from [state]
+func (vm *VM) getUTXOs(
+	state *prefixedState,
+	addrs ids.ShortSet,
+	startAddr ids.ShortID,
+	startUTXOID ids.ID,
+	limit int,
+	paginate bool,
 ) ([]*avax.UTXO, ids.ShortID, ids.ID, error) {
 	if limit <= 0 || limit > maxUTXOsToFetch {
 		limit = maxUTXOsToFetch
 	}
 
 	if paginate {
-		return vm.getPaginatedUTXOs(addrs, startAddr, startUTXOID, limit)
+		return vm.getPaginatedUTXOs(state, addrs, startAddr, startUTXOID, limit)
 	}
-	return vm.getAllUTXOs(addrs)
+	return vm.getAllUTXOs(state, addrs)
 }
 
-func (vm *VM) getPaginatedUTXOs(addrs ids.ShortSet,
+func (vm *VM) getPaginatedUTXOs(
+	state *prefixedState,
+	addrs ids.ShortSet,
 	startAddr ids.ShortID,
 	startUTXOID ids.ID,
 	limit int,
@@ -476,7 +573,7 @@ func (vm *VM) getPaginatedUTXOs(addrs ids.ShortSet,
 
 		// Get UTXOs associated with [addr]. [searchSize] is used here to ensure
 		// that no UTXOs are dropped due to duplicated fetching.
-		utxoIDs, err := vm.state.Funds(addr.Bytes(), start, searchSize)
+		utxoIDs, err := state.Funds(addr.Bytes(), start, searchSize)
 		if err != nil {
 			return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't get UTXOs for address %s: %w", addr, err)
 		}
@@ -488,7 +585,7 @@ func (vm *VM) getPaginatedUTXOs(addrs ids.ShortSet,
 				continue
 			}
 
-			utxo, err := vm.state.UTXO(utxoID)
+			utxo, err := state.UTXO(utxoID)
 			if err != nil {
 				return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't get UTXO %s: %w", utxoID, err)
 			}
@@ -504,7 +601,7 @@ func (vm *VM) getPaginatedUTXOs(addrs ids.ShortSet,
 	return utxos, lastAddr, lastIndex, nil // Didnt reach the [limit] utxos; no more were found
 }
 
-func (vm *VM) getAllUTXOs(addrs ids.ShortSet) ([]*avax.UTXO, ids.ShortID, ids.ID, error) {
+func (vm *VM) getAllUTXOs(state *prefixedState, addrs ids.ShortSet) ([]*avax.UTXO, ids.ShortID, ids.ID, error) {
 	var err error
 	lastAddr := ids.ShortEmpty
 	lastIndex := ids.Empty
@@ -517,7 +614,7 @@ func (vm *VM) getAllUTXOs(addrs ids.ShortSet) ([]*avax.UTXO, ids.ShortID, ids.ID
 
 	// iterate over the addresses and get all the utxos
 	for _, addr := range addrsList {
-		lastIndex, err = vm.getAllUniqueAddressUTXOs(addr, &seen, &utxos)
+		lastIndex, err = vm.getAllUniqueAddressUTXOs(state, addr, &seen, &utxos)
 		if err != nil {
 			return nil, ids.ShortID{}, ids.ID{}, fmt.Errorf("couldn't get UTXOs for address %s: %w", addr, err)
 		}
@@ -529,11 +626,11 @@ func (vm *VM) getAllUTXOs(addrs ids.ShortSet) ([]*avax.UTXO, ids.ShortID, ids.ID
 	return utxos, lastAddr, lastIndex, nil
 }
 
-func (vm *VM) getAllUniqueAddressUTXOs(addr ids.ShortID, seen *ids.Set, utxos *[]*avax.UTXO) (ids.ID, error) {
+func (vm *VM) getAllUniqueAddressUTXOs(state *prefixedState, addr ids.ShortID, seen *ids.Set, utxos *[]*avax.UTXO) (ids.ID, error) {
 	lastIndex := ids.Empty
 
 	for {
-		utxoIDs, err := vm.state.Funds(addr.Bytes(), lastIndex, maxUTXOsToFetch) // Get UTXOs associated with [addr]
+		utxoIDs, err := state.Funds(addr.Bytes(), lastIndex, maxUTXOsToFetch) // Get UTXOs associated with [addr]
 		if err != nil {
 			return ids.ID{}, err
 		}
@@ -549,7 +646,7 @@ func (vm *VM) getAllUniqueAddressUTXOs(addr ids.ShortID, seen *ids.Set, utxos *[
 				continue
 			}
 
-			utxo, err := vm.state.UTXO(utxoID)
+			utxo, err := state.UTXO(utxoID)
 			if err != nil {
 				return ids.ID{}, err
 			}
@@ -577,6 +674,9 @@ func (vm *VM) CodecRegistry() codec.Registry { return vm.codecRegistry }
 // Logger returns a reference to the internal logger of this VM
 func (vm *VM) Logger() logging.Logger { return vm.ctx.Log }
 
+// Context returns the context this VM was initialized with
+func (vm *VM) Context() *snow.Context { return vm.ctx }
+
 /*
  ******************************************************************************
  ********************************** Timer API *********************************
@@ -1297,3 +1397,17 @@ func (vm *VM) lookupAssetID(asset string) (ids.ID, error) {
 	}
 	return ids.ID{}, fmt.Errorf("asset '%s' not found", asset)
 }
+
+// stateValueSize returns the number of bytes [value] uses in the state cache.
+// UTXOs are cached unmarshalled, so their size is that of their serialization.
+func (vm *VM) stateValueSize(value interface{}) int {
+	utxo, ok := value.(*avax.UTXO)
+	if !ok {
+		return cache.BytesSize(value)
+	}
+	utxoBytes, err := vm.codec.Marshal(codecVersion, utxo)
+	if err != nil {
+		return 0
+	}
+	return len(utxoBytes)
+}
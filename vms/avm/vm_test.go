@@ -1548,3 +1548,54 @@ func TestTxVerifyAfterVerifyAncestorTx(t *testing.T) {
 		t.Fatalf("Should have errored due to a missing UTXO")
 	}
 }
+
+func TestSnapshotStateIgnoresLaterWrites(t *testing.T) {
+	_, _, vm, _ := GenesisVM(t)
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.ctx.Lock.Unlock()
+	}()
+
+	addr := ids.GenerateTestShortID()
+	addrs := ids.ShortSet{}
+	addrs.Add(addr)
+
+	state, db, err := vm.snapshotState()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	utxo := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: ids.GenerateTestID()},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr},
+			},
+		},
+	}
+	if err := vm.state.FundUTXO(utxo); err != nil {
+		t.Fatal(err)
+	}
+
+	utxos, _, _, err := vm.GetUTXOs(addrs, ids.ShortEmpty, ids.Empty, -1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(utxos) != 1 {
+		t.Fatalf("expected 1 UTXO in the current state but got %d", len(utxos))
+	}
+
+	utxos, _, _, err = vm.getUTXOs(state, addrs, ids.ShortEmpty, ids.Empty, -1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(utxos) != 0 {
+		t.Fatalf("expected no UTXOs in the snapshot but got %d", len(utxos))
+	}
+}
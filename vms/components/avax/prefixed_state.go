@@ -6,8 +6,12 @@ package avax
 import (
 	"bytes"
 	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/liraxapp/avalanchego/cache"
+	"github.com/liraxapp/avalanchego/cache/metercacher"
 	"github.com/liraxapp/avalanchego/database"
 	"github.com/liraxapp/avalanchego/database/prefixdb"
 	"github.com/liraxapp/avalanchego/ids"
@@ -137,45 +141,76 @@ type PrefixedState struct {
 	smallerChain, largerChain chainState
 }
 
-// NewPrefixedState ...
+// NewPrefixedState returns a new PrefixedState whose caches report metrics
+// under [namespace]
 func NewPrefixedState(
 	db database.Database,
 	genesisCodec,
 	codec codec.Manager,
 	myChain,
 	peerChain ids.ID,
-) *PrefixedState {
+	namespace string,
+	registerer prometheus.Registerer,
+) (*PrefixedState, error) {
+	stateCache, err := metercacher.New(namespace, "shared_state_cache", registerer, &cache.LRU{Size: stateCacheSize})
+	if err != nil {
+		return nil, err
+	}
 	state := &State{
-		Cache:        &cache.LRU{Size: stateCacheSize},
+		Cache:        stateCache,
 		DB:           db,
 		GenesisCodec: genesisCodec,
 		Codec:        codec,
 	}
+	smallerChain, err := newChainState(state, smallerUTXOID, smallerStatusID, smallerFundsID, namespace, "smaller_chain", registerer)
+	if err != nil {
+		return nil, err
+	}
+	largerChain, err := newChainState(state, largerUTXOID, largerStatusID, largerFundsID, namespace, "larger_chain", registerer)
+	if err != nil {
+		return nil, err
+	}
 	return &PrefixedState{
-		isSmaller: bytes.Compare(myChain[:], peerChain[:]) == -1,
-		smallerChain: chainState{
-			State: state,
-
-			utxoIDPrefix:   smallerUTXOID,
-			statusIDPrefix: smallerStatusID,
-			fundsIDPrefix:  smallerFundsID,
-
-			utxoID:   &cache.LRU{Size: idCacheSize},
-			statusID: &cache.LRU{Size: idCacheSize},
-			fundsID:  &cache.LRU{Size: idCacheSize},
-		},
-		largerChain: chainState{
-			State: state,
-
-			utxoIDPrefix:   largerUTXOID,
-			statusIDPrefix: largerStatusID,
-			fundsIDPrefix:  largerFundsID,
-
-			utxoID:   &cache.LRU{Size: idCacheSize},
-			statusID: &cache.LRU{Size: idCacheSize},
-			fundsID:  &cache.LRU{Size: idCacheSize},
-		},
+		isSmaller:    bytes.Compare(myChain[:], peerChain[:]) == -1,
+		smallerChain: smallerChain,
+		largerChain:  largerChain,
+	}, nil
+}
+
+// newChainState returns the state of one of the chains sharing a
+// PrefixedState, whose caches report metrics prefixed with [name]
+func newChainState(
+	state *State,
+	utxoIDPrefix,
+	statusIDPrefix,
+	fundsIDPrefix uint64,
+	namespace,
+	name string,
+	registerer prometheus.Registerer,
+) (chainState, error) {
+	utxoID, err := metercacher.New(namespace, fmt.Sprintf("%s_utxo_id_cache", name), registerer, &cache.LRU{Size: idCacheSize})
+	if err != nil {
+		return chainState{}, err
+	}
+	statusID, err := metercacher.New(namespace, fmt.Sprintf("%s_status_id_cache", name), registerer, &cache.LRU{Size: idCacheSize})
+	if err != nil {
+		return chainState{}, err
 	}
+	fundsID, err := metercacher.New(namespace, fmt.Sprintf("%s_funds_id_cache", name), registerer, &cache.LRU{Size: idCacheSize})
+	if err != nil {
+		return chainState{}, err
+	}
+	return chainState{
+		State: state,
+
+		utxoIDPrefix:   utxoIDPrefix,
+		statusIDPrefix: statusIDPrefix,
+		fundsIDPrefix:  fundsIDPrefix,
+
+		utxoID:   utxoID,
+		statusID: statusID,
+		fundsID:  fundsID,
+	}, nil
 }
 
 // UTXO attempts to load a utxo from storage.
@@ -7,6 +7,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/liraxapp/avalanchego/database/memdb"
@@ -29,8 +30,14 @@ func TestPrefixedFunds(t *testing.T) {
 
 	db := memdb.New()
 
-	st0 := NewPrefixedState(db, manager, manager, chain0ID, chain1ID)
-	st1 := NewPrefixedState(db, manager, manager, chain1ID, chain0ID)
+	st0, err := NewPrefixedState(db, manager, manager, chain0ID, chain1ID, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
+	st1, err := NewPrefixedState(db, manager, manager, chain1ID, chain0ID, "", prometheus.NewRegistry())
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	addr := ids.GenerateTestShortID()
 	addrBytes := addr.Bytes()
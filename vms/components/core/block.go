@@ -53,8 +53,8 @@ func (b *Block) Parent() snowman.Block {
 	return parent
 }
 
-// Accept sets this block's status to Accepted and sets lastAccepted to this
-// block's ID and saves this info to b.vm.DB
+// Accept sets this block's status to Accepted, sets lastAccepted to this
+// block's ID, indexes this block by its height and saves this info to b.vm.DB
 // Recall that b.vm.DB.Commit() must be called to persist to the DB
 func (b *Block) Accept() error {
 	b.SetStatus(choices.Accepted) // Change state of this block
@@ -64,6 +64,9 @@ func (b *Block) Accept() error {
 	if err := b.VM.State.PutStatus(b.VM.DB, blkID, choices.Accepted); err != nil {
 		return err
 	}
+	if err := b.VM.State.PutBlockIDAtHeight(b.VM.DB, b.Height(), blkID); err != nil {
+		return err
+	}
 	if err := b.VM.State.PutLastAccepted(b.VM.DB, blkID); err != nil {
 		return err
 	}
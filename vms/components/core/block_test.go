@@ -3,6 +3,8 @@ package core
 import (
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/liraxapp/avalanchego/database/memdb"
 	"github.com/liraxapp/avalanchego/database/versiondb"
 	"github.com/liraxapp/avalanchego/ids"
@@ -13,7 +15,7 @@ import (
 func TestBlock(t *testing.T) {
 	parentID := ids.ID{1, 2, 3, 4, 5}
 	db := versiondb.New(memdb.New())
-	state, err := NewSnowmanState(func([]byte) (snowman.Block, error) { return nil, nil })
+	state, err := NewSnowmanState("", prometheus.NewRegistry(), func([]byte) (snowman.Block, error) { return nil, nil })
 	if err != nil {
 		t.Fatal(err)
 	}
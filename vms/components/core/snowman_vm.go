@@ -5,6 +5,7 @@ package core
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -27,6 +28,20 @@ var (
 // the db has not yet been initialized
 var dbInitializedID = ids.ID{'d', 'b', ' ', 'i', 'n', 'i', 't'}
 
+// If the status of this ID is not choices.Accepted, the blocks accepted before
+// accepted blocks were indexed by height haven't been indexed yet
+var heightIndexedID = ids.ID{'h', 'e', 'i', 'g', 'h', 't', ' ', 'i', 'n', 'd', 'e', 'x'}
+
+// StatusIDs are the IDs whose statuses are kept about the database, rather
+// than about blocks
+var StatusIDs = []ids.ID{dbInitializedID, heightIndexedID}
+
+// indexedBlock is a block that can be indexed by height
+type indexedBlock interface {
+	ParentID() ids.ID
+	Height() uint64
+}
+
 // SnowmanVM provides the core functionality shared by most snowman vms
 type SnowmanVM struct {
 	State SnowmanState
@@ -79,12 +94,67 @@ func (svm *SnowmanVM) GetBlock(id ids.ID) (snowman.Block, error) {
 	return nil, errBadData // Should never happen
 }
 
+// GetBlockIDAtHeight returns the ID of the accepted block at [height]
+func (svm *SnowmanVM) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	return svm.State.GetBlockIDAtHeight(svm.DB, height)
+}
+
+// IndexHeights indexes the blocks that were accepted before accepted blocks
+// were indexed by height, by walking back from the last accepted block to
+// genesis. It does nothing once the index has been built. It should be called
+// at the end of Initialize, once blocks can be parsed.
+func (svm *SnowmanVM) IndexHeights() error {
+	if svm.State.GetStatus(svm.DB, heightIndexedID) == choices.Accepted {
+		return nil
+	}
+
+	blkID := svm.LastAcceptedID
+	for {
+		blkIntf, err := svm.GetBlock(blkID)
+		if err != nil {
+			return fmt.Errorf("couldn't get accepted block %s: %w", blkID, err)
+		}
+		blk, ok := blkIntf.(indexedBlock)
+		if !ok {
+			return errBadData
+		}
+		if err := svm.State.PutBlockIDAtHeight(svm.DB, blk.Height(), blkID); err != nil {
+			return err
+		}
+		if blk.Height() == 0 {
+			break
+		}
+		blkID = blk.ParentID()
+	}
+
+	if err := svm.State.PutStatus(svm.DB, heightIndexedID, choices.Accepted); err != nil {
+		return err
+	}
+	return svm.DB.Commit()
+}
+
 // Bootstrapping marks this VM as bootstrapping
 func (svm *SnowmanVM) Bootstrapping() error { return nil }
 
 // Bootstrapped marks this VM as bootstrapped
 func (svm *SnowmanVM) Bootstrapped() error { return nil }
 
+// AppRequest drops the request, as this VM doesn't use application level
+// messages
+func (svm *SnowmanVM) AppRequest(ids.ShortID, uint32, []byte) error { return nil }
+
+// AppResponse drops the response, as this VM doesn't send application level
+// requests
+func (svm *SnowmanVM) AppResponse(ids.ShortID, uint32, []byte) error { return nil }
+
+// AppRequestFailed does nothing, as this VM doesn't send application level
+// requests
+func (svm *SnowmanVM) AppRequestFailed(ids.ShortID, uint32) error { return nil }
+
+// AppGossip drops the message, as this VM doesn't use application level
+// messages
+func (svm *SnowmanVM) AppGossip(ids.ShortID, []byte) error { return nil }
+
 // Shutdown this vm
 func (svm *SnowmanVM) Shutdown() error {
 	if svm.DB == nil {
@@ -165,7 +235,7 @@ func (svm *SnowmanVM) Initialize(
 	svm.DB = versiondb.New(db)
 
 	var err error
-	svm.State, err = NewSnowmanState(unmarshalBlockFunc)
+	svm.State, err = NewSnowmanState(ctx.Namespace, ctx.Metrics, unmarshalBlockFunc)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,74 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/liraxapp/avalanchego/database/memdb"
+	"github.com/liraxapp/avalanchego/database/versiondb"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/consensus/snowman"
+)
+
+type testBlock struct{ *Block }
+
+func (b *testBlock) Verify() error { return nil }
+
+func TestIndexHeights(t *testing.T) {
+	blks := []*testBlock(nil)
+	state, err := NewSnowmanState("", prometheus.NewRegistry(), func(b []byte) (snowman.Block, error) { return blks[b[0]], nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm := &SnowmanVM{
+		DB:    versiondb.New(memdb.New()),
+		State: state,
+	}
+
+	// Blocks accepted before blocks were indexed by height
+	parentID := ids.Empty
+	for height := uint64(0); height < 3; height++ {
+		blk := &testBlock{Block: NewBlock(parentID, height)}
+		blk.Initialize([]byte{byte(height)}, vm)
+		blks = append(blks, blk)
+		if err := vm.SaveBlock(vm.DB, blk); err != nil {
+			t.Fatal(err)
+		}
+		parentID = blk.ID()
+	}
+	vm.LastAcceptedID = parentID
+
+	if err := vm.IndexHeights(); err != nil {
+		t.Fatal(err)
+	}
+	for height, blk := range blks {
+		if blkID, err := vm.GetBlockIDAtHeight(uint64(height)); err != nil {
+			t.Fatal(err)
+		} else if blkID != blk.ID() {
+			t.Fatalf("expected block %s at height %d but was %s", blk.ID(), height, blkID)
+		}
+	}
+
+	// Once the index is built, blocks are indexed when they're accepted
+	blk := &testBlock{Block: NewBlock(parentID, 3)}
+	blk.Initialize([]byte{3}, vm)
+	blks = append(blks, blk)
+	if err := blk.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	// The index shouldn't be built again, which would fail as the last
+	// accepted block is unknown
+	vm.LastAcceptedID = ids.Empty
+	if err := vm.IndexHeights(); err != nil {
+		t.Fatal(err)
+	}
+	if blkID, err := vm.GetBlockIDAtHeight(3); err != nil {
+		t.Fatal(err)
+	} else if blkID != blk.ID() {
+		t.Fatalf("expected block %s at height 3 but was %s", blk.ID(), blkID)
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/liraxapp/avalanchego/cache"
+	"github.com/liraxapp/avalanchego/cache/metercacher"
 	"github.com/liraxapp/avalanchego/database"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/snow/choices"
@@ -91,7 +94,11 @@ type state struct {
 	// Keys:   Type ID
 	// Values: Cache that stores uniqueIDs for values that were put with that type ID
 	//         (Saves us from having to re-compute uniqueIDs)
-	uniqueIDCaches map[uint64]*cache.LRU
+	uniqueIDCaches map[uint64]cache.Cacher
+
+	// Namespace and registerer of the metrics of [uniqueIDCaches]
+	namespace  string
+	registerer prometheus.Registerer
 }
 
 // Implements State.RegisterType
@@ -104,6 +111,11 @@ func (s *state) RegisterType(
 	if _, exists := s.unmarshallers[typeID]; exists {
 		return fmt.Errorf("there is already a type with ID %d", typeID)
 	}
+	uIDCache, err := metercacher.New(s.namespace, fmt.Sprintf("unique_id_cache_%d", typeID), s.registerer, &cache.LRU{Size: cacheSize})
+	if err != nil {
+		return err
+	}
+	s.uniqueIDCaches[typeID] = uIDCache
 	s.marshallers[typeID] = marshal
 	s.unmarshallers[typeID] = unmarshal
 	return nil
@@ -215,24 +227,26 @@ func (s *state) GetTime(db database.Database, key ids.ID) (time.Time, error) {
 // Prefix [ID] with [typeID] to prevent key collisions in the database
 func (s *state) uniqueID(id ids.ID, typeID uint64) ids.ID {
 	uIDCache, cacheExists := s.uniqueIDCaches[typeID]
-	if cacheExists {
-		if uID, uIDExists := uIDCache.Get(id); uIDExists { // Get the uniqueID associated with [typeID] and [ID]
-			return uID.(ids.ID)
-		}
-	} else {
-		s.uniqueIDCaches[typeID] = &cache.LRU{Size: cacheSize}
+	if !cacheExists {
+		// Unregistered types aren't cached
+		return id.Prefix(typeID)
+	}
+	if uID, uIDExists := uIDCache.Get(id); uIDExists { // Get the uniqueID associated with [typeID] and [ID]
+		return uID.(ids.ID)
 	}
 	uID := id.Prefix(typeID)
-	s.uniqueIDCaches[typeID].Put(id, uID)
+	uIDCache.Put(id, uID)
 	return uID
 }
 
-// NewState returns a new State
-func NewState() (State, error) {
+// NewState returns a new State whose caches report metrics under [namespace]
+func NewState(namespace string, registerer prometheus.Registerer) (State, error) {
 	state := &state{
 		marshallers:    make(map[uint64]func(interface{}) ([]byte, error)),
 		unmarshallers:  make(map[uint64]func([]byte) (interface{}, error)),
-		uniqueIDCaches: make(map[uint64]*cache.LRU),
+		uniqueIDCaches: make(map[uint64]cache.Cacher),
+		namespace:      namespace,
+		registerer:     registerer,
 	}
 
 	// Register ID, Status and time.Time so they can be put/get without client code
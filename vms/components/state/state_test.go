@@ -6,6 +6,8 @@ package state
 import (
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/liraxapp/avalanchego/database/memdb"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/utils/hashing"
@@ -97,7 +99,7 @@ func unmarshalAccount(bytes []byte) (interface{}, error) {
 // Ensure there is an error if someone tries to do a put without registering the type
 func TestPutUnregistered(t *testing.T) {
 	// make a state and a database
-	state, err := NewState()
+	state, err := NewState("", prometheus.NewRegistry())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -130,7 +132,7 @@ func TestPutUnregistered(t *testing.T) {
 // key that doesn't exist
 func TestKeyDoesNotExist(t *testing.T) {
 	// make a state and a database
-	state, err := NewState()
+	state, err := NewState("", prometheus.NewRegistry())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -156,7 +158,7 @@ func TestKeyDoesNotExist(t *testing.T) {
 // Ensure there is an error if someone tries to register a type ID that already exists
 func TestRegisterExistingTypeID(t *testing.T) {
 	// make a state and a database
-	state, err := NewState()
+	state, err := NewState("", prometheus.NewRegistry())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -179,7 +181,7 @@ func TestRegisterExistingTypeID(t *testing.T) {
 // Ensure there is an error when someone tries to get a value using the wrong typeID
 func TestGetWrongTypeID(t *testing.T) {
 	// make a state and a database
-	state, err := NewState()
+	state, err := NewState("", prometheus.NewRegistry())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -212,7 +214,7 @@ func TestGetWrongTypeID(t *testing.T) {
 // key but different type IDs
 func TestSameKeyDifferentTypeID(t *testing.T) {
 	// make a state and a database
-	state, err := NewState()
+	state, err := NewState("", prometheus.NewRegistry())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -293,7 +295,7 @@ func TestSameKeyDifferentTypeID(t *testing.T) {
 // Ensure that overwriting a value works
 func TestOverwrite(t *testing.T) {
 	// make a state and a database
-	state, err := NewState()
+	state, err := NewState("", prometheus.NewRegistry())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -351,7 +353,7 @@ func TestOverwrite(t *testing.T) {
 // Put 4 values, 2 of one type and 2 of another
 func TestHappyPath(t *testing.T) {
 	// make a state and a database
-	state, err := NewState()
+	state, err := NewState("", prometheus.NewRegistry())
 	if err != nil {
 		t.Fatal(err)
 	}
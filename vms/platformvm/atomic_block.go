@@ -111,7 +111,18 @@ func (ab *AtomicBlock) Accept() error {
 		return fmt.Errorf("failed to accept CommonBlock of %s: %w", ab.ID(), err)
 	}
 
+	requests, err := tx.AtomicRequests()
+	if err != nil {
+		return fmt.Errorf("failed to get atomic requests of tx %s: %w", tx.ID(), err)
+	}
+
 	// Update the state of the chain in the database
+	if err := ab.vm.recordAtomicRequests(ab.Height(), requests); err != nil {
+		return fmt.Errorf("failed to record atomic requests of block %s: %w", ab.ID(), err)
+	}
+	if err := ab.vm.recordState(ab.onAcceptDB, ab.Block, ab.Height()-1); err != nil {
+		return fmt.Errorf("failed to record the state of the chain for block %s: %w", ab.ID(), err)
+	}
 	if err := ab.onAcceptDB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit onAcceptDB for block %s: %w", ab.ID(), err)
 	}
@@ -125,6 +136,7 @@ func (ab *AtomicBlock) Accept() error {
 	if err := tx.Accept(ab.vm.Ctx, batch); err != nil {
 		return fmt.Errorf("failed to atomically accept tx %s in block %s: %w", tx.ID(), ab.ID(), err)
 	}
+	ab.vm.summarizeCommittedState()
 
 	for _, child := range ab.children {
 		child.setBaseDatabase(ab.vm.DB)
@@ -223,12 +223,16 @@ func (sdb *SingleDecisionBlock) Accept() error {
 	}
 
 	// Update the state of the chain in the database
+	if err := sdb.vm.recordState(sdb.onAcceptDB, sdb.Block, sdb.Height()-1); err != nil {
+		return fmt.Errorf("failed to record the state of the chain: %w", err)
+	}
 	if err := sdb.onAcceptDB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit onAcceptDB: %w", err)
 	}
 	if err := sdb.vm.DB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit vm's DB: %w", err)
 	}
+	sdb.vm.summarizeCommittedState()
 
 	for _, child := range sdb.children {
 		child.setBaseDatabase(sdb.vm.DB)
@@ -267,12 +271,16 @@ func (ddb *DoubleDecisionBlock) Accept() error {
 	}
 
 	// Update the state of the chain in the database
+	if err := ddb.vm.recordState(ddb.onAcceptDB, ddb.Block, ddb.Height()-2); err != nil {
+		return fmt.Errorf("failed to record the state of the chain: %w", err)
+	}
 	if err := ddb.onAcceptDB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit onAcceptDB: %w", err)
 	}
 	if err := ddb.vm.DB.Commit(); err != nil {
 		return fmt.Errorf("failed to commit vm's DB: %w", err)
 	}
+	ddb.vm.summarizeCommittedState()
 
 	for _, child := range ddb.children {
 		child.setBaseDatabase(ddb.vm.DB)
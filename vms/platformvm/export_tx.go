@@ -7,7 +7,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/liraxapp/avalanchego/chains/atomic"
 	"github.com/liraxapp/avalanchego/database"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/snow"
@@ -129,9 +128,18 @@ func (tx *UnsignedExportTx) SemanticVerify(
 
 // Accept this transaction.
 func (tx *UnsignedExportTx) Accept(ctx *snow.Context, batch database.Batch) error {
+	requests, err := tx.AtomicRequests()
+	if err != nil {
+		return err
+	}
+	return ctx.SharedMemory.Put(requests.PeerChainID, requests.elements(), batch)
+}
+
+// AtomicRequests returns the UTXOs this transaction exports
+func (tx *UnsignedExportTx) AtomicRequests() (*atomicRequests, error) {
 	txID := tx.ID()
 
-	elems := make([]*atomic.Element, len(tx.ExportedOutputs))
+	puts := make([]atomicElement, len(tx.ExportedOutputs))
 	for i, out := range tx.ExportedOutputs {
 		utxo := &avax.UTXO{
 			UTXOID: avax.UTXOID{
@@ -144,21 +152,24 @@ func (tx *UnsignedExportTx) Accept(ctx *snow.Context, batch database.Batch) erro
 
 		utxoBytes, err := Codec.Marshal(codecVersion, utxo)
 		if err != nil {
-			return fmt.Errorf("failed to marshal UTXO: %w", err)
+			return nil, fmt.Errorf("failed to marshal UTXO: %w", err)
 		}
 		utxoID := utxo.InputID()
-		elem := &atomic.Element{
+		put := atomicElement{
 			Key:   utxoID[:],
 			Value: utxoBytes,
 		}
 		if out, ok := utxo.Out.(avax.Addressable); ok {
-			elem.Traits = out.Addresses()
+			put.Traits = out.Addresses()
 		}
 
-		elems[i] = elem
+		puts[i] = put
 	}
 
-	return ctx.SharedMemory.Put(tx.DestinationChain, elems, batch)
+	return &atomicRequests{
+		PeerChainID: tx.DestinationChain,
+		Puts:        puts,
+	}, nil
 }
 
 // Create a new transaction
@@ -159,12 +159,24 @@ func (tx *UnsignedImportTx) SemanticVerify(
 // only to have the transaction not be Accepted. This would be inconsistent.
 // Recall that imported UTXOs are not kept in a versionDB.
 func (tx *UnsignedImportTx) Accept(ctx *snow.Context, batch database.Batch) error {
+	requests, err := tx.AtomicRequests()
+	if err != nil {
+		return err
+	}
+	return ctx.SharedMemory.Remove(requests.PeerChainID, requests.Removes, batch)
+}
+
+// AtomicRequests returns the UTXOs this transaction imports
+func (tx *UnsignedImportTx) AtomicRequests() (*atomicRequests, error) {
 	utxoIDs := make([][]byte, len(tx.ImportedInputs))
 	for i, in := range tx.ImportedInputs {
 		utxoID := in.InputID()
 		utxoIDs[i] = utxoID[:]
 	}
-	return ctx.SharedMemory.Remove(tx.SourceChain, utxoIDs, batch)
+	return &atomicRequests{
+		PeerChainID: tx.SourceChain,
+		Removes:     utxoIDs,
+	}, nil
 }
 
 // Create a new transaction
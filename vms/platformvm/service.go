@@ -11,7 +11,9 @@ import (
 	"time"
 
 	"github.com/liraxapp/avalanchego/api"
+	"github.com/liraxapp/avalanchego/database"
 	"github.com/liraxapp/avalanchego/database/prefixdb"
+	"github.com/liraxapp/avalanchego/database/snapshotdb"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/utils/constants"
 	"github.com/liraxapp/avalanchego/utils/crypto"
@@ -48,6 +50,17 @@ var (
 // Service defines the API calls that can be made to the platform chain
 type Service struct{ vm *VM }
 
+// snapshotDB returns a read-only view of the chain's database as of this call,
+// so that multi-step reads don't observe a partially committed block. The
+// returned database must be closed once it's no longer used.
+func (service *Service) snapshotDB() (database.Database, error) {
+	snapshot, err := service.vm.DB.NewSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't snapshot the database: %w", err)
+	}
+	return snapshotdb.New(snapshot), nil
+}
+
 // GetHeightResponse ...
 type GetHeightResponse struct {
 	Height json.Uint64 `json:"height"`
@@ -183,7 +196,14 @@ func (service *Service) GetBalance(_ *http.Request, args *api.JSONAddress, respo
 
 	addrs := ids.ShortSet{}
 	addrs.Add(addr)
-	utxos, _, _, err := service.vm.GetUTXOs(service.vm.DB, addrs, ids.ShortEmpty, ids.Empty, -1, false)
+
+	db, err := service.snapshotDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	utxos, _, _, err := service.vm.GetUTXOs(db, addrs, ids.ShortEmpty, ids.Empty, -1, false)
 	if err != nil {
 		addr, err2 := service.vm.FormatLocalAddress(addr)
 		if err2 != nil {
@@ -417,8 +437,14 @@ func (service *Service) GetUTXOs(_ *http.Request, args *GetUTXOsArgs, response *
 		err       error
 	)
 	if sourceChain == service.vm.Ctx.ChainID {
+		db, snapshotErr := service.snapshotDB()
+		if snapshotErr != nil {
+			return snapshotErr
+		}
+		defer db.Close()
+
 		utxos, endAddr, endUTXOID, err = service.vm.GetUTXOs(
-			service.vm.DB,
+			db,
 			addrSet,
 			startAddr,
 			startUTXO,
@@ -2005,8 +2031,16 @@ func (service *Service) GetStake(_ *http.Request, args *api.JSONAddresses, respo
 
 	var totalStake uint64
 
+	// Read the current and pending stakers from the same view, so that a staker
+	// moving between the two sets isn't counted twice or missed
+	db, err := service.snapshotDB()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
 	stopPrefix := []byte(fmt.Sprintf("%s%s", constants.PrimaryNetworkID, stopDBPrefix))
-	stopDB := prefixdb.NewNested(stopPrefix, service.vm.DB)
+	stopDB := prefixdb.NewNested(stopPrefix, db)
 	defer stopDB.Close()
 	stopIter := stopDB.NewIterator()
 	defer stopIter.Release()
@@ -2037,7 +2071,7 @@ func (service *Service) GetStake(_ *http.Request, args *api.JSONAddresses, respo
 
 	// Iterate over pending validators
 	startPrefix := []byte(fmt.Sprintf("%s%s", constants.PrimaryNetworkID, startDBPrefix))
-	startDB := prefixdb.NewNested(startPrefix, service.vm.DB)
+	startDB := prefixdb.NewNested(startPrefix, db)
 	defer startDB.Close()
 	startIter := startDB.NewIterator()
 	defer startIter.Release()
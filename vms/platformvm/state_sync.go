@@ -0,0 +1,800 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/prefixdb"
+	"github.com/liraxapp/avalanchego/database/snapshotdb"
+	"github.com/liraxapp/avalanchego/database/versiondb"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/choices"
+	"github.com/liraxapp/avalanchego/snow/engine/snowman/block"
+	"github.com/liraxapp/avalanchego/utils/hashing"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+	"github.com/liraxapp/avalanchego/vms/components/core"
+	"github.com/liraxapp/avalanchego/vms/components/state"
+)
+
+// The state of the chain is mirrored in the syncable state, keyed by
+// [stateEntryPrefix] followed by the key of the entry in the database. The
+// shared memory operations of the atomic txs that were accepted are kept
+// there too, keyed by [atomicEntryPrefix] followed by the height of the block
+// that accepted them, so that a node that syncs the state can apply the
+// operations it missed. Node-local data, such as uptimes, isn't mirrored.
+//
+// Every [stateSummaryInterval] blocks, the syncable state is split into
+// chunks of about [stateChunkSize] bytes, which are summarized by the hashes
+// of the chunks. Nodes that accepted the same blocks summarize the same
+// state, so a summary can be agreed on and its chunks verified by hash. The
+// summaries are built in the background, from a snapshot of the database
+// taken once the block that starts the interval is committed, and the chunks
+// are stored as they're built, keyed by the height of the block.
+//
+// Mirroring the state doubles the space the state takes on disk, and every
+// write to the state is made twice. This is the cost of building summaries
+// without having to tell the entries of the state apart from the other
+// entries of the database.
+const (
+	stateSummaryInterval = 4096
+	stateChunkSize       = 256 * 1024
+
+	stateEntryPrefix  byte = 0x00
+	atomicEntryPrefix byte = 0x01
+)
+
+var (
+	stateSyncableID = ids.ID{'s', 't', 'a', 't', 'e', ' ', 's', 'y', 'n', 'c', 'a', 'b', 'l', 'e'}
+
+	syncableStatePrefix = []byte("syncable state")
+	stateSummaryPrefix  = []byte("state summary")
+	syncedChunksPrefix  = []byte("synced chunks")
+
+	stateSummaryKey = []byte("summary")
+
+	// Uptimes are measured by this node and differ between nodes
+	uptimeDBKeyPrefix = hashing.ComputeHash256([]byte(uptimeDBPrefix))
+
+	errWrongStateSummaryType = errors.New("wrong state summary type")
+	errUnknownStateSummary   = errors.New("unknown state summary")
+	errUnknownStateChunk     = errors.New("unknown state chunk")
+	errInvalidStateChunk     = errors.New("state chunk doesn't match its summary")
+	errMissingStateChunk     = errors.New("state chunk wasn't synced")
+	errInvalidSummaryBlock   = errors.New("block doesn't match the state summary")
+
+	_ block.StateSyncableVM = &VM{}
+	_ block.StateSummary    = &stateSummary{}
+)
+
+// stateSummary is the summary of the syncable state as of an accepted
+// decision block
+type stateSummary struct {
+	id       ids.ID
+	height   uint64
+	blkID    ids.ID
+	blkBytes []byte
+	chunkIDs []ids.ID
+	bytes    []byte
+}
+
+func (s *stateSummary) ID() ids.ID        { return s.id }
+func (s *stateSummary) Height() uint64    { return s.height }
+func (s *stateSummary) BlockID() ids.ID   { return s.blkID }
+func (s *stateSummary) NumChunks() uint32 { return uint32(len(s.chunkIDs)) }
+func (s *stateSummary) Bytes() []byte     { return s.bytes }
+
+func newStateSummary(height uint64, blkID ids.ID, blkBytes []byte, chunkIDs []ids.ID) (*stateSummary, error) {
+	p := wrappers.Packer{MaxSize: wrappers.LongLen + hashing.HashLen + wrappers.IntLen + len(blkBytes) + wrappers.IntLen + len(chunkIDs)*hashing.HashLen}
+	p.PackLong(height)
+	p.PackFixedBytes(blkID[:])
+	p.PackBytes(blkBytes)
+	p.PackInt(uint32(len(chunkIDs)))
+	for _, chunkID := range chunkIDs {
+		p.PackFixedBytes(chunkID[:])
+	}
+	if p.Errored() {
+		return nil, p.Err
+	}
+	return &stateSummary{
+		id:       hashing.ComputeHash256Array(p.Bytes),
+		height:   height,
+		blkID:    blkID,
+		blkBytes: blkBytes,
+		chunkIDs: chunkIDs,
+		bytes:    p.Bytes,
+	}, nil
+}
+
+func parseStateSummary(summaryBytes []byte) (*stateSummary, error) {
+	p := wrappers.Packer{Bytes: summaryBytes}
+	summary := &stateSummary{
+		id:     hashing.ComputeHash256Array(summaryBytes),
+		height: p.UnpackLong(),
+		bytes:  summaryBytes,
+	}
+	copy(summary.blkID[:], p.UnpackFixedBytes(hashing.HashLen))
+	summary.blkBytes = p.UnpackBytes()
+	numChunks := p.UnpackInt()
+	// Don't trust the number of chunks before checking they're all there
+	if !p.Errored() && uint64(numChunks)*hashing.HashLen != uint64(len(summaryBytes)-p.Offset) {
+		return nil, fmt.Errorf("state summary has %d chunks but %d bytes left", numChunks, len(summaryBytes)-p.Offset)
+	}
+	summary.chunkIDs = make([]ids.ID, numChunks)
+	for i := range summary.chunkIDs {
+		copy(summary.chunkIDs[i][:], p.UnpackFixedBytes(hashing.HashLen))
+	}
+	if p.Errored() {
+		return nil, fmt.Errorf("couldn't parse state summary: %w", p.Err)
+	}
+	return summary, nil
+}
+
+// packStateChunk returns the bytes of a chunk of the syncable state that
+// holds [entries], which alternate between keys and values
+func packStateChunk(entries [][]byte, size int) ([]byte, error) {
+	p := wrappers.Packer{MaxSize: wrappers.IntLen + len(entries)*wrappers.IntLen + size}
+	p.PackInt(uint32(len(entries) / 2))
+	for _, entry := range entries {
+		p.PackBytes(entry)
+	}
+	return p.Bytes, p.Err
+}
+
+// parseStateChunk returns the entries of a chunk of the syncable state,
+// alternating between keys and values
+func parseStateChunk(chunk []byte) ([][]byte, error) {
+	p := wrappers.Packer{Bytes: chunk}
+	numEntries := p.UnpackInt()
+	entries := [][]byte(nil)
+	for i := uint32(0); i < numEntries && !p.Errored(); i++ {
+		entries = append(entries, p.UnpackBytes(), p.UnpackBytes())
+	}
+	if p.Errored() {
+		return nil, fmt.Errorf("couldn't parse state chunk: %w", p.Err)
+	}
+	if p.Offset != len(chunk) {
+		return nil, fmt.Errorf("state chunk has %d unexpected trailing bytes", len(chunk)-p.Offset)
+	}
+	return entries, nil
+}
+
+func chunkKey(index uint32) []byte {
+	p := wrappers.Packer{MaxSize: wrappers.IntLen}
+	p.PackInt(index)
+	return p.Bytes
+}
+
+// summaryChunkKey is the key of a chunk of the summary of the state as of the
+// block at [height]
+func summaryChunkKey(height uint64, index uint32) []byte {
+	p := wrappers.Packer{MaxSize: wrappers.LongLen + wrappers.IntLen}
+	p.PackLong(height)
+	p.PackInt(index)
+	return p.Bytes
+}
+
+func atomicEntryKey(height uint64) []byte {
+	p := wrappers.Packer{MaxSize: 1 + wrappers.LongLen}
+	p.PackByte(atomicEntryPrefix)
+	p.PackLong(height)
+	return p.Bytes
+}
+
+// syncableStateWriter mirrors the writes to the state of the chain in the
+// syncable state. Each write to the state is written again by the writer, so
+// the state is stored twice.
+type syncableStateWriter struct{ db database.KeyValueWriter }
+
+func (w *syncableStateWriter) Put(key, value []byte) error {
+	if bytes.HasPrefix(key, uptimeDBKeyPrefix) {
+		return nil
+	}
+	return w.db.Put(append([]byte{stateEntryPrefix}, key...), value)
+}
+
+func (w *syncableStateWriter) Delete(key []byte) error {
+	if bytes.HasPrefix(key, uptimeDBKeyPrefix) {
+		return nil
+	}
+	return w.db.Delete(append([]byte{stateEntryPrefix}, key...))
+}
+
+// recordStateChanges mirrors the changes [diff] makes to the state of the
+// chain in the syncable state. [diff] must be committed after this is called.
+func (vm *VM) recordStateChanges(diff *versiondb.Database) error {
+	if !vm.stateSyncable {
+		return nil
+	}
+	batch, err := diff.CommitBatch()
+	if err != nil {
+		return err
+	}
+	return batch.Replay(&syncableStateWriter{db: prefixdb.NewNested(syncableStatePrefix, vm.DB)})
+}
+
+// recordState mirrors the changes [diff] makes to the state of the chain when
+// [blk] is accepted. If [blk] is the first decision block after
+// [prevDecisionHeight] of a new summary interval, the state is summarized once
+// [blk] is committed, see summarizeCommittedState.
+func (vm *VM) recordState(diff *versiondb.Database, blk *core.Block, prevDecisionHeight uint64) error {
+	if err := vm.recordStateChanges(diff); err != nil {
+		return err
+	}
+	if vm.stateSyncable && blk.Height()/stateSummaryInterval != prevDecisionHeight/stateSummaryInterval {
+		vm.summaryBlk = blk
+	}
+	return nil
+}
+
+// recordAtomicRequests records the shared memory operations of the atomic tx
+// accepted at [height]
+func (vm *VM) recordAtomicRequests(height uint64, requests *atomicRequests) error {
+	if !vm.stateSyncable {
+		return nil
+	}
+	return vm.putAtomicRequests(prefixdb.NewNested(syncableStatePrefix, vm.DB), height, requests)
+}
+
+func (vm *VM) putAtomicRequests(stateDB database.KeyValueWriter, height uint64, requests *atomicRequests) error {
+	requestsBytes, err := vm.codec.Marshal(codecVersion, requests)
+	if err != nil {
+		return err
+	}
+	return stateDB.Put(atomicEntryKey(height), requestsBytes)
+}
+
+// backfillSyncableState mirrors the state of a chain that was created before
+// state sync was introduced in the syncable state. The state is copied from
+// the database, leaving out the blocks and the entries kept about blocks and
+// about the database, which aren't part of the state, and the shared memory
+// operations of the atomic txs that were accepted are recorded. This is only
+// done once, as the state is mirrored as it changes from then on. If it's
+// interrupted, the copy is made again the next time the chain is initialized.
+func (vm *VM) backfillSyncableState() error {
+	if vm.stateSyncable {
+		return nil
+	}
+	vm.Ctx.Log.Info("mirroring the state of the chain in the syncable state")
+	if err := vm.DB.Commit(); err != nil {
+		return err
+	}
+
+	baseDB := vm.DB.GetDatabase()
+	stateBatch := prefixdb.NewNested(syncableStatePrefix, baseDB).NewBatch()
+	writer := &syncableStateWriter{db: stateBatch}
+	writeFull := func() error {
+		if stateBatch.ValueSize() < stateChunkSize {
+			return nil
+		}
+		if err := stateBatch.Write(); err != nil {
+			return err
+		}
+		stateBatch.Reset()
+		return nil
+	}
+
+	// Copy every entry of the state
+	iter := baseDB.NewIterator()
+	for iter.Next() {
+		key, value := iter.Key(), iter.Value()
+		if !isStateKey(key) || isBlockEntry(key, value) {
+			continue
+		}
+		if err := writer.Put(key, value); err != nil {
+			iter.Release()
+			return err
+		}
+		if err := writeFull(); err != nil {
+			iter.Release()
+			return err
+		}
+	}
+	err := iter.Error()
+	iter.Release()
+	if err != nil {
+		return err
+	}
+
+	// Remove the entries kept about the blocks, which were copied along with
+	// the state, and record the atomic requests of the accepted blocks
+	excluder := &syncableStateExcluder{Database: baseDB, writer: writer}
+	iter = baseDB.NewIterator()
+	defer iter.Release()
+	for iter.Next() {
+		key, value := iter.Key(), iter.Value()
+		if !isStateKey(key) || !isBlockEntry(key, value) {
+			continue
+		}
+		blkIntf, err := vm.unmarshalBlockFunc(append([]byte(nil), value...))
+		if err != nil {
+			return err
+		}
+		blk, ok := blkIntf.(Block)
+		if !ok {
+			return errInvalidBlockType
+		}
+		blkID := blk.ID()
+		if err := vm.State.PutStatus(excluder, blkID, choices.Accepted); err != nil {
+			return err
+		}
+		if err := vm.State.PutBlockIDAtHeight(excluder, blk.Height(), blkID); err != nil {
+			return err
+		}
+		if atomicBlk, ok := blk.(*AtomicBlock); ok && vm.State.GetStatus(baseDB, blkID) == choices.Accepted {
+			tx, ok := atomicBlk.Tx.UnsignedTx.(UnsignedAtomicTx)
+			if !ok {
+				return errWrongTxType
+			}
+			requests, err := tx.AtomicRequests()
+			if err != nil {
+				return err
+			}
+			if err := vm.putAtomicRequests(stateBatch, blk.Height(), requests); err != nil {
+				return err
+			}
+		}
+		if err := writeFull(); err != nil {
+			return err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+
+	// Remove the entries kept about the database
+	for _, statusID := range append([]ids.ID{stateSyncableID}, core.StatusIDs...) {
+		if err := vm.State.PutStatus(excluder, statusID, choices.Accepted); err != nil {
+			return err
+		}
+	}
+	if err := vm.State.PutLastAccepted(excluder, ids.Empty); err != nil {
+		return err
+	}
+	if err := stateBatch.Write(); err != nil {
+		return err
+	}
+
+	if err := vm.State.PutStatus(vm.DB, stateSyncableID, choices.Accepted); err != nil {
+		return err
+	}
+	if err := vm.DB.Commit(); err != nil {
+		return err
+	}
+	vm.stateSyncable = true
+	return nil
+}
+
+// isStateKey returns false if [key] is the key of an entry that isn't, and
+// can't be, part of the state of the chain: the entries kept for state sync and
+// uptimes
+func isStateKey(key []byte) bool {
+	for _, prefix := range [][]byte{syncableStatePrefix, stateSummaryPrefix, syncedChunksPrefix} {
+		if bytes.HasPrefix(key, hashing.ComputeHash256(prefix)) {
+			return false
+		}
+	}
+	return !bytes.HasPrefix(key, uptimeDBKeyPrefix)
+}
+
+// isBlockEntry returns true if [key] and [value] are the entry of a block
+func isBlockEntry(key, value []byte) bool {
+	blkKey := ids.ID(hashing.ComputeHash256Array(value)).Prefix(state.BlockTypeID)
+	return bytes.Equal(key, blkKey[:])
+}
+
+// syncableStateExcluder is passed in place of the database to learn the keys
+// entries are written to, so that the entries with those keys are removed from
+// the syncable state
+type syncableStateExcluder struct {
+	database.Database
+	writer *syncableStateWriter
+}
+
+func (e *syncableStateExcluder) Put(key, _ []byte) error { return e.writer.Delete(key) }
+func (e *syncableStateExcluder) Delete(key []byte) error { return e.writer.Delete(key) }
+
+// summarizeCommittedState summarizes the state in the background if the block
+// that was just accepted started a new summary interval. It must be called
+// once the block is committed, so that the summary reads the state as of the
+// block.
+func (vm *VM) summarizeCommittedState() {
+	blk := vm.summaryBlk
+	if blk == nil {
+		return
+	}
+	vm.summaryBlk = nil
+	vm.summarizeState(blk)
+}
+
+// summarizeState summarizes the committed state as of the accepted block [blk]
+// in the background. Failing to summarize the state doesn't affect the chain,
+// so the error is only logged.
+func (vm *VM) summarizeState(blk *core.Block) {
+	baseDB := vm.DB.GetDatabase()
+	height, blkID, blkBytes := blk.Height(), blk.ID(), blk.Bytes()
+	snapshot, err := baseDB.NewSnapshot()
+	if err != nil {
+		vm.Ctx.Log.Error("couldn't snapshot the state as of block %s: %s", blkID, err)
+		return
+	}
+
+	vm.summaries.Add(1)
+	go func() {
+		defer vm.summaries.Done()
+
+		stateDB := snapshotdb.New(snapshot)
+		defer stateDB.Close()
+
+		summaryDB := prefixdb.NewNested(stateSummaryPrefix, baseDB)
+		summary, err := buildStateSummary(stateDB, summaryDB, height, blkID, blkBytes)
+		if err == nil {
+			err = vm.putStateSummary(summaryDB, summary)
+		}
+		if err != nil {
+			vm.Ctx.Log.Error("couldn't summarize the state as of block %s: %s", blkID, err)
+			return
+		}
+		vm.Ctx.Log.Debug("summarized the state as of block %s at height %d in %d chunks", blkID, height, summary.NumChunks())
+	}()
+}
+
+// buildStateSummary splits the syncable state in [db] into chunks, which are
+// written to [summaryDB] as they're built, and returns the summary of the
+// state as of the accepted block [blkID]. Only one chunk is held in memory at a
+// time.
+func buildStateSummary(db database.Database, summaryDB database.KeyValueWriter, height uint64, blkID ids.ID, blkBytes []byte) (*stateSummary, error) {
+	iter := prefixdb.NewNested(syncableStatePrefix, db).NewIterator()
+	defer iter.Release()
+
+	chunkIDs := []ids.ID(nil)
+	entries := [][]byte(nil)
+	size := 0
+	putChunk := func() error {
+		chunk, err := packStateChunk(entries, size)
+		if err != nil {
+			return err
+		}
+		if err := summaryDB.Put(summaryChunkKey(height, uint32(len(chunkIDs))), chunk); err != nil {
+			return err
+		}
+		chunkIDs = append(chunkIDs, hashing.ComputeHash256Array(chunk))
+		entries = nil
+		size = 0
+		return nil
+	}
+	for iter.Next() {
+		key := append([]byte(nil), iter.Key()...)
+		value := append([]byte(nil), iter.Value()...)
+		entries = append(entries, key, value)
+		size += len(key) + len(value)
+		if size < stateChunkSize {
+			continue
+		}
+		if err := putChunk(); err != nil {
+			return nil, err
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	if len(entries) > 0 {
+		if err := putChunk(); err != nil {
+			return nil, err
+		}
+	}
+	return newStateSummary(height, blkID, blkBytes, chunkIDs)
+}
+
+// putStateSummary replaces the summary stored in [summaryDB] with [summary],
+// whose chunks have already been put, unless the stored summary is more
+// recent. The chunks of the summary that isn't kept are deleted.
+func (vm *VM) putStateSummary(summaryDB database.Database, summary *stateSummary) error {
+	vm.summaryLock.Lock()
+	defer vm.summaryLock.Unlock()
+
+	oldSummaryBytes, err := summaryDB.Get(stateSummaryKey)
+	if err == database.ErrNotFound {
+		return summaryDB.Put(stateSummaryKey, summary.Bytes())
+	}
+	if err != nil {
+		return err
+	}
+	oldSummary, err := parseStateSummary(oldSummaryBytes)
+	if err != nil {
+		return err
+	}
+	if oldSummary.height > summary.height {
+		return deleteSummaryChunks(summaryDB, summary.height, 0, summary.NumChunks())
+	}
+	if err := summaryDB.Put(stateSummaryKey, summary.Bytes()); err != nil {
+		return err
+	}
+	if oldSummary.height == summary.height {
+		// The chunks of [summary] replaced those of the stored summary
+		return deleteSummaryChunks(summaryDB, oldSummary.height, summary.NumChunks(), oldSummary.NumChunks())
+	}
+	return deleteSummaryChunks(summaryDB, oldSummary.height, 0, oldSummary.NumChunks())
+}
+
+// deleteSummaryChunks deletes the chunks in [start, end) of the summary of the
+// state as of the block at [height]
+func deleteSummaryChunks(summaryDB database.KeyValueWriter, height uint64, start, end uint32) error {
+	for i := start; i < end; i++ {
+		if err := summaryDB.Delete(summaryChunkKey(height, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetStateSummary implements the block.StateSyncableVM interface
+func (vm *VM) GetStateSummary() (block.StateSummary, error) {
+	summaryBytes, err := prefixdb.NewNested(stateSummaryPrefix, vm.DB).Get(stateSummaryKey)
+	if err != nil {
+		return nil, err
+	}
+	return parseStateSummary(summaryBytes)
+}
+
+// ParseStateSummary implements the block.StateSyncableVM interface
+func (vm *VM) ParseStateSummary(summaryBytes []byte) (block.StateSummary, error) {
+	return parseStateSummary(summaryBytes)
+}
+
+// ShouldSyncState implements the block.StateSyncableVM interface. The state is
+// only synced if it's at least a summary interval beyond the last accepted
+// block, and if the syncable state is complete, as only then can the state
+// be replaced.
+func (vm *VM) ShouldSyncState(summary block.StateSummary) (bool, error) {
+	if !vm.stateSyncable {
+		return false, nil
+	}
+	lastAccepted, err := vm.getBlock(vm.LastAccepted())
+	if err != nil {
+		return false, err
+	}
+	if summary.Height() < lastAccepted.Height()+stateSummaryInterval {
+		return false, nil
+	}
+
+	// Drop the chunks of a previous, abandoned, sync
+	syncedDB := prefixdb.NewNested(syncedChunksPrefix, vm.DB)
+	if err := deleteAll(syncedDB); err != nil {
+		return false, err
+	}
+	return true, vm.DB.Commit()
+}
+
+// GetStateChunk implements the block.StateSyncableVM interface. Only the
+// chunks of the latest summary are available.
+func (vm *VM) GetStateChunk(summaryID ids.ID, index uint32) ([]byte, error) {
+	summaryDB := prefixdb.NewNested(stateSummaryPrefix, vm.DB)
+	summaryBytes, err := summaryDB.Get(stateSummaryKey)
+	if err == database.ErrNotFound {
+		return nil, errUnknownStateSummary
+	} else if err != nil {
+		return nil, err
+	}
+	if hashing.ComputeHash256Array(summaryBytes) != summaryID {
+		return nil, errUnknownStateSummary
+	}
+	summary, err := parseStateSummary(summaryBytes)
+	if err != nil {
+		return nil, err
+	}
+	chunk, err := summaryDB.Get(summaryChunkKey(summary.height, index))
+	if err == database.ErrNotFound {
+		return nil, errUnknownStateChunk
+	}
+	return chunk, err
+}
+
+// SyncStateChunk implements the block.StateSyncableVM interface
+func (vm *VM) SyncStateChunk(summaryIntf block.StateSummary, index uint32, chunk []byte) error {
+	summary, ok := summaryIntf.(*stateSummary)
+	if !ok {
+		return errWrongStateSummaryType
+	}
+	if index >= summary.NumChunks() {
+		return errUnknownStateChunk
+	}
+	if hashing.ComputeHash256Array(chunk) != summary.chunkIDs[index] {
+		return errInvalidStateChunk
+	}
+	if _, err := parseStateChunk(chunk); err != nil {
+		return err
+	}
+	if err := prefixdb.NewNested(syncedChunksPrefix, vm.DB).Put(chunkKey(index), chunk); err != nil {
+		return err
+	}
+	return vm.DB.Commit()
+}
+
+// StateSynced implements the block.StateSyncableVM interface. The state of the
+// chain is replaced by the synced state and the shared memory operations of
+// the atomic txs accepted after the last accepted block are applied. The
+// blocks between the last accepted block and the block of [summaryIntf]
+// aren't indexed by height.
+func (vm *VM) StateSynced(summaryIntf block.StateSummary) error {
+	summary, ok := summaryIntf.(*stateSummary)
+	if !ok {
+		return errWrongStateSummaryType
+	}
+
+	syncedDB := prefixdb.NewNested(syncedChunksPrefix, vm.DB)
+	chunks := make([][]byte, summary.NumChunks())
+	for i := range chunks {
+		chunk, err := syncedDB.Get(chunkKey(uint32(i)))
+		if err == database.ErrNotFound {
+			return errMissingStateChunk
+		} else if err != nil {
+			return err
+		}
+		// The chunk may have been synced for another summary
+		if hashing.ComputeHash256Array(chunk) != summary.chunkIDs[i] {
+			return errInvalidStateChunk
+		}
+		chunks[i] = chunk
+	}
+
+	blkIntf, err := vm.ParseBlock(summary.blkBytes)
+	if err != nil {
+		return err
+	}
+	blk, ok := blkIntf.(Block)
+	if _, isDecision := blkIntf.(decision); !ok || !isDecision || blk.ID() != summary.blkID || blk.Height() != summary.height {
+		return errInvalidSummaryBlock
+	}
+
+	lastAccepted, err := vm.getBlock(vm.LastAccepted())
+	if err != nil {
+		return err
+	}
+	lastAcceptedHeight := lastAccepted.Height()
+
+	// Remove the state that is being replaced
+	stateDB := prefixdb.NewNested(syncableStatePrefix, vm.DB)
+	if err := vm.clearSyncableState(stateDB); err != nil {
+		return err
+	}
+
+	requests := &atomicRequests{PeerChainID: vm.Ctx.XChainID}
+	for _, chunk := range chunks {
+		entries, err := parseStateChunk(chunk)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < len(entries); i += 2 {
+			key, value := entries[i], entries[i+1]
+			if err := stateDB.Put(key, value); err != nil {
+				return err
+			}
+			if len(key) == 0 {
+				return errInvalidStateChunk
+			}
+			switch key[0] {
+			case stateEntryPrefix:
+				if err := vm.DB.Put(key[1:], value); err != nil {
+					return err
+				}
+			case atomicEntryPrefix:
+				p := wrappers.Packer{Bytes: key[1:]}
+				if height := p.UnpackLong(); p.Errored() || height <= lastAcceptedHeight {
+					continue
+				}
+				blkRequests := atomicRequests{}
+				if _, err := vm.codec.Unmarshal(value, &blkRequests); err != nil {
+					return err
+				}
+				requests.Puts = append(requests.Puts, blkRequests.Puts...)
+				requests.Removes = append(requests.Removes, blkRequests.Removes...)
+			default:
+				return errInvalidStateChunk
+			}
+		}
+	}
+
+	blkID := blk.ID()
+	if err := vm.State.PutStatus(vm.DB, blkID, choices.Accepted); err != nil {
+		return err
+	}
+	if err := vm.State.PutBlockIDAtHeight(vm.DB, blk.Height(), blkID); err != nil {
+		return err
+	}
+	if err := vm.State.PutLastAccepted(vm.DB, blkID); err != nil {
+		return err
+	}
+	if err := deleteAll(syncedDB); err != nil {
+		return err
+	}
+	// The synced chunks are kept, so that the state can be served to others
+	summaryDB := prefixdb.NewNested(stateSummaryPrefix, vm.DB)
+	for i, chunk := range chunks {
+		if err := summaryDB.Put(summaryChunkKey(summary.height, uint32(i)), chunk); err != nil {
+			return err
+		}
+	}
+	if err := vm.putStateSummary(summaryDB, summary); err != nil {
+		return err
+	}
+
+	// Removes of keys that don't exist are recorded, so the removes are
+	// applied first, making it safe to apply them again if syncing fails
+	// before the state is committed.
+	if len(requests.Removes) > 0 {
+		if err := vm.Ctx.SharedMemory.Remove(requests.PeerChainID, requests.Removes); err != nil {
+			return fmt.Errorf("failed to remove synced atomic requests: %w", err)
+		}
+	}
+	batch, err := vm.DB.CommitBatch()
+	if err != nil {
+		return err
+	}
+	defer vm.DB.Abort()
+	if len(requests.Puts) > 0 {
+		if err := vm.Ctx.SharedMemory.Put(requests.PeerChainID, requests.elements(), batch); err != nil {
+			return fmt.Errorf("failed to put synced atomic requests: %w", err)
+		}
+	} else if err := batch.Write(); err != nil {
+		return err
+	}
+
+	vm.LastAcceptedID = blkID
+	vm.SetPreference(blkID)
+	vm.Ctx.Log.Info("synced the state as of block %s at height %d", blkID, blk.Height())
+
+	if err := vm.initSubnets(); err != nil {
+		return err
+	}
+	return vm.initBlockchains()
+}
+
+// clearSyncableState removes the mirrored state, and the mirror, from the
+// database
+func (vm *VM) clearSyncableState(stateDB database.Database) error {
+	iter := stateDB.NewIterator()
+	defer iter.Release()
+
+	keys := [][]byte(nil)
+	for iter.Next() {
+		keys = append(keys, append([]byte(nil), iter.Key()...))
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if key[0] == stateEntryPrefix {
+			if err := vm.DB.Delete(key[1:]); err != nil {
+				return err
+			}
+		}
+		if err := stateDB.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteAll(db database.Database) error {
+	iter := db.NewIterator()
+	defer iter.Release()
+
+	keys := [][]byte(nil)
+	for iter.Next() {
+		keys = append(keys, append([]byte(nil), iter.Key()...))
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := db.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
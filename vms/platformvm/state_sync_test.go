@@ -0,0 +1,252 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/liraxapp/avalanchego/chains"
+	"github.com/liraxapp/avalanchego/chains/atomic"
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/prefixdb"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/engine/common"
+	"github.com/liraxapp/avalanchego/snow/validators"
+	"github.com/liraxapp/avalanchego/utils/crypto"
+	"github.com/liraxapp/avalanchego/utils/logging"
+	"github.com/liraxapp/avalanchego/vms/components/core"
+	"github.com/liraxapp/avalanchego/vms/components/state"
+)
+
+func TestStateSync(t *testing.T) {
+	vm, _ := defaultVM()
+	vm.Ctx.Lock.Lock()
+	defer func() {
+		if err := vm.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		vm.Ctx.Lock.Unlock()
+	}()
+
+	syncVM, syncBaseDB := defaultVM()
+	syncVM.Ctx.Lock.Lock()
+	defer func() {
+		if err := syncVM.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		syncVM.Ctx.Lock.Unlock()
+	}()
+
+	m := &atomic.Memory{}
+	if err := m.Initialize(logging.NoLog{}, prefixdb.New([]byte{5}, syncBaseDB)); err != nil {
+		t.Fatal(err)
+	}
+	syncVM.Ctx.SharedMemory = m.NewSharedMemory(syncVM.Ctx.ChainID)
+	peerSharedMemory := m.NewSharedMemory(syncVM.Ctx.XChainID)
+
+	tx, err := vm.newExportTx(
+		100,
+		vm.Ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.mempool.IssueTx(tx); err != nil {
+		t.Fatal(err)
+	}
+	vm.SetPreference(vm.LastAccepted())
+	blkIntf, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk := blkIntf.(*AtomicBlock)
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	} else if err := blk.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	vm.summarizeState(blk.Block)
+	vm.summaries.Wait()
+
+	summary, err := vm.GetStateSummary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.BlockID() != blk.ID() {
+		t.Fatalf("summary should be of block %s but is of block %s", blk.ID(), summary.BlockID())
+	}
+	if summary.NumChunks() == 0 {
+		t.Fatal("summary should have chunks")
+	}
+	parsedSummary, err := syncVM.ParseStateSummary(summary.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsedSummary.ID() != summary.ID() {
+		t.Fatalf("parsed summary should have ID %s but has ID %s", summary.ID(), parsedSummary.ID())
+	}
+	if shouldSync, err := syncVM.ShouldSyncState(parsedSummary); err != nil {
+		t.Fatal(err)
+	} else if shouldSync {
+		t.Fatal("shouldn't sync a summary less than a summary interval ahead")
+	}
+
+	if _, err := vm.GetStateChunk(ids.GenerateTestID(), 0); err == nil {
+		t.Fatal("should have errored because the summary is unknown")
+	}
+	if _, err := vm.GetStateChunk(summary.ID(), summary.NumChunks()); err == nil {
+		t.Fatal("should have errored because the chunk is unknown")
+	}
+	for i := uint32(0); i < summary.NumChunks(); i++ {
+		chunk, err := vm.GetStateChunk(summary.ID(), i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			if err := syncVM.StateSynced(parsedSummary); err == nil {
+				t.Fatal("should have errored because a chunk wasn't synced")
+			}
+			invalidChunk := append([]byte(nil), chunk...)
+			invalidChunk[len(invalidChunk)-1]++
+			if err := syncVM.SyncStateChunk(parsedSummary, i, invalidChunk); err == nil {
+				t.Fatal("should have errored because the chunk is invalid")
+			}
+		}
+		if err := syncVM.SyncStateChunk(parsedSummary, i, chunk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := syncVM.StateSynced(parsedSummary); err != nil {
+		t.Fatal(err)
+	}
+
+	if lastAccepted := syncVM.LastAccepted(); lastAccepted != blk.ID() {
+		t.Fatalf("last accepted block should be %s but is %s", blk.ID(), lastAccepted)
+	}
+	if status, err := syncVM.getStatus(syncVM.DB, tx.ID()); err != nil {
+		t.Fatal(err)
+	} else if status != Committed {
+		t.Fatalf("status should be Committed but is %s", status)
+	}
+
+	// The UTXO exported after the last accepted block of [syncVM] must be put
+	// in shared memory
+	requests, err := tx.UnsignedTx.(UnsignedAtomicTx).AtomicRequests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := peerSharedMemory.Get(syncVM.Ctx.ChainID, [][]byte{requests.Puts[0].Key}); err != nil {
+		t.Fatalf("should have been able to read the exported utxo: %s", err)
+	}
+
+	// The synced state must be summarized like the state it was synced from
+	syncVM.summarizeState(blk.Block)
+	syncVM.summaries.Wait()
+	if syncedSummary, err := syncVM.GetStateSummary(); err != nil {
+		t.Fatal(err)
+	} else if syncedSummary.ID() != summary.ID() {
+		t.Fatalf("synced state should be summarized as %s but is summarized as %s", summary.ID(), syncedSummary.ID())
+	}
+}
+
+func TestBackfillSyncableState(t *testing.T) {
+	vm, baseDB := defaultVM()
+	vm.Ctx.Lock.Lock()
+
+	tx, err := vm.newExportTx(
+		100,
+		vm.Ctx.XChainID,
+		ids.GenerateTestShortID(),
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.mempool.IssueTx(tx); err != nil {
+		t.Fatal(err)
+	}
+	vm.SetPreference(vm.LastAccepted())
+	blk, err := vm.BuildBlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := blk.Verify(); err != nil {
+		t.Fatal(err)
+	} else if err := blk.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	if err := vm.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	vm.Ctx.Lock.Unlock()
+
+	// Drop the syncable state, as if the chain was created before state sync
+	// was introduced
+	chainDB := prefixdb.New([]byte{0}, baseDB)
+	stateDB := prefixdb.NewNested(syncableStatePrefix, chainDB)
+	expected := syncableStateEntries(t, stateDB)
+	if len(expected) == 0 {
+		t.Fatal("the state should have been mirrored")
+	}
+	if err := deleteAll(stateDB); err != nil {
+		t.Fatal(err)
+	}
+	stateSyncableKey := stateSyncableID.Prefix(state.StatusTypeID)
+	if err := chainDB.Delete(stateSyncableKey[:]); err != nil {
+		t.Fatal(err)
+	}
+
+	restartedVM := &VM{
+		SnowmanVM:          &core.SnowmanVM{},
+		chainManager:       chains.MockManager{},
+		minStakeDuration:   defaultMinStakingDuration,
+		maxStakeDuration:   defaultMaxStakingDuration,
+		stakeMintingPeriod: defaultMaxStakingDuration,
+	}
+	restartedVM.vdrMgr = validators.NewManager()
+	restartedVM.clock.Set(defaultGenesisTime)
+	ctx := defaultContext()
+	ctx.Lock.Lock()
+	defer func() {
+		if err := restartedVM.Shutdown(); err != nil {
+			t.Fatal(err)
+		}
+		ctx.Lock.Unlock()
+	}()
+	_, genesisBytes := defaultGenesis()
+	if err := restartedVM.Initialize(ctx, prefixdb.New([]byte{0}, baseDB), genesisBytes, make(chan common.Message, 1), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if !restartedVM.stateSyncable {
+		t.Fatal("the state should be syncable once the syncable state is backfilled")
+	}
+	backfilled := syncableStateEntries(t, stateDB)
+	if len(backfilled) != len(expected) {
+		t.Fatalf("backfilled %d entries but %d were mirrored", len(backfilled), len(expected))
+	}
+	for key, value := range expected {
+		if backfilledValue, ok := backfilled[key]; !ok || !bytes.Equal(backfilledValue, value) {
+			t.Fatalf("entry %x wasn't backfilled as it was mirrored", key)
+		}
+	}
+}
+
+func syncableStateEntries(t *testing.T, stateDB database.Database) map[string][]byte {
+	entries := make(map[string][]byte)
+	iter := stateDB.NewIterator()
+	defer iter.Release()
+	for iter.Next() {
+		entries[string(iter.Key())] = append([]byte(nil), iter.Value()...)
+	}
+	if err := iter.Error(); err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
@@ -6,6 +6,7 @@ package platformvm
 import (
 	"fmt"
 
+	"github.com/liraxapp/avalanchego/chains/atomic"
 	"github.com/liraxapp/avalanchego/database"
 	"github.com/liraxapp/avalanchego/database/versiondb"
 	"github.com/liraxapp/avalanchego/ids"
@@ -62,6 +63,40 @@ type UnsignedAtomicTx interface {
 
 	// Accept this transaction with the additionally provided state transitions.
 	Accept(ctx *snow.Context, batch database.Batch) error
+
+	// AtomicRequests returns the changes this transaction makes to shared
+	// memory when it is accepted
+	AtomicRequests() (*atomicRequests, error)
+}
+
+// atomicRequests are the changes an atomic transaction makes to the memory it
+// shares with the chain [PeerChainID]
+type atomicRequests struct {
+	PeerChainID ids.ID `serialize:"true"`
+	// Elements put on the peer chain's side of shared memory
+	Puts []atomicElement `serialize:"true"`
+	// Keys removed from this chain's side of shared memory
+	Removes [][]byte `serialize:"true"`
+}
+
+// atomicElement is an element of shared memory
+type atomicElement struct {
+	Key    []byte   `serialize:"true"`
+	Value  []byte   `serialize:"true"`
+	Traits [][]byte `serialize:"true"`
+}
+
+// elements returns the elements put on the peer chain's side of shared memory
+func (r *atomicRequests) elements() []*atomic.Element {
+	elems := make([]*atomic.Element, len(r.Puts))
+	for i, put := range r.Puts {
+		elems[i] = &atomic.Element{
+			Key:    put.Key,
+			Value:  put.Value,
+			Traits: put.Traits,
+		}
+	}
+	return elems
 }
 
 // Tx is a signed transaction
@@ -7,15 +7,18 @@ import (
 	"container/heap"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/liraxapp/avalanchego/cache"
+	"github.com/liraxapp/avalanchego/cache/metercacher"
 	"github.com/liraxapp/avalanchego/chains"
 	"github.com/liraxapp/avalanchego/database"
 	"github.com/liraxapp/avalanchego/database/prefixdb"
 	"github.com/liraxapp/avalanchego/database/versiondb"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/snow"
+	"github.com/liraxapp/avalanchego/snow/choices"
 	"github.com/liraxapp/avalanchego/snow/consensus/snowman"
 	"github.com/liraxapp/avalanchego/snow/engine/common"
 	"github.com/liraxapp/avalanchego/snow/engine/snowman/block"
@@ -87,8 +90,10 @@ var (
 	errStartTimeTooEarly        = errors.New("start time is before the current chain time")
 	errStartAfterEndTime        = errors.New("start time is after the end time")
 
-	_ block.ChainVM        = &VM{}
-	_ validators.Connector = &VM{}
+	_ block.ChainVM              = &VM{}
+	_ block.HeightIndexedChainVM = &VM{}
+	_ validators.Connector       = &VM{}
+	_ secp256k1fx.MeteredVM      = &VM{}
 )
 
 // VM implements the snowman.ChainVM interface
@@ -158,13 +163,26 @@ type VM struct {
 	// to see if it was later committed/aborted before reporting that it's dropped.
 	// Key: Tx ID
 	// Value: String repr. of the verification error
-	droppedTxCache cache.LRU
+	droppedTxCache cache.Cacher
 
 	// Bootstrapped remembers if this chain has finished bootstrapping or not
 	bootstrapped bool
 
 	bootstrappedTime time.Time
 
+	// stateSyncable is true if the state of the chain is mirrored in the
+	// syncable state, which is the case if the chain was created, or its
+	// state was synced, after state sync was introduced
+	stateSyncable bool
+
+	// summaryBlk is the accepted block the state should be summarized as of,
+	// once it's committed
+	summaryBlk *core.Block
+	// summaryLock is held while the stored state summary is replaced
+	summaryLock sync.Mutex
+	// summaries tracks the state summaries being built in the background
+	summaries sync.WaitGroup
+
 	connections map[[20]byte]time.Time
 }
 
@@ -191,7 +209,11 @@ func (vm *VM) Initialize(
 		return err
 	}
 
-	vm.droppedTxCache = cache.LRU{Size: droppedTxCacheSize}
+	droppedTxCache, err := metercacher.New(ctx.Namespace, "dropped_tx_cache", ctx.Metrics, &cache.LRU{Size: droppedTxCacheSize})
+	if err != nil {
+		return err
+	}
+	vm.droppedTxCache = droppedTxCache
 	vm.connections = make(map[[20]byte]time.Time)
 
 	// Register this VM's types with the database so we can get/put structs to/from it
@@ -210,20 +232,24 @@ func (vm *VM) Initialize(
 			return err
 		}
 
+		// The state at genesis is written to [genesisState] so that it can be
+		// mirrored in the syncable state
+		genesisState := versiondb.New(vm.DB)
+
 		// Persist UTXOs that exist at genesis
 		for _, utxo := range genesis.UTXOs {
-			if err := vm.putUTXO(vm.DB, &utxo.UTXO); err != nil {
+			if err := vm.putUTXO(genesisState, &utxo.UTXO); err != nil {
 				return err
 			}
 		}
 
 		// Persist the platform chain's timestamp at genesis
 		genesisTime := time.Unix(int64(genesis.Timestamp), 0)
-		if err := vm.State.PutTime(vm.DB, timestampKey, genesisTime); err != nil {
+		if err := vm.State.PutTime(genesisState, timestampKey, genesisTime); err != nil {
 			return err
 		}
 
-		if err := vm.putCurrentSupply(vm.DB, genesis.InitialSupply); err != nil {
+		if err := vm.putCurrentSupply(genesisState, genesis.InitialSupply); err != nil {
 			return err
 		}
 
@@ -240,7 +266,7 @@ func (vm *VM) Initialize(
 			default:
 				return errWrongTxType
 			}
-			reward, err := vm.calculateReward(vm.DB, stakeDuration, stakeAmount)
+			reward, err := vm.calculateReward(genesisState, stakeDuration, stakeAmount)
 			if err != nil {
 				return err
 			}
@@ -248,13 +274,13 @@ func (vm *VM) Initialize(
 				Reward: reward,
 				Tx:     *vdrTx,
 			}
-			if err := vm.addStaker(vm.DB, constants.PrimaryNetworkID, &tx); err != nil {
+			if err := vm.addStaker(genesisState, constants.PrimaryNetworkID, &tx); err != nil {
 				return err
 			}
 		}
 
 		// Persist the subnets that exist at genesis (none do)
-		if err := vm.putSubnets(vm.DB, []*Tx{}); err != nil {
+		if err := vm.putSubnets(genesisState, []*Tx{}); err != nil {
 			return fmt.Errorf("error putting genesis subnets: %v", err)
 		}
 
@@ -275,7 +301,18 @@ func (vm *VM) Initialize(
 		}
 
 		// Persist the chains that exist at genesis
-		if err := vm.putChains(vm.DB, filteredChains); err != nil {
+		if err := vm.putChains(genesisState, filteredChains); err != nil {
+			return err
+		}
+
+		vm.stateSyncable = true
+		if err := vm.recordStateChanges(genesisState); err != nil {
+			return err
+		}
+		if err := genesisState.Commit(); err != nil {
+			return err
+		}
+		if err := vm.State.PutStatus(vm.DB, stateSyncableID, choices.Accepted); err != nil {
 			return err
 		}
 
@@ -304,6 +341,7 @@ func (vm *VM) Initialize(
 		}
 	}
 
+	vm.stateSyncable = vm.State.GetStatus(vm.DB, stateSyncableID) == choices.Accepted
 	vm.currentBlocks = make(map[ids.ID]Block)
 
 	if err := vm.initSubnets(); err != nil {
@@ -334,7 +372,13 @@ func (vm *VM) Initialize(
 		return errInvalidLastAcceptedBlock
 	}
 
-	return nil
+	// Index the blocks accepted before accepted blocks were indexed by height
+	if err := vm.IndexHeights(); err != nil {
+		return err
+	}
+
+	// Mirror the state of chains created before state sync was introduced
+	return vm.backfillSyncableState()
 }
 
 // Create all chains that exist that this node validates
@@ -475,6 +519,8 @@ func (vm *VM) Shutdown() error {
 		return nil
 	}
 
+	vm.summaries.Wait()
+
 	vm.mempool.Shutdown()
 
 	stopPrefix := []byte(fmt.Sprintf("%s%s", constants.PrimaryNetworkID, stopDBPrefix))
@@ -1010,6 +1056,9 @@ func (vm *VM) Clock() *timer.Clock { return &vm.clock }
 // Logger ...
 func (vm *VM) Logger() logging.Logger { return vm.Ctx.Log }
 
+// Context ...
+func (vm *VM) Context() *snow.Context { return vm.Ctx }
+
 // GetAtomicUTXOs returns imported/exports UTXOs such that at least one of the addresses in [addrs] is referenced.
 // Returns at most [limit] UTXOs.
 // If [limit] <= 0 or [limit] > maxUTXOsToFetch, it is set to [maxUTXOsToFetch].
@@ -0,0 +1,302 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/database/rpcdb"
+	"github.com/liraxapp/avalanchego/database/rpcdb/rpcdbproto"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow"
+	"github.com/liraxapp/avalanchego/snow/engine/common"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/galiaslookup"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/galiaslookup/galiaslookupproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gappsender"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gappsender/gappsenderproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/ghttp"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/ghttp/ghttpproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gkeystore"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gkeystore/gkeystoreproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/grpcutils"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsharedmemory"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsharedmemory/gsharedmemoryproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsubnetlookup"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsubnetlookup/gsubnetlookupproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/messenger"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/messenger/messengerproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/vmproto"
+)
+
+var errUnsupportedFXs = errors.New("unsupported feature extensions")
+
+// commonVMClient is the part of the generated client that every kind of VM
+// serves
+type commonVMClient interface {
+	Initialize(ctx context.Context, in *vmproto.InitializeRequest, opts ...grpc.CallOption) (*vmproto.InitializeResponse, error)
+	Bootstrapping(ctx context.Context, in *vmproto.BootstrappingRequest, opts ...grpc.CallOption) (*vmproto.BootstrappingResponse, error)
+	Bootstrapped(ctx context.Context, in *vmproto.BootstrappedRequest, opts ...grpc.CallOption) (*vmproto.BootstrappedResponse, error)
+	Shutdown(ctx context.Context, in *vmproto.ShutdownRequest, opts ...grpc.CallOption) (*vmproto.ShutdownResponse, error)
+	CreateHandlers(ctx context.Context, in *vmproto.CreateHandlersRequest, opts ...grpc.CallOption) (*vmproto.CreateHandlersResponse, error)
+	Health(ctx context.Context, in *vmproto.HealthRequest, opts ...grpc.CallOption) (*vmproto.HealthResponse, error)
+	AppRequest(ctx context.Context, in *vmproto.AppRequestMsg, opts ...grpc.CallOption) (*vmproto.EmptyMsg, error)
+	AppRequestFailed(ctx context.Context, in *vmproto.AppRequestFailedMsg, opts ...grpc.CallOption) (*vmproto.EmptyMsg, error)
+	AppResponse(ctx context.Context, in *vmproto.AppResponseMsg, opts ...grpc.CallOption) (*vmproto.EmptyMsg, error)
+	AppGossip(ctx context.Context, in *vmproto.AppGossipMsg, opts ...grpc.CallOption) (*vmproto.EmptyMsg, error)
+}
+
+// commonClient implements the methods of common.VM over RPC. It's embedded by
+// the clients of each kind of VM.
+type commonClient struct {
+	client commonVMClient
+	broker *plugin.GRPCBroker
+	proc   *plugin.Client
+
+	db           *rpcdb.DatabaseServer
+	messenger    *messenger.Server
+	keystore     *gkeystore.Server
+	sharedMemory *gsharedmemory.Server
+	bcLookup     *galiaslookup.Server
+	snLookup     *gsubnetlookup.Server
+	appSender    *gappsender.Server
+
+	serverCloser grpcutils.ServerCloser
+	conns        []*grpc.ClientConn
+
+	ctx *snow.Context
+}
+
+// SetProcess ...
+func (vm *commonClient) SetProcess(proc *plugin.Client) {
+	vm.proc = proc
+}
+
+// initialize serves the services of the node to the VM and initializes the VM
+// with them
+func (vm *commonClient) initialize(
+	ctx *snow.Context,
+	db database.Database,
+	genesisBytes []byte,
+	toEngine chan<- common.Message,
+	fxs []*common.Fx,
+) (*vmproto.InitializeResponse, error) {
+	if len(fxs) != 0 {
+		return nil, errUnsupportedFXs
+	}
+
+	vm.ctx = ctx
+
+	vm.db = rpcdb.NewServer(db)
+	vm.messenger = messenger.NewServer(toEngine)
+	vm.keystore = gkeystore.NewServer(ctx.Keystore, vm.broker)
+	vm.sharedMemory = gsharedmemory.NewServer(ctx.SharedMemory, db)
+	vm.bcLookup = galiaslookup.NewServer(ctx.BCLookup)
+	vm.snLookup = gsubnetlookup.NewServer(ctx.SNLookup)
+	vm.appSender = gappsender.NewServer(ctx.AppSender)
+
+	// start the db server
+	dbBrokerID := vm.broker.NextId()
+	go vm.broker.AcceptAndServe(dbBrokerID, vm.startDBServer)
+
+	// start the messenger server
+	messengerBrokerID := vm.broker.NextId()
+	go vm.broker.AcceptAndServe(messengerBrokerID, vm.startMessengerServer)
+
+	// start the keystore server
+	keystoreBrokerID := vm.broker.NextId()
+	go vm.broker.AcceptAndServe(keystoreBrokerID, vm.startKeystoreServer)
+
+	// start the shared memory server
+	sharedMemoryBrokerID := vm.broker.NextId()
+	go vm.broker.AcceptAndServe(sharedMemoryBrokerID, vm.startSharedMemoryServer)
+
+	// start the blockchain alias server
+	bcLookupBrokerID := vm.broker.NextId()
+	go vm.broker.AcceptAndServe(bcLookupBrokerID, vm.startBCLookupServer)
+
+	// start the subnet alias server
+	snLookupBrokerID := vm.broker.NextId()
+	go vm.broker.AcceptAndServe(snLookupBrokerID, vm.startSNLookupServer)
+
+	// start the application level sender server
+	appSenderBrokerID := vm.broker.NextId()
+	go vm.broker.AcceptAndServe(appSenderBrokerID, vm.startAppSenderServer)
+
+	return vm.client.Initialize(context.Background(), &vmproto.InitializeRequest{
+		NetworkID:          ctx.NetworkID,
+		SubnetID:           ctx.SubnetID[:],
+		ChainID:            ctx.ChainID[:],
+		NodeID:             ctx.NodeID.Bytes(),
+		XChainID:           ctx.XChainID[:],
+		AvaxAssetID:        ctx.AVAXAssetID[:],
+		GenesisBytes:       genesisBytes,
+		DbServer:           dbBrokerID,
+		EngineServer:       messengerBrokerID,
+		KeystoreServer:     keystoreBrokerID,
+		SharedMemoryServer: sharedMemoryBrokerID,
+		BcLookupServer:     bcLookupBrokerID,
+		SnLookupServer:     snLookupBrokerID,
+		AppSenderServer:    appSenderBrokerID,
+	})
+}
+
+func (vm *commonClient) startDBServer(opts []grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	vm.serverCloser.Add(server)
+	rpcdbproto.RegisterDatabaseServer(server, vm.db)
+	return server
+}
+
+func (vm *commonClient) startMessengerServer(opts []grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	vm.serverCloser.Add(server)
+	messengerproto.RegisterMessengerServer(server, vm.messenger)
+	return server
+}
+
+func (vm *commonClient) startKeystoreServer(opts []grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	vm.serverCloser.Add(server)
+	gkeystoreproto.RegisterKeystoreServer(server, vm.keystore)
+	return server
+}
+
+func (vm *commonClient) startSharedMemoryServer(opts []grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	vm.serverCloser.Add(server)
+	gsharedmemoryproto.RegisterSharedMemoryServer(server, vm.sharedMemory)
+	return server
+}
+
+func (vm *commonClient) startBCLookupServer(opts []grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	vm.serverCloser.Add(server)
+	galiaslookupproto.RegisterAliasLookupServer(server, vm.bcLookup)
+	return server
+}
+
+func (vm *commonClient) startSNLookupServer(opts []grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	vm.serverCloser.Add(server)
+	gsubnetlookupproto.RegisterSubnetLookupServer(server, vm.snLookup)
+	return server
+}
+
+func (vm *commonClient) startAppSenderServer(opts []grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	vm.serverCloser.Add(server)
+	gappsenderproto.RegisterAppSenderServer(server, vm.appSender)
+	return server
+}
+
+// Bootstrapping ...
+func (vm *commonClient) Bootstrapping() error {
+	_, err := vm.client.Bootstrapping(context.Background(), &vmproto.BootstrappingRequest{})
+	return err
+}
+
+// Bootstrapped ...
+func (vm *commonClient) Bootstrapped() error {
+	_, err := vm.client.Bootstrapped(context.Background(), &vmproto.BootstrappedRequest{})
+	return err
+}
+
+// Shutdown ...
+func (vm *commonClient) Shutdown() error {
+	errs := wrappers.Errs{}
+	_, err := vm.client.Shutdown(context.Background(), &vmproto.ShutdownRequest{})
+	errs.Add(err)
+
+	vm.serverCloser.Stop()
+	for _, conn := range vm.conns {
+		errs.Add(conn.Close())
+	}
+
+	vm.proc.Kill()
+	return errs.Err
+}
+
+// CreateHandlers ...
+func (vm *commonClient) CreateHandlers() map[string]*common.HTTPHandler {
+	resp, err := vm.client.CreateHandlers(context.Background(), &vmproto.CreateHandlersRequest{})
+	vm.ctx.Log.AssertNoError(err)
+
+	handlers := make(map[string]*common.HTTPHandler, len(resp.Handlers))
+	for _, handler := range resp.Handlers {
+		conn, err := vm.broker.Dial(handler.Server)
+		vm.ctx.Log.AssertNoError(err)
+
+		vm.conns = append(vm.conns, conn)
+		handlers[handler.Prefix] = &common.HTTPHandler{
+			LockOptions: common.LockOption(handler.LockOptions),
+			Handler:     ghttp.NewClient(ghttpproto.NewHTTPClient(conn), vm.broker),
+		}
+	}
+	return handlers
+}
+
+// Health ...
+func (vm *commonClient) Health() (interface{}, error) {
+	return vm.client.Health(
+		context.Background(),
+		&vmproto.HealthRequest{},
+	)
+}
+
+// AppRequest ...
+func (vm *commonClient) AppRequest(nodeID ids.ShortID, requestID uint32, request []byte) error {
+	_, err := vm.client.AppRequest(
+		context.Background(),
+		&vmproto.AppRequestMsg{
+			NodeID:    nodeID.Bytes(),
+			RequestID: requestID,
+			Request:   request,
+		},
+	)
+	return err
+}
+
+// AppResponse ...
+func (vm *commonClient) AppResponse(nodeID ids.ShortID, requestID uint32, response []byte) error {
+	_, err := vm.client.AppResponse(
+		context.Background(),
+		&vmproto.AppResponseMsg{
+			NodeID:    nodeID.Bytes(),
+			RequestID: requestID,
+			Response:  response,
+		},
+	)
+	return err
+}
+
+// AppRequestFailed ...
+func (vm *commonClient) AppRequestFailed(nodeID ids.ShortID, requestID uint32) error {
+	_, err := vm.client.AppRequestFailed(
+		context.Background(),
+		&vmproto.AppRequestFailedMsg{
+			NodeID:    nodeID.Bytes(),
+			RequestID: requestID,
+		},
+	)
+	return err
+}
+
+// AppGossip ...
+func (vm *commonClient) AppGossip(nodeID ids.ShortID, msg []byte) error {
+	_, err := vm.client.AppGossip(
+		context.Background(),
+		&vmproto.AppGossipMsg{
+			NodeID: nodeID.Bytes(),
+			Msg:    msg,
+		},
+	)
+	return err
+}
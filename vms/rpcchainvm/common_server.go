@@ -0,0 +1,298 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/liraxapp/avalanchego/database/rpcdb"
+	"github.com/liraxapp/avalanchego/database/rpcdb/rpcdbproto"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow"
+	"github.com/liraxapp/avalanchego/snow/engine/common"
+	"github.com/liraxapp/avalanchego/utils/logging"
+	"github.com/liraxapp/avalanchego/utils/wrappers"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/galiaslookup"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/galiaslookup/galiaslookupproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gappsender"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gappsender/gappsenderproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/ghttp"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/ghttp/ghttpproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gkeystore"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gkeystore/gkeystoreproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/grpcutils"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsharedmemory"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsharedmemory/gsharedmemoryproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsubnetlookup"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsubnetlookup/gsubnetlookupproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/messenger"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/messenger/messengerproto"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/vmproto"
+)
+
+// commonServer serves the methods that every kind of VM implements over RPC
+type commonServer struct {
+	vm     common.VM
+	broker *plugin.GRPCBroker
+
+	serverCloser grpcutils.ServerCloser
+	conns        []*grpc.ClientConn
+
+	ctx      *snow.Context
+	toEngine chan common.Message
+}
+
+// initialize connects to the services of the node that are served over RPC
+// and initializes the VM with them
+func (vm *commonServer) initialize(req *vmproto.InitializeRequest) error {
+	subnetID, err := ids.ToID(req.SubnetID)
+	if err != nil {
+		return err
+	}
+	chainID, err := ids.ToID(req.ChainID)
+	if err != nil {
+		return err
+	}
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return err
+	}
+	xChainID, err := ids.ToID(req.XChainID)
+	if err != nil {
+		return err
+	}
+	avaxAssetID, err := ids.ToID(req.AvaxAssetID)
+	if err != nil {
+		return err
+	}
+
+	dbConn, err := vm.broker.Dial(req.DbServer)
+	if err != nil {
+		return err
+	}
+	msgConn, err := vm.broker.Dial(req.EngineServer)
+	if err != nil {
+		// Ignore DB closing error to return the original error
+		_ = dbConn.Close()
+		return err
+	}
+	keystoreConn, err := vm.broker.Dial(req.KeystoreServer)
+	if err != nil {
+		// Ignore closing error to return the original error
+		_ = dbConn.Close()
+		_ = msgConn.Close()
+		return err
+	}
+	sharedMemoryConn, err := vm.broker.Dial(req.SharedMemoryServer)
+	if err != nil {
+		// Ignore closing error to return the original error
+		_ = dbConn.Close()
+		_ = msgConn.Close()
+		_ = keystoreConn.Close()
+		return err
+	}
+	bcLookupConn, err := vm.broker.Dial(req.BcLookupServer)
+	if err != nil {
+		// Ignore closing error to return the original error
+		_ = dbConn.Close()
+		_ = msgConn.Close()
+		_ = keystoreConn.Close()
+		_ = sharedMemoryConn.Close()
+		return err
+	}
+	snLookupConn, err := vm.broker.Dial(req.SnLookupServer)
+	if err != nil {
+		// Ignore closing error to return the original error
+		_ = dbConn.Close()
+		_ = msgConn.Close()
+		_ = keystoreConn.Close()
+		_ = sharedMemoryConn.Close()
+		_ = bcLookupConn.Close()
+		return err
+	}
+	appSenderConn, err := vm.broker.Dial(req.AppSenderServer)
+	if err != nil {
+		// Ignore closing error to return the original error
+		_ = dbConn.Close()
+		_ = msgConn.Close()
+		_ = keystoreConn.Close()
+		_ = sharedMemoryConn.Close()
+		_ = bcLookupConn.Close()
+		_ = snLookupConn.Close()
+		return err
+	}
+
+	dbClient := rpcdb.NewClient(rpcdbproto.NewDatabaseClient(dbConn))
+	msgClient := messenger.NewClient(messengerproto.NewMessengerClient(msgConn))
+	keystoreClient := gkeystore.NewClient(gkeystoreproto.NewKeystoreClient(keystoreConn), vm.broker)
+	sharedMemoryClient := gsharedmemory.NewClient(gsharedmemoryproto.NewSharedMemoryClient(sharedMemoryConn))
+	bcLookupClient := galiaslookup.NewClient(galiaslookupproto.NewAliasLookupClient(bcLookupConn))
+	snLookupClient := gsubnetlookup.NewClient(gsubnetlookupproto.NewSubnetLookupClient(snLookupConn))
+	appSenderClient := gappsender.NewClient(gappsenderproto.NewAppSenderClient(appSenderConn))
+
+	toEngine := make(chan common.Message, 1)
+	go func() {
+		for msg := range toEngine {
+			// Nothing to do with the error within the goroutine
+			_ = msgClient.Notify(msg)
+		}
+	}()
+
+	vm.ctx = &snow.Context{
+		NetworkID:           req.NetworkID,
+		SubnetID:            subnetID,
+		ChainID:             chainID,
+		NodeID:              nodeID,
+		XChainID:            xChainID,
+		AVAXAssetID:         avaxAssetID,
+		Log:                 logging.NoLog{},
+		DecisionDispatcher:  nil,
+		ConsensusDispatcher: nil,
+		Keystore:            keystoreClient,
+		SharedMemory:        sharedMemoryClient,
+		BCLookup:            bcLookupClient,
+		SNLookup:            snLookupClient,
+		AppSender:           appSenderClient,
+	}
+
+	if err := vm.vm.Initialize(vm.ctx, dbClient, req.GenesisBytes, toEngine, nil); err != nil {
+		// Ignore errors closing resources to return the original error
+		_ = dbConn.Close()
+		_ = msgConn.Close()
+		_ = keystoreConn.Close()
+		_ = sharedMemoryConn.Close()
+		_ = bcLookupConn.Close()
+		_ = snLookupConn.Close()
+		_ = appSenderConn.Close()
+		close(toEngine)
+		return err
+	}
+
+	vm.conns = append(vm.conns, dbConn)
+	vm.conns = append(vm.conns, msgConn)
+	vm.toEngine = toEngine
+	return nil
+}
+
+// Bootstrapping ...
+func (vm *commonServer) Bootstrapping(context.Context, *vmproto.BootstrappingRequest) (*vmproto.BootstrappingResponse, error) {
+	return &vmproto.BootstrappingResponse{}, vm.vm.Bootstrapping()
+}
+
+// Bootstrapped ...
+func (vm *commonServer) Bootstrapped(context.Context, *vmproto.BootstrappedRequest) (*vmproto.BootstrappedResponse, error) {
+	vm.ctx.Bootstrapped()
+	return &vmproto.BootstrappedResponse{}, vm.vm.Bootstrapped()
+}
+
+// Shutdown ...
+func (vm *commonServer) Shutdown(context.Context, *vmproto.ShutdownRequest) (*vmproto.ShutdownResponse, error) {
+	if vm.toEngine == nil {
+		return &vmproto.ShutdownResponse{}, nil
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(vm.vm.Shutdown())
+	close(vm.toEngine)
+
+	vm.serverCloser.Stop()
+	for _, conn := range vm.conns {
+		errs.Add(conn.Close())
+	}
+	return &vmproto.ShutdownResponse{}, errs.Err
+}
+
+// CreateHandlers ...
+func (vm *commonServer) CreateHandlers(_ context.Context, req *vmproto.CreateHandlersRequest) (*vmproto.CreateHandlersResponse, error) {
+	handlers := vm.vm.CreateHandlers()
+	resp := &vmproto.CreateHandlersResponse{}
+	for prefix, h := range handlers {
+		handler := h
+
+		// start the messenger server
+		serverID := vm.broker.NextId()
+		go vm.broker.AcceptAndServe(serverID, func(opts []grpc.ServerOption) *grpc.Server {
+			server := grpc.NewServer(opts...)
+			vm.serverCloser.Add(server)
+			ghttpproto.RegisterHTTPServer(server, ghttp.NewServer(handler.Handler, vm.broker))
+			return server
+		})
+
+		resp.Handlers = append(resp.Handlers, &vmproto.Handler{
+			Prefix:      prefix,
+			LockOptions: uint32(handler.LockOptions),
+			Server:      serverID,
+		})
+	}
+	return resp, nil
+}
+
+// Health ...
+func (vm *commonServer) Health(_ context.Context, req *vmproto.HealthRequest) (*vmproto.HealthResponse, error) {
+	details, err := vm.vm.Health()
+	if err != nil {
+		return &vmproto.HealthResponse{}, err
+	}
+
+	// Try to stringify the details
+	detailsStr := "couldn't parse health check details to string"
+	switch details := details.(type) {
+	case nil:
+		detailsStr = ""
+	case string:
+		detailsStr = details
+	case map[string]string:
+		asJSON, err := json.Marshal(details)
+		if err != nil {
+			detailsStr = string(asJSON)
+		}
+	case []byte:
+		detailsStr = string(details)
+	}
+
+	return &vmproto.HealthResponse{
+		Details: detailsStr,
+	}, nil
+}
+
+// AppRequest ...
+func (vm *commonServer) AppRequest(_ context.Context, req *vmproto.AppRequestMsg) (*vmproto.EmptyMsg, error) {
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.EmptyMsg{}, vm.vm.AppRequest(nodeID, req.RequestID, req.Request)
+}
+
+// AppResponse ...
+func (vm *commonServer) AppResponse(_ context.Context, req *vmproto.AppResponseMsg) (*vmproto.EmptyMsg, error) {
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.EmptyMsg{}, vm.vm.AppResponse(nodeID, req.RequestID, req.Response)
+}
+
+// AppRequestFailed ...
+func (vm *commonServer) AppRequestFailed(_ context.Context, req *vmproto.AppRequestFailedMsg) (*vmproto.EmptyMsg, error) {
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.EmptyMsg{}, vm.vm.AppRequestFailed(nodeID, req.RequestID)
+}
+
+// AppGossip ...
+func (vm *commonServer) AppGossip(_ context.Context, req *vmproto.AppGossipMsg) (*vmproto.EmptyMsg, error) {
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.EmptyMsg{}, vm.vm.AppGossip(nodeID, req.Msg)
+}
@@ -0,0 +1,241 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/liraxapp/avalanchego/database"
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow"
+	"github.com/liraxapp/avalanchego/snow/choices"
+	"github.com/liraxapp/avalanchego/snow/consensus/snowstorm"
+	"github.com/liraxapp/avalanchego/snow/engine/avalanche/vertex"
+	"github.com/liraxapp/avalanchego/snow/engine/common"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/vmproto"
+)
+
+var _ vertex.DAGVM = &DAGVMClient{}
+
+// DAGVMClient is an implementation of DAGVM that talks over RPC.
+type DAGVMClient struct {
+	commonClient
+
+	client vmproto.DAGVMClient
+	txs    map[ids.ID]*TxClient
+}
+
+// NewDAGClient returns a DAG vm instance connected to a remote DAG vm instance
+func NewDAGClient(client vmproto.DAGVMClient, broker *plugin.GRPCBroker) *DAGVMClient {
+	return &DAGVMClient{
+		commonClient: commonClient{
+			client: client,
+			broker: broker,
+		},
+		client: client,
+		txs:    make(map[ids.ID]*TxClient),
+	}
+}
+
+// Initialize ...
+func (vm *DAGVMClient) Initialize(
+	ctx *snow.Context,
+	db database.Database,
+	genesisBytes []byte,
+	toEngine chan<- common.Message,
+	fxs []*common.Fx,
+) error {
+	_, err := vm.initialize(ctx, db, genesisBytes, toEngine, fxs)
+	return err
+}
+
+// PendingTxs ...
+func (vm *DAGVMClient) PendingTxs() []snowstorm.Tx {
+	resp, err := vm.client.PendingTxs(context.Background(), &vmproto.PendingTxsRequest{})
+	if err != nil {
+		vm.ctx.Log.Error("failed to get the pending transactions: %s", err)
+		return nil
+	}
+
+	txs := make([]snowstorm.Tx, len(resp.Txs))
+	for i, tx := range resp.Txs {
+		id, err := ids.ToID(tx.Id)
+		vm.ctx.Log.AssertNoError(err)
+
+		if cachedTx, cached := vm.txs[id]; cached {
+			txs[i] = cachedTx
+			continue
+		}
+
+		status := choices.Status(tx.Status)
+		vm.ctx.Log.AssertDeferredNoError(status.Valid)
+
+		txs[i] = &TxClient{
+			vm:     vm,
+			id:     id,
+			status: status,
+			bytes:  tx.Bytes,
+		}
+	}
+	return txs
+}
+
+// ParseTx ...
+func (vm *DAGVMClient) ParseTx(bytes []byte) (snowstorm.Tx, error) {
+	resp, err := vm.client.ParseTx(context.Background(), &vmproto.ParseTxRequest{
+		Bytes: bytes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := ids.ToID(resp.Id)
+	vm.ctx.Log.AssertNoError(err)
+
+	if tx, cached := vm.txs[id]; cached {
+		return tx, nil
+	}
+
+	status := choices.Status(resp.Status)
+	vm.ctx.Log.AssertDeferredNoError(status.Valid)
+
+	return &TxClient{
+		vm:     vm,
+		id:     id,
+		status: status,
+		bytes:  bytes,
+	}, nil
+}
+
+// GetTx ...
+func (vm *DAGVMClient) GetTx(id ids.ID) (snowstorm.Tx, error) {
+	if tx, cached := vm.txs[id]; cached {
+		return tx, nil
+	}
+
+	resp, err := vm.client.GetTx(context.Background(), &vmproto.GetTxRequest{
+		Id: id[:],
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	status := choices.Status(resp.Status)
+	vm.ctx.Log.AssertDeferredNoError(status.Valid)
+
+	return &TxClient{
+		vm:     vm,
+		id:     id,
+		status: status,
+		bytes:  resp.Bytes,
+	}, nil
+}
+
+// TxClient is an implementation of Tx that talks over RPC.
+type TxClient struct {
+	vm *DAGVMClient
+
+	id     ids.ID
+	status choices.Status
+	bytes  []byte
+
+	// dependencies and inputs are fetched on first use, as they don't change
+	deps     []ids.ID
+	inputIDs []ids.ID
+}
+
+// ID ...
+func (tx *TxClient) ID() ids.ID { return tx.id }
+
+// Accept ...
+func (tx *TxClient) Accept() error {
+	delete(tx.vm.txs, tx.id)
+	tx.status = choices.Accepted
+	_, err := tx.vm.client.TxAccept(context.Background(), &vmproto.TxAcceptRequest{
+		Id: tx.id[:],
+	})
+	return err
+}
+
+// Reject ...
+func (tx *TxClient) Reject() error {
+	delete(tx.vm.txs, tx.id)
+	tx.status = choices.Rejected
+	_, err := tx.vm.client.TxReject(context.Background(), &vmproto.TxRejectRequest{
+		Id: tx.id[:],
+	})
+	return err
+}
+
+// Status ...
+func (tx *TxClient) Status() choices.Status { return tx.status }
+
+// Dependencies ...
+func (tx *TxClient) Dependencies() []snowstorm.Tx {
+	if tx.deps == nil {
+		resp, err := tx.vm.client.TxDependencies(context.Background(), &vmproto.TxDependenciesRequest{
+			Id: tx.id[:],
+		})
+		if err != nil {
+			tx.vm.ctx.Log.Error("failed to get the dependencies of tx %s: %s", tx.id, err)
+			return nil
+		}
+		tx.deps = make([]ids.ID, len(resp.Ids))
+		for i, depID := range resp.Ids {
+			tx.deps[i], err = ids.ToID(depID)
+			tx.vm.ctx.Log.AssertNoError(err)
+		}
+	}
+
+	deps := make([]snowstorm.Tx, len(tx.deps))
+	for i, depID := range tx.deps {
+		if dep, err := tx.vm.GetTx(depID); err == nil {
+			deps[i] = dep
+		} else {
+			deps[i] = &TxClient{
+				vm:     tx.vm,
+				id:     depID,
+				status: choices.Unknown,
+			}
+		}
+	}
+	return deps
+}
+
+// InputIDs ...
+func (tx *TxClient) InputIDs() []ids.ID {
+	if tx.inputIDs == nil {
+		resp, err := tx.vm.client.TxInputIDs(context.Background(), &vmproto.TxInputIDsRequest{
+			Id: tx.id[:],
+		})
+		if err != nil {
+			tx.vm.ctx.Log.Error("failed to get the inputs of tx %s: %s", tx.id, err)
+			return nil
+		}
+		tx.inputIDs = make([]ids.ID, len(resp.InputIDs))
+		for i, inputID := range resp.InputIDs {
+			tx.inputIDs[i], err = ids.ToID(inputID)
+			tx.vm.ctx.Log.AssertNoError(err)
+		}
+	}
+	return tx.inputIDs
+}
+
+// Verify ...
+func (tx *TxClient) Verify() error {
+	_, err := tx.vm.client.TxVerify(context.Background(), &vmproto.TxVerifyRequest{
+		Id: tx.id[:],
+	})
+	if err != nil {
+		return err
+	}
+
+	tx.vm.txs[tx.id] = tx
+	return nil
+}
+
+// Bytes ...
+func (tx *TxClient) Bytes() []byte { return tx.bytes }
@@ -0,0 +1,172 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow/engine/avalanche/vertex"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/vmproto"
+)
+
+// DAGVMServer is a DAG VM that is managed over RPC.
+type DAGVMServer struct {
+	commonServer
+
+	vm vertex.DAGVM
+}
+
+// NewDAGServer returns a DAG vm instance connected to a remote DAG vm instance
+func NewDAGServer(vm vertex.DAGVM, broker *plugin.GRPCBroker) *DAGVMServer {
+	return &DAGVMServer{
+		commonServer: commonServer{
+			vm:     vm,
+			broker: broker,
+		},
+		vm: vm,
+	}
+}
+
+// Initialize ...
+func (vm *DAGVMServer) Initialize(_ context.Context, req *vmproto.InitializeRequest) (*vmproto.InitializeResponse, error) {
+	if err := vm.initialize(req); err != nil {
+		return nil, err
+	}
+	return &vmproto.InitializeResponse{}, nil
+}
+
+// PendingTxs ...
+func (vm *DAGVMServer) PendingTxs(_ context.Context, _ *vmproto.PendingTxsRequest) (*vmproto.PendingTxsResponse, error) {
+	txs := vm.vm.PendingTxs()
+	resp := &vmproto.PendingTxsResponse{
+		Txs: make([]*vmproto.Tx, len(txs)),
+	}
+	for i, tx := range txs {
+		txID := tx.ID()
+		resp.Txs[i] = &vmproto.Tx{
+			Id:     txID[:],
+			Bytes:  tx.Bytes(),
+			Status: uint32(tx.Status()),
+		}
+	}
+	return resp, nil
+}
+
+// ParseTx ...
+func (vm *DAGVMServer) ParseTx(_ context.Context, req *vmproto.ParseTxRequest) (*vmproto.ParseTxResponse, error) {
+	tx, err := vm.vm.ParseTx(req.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	txID := tx.ID()
+	return &vmproto.ParseTxResponse{
+		Id:     txID[:],
+		Status: uint32(tx.Status()),
+	}, nil
+}
+
+// GetTx ...
+func (vm *DAGVMServer) GetTx(_ context.Context, req *vmproto.GetTxRequest) (*vmproto.GetTxResponse, error) {
+	id, err := ids.ToID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := vm.vm.GetTx(id)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.GetTxResponse{
+		Bytes:  tx.Bytes(),
+		Status: uint32(tx.Status()),
+	}, nil
+}
+
+// TxVerify ...
+func (vm *DAGVMServer) TxVerify(_ context.Context, req *vmproto.TxVerifyRequest) (*vmproto.TxVerifyResponse, error) {
+	id, err := ids.ToID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := vm.vm.GetTx(id)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.TxVerifyResponse{}, tx.Verify()
+}
+
+// TxAccept ...
+func (vm *DAGVMServer) TxAccept(_ context.Context, req *vmproto.TxAcceptRequest) (*vmproto.TxAcceptResponse, error) {
+	id, err := ids.ToID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := vm.vm.GetTx(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Accept(); err != nil {
+		return nil, err
+	}
+	return &vmproto.TxAcceptResponse{}, nil
+}
+
+// TxReject ...
+func (vm *DAGVMServer) TxReject(_ context.Context, req *vmproto.TxRejectRequest) (*vmproto.TxRejectResponse, error) {
+	id, err := ids.ToID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := vm.vm.GetTx(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Reject(); err != nil {
+		return nil, err
+	}
+	return &vmproto.TxRejectResponse{}, nil
+}
+
+// TxDependencies ...
+func (vm *DAGVMServer) TxDependencies(_ context.Context, req *vmproto.TxDependenciesRequest) (*vmproto.TxDependenciesResponse, error) {
+	id, err := ids.ToID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := vm.vm.GetTx(id)
+	if err != nil {
+		return nil, err
+	}
+	deps := tx.Dependencies()
+	resp := &vmproto.TxDependenciesResponse{
+		Ids: make([][]byte, len(deps)),
+	}
+	for i, dep := range deps {
+		depID := dep.ID()
+		resp.Ids[i] = depID[:]
+	}
+	return resp, nil
+}
+
+// TxInputIDs ...
+func (vm *DAGVMServer) TxInputIDs(_ context.Context, req *vmproto.TxInputIDsRequest) (*vmproto.TxInputIDsResponse, error) {
+	id, err := ids.ToID(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := vm.vm.GetTx(id)
+	if err != nil {
+		return nil, err
+	}
+	inputIDs := tx.InputIDs()
+	resp := &vmproto.TxInputIDsResponse{
+		InputIDs: make([][]byte, len(inputIDs)),
+	}
+	for i := range inputIDs {
+		resp.InputIDs[i] = inputIDs[i][:]
+	}
+	return resp, nil
+}
@@ -0,0 +1,233 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow"
+	"github.com/liraxapp/avalanchego/snow/choices"
+	"github.com/liraxapp/avalanchego/snow/consensus/snowstorm"
+	"github.com/liraxapp/avalanchego/snow/engine/avalanche/vertex"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/vmproto"
+)
+
+const (
+	bufSize = 1 << 20
+)
+
+var (
+	errUnknownTx = errors.New("unknown tx")
+	errInvalidTx = errors.New("invalid tx")
+)
+
+// newDAGVMClient returns a client connected to a server that serves [vm]
+func newDAGVMClient(t *testing.T, vm vertex.DAGVM) (*DAGVMClient, *grpc.ClientConn) {
+	listener := bufconn.Listen(bufSize)
+	server := grpc.NewServer()
+	vmproto.RegisterDAGVMServer(server, NewDAGServer(vm, nil))
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatalf("Server exited with error: %v", err)
+		}
+	}()
+
+	dialer := grpc.WithContextDialer(
+		func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		})
+
+	conn, err := grpc.DialContext(context.Background(), "", dialer, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial: %s", err)
+	}
+
+	client := NewDAGClient(vmproto.NewDAGVMClient(conn), nil)
+	client.ctx = snow.DefaultContextTest()
+	return client, conn
+}
+
+// newTestTxs returns a VM that knows of the returned txs. The second tx
+// depends on the first.
+func newTestTxs(t *testing.T) (*vertex.TestVM, []*snowstorm.TestTx) {
+	tx0 := &snowstorm.TestTx{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(0),
+			StatusV: choices.Processing,
+		},
+		InputIDsV: []ids.ID{ids.Empty.Prefix(100)},
+		BytesV:    []byte{0},
+	}
+	tx1 := &snowstorm.TestTx{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		DependenciesV: []snowstorm.Tx{tx0},
+		InputIDsV:     []ids.ID{ids.Empty.Prefix(101), ids.Empty.Prefix(102)},
+		BytesV:        []byte{1},
+	}
+	txs := []*snowstorm.TestTx{tx0, tx1}
+
+	vm := &vertex.TestVM{}
+	vm.T = t
+	vm.Default(true)
+	vm.ParseTxF = func(b []byte) (snowstorm.Tx, error) {
+		for _, tx := range txs {
+			if bytes.Equal(b, tx.Bytes()) {
+				return tx, nil
+			}
+		}
+		return nil, errUnknownTx
+	}
+	vm.GetTxF = func(txID ids.ID) (snowstorm.Tx, error) {
+		for _, tx := range txs {
+			if tx.ID() == txID {
+				return tx, nil
+			}
+		}
+		return nil, errUnknownTx
+	}
+	return vm, txs
+}
+
+func TestDAGVMParseTx(t *testing.T) {
+	vm, txs := newTestTxs(t)
+	client, conn := newDAGVMClient(t, vm)
+	defer conn.Close()
+
+	tx, err := client.ParseTx([]byte{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case tx.ID() != txs[1].ID():
+		t.Fatalf("parsed the wrong tx")
+	case tx.Status() != choices.Processing:
+		t.Fatalf("tx should be processing")
+	case !bytes.Equal(tx.Bytes(), []byte{1}):
+		t.Fatalf("wrong tx bytes")
+	}
+
+	if _, err := client.ParseTx([]byte{2}); err == nil {
+		t.Fatalf("shouldn't have parsed an unknown tx")
+	}
+
+	deps := tx.Dependencies()
+	if len(deps) != 1 {
+		t.Fatalf("expected 1 dependency but got %d", len(deps))
+	}
+	switch dep := deps[0]; {
+	case dep.ID() != txs[0].ID():
+		t.Fatalf("wrong dependency")
+	case dep.Status() != choices.Processing:
+		t.Fatalf("dependency should be processing")
+	case !bytes.Equal(dep.Bytes(), []byte{0}):
+		t.Fatalf("wrong dependency bytes")
+	}
+
+	inputIDs := tx.InputIDs()
+	if len(inputIDs) != len(txs[1].InputIDsV) {
+		t.Fatalf("expected %d inputs but got %d", len(txs[1].InputIDsV), len(inputIDs))
+	}
+	for i, inputID := range inputIDs {
+		if inputID != txs[1].InputIDsV[i] {
+			t.Fatalf("wrong input %d", i)
+		}
+	}
+}
+
+func TestDAGVMVerifyAccept(t *testing.T) {
+	vm, txs := newTestTxs(t)
+	client, conn := newDAGVMClient(t, vm)
+	defer conn.Close()
+
+	txs[0].VerifyV = errInvalidTx
+	tx0, err := client.GetTx(txs[0].ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx0.Verify(); err == nil {
+		t.Fatalf("should have failed verification")
+	}
+
+	txs[0].VerifyV = nil
+	if err := tx0.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	// Verified txs are cached until they're decided
+	if tx, err := client.GetTx(txs[0].ID()); err != nil {
+		t.Fatal(err)
+	} else if tx != tx0 {
+		t.Fatalf("verified tx should have been cached")
+	}
+
+	if err := tx0.Accept(); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case tx0.Status() != choices.Accepted:
+		t.Fatalf("tx should be accepted")
+	case txs[0].Status() != choices.Accepted:
+		t.Fatalf("tx should have been accepted by the VM")
+	}
+
+	tx1, err := client.ParseTx(txs[1].Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tx1.Reject(); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case tx1.Status() != choices.Rejected:
+		t.Fatalf("tx should be rejected")
+	case txs[1].Status() != choices.Rejected:
+		t.Fatalf("tx should have been rejected by the VM")
+	}
+
+	if _, err := client.GetTx(ids.Empty.Prefix(2)); err == nil {
+		t.Fatalf("shouldn't have gotten an unknown tx")
+	}
+}
+
+func TestDAGVMPendingTxs(t *testing.T) {
+	vm, txs := newTestTxs(t)
+	client, conn := newDAGVMClient(t, vm)
+	defer conn.Close()
+
+	vm.PendingTxsF = func() []snowstorm.Tx {
+		return []snowstorm.Tx{txs[0], txs[1]}
+	}
+
+	pendingTxs := client.PendingTxs()
+	if len(pendingTxs) != len(txs) {
+		t.Fatalf("expected %d pending txs but got %d", len(txs), len(pendingTxs))
+	}
+	for i, tx := range pendingTxs {
+		switch {
+		case tx.ID() != txs[i].ID():
+			t.Fatalf("wrong pending tx %d", i)
+		case tx.Status() != txs[i].Status():
+			t.Fatalf("wrong status of pending tx %d", i)
+		case !bytes.Equal(tx.Bytes(), txs[i].Bytes()):
+			t.Fatalf("wrong bytes of pending tx %d", i)
+		}
+	}
+
+	vm.PendingTxsF = func() []snowstorm.Tx { return nil }
+	if pendingTxs := client.PendingTxs(); len(pendingTxs) != 0 {
+		t.Fatalf("shouldn't have any pending txs")
+	}
+}
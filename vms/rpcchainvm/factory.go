@@ -23,6 +23,8 @@ var (
 type Factory struct {
 	Path   string
 	Config string
+	// DAG is true if the plugin is a DAG VM rather than a chain VM
+	DAG bool
 }
 
 // New ...
@@ -61,6 +63,23 @@ func (f *Factory) New(ctx *snow.Context) (interface{}, error) {
 		return nil, err
 	}
 
+	if f.DAG {
+		raw, err := rpcClient.Dispense("dagvm")
+		if err != nil {
+			client.Kill()
+			return nil, err
+		}
+
+		vm, ok := raw.(*DAGVMClient)
+		if !ok {
+			client.Kill()
+			return nil, errWrongVM
+		}
+
+		vm.SetProcess(client)
+		return vm, nil
+	}
+
 	raw, err := rpcClient.Dispense("vm")
 	if err != nil {
 		client.Kill()
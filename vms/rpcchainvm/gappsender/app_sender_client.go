@@ -0,0 +1,68 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gappsender
+
+import (
+	"context"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gappsender/gappsenderproto"
+)
+
+var (
+	_ snow.AppSender = &Client{}
+)
+
+// Client is an implementation of an application level sender that talks over
+// RPC.
+type Client struct {
+	client gappsenderproto.AppSenderClient
+}
+
+// NewClient returns a client that is connected to a remote AppSender.
+func NewClient(client gappsenderproto.AppSenderClient) *Client {
+	return &Client{client: client}
+}
+
+// SendAppRequest ...
+func (c *Client) SendAppRequest(nodeIDs ids.ShortSet, requestID uint32, request []byte) error {
+	nodeIDsBytes := make([][]byte, 0, nodeIDs.Len())
+	for _, nodeID := range nodeIDs.List() {
+		nodeIDsBytes = append(nodeIDsBytes, nodeID.Bytes())
+	}
+	_, err := c.client.SendAppRequest(
+		context.Background(),
+		&gappsenderproto.SendAppRequestMsg{
+			NodeIDs:   nodeIDsBytes,
+			RequestID: requestID,
+			Request:   request,
+		},
+	)
+	return err
+}
+
+// SendAppResponse ...
+func (c *Client) SendAppResponse(nodeID ids.ShortID, requestID uint32, response []byte) error {
+	_, err := c.client.SendAppResponse(
+		context.Background(),
+		&gappsenderproto.SendAppResponseMsg{
+			NodeID:    nodeID.Bytes(),
+			RequestID: requestID,
+			Response:  response,
+		},
+	)
+	return err
+}
+
+// SendAppGossip ...
+func (c *Client) SendAppGossip(msg []byte) error {
+	_, err := c.client.SendAppGossip(
+		context.Background(),
+		&gappsenderproto.SendAppGossipMsg{
+			Msg: msg,
+		},
+	)
+	return err
+}
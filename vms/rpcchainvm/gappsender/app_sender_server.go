@@ -0,0 +1,52 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gappsender
+
+import (
+	"context"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gappsender/gappsenderproto"
+)
+
+// Server is an application level sender that is managed over RPC.
+type Server struct {
+	appSender snow.AppSender
+}
+
+// NewServer returns a server that forwards messages to [appSender].
+func NewServer(appSender snow.AppSender) *Server {
+	return &Server{appSender: appSender}
+}
+
+// SendAppRequest ...
+func (s *Server) SendAppRequest(_ context.Context, req *gappsenderproto.SendAppRequestMsg) (*gappsenderproto.EmptyMsg, error) {
+	nodeIDs := ids.ShortSet{}
+	for _, nodeIDBytes := range req.NodeIDs {
+		nodeID, err := ids.ToShortID(nodeIDBytes)
+		if err != nil {
+			return nil, err
+		}
+		nodeIDs.Add(nodeID)
+	}
+	err := s.appSender.SendAppRequest(nodeIDs, req.RequestID, req.Request)
+	return &gappsenderproto.EmptyMsg{}, err
+}
+
+// SendAppResponse ...
+func (s *Server) SendAppResponse(_ context.Context, req *gappsenderproto.SendAppResponseMsg) (*gappsenderproto.EmptyMsg, error) {
+	nodeID, err := ids.ToShortID(req.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	err = s.appSender.SendAppResponse(nodeID, req.RequestID, req.Response)
+	return &gappsenderproto.EmptyMsg{}, err
+}
+
+// SendAppGossip ...
+func (s *Server) SendAppGossip(_ context.Context, req *gappsenderproto.SendAppGossipMsg) (*gappsenderproto.EmptyMsg, error) {
+	err := s.appSender.SendAppGossip(req.Msg)
+	return &gappsenderproto.EmptyMsg{}, err
+}
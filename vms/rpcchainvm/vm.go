@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/go-plugin"
 
+	"github.com/liraxapp/avalanchego/snow/engine/avalanche/vertex"
 	"github.com/liraxapp/avalanchego/snow/engine/snowman/block"
 	"github.com/liraxapp/avalanchego/vms/rpcchainvm/vmproto"
 )
@@ -23,7 +24,8 @@ var Handshake = plugin.HandshakeConfig{
 
 // PluginMap is the map of plugins we can dispense.
 var PluginMap = map[string]plugin.Plugin{
-	"vm": &Plugin{},
+	"vm":    &Plugin{},
+	"dagvm": &DAGPlugin{},
 }
 
 // Plugin is the implementation of plugin.Plugin so we can serve/consume this.
@@ -48,3 +50,26 @@ func (p *Plugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
 func (p *Plugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
 	return NewClient(vmproto.NewVMClient(c), broker), nil
 }
+
+// DAGPlugin is the implementation of plugin.Plugin for DAG VMs. Like Plugin, it
+// can only be served/consumed over gRPC.
+type DAGPlugin struct {
+	plugin.NetRPCUnsupportedPlugin
+	// Concrete implementation, written in Go. This is only used for plugins
+	// that are written in Go.
+	vm vertex.DAGVM
+}
+
+// NewDAG ...
+func NewDAG(vm vertex.DAGVM) *DAGPlugin { return &DAGPlugin{vm: vm} }
+
+// GRPCServer ...
+func (p *DAGPlugin) GRPCServer(broker *plugin.GRPCBroker, s *grpc.Server) error {
+	vmproto.RegisterDAGVMServer(s, NewDAGServer(p.vm, broker))
+	return nil
+}
+
+// GRPCClient ...
+func (p *DAGPlugin) GRPCClient(ctx context.Context, broker *plugin.GRPCBroker, c *grpc.ClientConn) (interface{}, error) {
+	return NewDAGClient(vmproto.NewDAGVMClient(c), broker), nil
+}
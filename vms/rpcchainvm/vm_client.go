@@ -5,78 +5,67 @@ package rpcchainvm
 
 import (
 	"context"
-	"errors"
-
-	"google.golang.org/grpc"
 
 	"github.com/hashicorp/go-plugin"
 
+	"github.com/liraxapp/avalanchego/cache"
 	"github.com/liraxapp/avalanchego/database"
-	"github.com/liraxapp/avalanchego/database/rpcdb"
-	"github.com/liraxapp/avalanchego/database/rpcdb/rpcdbproto"
 	"github.com/liraxapp/avalanchego/ids"
 	"github.com/liraxapp/avalanchego/snow"
 	"github.com/liraxapp/avalanchego/snow/choices"
 	"github.com/liraxapp/avalanchego/snow/consensus/snowman"
 	"github.com/liraxapp/avalanchego/snow/engine/common"
-	"github.com/liraxapp/avalanchego/utils/wrappers"
+	"github.com/liraxapp/avalanchego/snow/engine/snowman/block"
+	"github.com/liraxapp/avalanchego/utils/units"
 	"github.com/liraxapp/avalanchego/vms/components/missing"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/galiaslookup"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/galiaslookup/galiaslookupproto"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/ghttp"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/ghttp/ghttpproto"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gkeystore"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gkeystore/gkeystoreproto"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/grpcutils"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsharedmemory"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsharedmemory/gsharedmemoryproto"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsubnetlookup"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsubnetlookup/gsubnetlookupproto"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/messenger"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/messenger/messengerproto"
 	"github.com/liraxapp/avalanchego/vms/rpcchainvm/vmproto"
 )
 
+const (
+	decidedCacheSize = 32 * units.MiB
+)
+
 var (
-	errUnsupportedFXs = errors.New("unsupported feature extensions")
+	_ block.ChainVM              = &VMClient{}
+	_ block.Negotiator           = &VMClient{}
+	_ block.StateSyncableVM      = &stateSyncableVMClient{}
+	_ block.HeightIndexedChainVM = &heightIndexedVMClient{}
+	_ block.StateSyncableVM      = &stateSyncableHeightIndexedVMClient{}
+	_ block.HeightIndexedChainVM = &stateSyncableHeightIndexedVMClient{}
+	_ block.StateSummary         = &StateSummaryClient{}
 )
 
 // VMClient is an implementation of VM that talks over RPC.
 type VMClient struct {
-	client vmproto.VMClient
-	broker *plugin.GRPCBroker
-	proc   *plugin.Client
+	commonClient
 
-	db           *rpcdb.DatabaseServer
-	messenger    *messenger.Server
-	keystore     *gkeystore.Server
-	sharedMemory *gsharedmemory.Server
-	bcLookup     *galiaslookup.Server
-	snLookup     *gsubnetlookup.Server
-
-	serverCloser grpcutils.ServerCloser
-	conns        []*grpc.ClientConn
-
-	ctx  *snow.Context
-	blks map[ids.ID]*BlockClient
+	client vmproto.VMClient
+	blks   map[ids.ID]*BlockClient
+	// decided caches the blocks that were accepted or rejected, so that walking
+	// the accepted chain doesn't fetch every block over RPC
+	decided *cache.SizedLRU
 
 	lastAccepted ids.ID
+
+	// stateSyncable is true if the VM served over RPC syncs its state
+	stateSyncable bool
+	// heightIndexed is true if the VM served over RPC indexes its accepted
+	// blocks by height
+	heightIndexed bool
 }
 
 // NewClient returns a database instance connected to a remote database instance
 func NewClient(client vmproto.VMClient, broker *plugin.GRPCBroker) *VMClient {
 	return &VMClient{
+		commonClient: commonClient{
+			client: client,
+			broker: broker,
+		},
 		client: client,
-		broker: broker,
 		blks:   make(map[ids.ID]*BlockClient),
 	}
 }
 
-// SetProcess ...
-func (vm *VMClient) SetProcess(proc *plugin.Client) {
-	vm.proc = proc
-}
-
 // Initialize ...
 func (vm *VMClient) Initialize(
 	ctx *snow.Context,
@@ -85,58 +74,14 @@ func (vm *VMClient) Initialize(
 	toEngine chan<- common.Message,
 	fxs []*common.Fx,
 ) error {
-	if len(fxs) != 0 {
-		return errUnsupportedFXs
+	decided, err := cache.NewSizedLRU(ctx.Namespace, "decided_block_cache", ctx.Metrics, decidedCacheSize, cache.BytesSize)
+	if err != nil {
+		return err
 	}
+	ctx.CacheBudget.Track(1, decided)
+	vm.decided = decided
 
-	vm.ctx = ctx
-
-	vm.db = rpcdb.NewServer(db)
-	vm.messenger = messenger.NewServer(toEngine)
-	vm.keystore = gkeystore.NewServer(ctx.Keystore, vm.broker)
-	vm.sharedMemory = gsharedmemory.NewServer(ctx.SharedMemory, db)
-	vm.bcLookup = galiaslookup.NewServer(ctx.BCLookup)
-	vm.snLookup = gsubnetlookup.NewServer(ctx.SNLookup)
-
-	// start the db server
-	dbBrokerID := vm.broker.NextId()
-	go vm.broker.AcceptAndServe(dbBrokerID, vm.startDBServer)
-
-	// start the messenger server
-	messengerBrokerID := vm.broker.NextId()
-	go vm.broker.AcceptAndServe(messengerBrokerID, vm.startMessengerServer)
-
-	// start the keystore server
-	keystoreBrokerID := vm.broker.NextId()
-	go vm.broker.AcceptAndServe(keystoreBrokerID, vm.startKeystoreServer)
-
-	// start the shared memory server
-	sharedMemoryBrokerID := vm.broker.NextId()
-	go vm.broker.AcceptAndServe(sharedMemoryBrokerID, vm.startSharedMemoryServer)
-
-	// start the blockchain alias server
-	bcLookupBrokerID := vm.broker.NextId()
-	go vm.broker.AcceptAndServe(bcLookupBrokerID, vm.startBCLookupServer)
-
-	// start the subnet alias server
-	snLookupBrokerID := vm.broker.NextId()
-	go vm.broker.AcceptAndServe(snLookupBrokerID, vm.startSNLookupServer)
-
-	resp, err := vm.client.Initialize(context.Background(), &vmproto.InitializeRequest{
-		NetworkID:          ctx.NetworkID,
-		SubnetID:           ctx.SubnetID[:],
-		ChainID:            ctx.ChainID[:],
-		NodeID:             ctx.NodeID.Bytes(),
-		XChainID:           ctx.XChainID[:],
-		AvaxAssetID:        ctx.AVAXAssetID[:],
-		GenesisBytes:       genesisBytes,
-		DbServer:           dbBrokerID,
-		EngineServer:       messengerBrokerID,
-		KeystoreServer:     keystoreBrokerID,
-		SharedMemoryServer: sharedMemoryBrokerID,
-		BcLookupServer:     bcLookupBrokerID,
-		SnLookupServer:     snLookupBrokerID,
-	})
+	resp, err := vm.initialize(ctx, db, genesisBytes, toEngine, fxs)
 	if err != nil {
 		return err
 	}
@@ -147,95 +92,35 @@ func (vm *VMClient) Initialize(
 	}
 
 	vm.lastAccepted = lastAccepted
+	vm.stateSyncable = resp.StateSyncable
+	vm.heightIndexed = resp.HeightIndexed
 	return nil
 }
 
-func (vm *VMClient) startDBServer(opts []grpc.ServerOption) *grpc.Server {
-	server := grpc.NewServer(opts...)
-	vm.serverCloser.Add(server)
-	rpcdbproto.RegisterDatabaseServer(server, vm.db)
-	return server
-}
-
-func (vm *VMClient) startMessengerServer(opts []grpc.ServerOption) *grpc.Server {
-	server := grpc.NewServer(opts...)
-	vm.serverCloser.Add(server)
-	messengerproto.RegisterMessengerServer(server, vm.messenger)
-	return server
-}
-
-func (vm *VMClient) startKeystoreServer(opts []grpc.ServerOption) *grpc.Server {
-	server := grpc.NewServer(opts...)
-	vm.serverCloser.Add(server)
-	gkeystoreproto.RegisterKeystoreServer(server, vm.keystore)
-	return server
-}
-
-func (vm *VMClient) startSharedMemoryServer(opts []grpc.ServerOption) *grpc.Server {
-	server := grpc.NewServer(opts...)
-	vm.serverCloser.Add(server)
-	gsharedmemoryproto.RegisterSharedMemoryServer(server, vm.sharedMemory)
-	return server
-}
-
-func (vm *VMClient) startBCLookupServer(opts []grpc.ServerOption) *grpc.Server {
-	server := grpc.NewServer(opts...)
-	vm.serverCloser.Add(server)
-	galiaslookupproto.RegisterAliasLookupServer(server, vm.bcLookup)
-	return server
-}
-
-func (vm *VMClient) startSNLookupServer(opts []grpc.ServerOption) *grpc.Server {
-	server := grpc.NewServer(opts...)
-	vm.serverCloser.Add(server)
-	gsubnetlookupproto.RegisterSubnetLookupServer(server, vm.snLookup)
-	return server
-}
-
-// Bootstrapping ...
-func (vm *VMClient) Bootstrapping() error {
-	_, err := vm.client.Bootstrapping(context.Background(), &vmproto.BootstrappingRequest{})
-	return err
-}
-
-// Bootstrapped ...
-func (vm *VMClient) Bootstrapped() error {
-	_, err := vm.client.Bootstrapped(context.Background(), &vmproto.BootstrappedRequest{})
-	return err
-}
-
-// Shutdown ...
-func (vm *VMClient) Shutdown() error {
-	errs := wrappers.Errs{}
-	_, err := vm.client.Shutdown(context.Background(), &vmproto.ShutdownRequest{})
-	errs.Add(err)
-
-	vm.serverCloser.Stop()
-	for _, conn := range vm.conns {
-		errs.Add(conn.Close())
-	}
-
-	vm.proc.Kill()
-	return errs.Err
-}
-
-// CreateHandlers ...
-func (vm *VMClient) CreateHandlers() map[string]*common.HTTPHandler {
-	resp, err := vm.client.CreateHandlers(context.Background(), &vmproto.CreateHandlersRequest{})
-	vm.ctx.Log.AssertNoError(err)
-
-	handlers := make(map[string]*common.HTTPHandler, len(resp.Handlers))
-	for _, handler := range resp.Handlers {
-		conn, err := vm.broker.Dial(handler.Server)
-		vm.ctx.Log.AssertNoError(err)
-
-		vm.conns = append(vm.conns, conn)
-		handlers[handler.Prefix] = &common.HTTPHandler{
-			LockOptions: common.LockOption(handler.LockOptions),
-			Handler:     ghttp.NewClient(ghttpproto.NewHTTPClient(conn), vm.broker),
+// Negotiated returns this client as a StateSyncableVM if the VM served over
+// RPC syncs its state, and as a HeightIndexedChainVM if the VM indexes its
+// accepted blocks by height
+func (vm *VMClient) Negotiated() block.ChainVM {
+	switch {
+	case vm.stateSyncable && vm.heightIndexed:
+		return &stateSyncableHeightIndexedVMClient{
+			VMClient:          vm,
+			stateSyncClient:   &stateSyncClient{vm: vm},
+			heightIndexClient: &heightIndexClient{vm: vm},
 		}
+	case vm.stateSyncable:
+		return &stateSyncableVMClient{
+			VMClient:        vm,
+			stateSyncClient: &stateSyncClient{vm: vm},
+		}
+	case vm.heightIndexed:
+		return &heightIndexedVMClient{
+			VMClient:          vm,
+			heightIndexClient: &heightIndexClient{vm: vm},
+		}
+	default:
+		return vm
 	}
-	return handlers
 }
 
 // BuildBlock ...
@@ -294,6 +179,9 @@ func (vm *VMClient) GetBlock(id ids.ID) (snowman.Block, error) {
 	if blk, cached := vm.blks[id]; cached {
 		return blk, nil
 	}
+	if blk, cached := vm.decided.Get(id); cached {
+		return blk.(*BlockClient), nil
+	}
 
 	resp, err := vm.client.GetBlock(context.Background(), &vmproto.GetBlockRequest{
 		Id: id[:],
@@ -307,13 +195,17 @@ func (vm *VMClient) GetBlock(id ids.ID) (snowman.Block, error) {
 	status := choices.Status(resp.Status)
 	vm.ctx.Log.AssertDeferredNoError(status.Valid)
 
-	return &BlockClient{
+	blk := &BlockClient{
 		vm:       vm,
 		id:       id,
 		parentID: parentID,
 		status:   status,
 		bytes:    resp.Bytes,
-	}, nil
+	}
+	if status.Decided() {
+		vm.decided.Put(id, blk)
+	}
+	return blk, nil
 }
 
 // SetPreference ...
@@ -327,14 +219,6 @@ func (vm *VMClient) SetPreference(id ids.ID) {
 // LastAccepted ...
 func (vm *VMClient) LastAccepted() ids.ID { return vm.lastAccepted }
 
-// Health ...
-func (vm *VMClient) Health() (interface{}, error) {
-	return vm.client.Health(
-		context.Background(),
-		&vmproto.HealthRequest{},
-	)
-}
-
 // BlockClient is an implementation of Block that talks over RPC.
 type BlockClient struct {
 	vm *VMClient
@@ -360,6 +244,7 @@ func (b *BlockClient) Accept() error {
 	}
 
 	b.vm.lastAccepted = b.id
+	b.vm.decided.Put(b.id, b)
 	return nil
 }
 
@@ -370,7 +255,12 @@ func (b *BlockClient) Reject() error {
 	_, err := b.vm.client.BlockReject(context.Background(), &vmproto.BlockRejectRequest{
 		Id: b.id[:],
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	b.vm.decided.Put(b.id, b)
+	return nil
 }
 
 // Status ...
@@ -399,3 +289,155 @@ func (b *BlockClient) Verify() error {
 
 // Bytes ...
 func (b *BlockClient) Bytes() []byte { return b.bytes }
+
+// stateSyncableVMClient is a VMClient whose VM syncs its state
+type stateSyncableVMClient struct {
+	*VMClient
+	*stateSyncClient
+}
+
+// heightIndexedVMClient is a VMClient whose VM indexes its accepted blocks by
+// height
+type heightIndexedVMClient struct {
+	*VMClient
+	*heightIndexClient
+}
+
+// stateSyncableHeightIndexedVMClient is a VMClient whose VM syncs its state
+// and indexes its accepted blocks by height
+type stateSyncableHeightIndexedVMClient struct {
+	*VMClient
+	*stateSyncClient
+	*heightIndexClient
+}
+
+// heightIndexClient implements the methods of HeightIndexedChainVM over RPC
+type heightIndexClient struct {
+	vm *VMClient
+}
+
+// GetBlockIDAtHeight ...
+func (c *heightIndexClient) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
+	resp, err := c.vm.client.GetBlockIDAtHeight(context.Background(), &vmproto.GetBlockIDAtHeightRequest{
+		Height: height,
+	})
+	if err != nil {
+		return ids.ID{}, err
+	}
+	return ids.ToID(resp.BlkID)
+}
+
+// stateSyncClient implements the state sync methods of StateSyncableVM over
+// RPC
+type stateSyncClient struct {
+	vm *VMClient
+}
+
+// GetStateSummary ...
+func (c *stateSyncClient) GetStateSummary() (block.StateSummary, error) {
+	resp, err := c.vm.client.GetStateSummary(context.Background(), &vmproto.GetStateSummaryRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return newStateSummaryClient(resp.Id, resp.Height, resp.BlkID, resp.NumChunks, resp.Bytes)
+}
+
+// ParseStateSummary ...
+func (c *stateSyncClient) ParseStateSummary(bytes []byte) (block.StateSummary, error) {
+	resp, err := c.vm.client.ParseStateSummary(context.Background(), &vmproto.ParseStateSummaryRequest{
+		Bytes: bytes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newStateSummaryClient(resp.Id, resp.Height, resp.BlkID, resp.NumChunks, bytes)
+}
+
+// ShouldSyncState ...
+func (c *stateSyncClient) ShouldSyncState(summary block.StateSummary) (bool, error) {
+	resp, err := c.vm.client.ShouldSyncState(context.Background(), &vmproto.ShouldSyncStateRequest{
+		Summary: summary.Bytes(),
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.ShouldSync, nil
+}
+
+// GetStateChunk ...
+func (c *stateSyncClient) GetStateChunk(summaryID ids.ID, index uint32) ([]byte, error) {
+	resp, err := c.vm.client.GetStateChunk(context.Background(), &vmproto.GetStateChunkRequest{
+		SummaryID: summaryID[:],
+		Index:     index,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Chunk, nil
+}
+
+// SyncStateChunk ...
+func (c *stateSyncClient) SyncStateChunk(summary block.StateSummary, index uint32, chunk []byte) error {
+	_, err := c.vm.client.SyncStateChunk(context.Background(), &vmproto.SyncStateChunkRequest{
+		Summary: summary.Bytes(),
+		Index:   index,
+		Chunk:   chunk,
+	})
+	return err
+}
+
+// StateSynced ...
+func (c *stateSyncClient) StateSynced(summary block.StateSummary) error {
+	_, err := c.vm.client.StateSynced(context.Background(), &vmproto.StateSyncedRequest{
+		Summary: summary.Bytes(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The last accepted block is now the block of the summary
+	c.vm.lastAccepted = summary.BlockID()
+	return nil
+}
+
+// StateSummaryClient is an implementation of StateSummary that talks over RPC.
+type StateSummaryClient struct {
+	id        ids.ID
+	height    uint64
+	blkID     ids.ID
+	numChunks uint32
+	bytes     []byte
+}
+
+func newStateSummaryClient(id []byte, height uint64, blkID []byte, numChunks uint32, bytes []byte) (*StateSummaryClient, error) {
+	summaryID, err := ids.ToID(id)
+	if err != nil {
+		return nil, err
+	}
+	summaryBlkID, err := ids.ToID(blkID)
+	if err != nil {
+		return nil, err
+	}
+	return &StateSummaryClient{
+		id:        summaryID,
+		height:    height,
+		blkID:     summaryBlkID,
+		numChunks: numChunks,
+		bytes:     bytes,
+	}, nil
+}
+
+// ID ...
+func (s *StateSummaryClient) ID() ids.ID { return s.id }
+
+// Height ...
+func (s *StateSummaryClient) Height() uint64 { return s.height }
+
+// BlockID ...
+func (s *StateSummaryClient) BlockID() ids.ID { return s.blkID }
+
+// NumChunks ...
+func (s *StateSummaryClient) NumChunks() uint32 { return s.numChunks }
+
+// Bytes ...
+func (s *StateSummaryClient) Bytes() []byte { return s.bytes }
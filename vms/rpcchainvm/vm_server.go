@@ -5,229 +5,53 @@ package rpcchainvm
 
 import (
 	"context"
-	"encoding/json"
-
-	"google.golang.org/grpc"
+	"errors"
 
 	"github.com/hashicorp/go-plugin"
 
-	"github.com/liraxapp/avalanchego/database/rpcdb"
-	"github.com/liraxapp/avalanchego/database/rpcdb/rpcdbproto"
 	"github.com/liraxapp/avalanchego/ids"
-	"github.com/liraxapp/avalanchego/snow"
-	"github.com/liraxapp/avalanchego/snow/engine/common"
 	"github.com/liraxapp/avalanchego/snow/engine/snowman/block"
-	"github.com/liraxapp/avalanchego/utils/logging"
-	"github.com/liraxapp/avalanchego/utils/wrappers"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/galiaslookup"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/galiaslookup/galiaslookupproto"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/ghttp"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/ghttp/ghttpproto"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gkeystore"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gkeystore/gkeystoreproto"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/grpcutils"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsharedmemory"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsharedmemory/gsharedmemoryproto"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsubnetlookup"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/gsubnetlookup/gsubnetlookupproto"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/messenger"
-	"github.com/liraxapp/avalanchego/vms/rpcchainvm/messenger/messengerproto"
 	"github.com/liraxapp/avalanchego/vms/rpcchainvm/vmproto"
 )
 
+var (
+	errHeightIndexNotImplemented = errors.New("vm doesn't index its accepted blocks by height")
+	errStateSyncNotImplemented   = errors.New("vm doesn't sync its state")
+)
+
 // VMServer is a VM that is managed over RPC.
 type VMServer struct {
-	vm     block.ChainVM
-	broker *plugin.GRPCBroker
-
-	serverCloser grpcutils.ServerCloser
-	conns        []*grpc.ClientConn
+	commonServer
 
-	ctx      *snow.Context
-	toEngine chan common.Message
+	vm block.ChainVM
 }
 
 // NewServer returns a vm instance connected to a remote vm instance
 func NewServer(vm block.ChainVM, broker *plugin.GRPCBroker) *VMServer {
 	return &VMServer{
-		vm:     vm,
-		broker: broker,
+		commonServer: commonServer{
+			vm:     vm,
+			broker: broker,
+		},
+		vm: vm,
 	}
 }
 
 // Initialize ...
 func (vm *VMServer) Initialize(_ context.Context, req *vmproto.InitializeRequest) (*vmproto.InitializeResponse, error) {
-	subnetID, err := ids.ToID(req.SubnetID)
-	if err != nil {
-		return nil, err
-	}
-	chainID, err := ids.ToID(req.ChainID)
-	if err != nil {
-		return nil, err
-	}
-	nodeID, err := ids.ToShortID(req.NodeID)
-	if err != nil {
-		return nil, err
-	}
-	xChainID, err := ids.ToID(req.XChainID)
-	if err != nil {
-		return nil, err
-	}
-	avaxAssetID, err := ids.ToID(req.AvaxAssetID)
-	if err != nil {
-		return nil, err
-	}
-
-	dbConn, err := vm.broker.Dial(req.DbServer)
-	if err != nil {
-		return nil, err
-	}
-	msgConn, err := vm.broker.Dial(req.EngineServer)
-	if err != nil {
-		// Ignore DB closing error to return the original error
-		_ = dbConn.Close()
-		return nil, err
-	}
-	keystoreConn, err := vm.broker.Dial(req.KeystoreServer)
-	if err != nil {
-		// Ignore closing error to return the original error
-		_ = dbConn.Close()
-		_ = msgConn.Close()
-		return nil, err
-	}
-	sharedMemoryConn, err := vm.broker.Dial(req.SharedMemoryServer)
-	if err != nil {
-		// Ignore closing error to return the original error
-		_ = dbConn.Close()
-		_ = msgConn.Close()
-		_ = keystoreConn.Close()
-		return nil, err
-	}
-	bcLookupConn, err := vm.broker.Dial(req.BcLookupServer)
-	if err != nil {
-		// Ignore closing error to return the original error
-		_ = dbConn.Close()
-		_ = msgConn.Close()
-		_ = keystoreConn.Close()
-		_ = sharedMemoryConn.Close()
+	if err := vm.initialize(req); err != nil {
 		return nil, err
 	}
-	snLookupConn, err := vm.broker.Dial(req.SnLookupServer)
-	if err != nil {
-		// Ignore closing error to return the original error
-		_ = dbConn.Close()
-		_ = msgConn.Close()
-		_ = keystoreConn.Close()
-		_ = sharedMemoryConn.Close()
-		_ = bcLookupConn.Close()
-		return nil, err
-	}
-
-	dbClient := rpcdb.NewClient(rpcdbproto.NewDatabaseClient(dbConn))
-	msgClient := messenger.NewClient(messengerproto.NewMessengerClient(msgConn))
-	keystoreClient := gkeystore.NewClient(gkeystoreproto.NewKeystoreClient(keystoreConn), vm.broker)
-	sharedMemoryClient := gsharedmemory.NewClient(gsharedmemoryproto.NewSharedMemoryClient(sharedMemoryConn))
-	bcLookupClient := galiaslookup.NewClient(galiaslookupproto.NewAliasLookupClient(bcLookupConn))
-	snLookupClient := gsubnetlookup.NewClient(gsubnetlookupproto.NewSubnetLookupClient(snLookupConn))
-
-	toEngine := make(chan common.Message, 1)
-	go func() {
-		for msg := range toEngine {
-			// Nothing to do with the error within the goroutine
-			_ = msgClient.Notify(msg)
-		}
-	}()
-
-	vm.ctx = &snow.Context{
-		NetworkID:           req.NetworkID,
-		SubnetID:            subnetID,
-		ChainID:             chainID,
-		NodeID:              nodeID,
-		XChainID:            xChainID,
-		AVAXAssetID:         avaxAssetID,
-		Log:                 logging.NoLog{},
-		DecisionDispatcher:  nil,
-		ConsensusDispatcher: nil,
-		Keystore:            keystoreClient,
-		SharedMemory:        sharedMemoryClient,
-		BCLookup:            bcLookupClient,
-		SNLookup:            snLookupClient,
-	}
-
-	if err := vm.vm.Initialize(vm.ctx, dbClient, req.GenesisBytes, toEngine, nil); err != nil {
-		// Ignore errors closing resources to return the original error
-		_ = dbConn.Close()
-		_ = msgConn.Close()
-		_ = keystoreConn.Close()
-		_ = sharedMemoryConn.Close()
-		_ = bcLookupConn.Close()
-		_ = snLookupConn.Close()
-		close(toEngine)
-		return nil, err
-	}
-
-	vm.conns = append(vm.conns, dbConn)
-	vm.conns = append(vm.conns, msgConn)
-	vm.toEngine = toEngine
 	lastAccepted := vm.vm.LastAccepted()
+	_, stateSyncable := vm.vm.(block.StateSyncableVM)
+	_, heightIndexed := vm.vm.(block.HeightIndexedChainVM)
 	return &vmproto.InitializeResponse{
 		LastAcceptedID: lastAccepted[:],
+		StateSyncable:  stateSyncable,
+		HeightIndexed:  heightIndexed,
 	}, nil
 }
 
-// Bootstrapping ...
-func (vm *VMServer) Bootstrapping(context.Context, *vmproto.BootstrappingRequest) (*vmproto.BootstrappingResponse, error) {
-	return &vmproto.BootstrappingResponse{}, vm.vm.Bootstrapping()
-}
-
-// Bootstrapped ...
-func (vm *VMServer) Bootstrapped(context.Context, *vmproto.BootstrappedRequest) (*vmproto.BootstrappedResponse, error) {
-	vm.ctx.Bootstrapped()
-	return &vmproto.BootstrappedResponse{}, vm.vm.Bootstrapped()
-}
-
-// Shutdown ...
-func (vm *VMServer) Shutdown(context.Context, *vmproto.ShutdownRequest) (*vmproto.ShutdownResponse, error) {
-	if vm.toEngine == nil {
-		return &vmproto.ShutdownResponse{}, nil
-	}
-
-	errs := wrappers.Errs{}
-	errs.Add(vm.vm.Shutdown())
-	close(vm.toEngine)
-
-	vm.serverCloser.Stop()
-	for _, conn := range vm.conns {
-		errs.Add(conn.Close())
-	}
-	return &vmproto.ShutdownResponse{}, errs.Err
-}
-
-// CreateHandlers ...
-func (vm *VMServer) CreateHandlers(_ context.Context, req *vmproto.CreateHandlersRequest) (*vmproto.CreateHandlersResponse, error) {
-	handlers := vm.vm.CreateHandlers()
-	resp := &vmproto.CreateHandlersResponse{}
-	for prefix, h := range handlers {
-		handler := h
-
-		// start the messenger server
-		serverID := vm.broker.NextId()
-		go vm.broker.AcceptAndServe(serverID, func(opts []grpc.ServerOption) *grpc.Server {
-			server := grpc.NewServer(opts...)
-			vm.serverCloser.Add(server)
-			ghttpproto.RegisterHTTPServer(server, ghttp.NewServer(handler.Handler, vm.broker))
-			return server
-		})
-
-		resp.Handlers = append(resp.Handlers, &vmproto.Handler{
-			Prefix:      prefix,
-			LockOptions: uint32(handler.LockOptions),
-			Server:      serverID,
-		})
-	}
-	return resp, nil
-}
-
 // BuildBlock ...
 func (vm *VMServer) BuildBlock(_ context.Context, _ *vmproto.BuildBlockRequest) (*vmproto.BuildBlockResponse, error) {
 	blk, err := vm.vm.BuildBlock()
@@ -276,6 +100,21 @@ func (vm *VMServer) GetBlock(_ context.Context, req *vmproto.GetBlockRequest) (*
 	}, nil
 }
 
+// GetBlockIDAtHeight ...
+func (vm *VMServer) GetBlockIDAtHeight(_ context.Context, req *vmproto.GetBlockIDAtHeightRequest) (*vmproto.GetBlockIDAtHeightResponse, error) {
+	hvm, ok := vm.vm.(block.HeightIndexedChainVM)
+	if !ok {
+		return nil, errHeightIndexNotImplemented
+	}
+	blkID, err := hvm.GetBlockIDAtHeight(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.GetBlockIDAtHeightResponse{
+		BlkID: blkID[:],
+	}, nil
+}
+
 // SetPreference ...
 func (vm *VMServer) SetPreference(_ context.Context, req *vmproto.SetPreferenceRequest) (*vmproto.SetPreferenceResponse, error) {
 	id, err := ids.ToID(req.Id)
@@ -286,34 +125,6 @@ func (vm *VMServer) SetPreference(_ context.Context, req *vmproto.SetPreferenceR
 	return &vmproto.SetPreferenceResponse{}, nil
 }
 
-// Health ...
-func (vm *VMServer) Health(_ context.Context, req *vmproto.HealthRequest) (*vmproto.HealthResponse, error) {
-	details, err := vm.vm.Health()
-	if err != nil {
-		return &vmproto.HealthResponse{}, err
-	}
-
-	// Try to stringify the details
-	detailsStr := "couldn't parse health check details to string"
-	switch details := details.(type) {
-	case nil:
-		detailsStr = ""
-	case string:
-		detailsStr = details
-	case map[string]string:
-		asJSON, err := json.Marshal(details)
-		if err != nil {
-			detailsStr = string(asJSON)
-		}
-	case []byte:
-		detailsStr = string(details)
-	}
-
-	return &vmproto.HealthResponse{
-		Details: detailsStr,
-	}, nil
-}
-
 // BlockVerify ...
 func (vm *VMServer) BlockVerify(_ context.Context, req *vmproto.BlockVerifyRequest) (*vmproto.BlockVerifyResponse, error) {
 	id, err := ids.ToID(req.Id)
@@ -358,3 +169,103 @@ func (vm *VMServer) BlockReject(_ context.Context, req *vmproto.BlockRejectReque
 	}
 	return &vmproto.BlockRejectResponse{}, nil
 }
+
+// GetStateSummary ...
+func (vm *VMServer) GetStateSummary(_ context.Context, _ *vmproto.GetStateSummaryRequest) (*vmproto.GetStateSummaryResponse, error) {
+	svm, ok := vm.vm.(block.StateSyncableVM)
+	if !ok {
+		return nil, errStateSyncNotImplemented
+	}
+	summary, err := svm.GetStateSummary()
+	if err != nil {
+		return nil, err
+	}
+	summaryID := summary.ID()
+	blkID := summary.BlockID()
+	return &vmproto.GetStateSummaryResponse{
+		Id:        summaryID[:],
+		Height:    summary.Height(),
+		BlkID:     blkID[:],
+		NumChunks: summary.NumChunks(),
+		Bytes:     summary.Bytes(),
+	}, nil
+}
+
+// ParseStateSummary ...
+func (vm *VMServer) ParseStateSummary(_ context.Context, req *vmproto.ParseStateSummaryRequest) (*vmproto.ParseStateSummaryResponse, error) {
+	svm, ok := vm.vm.(block.StateSyncableVM)
+	if !ok {
+		return nil, errStateSyncNotImplemented
+	}
+	summary, err := svm.ParseStateSummary(req.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	summaryID := summary.ID()
+	blkID := summary.BlockID()
+	return &vmproto.ParseStateSummaryResponse{
+		Id:        summaryID[:],
+		Height:    summary.Height(),
+		BlkID:     blkID[:],
+		NumChunks: summary.NumChunks(),
+	}, nil
+}
+
+// ShouldSyncState ...
+func (vm *VMServer) ShouldSyncState(_ context.Context, req *vmproto.ShouldSyncStateRequest) (*vmproto.ShouldSyncStateResponse, error) {
+	svm, summary, err := vm.stateSummary(req.Summary)
+	if err != nil {
+		return nil, err
+	}
+	shouldSync, err := svm.ShouldSyncState(summary)
+	return &vmproto.ShouldSyncStateResponse{
+		ShouldSync: shouldSync,
+	}, err
+}
+
+// GetStateChunk ...
+func (vm *VMServer) GetStateChunk(_ context.Context, req *vmproto.GetStateChunkRequest) (*vmproto.GetStateChunkResponse, error) {
+	svm, ok := vm.vm.(block.StateSyncableVM)
+	if !ok {
+		return nil, errStateSyncNotImplemented
+	}
+	summaryID, err := ids.ToID(req.SummaryID)
+	if err != nil {
+		return nil, err
+	}
+	chunk, err := svm.GetStateChunk(summaryID, req.Index)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.GetStateChunkResponse{
+		Chunk: chunk,
+	}, nil
+}
+
+// SyncStateChunk ...
+func (vm *VMServer) SyncStateChunk(_ context.Context, req *vmproto.SyncStateChunkRequest) (*vmproto.SyncStateChunkResponse, error) {
+	svm, summary, err := vm.stateSummary(req.Summary)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.SyncStateChunkResponse{}, svm.SyncStateChunk(summary, req.Index, req.Chunk)
+}
+
+// StateSynced ...
+func (vm *VMServer) StateSynced(_ context.Context, req *vmproto.StateSyncedRequest) (*vmproto.StateSyncedResponse, error) {
+	svm, summary, err := vm.stateSummary(req.Summary)
+	if err != nil {
+		return nil, err
+	}
+	return &vmproto.StateSyncedResponse{}, svm.StateSynced(summary)
+}
+
+// stateSummary parses the summary the client refers to by its bytes
+func (vm *VMServer) stateSummary(summaryBytes []byte) (block.StateSyncableVM, block.StateSummary, error) {
+	svm, ok := vm.vm.(block.StateSyncableVM)
+	if !ok {
+		return nil, nil, errStateSyncNotImplemented
+	}
+	summary, err := svm.ParseStateSummary(summaryBytes)
+	return svm, summary, err
+}
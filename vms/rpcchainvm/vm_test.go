@@ -0,0 +1,206 @@
+// (c) 2019-2020, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"net"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/liraxapp/avalanchego/ids"
+	"github.com/liraxapp/avalanchego/snow"
+	"github.com/liraxapp/avalanchego/snow/engine/snowman/block"
+	"github.com/liraxapp/avalanchego/vms/rpcchainvm/vmproto"
+)
+
+var errInvalidChunk = errors.New("invalid chunk")
+
+// newVMClient returns a client connected to a server that serves [vm]
+func newVMClient(t *testing.T, vm block.ChainVM) (*VMClient, *grpc.ClientConn) {
+	listener := bufconn.Listen(bufSize)
+	server := grpc.NewServer()
+	vmproto.RegisterVMServer(server, NewServer(vm, nil))
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatalf("Server exited with error: %v", err)
+		}
+	}()
+
+	dialer := grpc.WithContextDialer(
+		func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		})
+
+	conn, err := grpc.DialContext(context.Background(), "", dialer, grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial: %s", err)
+	}
+
+	client := NewClient(vmproto.NewVMClient(conn), nil)
+	client.ctx = snow.DefaultContextTest()
+	return client, conn
+}
+
+func TestVMNegotiatedStateSync(t *testing.T) {
+	vm := &block.TestVM{}
+	vm.T = t
+	vm.Default(true)
+	client, conn := newVMClient(t, vm)
+	defer conn.Close()
+
+	if _, ok := client.Negotiated().(block.StateSyncableVM); ok {
+		t.Fatalf("client shouldn't sync the state of a VM that doesn't sync its state")
+	}
+	stateSyncClient := &stateSyncClient{vm: client}
+	if _, err := stateSyncClient.GetStateSummary(); err == nil {
+		t.Fatalf("server shouldn't sync the state of a VM that doesn't sync its state")
+	}
+
+	client.stateSyncable = true
+	if _, ok := client.Negotiated().(block.StateSyncableVM); !ok {
+		t.Fatalf("client should sync the state of a VM that syncs its state")
+	}
+}
+
+func TestVMNegotiatedHeightIndex(t *testing.T) {
+	vm := &block.TestVM{}
+	vm.T = t
+	vm.Default(true)
+	client, conn := newVMClient(t, vm)
+	defer conn.Close()
+
+	if _, ok := client.Negotiated().(block.HeightIndexedChainVM); ok {
+		t.Fatalf("client shouldn't index a VM that doesn't index its blocks by height")
+	}
+
+	client.heightIndexed = true
+	if _, ok := client.Negotiated().(block.HeightIndexedChainVM); !ok {
+		t.Fatalf("client should index a VM that indexes its blocks by height")
+	}
+	if _, ok := client.Negotiated().(block.StateSyncableVM); ok {
+		t.Fatalf("client shouldn't sync the state of a VM that doesn't sync its state")
+	}
+
+	client.stateSyncable = true
+	negotiated := client.Negotiated()
+	if _, ok := negotiated.(block.HeightIndexedChainVM); !ok {
+		t.Fatalf("client should index a VM that indexes its blocks by height")
+	}
+	if _, ok := negotiated.(block.StateSyncableVM); !ok {
+		t.Fatalf("client should sync the state of a VM that syncs its state")
+	}
+}
+
+func TestVMStateSync(t *testing.T) {
+	summary := &block.TestStateSummary{
+		IDV:        ids.Empty.Prefix(0),
+		HeightV:    100,
+		BlockIDV:   ids.Empty.Prefix(1),
+		NumChunksV: 2,
+		BytesV:     []byte{0},
+	}
+	chunk := []byte{1}
+	syncedChunks := 0
+	synced := false
+
+	vm := &block.TestStateSyncableVM{}
+	vm.T = t
+	vm.Default(true)
+	vm.GetStateSummaryF = func() (block.StateSummary, error) { return summary, nil }
+	vm.ParseStateSummaryF = func(b []byte) (block.StateSummary, error) {
+		if !bytes.Equal(b, summary.Bytes()) {
+			t.Fatalf("parsed the wrong summary")
+		}
+		return summary, nil
+	}
+	vm.ShouldSyncStateF = func(s block.StateSummary) (bool, error) { return s == summary, nil }
+	vm.GetStateChunkF = func(summaryID ids.ID, index uint32) ([]byte, error) {
+		if summaryID != summary.ID() || index >= summary.NumChunks() {
+			return nil, errUnknownTx
+		}
+		return chunk, nil
+	}
+	vm.SyncStateChunkF = func(_ block.StateSummary, _ uint32, c []byte) error {
+		if !bytes.Equal(c, chunk) {
+			return errInvalidChunk
+		}
+		syncedChunks++
+		return nil
+	}
+	vm.StateSyncedF = func(block.StateSummary) error {
+		synced = true
+		return nil
+	}
+
+	client, conn := newVMClient(t, vm)
+	defer conn.Close()
+	client.stateSyncable = true
+	svm := client.Negotiated().(block.StateSyncableVM)
+
+	gotSummary, err := svm.GetStateSummary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case gotSummary.ID() != summary.ID():
+		t.Fatalf("wrong summary ID")
+	case gotSummary.Height() != summary.Height():
+		t.Fatalf("wrong summary height")
+	case gotSummary.BlockID() != summary.BlockID():
+		t.Fatalf("wrong summary block ID")
+	case gotSummary.NumChunks() != summary.NumChunks():
+		t.Fatalf("wrong number of chunks")
+	case !bytes.Equal(gotSummary.Bytes(), summary.Bytes()):
+		t.Fatalf("wrong summary bytes")
+	}
+
+	parsedSummary, err := svm.ParseStateSummary(summary.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsedSummary.ID() != summary.ID() {
+		t.Fatalf("wrong summary ID")
+	}
+	if shouldSync, err := svm.ShouldSyncState(parsedSummary); err != nil {
+		t.Fatal(err)
+	} else if !shouldSync {
+		t.Fatalf("should have synced the state")
+	}
+
+	if _, err := svm.GetStateChunk(summary.ID(), summary.NumChunks()); err == nil {
+		t.Fatalf("shouldn't have gotten an unknown chunk")
+	}
+	for i := uint32(0); i < summary.NumChunks(); i++ {
+		c, err := svm.GetStateChunk(summary.ID(), i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := svm.SyncStateChunk(parsedSummary, i, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := svm.SyncStateChunk(parsedSummary, 0, []byte{2}); err == nil {
+		t.Fatalf("shouldn't have synced an invalid chunk")
+	}
+	if syncedChunks != int(summary.NumChunks()) {
+		t.Fatalf("expected %d synced chunks but got %d", summary.NumChunks(), syncedChunks)
+	}
+
+	if err := svm.StateSynced(parsedSummary); err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case !synced:
+		t.Fatalf("VM should have synced the state")
+	case svm.LastAccepted() != summary.BlockID():
+		t.Fatalf("last accepted block should be the block of the summary")
+	}
+}
@@ -39,6 +39,7 @@ type InitializeRequest struct {
 	SharedMemoryServer   uint32   `protobuf:"varint,11,opt,name=sharedMemoryServer,proto3" json:"sharedMemoryServer,omitempty"`
 	BcLookupServer       uint32   `protobuf:"varint,12,opt,name=bcLookupServer,proto3" json:"bcLookupServer,omitempty"`
 	SnLookupServer       uint32   `protobuf:"varint,13,opt,name=snLookupServer,proto3" json:"snLookupServer,omitempty"`
+	AppSenderServer      uint32   `protobuf:"varint,14,opt,name=appSenderServer,proto3" json:"appSenderServer,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -160,8 +161,17 @@ func (m *InitializeRequest) GetSnLookupServer() uint32 {
 	return 0
 }
 
+func (m *InitializeRequest) GetAppSenderServer() uint32 {
+	if m != nil {
+		return m.AppSenderServer
+	}
+	return 0
+}
+
 type InitializeResponse struct {
 	LastAcceptedID       []byte   `protobuf:"bytes,1,opt,name=lastAcceptedID,proto3" json:"lastAcceptedID,omitempty"`
+	StateSyncable        bool     `protobuf:"varint,2,opt,name=stateSyncable,proto3" json:"stateSyncable,omitempty"`
+	HeightIndexed        bool     `protobuf:"varint,3,opt,name=heightIndexed,proto3" json:"heightIndexed,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -199,6 +209,20 @@ func (m *InitializeResponse) GetLastAcceptedID() []byte {
 	return nil
 }
 
+func (m *InitializeResponse) GetStateSyncable() bool {
+	if m != nil {
+		return m.StateSyncable
+	}
+	return false
+}
+
+func (m *InitializeResponse) GetHeightIndexed() bool {
+	if m != nil {
+		return m.HeightIndexed
+	}
+	return false
+}
+
 type BootstrappingRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -784,6 +808,84 @@ func (m *GetBlockResponse) GetStatus() uint32 {
 	return 0
 }
 
+type GetBlockIDAtHeightRequest struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockIDAtHeightRequest) Reset()         { *m = GetBlockIDAtHeightRequest{} }
+func (m *GetBlockIDAtHeightRequest) String() string { return proto.CompactTextString(m) }
+func (*GetBlockIDAtHeightRequest) ProtoMessage()    {}
+func (*GetBlockIDAtHeightRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{17}
+}
+
+func (m *GetBlockIDAtHeightRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockIDAtHeightRequest.Unmarshal(m, b)
+}
+func (m *GetBlockIDAtHeightRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockIDAtHeightRequest.Marshal(b, m, deterministic)
+}
+func (m *GetBlockIDAtHeightRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockIDAtHeightRequest.Merge(m, src)
+}
+func (m *GetBlockIDAtHeightRequest) XXX_Size() int {
+	return xxx_messageInfo_GetBlockIDAtHeightRequest.Size(m)
+}
+func (m *GetBlockIDAtHeightRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockIDAtHeightRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockIDAtHeightRequest proto.InternalMessageInfo
+
+func (m *GetBlockIDAtHeightRequest) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type GetBlockIDAtHeightResponse struct {
+	BlkID                []byte   `protobuf:"bytes,1,opt,name=blkID,proto3" json:"blkID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetBlockIDAtHeightResponse) Reset()         { *m = GetBlockIDAtHeightResponse{} }
+func (m *GetBlockIDAtHeightResponse) String() string { return proto.CompactTextString(m) }
+func (*GetBlockIDAtHeightResponse) ProtoMessage()    {}
+func (*GetBlockIDAtHeightResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{18}
+}
+
+func (m *GetBlockIDAtHeightResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetBlockIDAtHeightResponse.Unmarshal(m, b)
+}
+func (m *GetBlockIDAtHeightResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetBlockIDAtHeightResponse.Marshal(b, m, deterministic)
+}
+func (m *GetBlockIDAtHeightResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetBlockIDAtHeightResponse.Merge(m, src)
+}
+func (m *GetBlockIDAtHeightResponse) XXX_Size() int {
+	return xxx_messageInfo_GetBlockIDAtHeightResponse.Size(m)
+}
+func (m *GetBlockIDAtHeightResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetBlockIDAtHeightResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetBlockIDAtHeightResponse proto.InternalMessageInfo
+
+func (m *GetBlockIDAtHeightResponse) GetBlkID() []byte {
+	if m != nil {
+		return m.BlkID
+	}
+	return nil
+}
+
 type SetPreferenceRequest struct {
 	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -795,7 +897,7 @@ func (m *SetPreferenceRequest) Reset()         { *m = SetPreferenceRequest{} }
 func (m *SetPreferenceRequest) String() string { return proto.CompactTextString(m) }
 func (*SetPreferenceRequest) ProtoMessage()    {}
 func (*SetPreferenceRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cab246c8c7c5372d, []int{17}
+	return fileDescriptor_cab246c8c7c5372d, []int{19}
 }
 
 func (m *SetPreferenceRequest) XXX_Unmarshal(b []byte) error {
@@ -833,7 +935,7 @@ func (m *SetPreferenceResponse) Reset()         { *m = SetPreferenceResponse{} }
 func (m *SetPreferenceResponse) String() string { return proto.CompactTextString(m) }
 func (*SetPreferenceResponse) ProtoMessage()    {}
 func (*SetPreferenceResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cab246c8c7c5372d, []int{18}
+	return fileDescriptor_cab246c8c7c5372d, []int{20}
 }
 
 func (m *SetPreferenceResponse) XXX_Unmarshal(b []byte) error {
@@ -865,7 +967,7 @@ func (m *BlockVerifyRequest) Reset()         { *m = BlockVerifyRequest{} }
 func (m *BlockVerifyRequest) String() string { return proto.CompactTextString(m) }
 func (*BlockVerifyRequest) ProtoMessage()    {}
 func (*BlockVerifyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cab246c8c7c5372d, []int{19}
+	return fileDescriptor_cab246c8c7c5372d, []int{21}
 }
 
 func (m *BlockVerifyRequest) XXX_Unmarshal(b []byte) error {
@@ -903,7 +1005,7 @@ func (m *BlockVerifyResponse) Reset()         { *m = BlockVerifyResponse{} }
 func (m *BlockVerifyResponse) String() string { return proto.CompactTextString(m) }
 func (*BlockVerifyResponse) ProtoMessage()    {}
 func (*BlockVerifyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cab246c8c7c5372d, []int{20}
+	return fileDescriptor_cab246c8c7c5372d, []int{22}
 }
 
 func (m *BlockVerifyResponse) XXX_Unmarshal(b []byte) error {
@@ -935,7 +1037,7 @@ func (m *BlockAcceptRequest) Reset()         { *m = BlockAcceptRequest{} }
 func (m *BlockAcceptRequest) String() string { return proto.CompactTextString(m) }
 func (*BlockAcceptRequest) ProtoMessage()    {}
 func (*BlockAcceptRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cab246c8c7c5372d, []int{21}
+	return fileDescriptor_cab246c8c7c5372d, []int{23}
 }
 
 func (m *BlockAcceptRequest) XXX_Unmarshal(b []byte) error {
@@ -973,7 +1075,7 @@ func (m *BlockAcceptResponse) Reset()         { *m = BlockAcceptResponse{} }
 func (m *BlockAcceptResponse) String() string { return proto.CompactTextString(m) }
 func (*BlockAcceptResponse) ProtoMessage()    {}
 func (*BlockAcceptResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cab246c8c7c5372d, []int{22}
+	return fileDescriptor_cab246c8c7c5372d, []int{24}
 }
 
 func (m *BlockAcceptResponse) XXX_Unmarshal(b []byte) error {
@@ -1005,7 +1107,7 @@ func (m *BlockRejectRequest) Reset()         { *m = BlockRejectRequest{} }
 func (m *BlockRejectRequest) String() string { return proto.CompactTextString(m) }
 func (*BlockRejectRequest) ProtoMessage()    {}
 func (*BlockRejectRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cab246c8c7c5372d, []int{23}
+	return fileDescriptor_cab246c8c7c5372d, []int{25}
 }
 
 func (m *BlockRejectRequest) XXX_Unmarshal(b []byte) error {
@@ -1043,7 +1145,7 @@ func (m *BlockRejectResponse) Reset()         { *m = BlockRejectResponse{} }
 func (m *BlockRejectResponse) String() string { return proto.CompactTextString(m) }
 func (*BlockRejectResponse) ProtoMessage()    {}
 func (*BlockRejectResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cab246c8c7c5372d, []int{24}
+	return fileDescriptor_cab246c8c7c5372d, []int{26}
 }
 
 func (m *BlockRejectResponse) XXX_Unmarshal(b []byte) error {
@@ -1064,245 +1166,1767 @@ func (m *BlockRejectResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_BlockRejectResponse proto.InternalMessageInfo
 
-type HealthRequest struct {
+type Tx struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Bytes                []byte   `protobuf:"bytes,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Status               uint32   `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *HealthRequest) Reset()         { *m = HealthRequest{} }
-func (m *HealthRequest) String() string { return proto.CompactTextString(m) }
-func (*HealthRequest) ProtoMessage()    {}
-func (*HealthRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cab246c8c7c5372d, []int{25}
+func (m *Tx) Reset()         { *m = Tx{} }
+func (m *Tx) String() string { return proto.CompactTextString(m) }
+func (*Tx) ProtoMessage()    {}
+func (*Tx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{27}
 }
 
-func (m *HealthRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_HealthRequest.Unmarshal(m, b)
+func (m *Tx) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tx.Unmarshal(m, b)
 }
-func (m *HealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_HealthRequest.Marshal(b, m, deterministic)
+func (m *Tx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tx.Marshal(b, m, deterministic)
 }
-func (m *HealthRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_HealthRequest.Merge(m, src)
+func (m *Tx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tx.Merge(m, src)
 }
-func (m *HealthRequest) XXX_Size() int {
-	return xxx_messageInfo_HealthRequest.Size(m)
+func (m *Tx) XXX_Size() int {
+	return xxx_messageInfo_Tx.Size(m)
 }
-func (m *HealthRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_HealthRequest.DiscardUnknown(m)
+func (m *Tx) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tx.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_HealthRequest proto.InternalMessageInfo
+var xxx_messageInfo_Tx proto.InternalMessageInfo
 
-type HealthResponse struct {
-	Details              string   `protobuf:"bytes,1,opt,name=details,proto3" json:"details,omitempty"`
+func (m *Tx) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *Tx) GetBytes() []byte {
+	if m != nil {
+		return m.Bytes
+	}
+	return nil
+}
+
+func (m *Tx) GetStatus() uint32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+type PendingTxsRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *HealthResponse) Reset()         { *m = HealthResponse{} }
-func (m *HealthResponse) String() string { return proto.CompactTextString(m) }
-func (*HealthResponse) ProtoMessage()    {}
-func (*HealthResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cab246c8c7c5372d, []int{26}
+func (m *PendingTxsRequest) Reset()         { *m = PendingTxsRequest{} }
+func (m *PendingTxsRequest) String() string { return proto.CompactTextString(m) }
+func (*PendingTxsRequest) ProtoMessage()    {}
+func (*PendingTxsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{28}
 }
 
-func (m *HealthResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_HealthResponse.Unmarshal(m, b)
+func (m *PendingTxsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingTxsRequest.Unmarshal(m, b)
 }
-func (m *HealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_HealthResponse.Marshal(b, m, deterministic)
+func (m *PendingTxsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingTxsRequest.Marshal(b, m, deterministic)
 }
-func (m *HealthResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_HealthResponse.Merge(m, src)
+func (m *PendingTxsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingTxsRequest.Merge(m, src)
 }
-func (m *HealthResponse) XXX_Size() int {
-	return xxx_messageInfo_HealthResponse.Size(m)
+func (m *PendingTxsRequest) XXX_Size() int {
+	return xxx_messageInfo_PendingTxsRequest.Size(m)
 }
-func (m *HealthResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_HealthResponse.DiscardUnknown(m)
+func (m *PendingTxsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingTxsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_HealthResponse proto.InternalMessageInfo
+var xxx_messageInfo_PendingTxsRequest proto.InternalMessageInfo
 
-func (m *HealthResponse) GetDetails() string {
-	if m != nil {
-		return m.Details
-	}
-	return ""
+type PendingTxsResponse struct {
+	Txs                  []*Tx    `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterType((*InitializeRequest)(nil), "vmproto.InitializeRequest")
-	proto.RegisterType((*InitializeResponse)(nil), "vmproto.InitializeResponse")
-	proto.RegisterType((*BootstrappingRequest)(nil), "vmproto.BootstrappingRequest")
-	proto.RegisterType((*BootstrappingResponse)(nil), "vmproto.BootstrappingResponse")
-	proto.RegisterType((*BootstrappedRequest)(nil), "vmproto.BootstrappedRequest")
-	proto.RegisterType((*BootstrappedResponse)(nil), "vmproto.BootstrappedResponse")
-	proto.RegisterType((*ShutdownRequest)(nil), "vmproto.ShutdownRequest")
-	proto.RegisterType((*ShutdownResponse)(nil), "vmproto.ShutdownResponse")
-	proto.RegisterType((*CreateHandlersRequest)(nil), "vmproto.CreateHandlersRequest")
-	proto.RegisterType((*CreateHandlersResponse)(nil), "vmproto.CreateHandlersResponse")
-	proto.RegisterType((*Handler)(nil), "vmproto.Handler")
-	proto.RegisterType((*BuildBlockRequest)(nil), "vmproto.BuildBlockRequest")
-	proto.RegisterType((*BuildBlockResponse)(nil), "vmproto.BuildBlockResponse")
-	proto.RegisterType((*ParseBlockRequest)(nil), "vmproto.ParseBlockRequest")
-	proto.RegisterType((*ParseBlockResponse)(nil), "vmproto.ParseBlockResponse")
-	proto.RegisterType((*GetBlockRequest)(nil), "vmproto.GetBlockRequest")
-	proto.RegisterType((*GetBlockResponse)(nil), "vmproto.GetBlockResponse")
-	proto.RegisterType((*SetPreferenceRequest)(nil), "vmproto.SetPreferenceRequest")
-	proto.RegisterType((*SetPreferenceResponse)(nil), "vmproto.SetPreferenceResponse")
-	proto.RegisterType((*BlockVerifyRequest)(nil), "vmproto.BlockVerifyRequest")
-	proto.RegisterType((*BlockVerifyResponse)(nil), "vmproto.BlockVerifyResponse")
-	proto.RegisterType((*BlockAcceptRequest)(nil), "vmproto.BlockAcceptRequest")
-	proto.RegisterType((*BlockAcceptResponse)(nil), "vmproto.BlockAcceptResponse")
-	proto.RegisterType((*BlockRejectRequest)(nil), "vmproto.BlockRejectRequest")
-	proto.RegisterType((*BlockRejectResponse)(nil), "vmproto.BlockRejectResponse")
-	proto.RegisterType((*HealthRequest)(nil), "vmproto.HealthRequest")
-	proto.RegisterType((*HealthResponse)(nil), "vmproto.HealthResponse")
+func (m *PendingTxsResponse) Reset()         { *m = PendingTxsResponse{} }
+func (m *PendingTxsResponse) String() string { return proto.CompactTextString(m) }
+func (*PendingTxsResponse) ProtoMessage()    {}
+func (*PendingTxsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{29}
 }
 
-func init() {
-	proto.RegisterFile("vm.proto", fileDescriptor_cab246c8c7c5372d)
+func (m *PendingTxsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingTxsResponse.Unmarshal(m, b)
 }
-
-var fileDescriptor_cab246c8c7c5372d = []byte{
-	// 841 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdd, 0x6e, 0xf3, 0x44,
-	0x10, 0x55, 0x12, 0xbe, 0x24, 0x9d, 0xfc, 0xb4, 0xd9, 0x36, 0x89, 0x31, 0x2d, 0x04, 0x0b, 0x55,
-	0x05, 0xa1, 0x5c, 0x94, 0x2b, 0x24, 0xa4, 0xaa, 0x69, 0x0a, 0x8d, 0xa0, 0xa5, 0xb8, 0x52, 0x85,
-	0x04, 0x37, 0x4e, 0x3c, 0x6d, 0x4c, 0xd2, 0xb5, 0xd9, 0xdd, 0xa4, 0x0d, 0xcf, 0xc5, 0x4b, 0xf0,
-	0x56, 0xc8, 0xeb, 0xb5, 0xbd, 0x76, 0x1c, 0x55, 0xfa, 0xee, 0x32, 0x67, 0xce, 0x1c, 0xcf, 0xee,
-	0xce, 0x99, 0x40, 0x7d, 0xfd, 0x32, 0x0c, 0x98, 0x2f, 0x7c, 0x52, 0x5b, 0xbf, 0xc8, 0x1f, 0xd6,
-	0x7f, 0x15, 0xe8, 0x4c, 0xa8, 0x27, 0x3c, 0x67, 0xe9, 0xfd, 0x83, 0x36, 0xfe, 0xbd, 0x42, 0x2e,
-	0xc8, 0x31, 0xec, 0x51, 0x14, 0xaf, 0x3e, 0x5b, 0x4c, 0xc6, 0x46, 0x69, 0x50, 0x3a, 0x6b, 0xd9,
-	0x29, 0x40, 0x4c, 0xa8, 0xf3, 0xd5, 0x94, 0xa2, 0x98, 0x8c, 0x8d, 0xf2, 0xa0, 0x74, 0xd6, 0xb4,
-	0x93, 0x98, 0x18, 0x50, 0x9b, 0xcd, 0x1d, 0x8f, 0x4e, 0xc6, 0x46, 0x45, 0xa6, 0xe2, 0x90, 0xf4,
-	0xa0, 0x4a, 0x7d, 0x17, 0x27, 0x63, 0xe3, 0x13, 0x99, 0x50, 0x51, 0xa8, 0xf6, 0x76, 0xa5, 0x4a,
-	0x3e, 0x44, 0x6a, 0x71, 0x4c, 0x06, 0xd0, 0x70, 0xd6, 0xce, 0xdb, 0x25, 0xe7, 0xf2, 0x63, 0x55,
-	0x99, 0xd6, 0x21, 0x62, 0x41, 0xf3, 0x19, 0x29, 0x72, 0x8f, 0x8f, 0x36, 0x02, 0xb9, 0x51, 0x93,
-	0x94, 0x0c, 0x16, 0x7e, 0xc1, 0x9d, 0x3e, 0x20, 0x5b, 0x23, 0x33, 0xea, 0xf2, 0x30, 0x49, 0x1c,
-	0xd6, 0x23, 0x7d, 0xf6, 0x28, 0xaa, 0xfc, 0x9e, 0xcc, 0x67, 0x30, 0x72, 0x0a, 0xed, 0x05, 0x6e,
-	0xb8, 0xf0, 0x59, 0xcc, 0x02, 0xc9, 0xca, 0xa1, 0x64, 0x08, 0x84, 0xcf, 0x1d, 0x86, 0xee, 0x2d,
-	0xbe, 0xf8, 0x6c, 0xa3, 0xb8, 0x0d, 0xc9, 0x2d, 0xc8, 0x84, 0xba, 0xd3, 0xd9, 0x2f, 0xbe, 0xbf,
-	0x58, 0x05, 0x8a, 0xdb, 0x8c, 0x74, 0xb3, 0x68, 0xc8, 0xe3, 0x34, 0xc3, 0x6b, 0x45, 0xbc, 0x2c,
-	0x6a, 0xfd, 0x00, 0x44, 0x7f, 0x4a, 0x1e, 0xf8, 0x94, 0x63, 0x58, 0xbd, 0x74, 0xb8, 0xb8, 0x9c,
-	0xcd, 0x30, 0x10, 0xe8, 0xaa, 0x07, 0x6d, 0xda, 0x39, 0xd4, 0xea, 0xc1, 0xd1, 0xc8, 0xf7, 0x05,
-	0x17, 0xcc, 0x09, 0x02, 0x8f, 0x3e, 0xab, 0x59, 0xb0, 0xfa, 0xd0, 0xcd, 0xe1, 0x91, 0xb0, 0xd5,
-	0x85, 0xc3, 0x34, 0x81, 0x6e, 0xcc, 0xcf, 0xe8, 0x84, 0xb0, 0xa2, 0x77, 0x60, 0xff, 0x61, 0xbe,
-	0x12, 0xae, 0xff, 0x4a, 0x63, 0x2a, 0x81, 0x83, 0x14, 0x52, 0xb4, 0x3e, 0x74, 0xaf, 0x18, 0x3a,
-	0x02, 0x6f, 0x1c, 0xea, 0x2e, 0x91, 0xf1, 0x98, 0xfc, 0x23, 0xf4, 0xf2, 0x09, 0x75, 0xc2, 0x6f,
-	0xa1, 0x3e, 0x57, 0x98, 0x51, 0x1a, 0x54, 0xce, 0x1a, 0xe7, 0x07, 0x43, 0x35, 0xdf, 0x43, 0x45,
-	0xb6, 0x13, 0x86, 0xf5, 0x07, 0xd4, 0x14, 0x18, 0x8e, 0x64, 0xc0, 0xf0, 0xc9, 0x7b, 0x93, 0x57,
-	0xb2, 0x67, 0xab, 0x28, 0x1c, 0xbb, 0xa5, 0x3f, 0x5b, 0xfc, 0x1a, 0x08, 0xcf, 0xa7, 0x5c, 0xce,
-	0x78, 0xcb, 0xd6, 0xa1, 0xb0, 0x92, 0x47, 0x4f, 0x51, 0x91, 0x49, 0x15, 0x59, 0x87, 0xd0, 0x19,
-	0xad, 0xbc, 0xa5, 0x3b, 0x0a, 0xc9, 0x71, 0xe7, 0x8f, 0x40, 0x74, 0x50, 0x75, 0xdd, 0x86, 0xb2,
-	0xe7, 0xaa, 0xb7, 0x28, 0x7b, 0x6e, 0x38, 0xa5, 0x81, 0xc3, 0x90, 0x6a, 0xae, 0x8a, 0x63, 0x72,
-	0x04, 0x1f, 0xa6, 0x72, 0xbc, 0x23, 0x4f, 0x45, 0x81, 0xf5, 0x35, 0x74, 0xee, 0x1d, 0xc6, 0x51,
-	0xff, 0x58, 0x4a, 0x2d, 0xe9, 0xd4, 0xdf, 0x81, 0xe8, 0xd4, 0x8f, 0x68, 0x21, 0x3c, 0xb1, 0x70,
-	0xc4, 0x8a, 0x27, 0x27, 0x96, 0x91, 0xf5, 0x25, 0xec, 0xff, 0x84, 0x22, 0xd3, 0x42, 0x4e, 0xd6,
-	0xfa, 0x13, 0x0e, 0x52, 0x8a, 0xfa, 0xb4, 0xfe, 0xa9, 0xd2, 0xae, 0xd3, 0x96, 0xb5, 0x23, 0xec,
-	0x6c, 0xe0, 0x14, 0x8e, 0x1e, 0x50, 0xdc, 0x33, 0x7c, 0x42, 0x86, 0x74, 0x86, 0xbb, 0xba, 0xe8,
-	0x43, 0x37, 0xc7, 0x53, 0x13, 0xf7, 0x15, 0x10, 0xd9, 0xdb, 0x23, 0x32, 0xef, 0x69, 0xb3, 0xab,
-	0x3c, 0x9c, 0x76, 0x9d, 0x95, 0x2b, 0x8e, 0x8c, 0xf4, 0x5e, 0x71, 0xcc, 0xca, 0x15, 0xdb, 0xf8,
-	0x17, 0xce, 0xde, 0x2d, 0x8e, 0x59, 0xaa, 0x78, 0x1f, 0x5a, 0x37, 0xe8, 0x2c, 0xc5, 0x3c, 0x1e,
-	0xb3, 0x6f, 0xa0, 0x1d, 0x03, 0xea, 0x92, 0x0d, 0xa8, 0xb9, 0x28, 0x1c, 0x6f, 0xc9, 0xd5, 0x80,
-	0xc7, 0xe1, 0xf9, 0xbf, 0x35, 0x28, 0x3f, 0xde, 0x92, 0x6b, 0x80, 0x74, 0x63, 0x10, 0x33, 0x71,
-	0xcd, 0xd6, 0x3f, 0x82, 0xf9, 0x59, 0x61, 0x4e, 0x7d, 0xe7, 0x0e, 0x5a, 0x99, 0x15, 0x41, 0x4e,
-	0x12, 0x76, 0xd1, 0x4a, 0x31, 0x3f, 0xdf, 0x95, 0x56, 0x7a, 0x3f, 0x43, 0x53, 0x5f, 0x21, 0xe4,
-	0xb8, 0x80, 0x9f, 0x2c, 0x1c, 0xf3, 0x64, 0x47, 0x56, 0x89, 0x5d, 0x40, 0x3d, 0x5e, 0x32, 0xc4,
-	0x48, 0xa8, 0xb9, 0x55, 0x64, 0x7e, 0x5a, 0x90, 0x51, 0x02, 0xbf, 0x41, 0x3b, 0xbb, 0x78, 0x48,
-	0xda, 0x7f, 0xe1, 0xaa, 0x32, 0xbf, 0xd8, 0x99, 0x57, 0x92, 0xd7, 0x00, 0xe9, 0x46, 0xd0, 0xee,
-	0x7d, 0x6b, 0x77, 0x68, 0xf7, 0x5e, 0xb0, 0x42, 0xae, 0x01, 0x52, 0x57, 0x6b, 0x32, 0x5b, 0x5b,
-	0x41, 0x93, 0x29, 0x58, 0x03, 0x17, 0x50, 0x8f, 0xfd, 0xa9, 0xdd, 0x50, 0xce, 0xd5, 0xda, 0x0d,
-	0x6d, 0x99, 0xf9, 0x0e, 0x5a, 0x19, 0x6b, 0x69, 0xef, 0x5f, 0x64, 0x4d, 0xed, 0xfd, 0x0b, 0x1d,
-	0x49, 0xbe, 0x87, 0x6a, 0x34, 0xc9, 0xa4, 0x97, 0x2e, 0x72, 0x7d, 0xd6, 0xcd, 0xfe, 0x16, 0xae,
-	0x4a, 0x6f, 0xa0, 0xa1, 0xd9, 0x94, 0x68, 0xd7, 0xb7, 0x65, 0x71, 0xf3, 0xb8, 0x38, 0x99, 0x53,
-	0x8a, 0x3c, 0x9b, 0x57, 0xca, 0xf8, 0x3d, 0xaf, 0x94, 0xb5, 0x79, 0xa2, 0x14, 0x19, 0x38, 0xaf,
-	0x94, 0x31, 0x7f, 0x5e, 0x29, 0xeb, 0xf9, 0x69, 0x55, 0xa6, 0xbe, 0xfb, 0x3f, 0x00, 0x00, 0xff,
-	0xff, 0x4d, 0xf2, 0x90, 0xfe, 0xc9, 0x09, 0x00, 0x00,
+func (m *PendingTxsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingTxsResponse.Marshal(b, m, deterministic)
+}
+func (m *PendingTxsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingTxsResponse.Merge(m, src)
+}
+func (m *PendingTxsResponse) XXX_Size() int {
+	return xxx_messageInfo_PendingTxsResponse.Size(m)
+}
+func (m *PendingTxsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingTxsResponse.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
+var xxx_messageInfo_PendingTxsResponse proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+func (m *PendingTxsResponse) GetTxs() []*Tx {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
 
-// VMClient is the client API for VM service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type VMClient interface {
-	Initialize(ctx context.Context, in *InitializeRequest, opts ...grpc.CallOption) (*InitializeResponse, error)
-	Bootstrapping(ctx context.Context, in *BootstrappingRequest, opts ...grpc.CallOption) (*BootstrappingResponse, error)
-	Bootstrapped(ctx context.Context, in *BootstrappedRequest, opts ...grpc.CallOption) (*BootstrappedResponse, error)
-	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
-	CreateHandlers(ctx context.Context, in *CreateHandlersRequest, opts ...grpc.CallOption) (*CreateHandlersResponse, error)
-	BuildBlock(ctx context.Context, in *BuildBlockRequest, opts ...grpc.CallOption) (*BuildBlockResponse, error)
-	ParseBlock(ctx context.Context, in *ParseBlockRequest, opts ...grpc.CallOption) (*ParseBlockResponse, error)
-	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
-	SetPreference(ctx context.Context, in *SetPreferenceRequest, opts ...grpc.CallOption) (*SetPreferenceResponse, error)
-	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
-	BlockVerify(ctx context.Context, in *BlockVerifyRequest, opts ...grpc.CallOption) (*BlockVerifyResponse, error)
-	BlockAccept(ctx context.Context, in *BlockAcceptRequest, opts ...grpc.CallOption) (*BlockAcceptResponse, error)
-	BlockReject(ctx context.Context, in *BlockRejectRequest, opts ...grpc.CallOption) (*BlockRejectResponse, error)
+type ParseTxRequest struct {
+	Bytes                []byte   `protobuf:"bytes,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type vMClient struct {
-	cc grpc.ClientConnInterface
+func (m *ParseTxRequest) Reset()         { *m = ParseTxRequest{} }
+func (m *ParseTxRequest) String() string { return proto.CompactTextString(m) }
+func (*ParseTxRequest) ProtoMessage()    {}
+func (*ParseTxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{30}
 }
 
-func NewVMClient(cc grpc.ClientConnInterface) VMClient {
-	return &vMClient{cc}
+func (m *ParseTxRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParseTxRequest.Unmarshal(m, b)
+}
+func (m *ParseTxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParseTxRequest.Marshal(b, m, deterministic)
+}
+func (m *ParseTxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParseTxRequest.Merge(m, src)
+}
+func (m *ParseTxRequest) XXX_Size() int {
+	return xxx_messageInfo_ParseTxRequest.Size(m)
+}
+func (m *ParseTxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParseTxRequest.DiscardUnknown(m)
 }
 
-func (c *vMClient) Initialize(ctx context.Context, in *InitializeRequest, opts ...grpc.CallOption) (*InitializeResponse, error) {
-	out := new(InitializeResponse)
-	err := c.cc.Invoke(ctx, "/vmproto.VM/Initialize", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ParseTxRequest proto.InternalMessageInfo
+
+func (m *ParseTxRequest) GetBytes() []byte {
+	if m != nil {
+		return m.Bytes
 	}
-	return out, nil
+	return nil
 }
 
-func (c *vMClient) Bootstrapping(ctx context.Context, in *BootstrappingRequest, opts ...grpc.CallOption) (*BootstrappingResponse, error) {
-	out := new(BootstrappingResponse)
-	err := c.cc.Invoke(ctx, "/vmproto.VM/Bootstrapping", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ParseTxResponse struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status               uint32   `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *vMClient) Bootstrapped(ctx context.Context, in *BootstrappedRequest, opts ...grpc.CallOption) (*BootstrappedResponse, error) {
-	out := new(BootstrappedResponse)
-	err := c.cc.Invoke(ctx, "/vmproto.VM/Bootstrapped", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ParseTxResponse) Reset()         { *m = ParseTxResponse{} }
+func (m *ParseTxResponse) String() string { return proto.CompactTextString(m) }
+func (*ParseTxResponse) ProtoMessage()    {}
+func (*ParseTxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{31}
 }
 
-func (c *vMClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
-	out := new(ShutdownResponse)
-	err := c.cc.Invoke(ctx, "/vmproto.VM/Shutdown", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *ParseTxResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParseTxResponse.Unmarshal(m, b)
+}
+func (m *ParseTxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParseTxResponse.Marshal(b, m, deterministic)
+}
+func (m *ParseTxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParseTxResponse.Merge(m, src)
+}
+func (m *ParseTxResponse) XXX_Size() int {
+	return xxx_messageInfo_ParseTxResponse.Size(m)
+}
+func (m *ParseTxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParseTxResponse.DiscardUnknown(m)
 }
 
-func (c *vMClient) CreateHandlers(ctx context.Context, in *CreateHandlersRequest, opts ...grpc.CallOption) (*CreateHandlersResponse, error) {
-	out := new(CreateHandlersResponse)
-	err := c.cc.Invoke(ctx, "/vmproto.VM/CreateHandlers", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ParseTxResponse proto.InternalMessageInfo
+
+func (m *ParseTxResponse) GetId() []byte {
+	if m != nil {
+		return m.Id
 	}
-	return out, nil
+	return nil
+}
+
+func (m *ParseTxResponse) GetStatus() uint32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+type GetTxRequest struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxRequest) Reset()         { *m = GetTxRequest{} }
+func (m *GetTxRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTxRequest) ProtoMessage()    {}
+func (*GetTxRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{32}
+}
+
+func (m *GetTxRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxRequest.Unmarshal(m, b)
+}
+func (m *GetTxRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxRequest.Marshal(b, m, deterministic)
+}
+func (m *GetTxRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxRequest.Merge(m, src)
+}
+func (m *GetTxRequest) XXX_Size() int {
+	return xxx_messageInfo_GetTxRequest.Size(m)
+}
+func (m *GetTxRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxRequest proto.InternalMessageInfo
+
+func (m *GetTxRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type GetTxResponse struct {
+	Bytes                []byte   `protobuf:"bytes,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Status               uint32   `protobuf:"varint,2,opt,name=status,proto3" json:"status,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetTxResponse) Reset()         { *m = GetTxResponse{} }
+func (m *GetTxResponse) String() string { return proto.CompactTextString(m) }
+func (*GetTxResponse) ProtoMessage()    {}
+func (*GetTxResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{33}
+}
+
+func (m *GetTxResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetTxResponse.Unmarshal(m, b)
+}
+func (m *GetTxResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetTxResponse.Marshal(b, m, deterministic)
+}
+func (m *GetTxResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTxResponse.Merge(m, src)
+}
+func (m *GetTxResponse) XXX_Size() int {
+	return xxx_messageInfo_GetTxResponse.Size(m)
+}
+func (m *GetTxResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTxResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetTxResponse proto.InternalMessageInfo
+
+func (m *GetTxResponse) GetBytes() []byte {
+	if m != nil {
+		return m.Bytes
+	}
+	return nil
+}
+
+func (m *GetTxResponse) GetStatus() uint32 {
+	if m != nil {
+		return m.Status
+	}
+	return 0
+}
+
+type TxVerifyRequest struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxVerifyRequest) Reset()         { *m = TxVerifyRequest{} }
+func (m *TxVerifyRequest) String() string { return proto.CompactTextString(m) }
+func (*TxVerifyRequest) ProtoMessage()    {}
+func (*TxVerifyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{34}
+}
+
+func (m *TxVerifyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxVerifyRequest.Unmarshal(m, b)
+}
+func (m *TxVerifyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxVerifyRequest.Marshal(b, m, deterministic)
+}
+func (m *TxVerifyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxVerifyRequest.Merge(m, src)
+}
+func (m *TxVerifyRequest) XXX_Size() int {
+	return xxx_messageInfo_TxVerifyRequest.Size(m)
+}
+func (m *TxVerifyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxVerifyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxVerifyRequest proto.InternalMessageInfo
+
+func (m *TxVerifyRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type TxVerifyResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxVerifyResponse) Reset()         { *m = TxVerifyResponse{} }
+func (m *TxVerifyResponse) String() string { return proto.CompactTextString(m) }
+func (*TxVerifyResponse) ProtoMessage()    {}
+func (*TxVerifyResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{35}
+}
+
+func (m *TxVerifyResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxVerifyResponse.Unmarshal(m, b)
+}
+func (m *TxVerifyResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxVerifyResponse.Marshal(b, m, deterministic)
+}
+func (m *TxVerifyResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxVerifyResponse.Merge(m, src)
+}
+func (m *TxVerifyResponse) XXX_Size() int {
+	return xxx_messageInfo_TxVerifyResponse.Size(m)
+}
+func (m *TxVerifyResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxVerifyResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxVerifyResponse proto.InternalMessageInfo
+
+type TxAcceptRequest struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxAcceptRequest) Reset()         { *m = TxAcceptRequest{} }
+func (m *TxAcceptRequest) String() string { return proto.CompactTextString(m) }
+func (*TxAcceptRequest) ProtoMessage()    {}
+func (*TxAcceptRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{36}
+}
+
+func (m *TxAcceptRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxAcceptRequest.Unmarshal(m, b)
+}
+func (m *TxAcceptRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxAcceptRequest.Marshal(b, m, deterministic)
+}
+func (m *TxAcceptRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxAcceptRequest.Merge(m, src)
+}
+func (m *TxAcceptRequest) XXX_Size() int {
+	return xxx_messageInfo_TxAcceptRequest.Size(m)
+}
+func (m *TxAcceptRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxAcceptRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxAcceptRequest proto.InternalMessageInfo
+
+func (m *TxAcceptRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type TxAcceptResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxAcceptResponse) Reset()         { *m = TxAcceptResponse{} }
+func (m *TxAcceptResponse) String() string { return proto.CompactTextString(m) }
+func (*TxAcceptResponse) ProtoMessage()    {}
+func (*TxAcceptResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{37}
+}
+
+func (m *TxAcceptResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxAcceptResponse.Unmarshal(m, b)
+}
+func (m *TxAcceptResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxAcceptResponse.Marshal(b, m, deterministic)
+}
+func (m *TxAcceptResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxAcceptResponse.Merge(m, src)
+}
+func (m *TxAcceptResponse) XXX_Size() int {
+	return xxx_messageInfo_TxAcceptResponse.Size(m)
+}
+func (m *TxAcceptResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxAcceptResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxAcceptResponse proto.InternalMessageInfo
+
+type TxRejectRequest struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxRejectRequest) Reset()         { *m = TxRejectRequest{} }
+func (m *TxRejectRequest) String() string { return proto.CompactTextString(m) }
+func (*TxRejectRequest) ProtoMessage()    {}
+func (*TxRejectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{38}
+}
+
+func (m *TxRejectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxRejectRequest.Unmarshal(m, b)
+}
+func (m *TxRejectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxRejectRequest.Marshal(b, m, deterministic)
+}
+func (m *TxRejectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxRejectRequest.Merge(m, src)
+}
+func (m *TxRejectRequest) XXX_Size() int {
+	return xxx_messageInfo_TxRejectRequest.Size(m)
+}
+func (m *TxRejectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxRejectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxRejectRequest proto.InternalMessageInfo
+
+func (m *TxRejectRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type TxRejectResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxRejectResponse) Reset()         { *m = TxRejectResponse{} }
+func (m *TxRejectResponse) String() string { return proto.CompactTextString(m) }
+func (*TxRejectResponse) ProtoMessage()    {}
+func (*TxRejectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{39}
+}
+
+func (m *TxRejectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxRejectResponse.Unmarshal(m, b)
+}
+func (m *TxRejectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxRejectResponse.Marshal(b, m, deterministic)
+}
+func (m *TxRejectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxRejectResponse.Merge(m, src)
+}
+func (m *TxRejectResponse) XXX_Size() int {
+	return xxx_messageInfo_TxRejectResponse.Size(m)
+}
+func (m *TxRejectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxRejectResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxRejectResponse proto.InternalMessageInfo
+
+type TxDependenciesRequest struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxDependenciesRequest) Reset()         { *m = TxDependenciesRequest{} }
+func (m *TxDependenciesRequest) String() string { return proto.CompactTextString(m) }
+func (*TxDependenciesRequest) ProtoMessage()    {}
+func (*TxDependenciesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{40}
+}
+
+func (m *TxDependenciesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxDependenciesRequest.Unmarshal(m, b)
+}
+func (m *TxDependenciesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxDependenciesRequest.Marshal(b, m, deterministic)
+}
+func (m *TxDependenciesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxDependenciesRequest.Merge(m, src)
+}
+func (m *TxDependenciesRequest) XXX_Size() int {
+	return xxx_messageInfo_TxDependenciesRequest.Size(m)
+}
+func (m *TxDependenciesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxDependenciesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxDependenciesRequest proto.InternalMessageInfo
+
+func (m *TxDependenciesRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type TxDependenciesResponse struct {
+	Ids                  [][]byte `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxDependenciesResponse) Reset()         { *m = TxDependenciesResponse{} }
+func (m *TxDependenciesResponse) String() string { return proto.CompactTextString(m) }
+func (*TxDependenciesResponse) ProtoMessage()    {}
+func (*TxDependenciesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{41}
+}
+
+func (m *TxDependenciesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxDependenciesResponse.Unmarshal(m, b)
+}
+func (m *TxDependenciesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxDependenciesResponse.Marshal(b, m, deterministic)
+}
+func (m *TxDependenciesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxDependenciesResponse.Merge(m, src)
+}
+func (m *TxDependenciesResponse) XXX_Size() int {
+	return xxx_messageInfo_TxDependenciesResponse.Size(m)
+}
+func (m *TxDependenciesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxDependenciesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxDependenciesResponse proto.InternalMessageInfo
+
+func (m *TxDependenciesResponse) GetIds() [][]byte {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+type TxInputIDsRequest struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxInputIDsRequest) Reset()         { *m = TxInputIDsRequest{} }
+func (m *TxInputIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*TxInputIDsRequest) ProtoMessage()    {}
+func (*TxInputIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{42}
+}
+
+func (m *TxInputIDsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxInputIDsRequest.Unmarshal(m, b)
+}
+func (m *TxInputIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxInputIDsRequest.Marshal(b, m, deterministic)
+}
+func (m *TxInputIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxInputIDsRequest.Merge(m, src)
+}
+func (m *TxInputIDsRequest) XXX_Size() int {
+	return xxx_messageInfo_TxInputIDsRequest.Size(m)
+}
+func (m *TxInputIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxInputIDsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxInputIDsRequest proto.InternalMessageInfo
+
+func (m *TxInputIDsRequest) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+type TxInputIDsResponse struct {
+	InputIDs             [][]byte `protobuf:"bytes,1,rep,name=inputIDs,proto3" json:"inputIDs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TxInputIDsResponse) Reset()         { *m = TxInputIDsResponse{} }
+func (m *TxInputIDsResponse) String() string { return proto.CompactTextString(m) }
+func (*TxInputIDsResponse) ProtoMessage()    {}
+func (*TxInputIDsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{43}
+}
+
+func (m *TxInputIDsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TxInputIDsResponse.Unmarshal(m, b)
+}
+func (m *TxInputIDsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TxInputIDsResponse.Marshal(b, m, deterministic)
+}
+func (m *TxInputIDsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxInputIDsResponse.Merge(m, src)
+}
+func (m *TxInputIDsResponse) XXX_Size() int {
+	return xxx_messageInfo_TxInputIDsResponse.Size(m)
+}
+func (m *TxInputIDsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxInputIDsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxInputIDsResponse proto.InternalMessageInfo
+
+func (m *TxInputIDsResponse) GetInputIDs() [][]byte {
+	if m != nil {
+		return m.InputIDs
+	}
+	return nil
+}
+
+type AppRequestMsg struct {
+	NodeID               []byte   `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	RequestID            uint32   `protobuf:"varint,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	Request              []byte   `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppRequestMsg) Reset()         { *m = AppRequestMsg{} }
+func (m *AppRequestMsg) String() string { return proto.CompactTextString(m) }
+func (*AppRequestMsg) ProtoMessage()    {}
+func (*AppRequestMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{44}
+}
+
+func (m *AppRequestMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppRequestMsg.Unmarshal(m, b)
+}
+func (m *AppRequestMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppRequestMsg.Marshal(b, m, deterministic)
+}
+func (m *AppRequestMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppRequestMsg.Merge(m, src)
+}
+func (m *AppRequestMsg) XXX_Size() int {
+	return xxx_messageInfo_AppRequestMsg.Size(m)
+}
+func (m *AppRequestMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppRequestMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppRequestMsg proto.InternalMessageInfo
+
+func (m *AppRequestMsg) GetNodeID() []byte {
+	if m != nil {
+		return m.NodeID
+	}
+	return nil
+}
+
+func (m *AppRequestMsg) GetRequestID() uint32 {
+	if m != nil {
+		return m.RequestID
+	}
+	return 0
+}
+
+func (m *AppRequestMsg) GetRequest() []byte {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+type AppRequestFailedMsg struct {
+	NodeID               []byte   `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	RequestID            uint32   `protobuf:"varint,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppRequestFailedMsg) Reset()         { *m = AppRequestFailedMsg{} }
+func (m *AppRequestFailedMsg) String() string { return proto.CompactTextString(m) }
+func (*AppRequestFailedMsg) ProtoMessage()    {}
+func (*AppRequestFailedMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{45}
+}
+
+func (m *AppRequestFailedMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppRequestFailedMsg.Unmarshal(m, b)
+}
+func (m *AppRequestFailedMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppRequestFailedMsg.Marshal(b, m, deterministic)
+}
+func (m *AppRequestFailedMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppRequestFailedMsg.Merge(m, src)
+}
+func (m *AppRequestFailedMsg) XXX_Size() int {
+	return xxx_messageInfo_AppRequestFailedMsg.Size(m)
+}
+func (m *AppRequestFailedMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppRequestFailedMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppRequestFailedMsg proto.InternalMessageInfo
+
+func (m *AppRequestFailedMsg) GetNodeID() []byte {
+	if m != nil {
+		return m.NodeID
+	}
+	return nil
+}
+
+func (m *AppRequestFailedMsg) GetRequestID() uint32 {
+	if m != nil {
+		return m.RequestID
+	}
+	return 0
+}
+
+type AppResponseMsg struct {
+	NodeID               []byte   `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	RequestID            uint32   `protobuf:"varint,2,opt,name=requestID,proto3" json:"requestID,omitempty"`
+	Response             []byte   `protobuf:"bytes,3,opt,name=response,proto3" json:"response,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppResponseMsg) Reset()         { *m = AppResponseMsg{} }
+func (m *AppResponseMsg) String() string { return proto.CompactTextString(m) }
+func (*AppResponseMsg) ProtoMessage()    {}
+func (*AppResponseMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{46}
+}
+
+func (m *AppResponseMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppResponseMsg.Unmarshal(m, b)
+}
+func (m *AppResponseMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppResponseMsg.Marshal(b, m, deterministic)
+}
+func (m *AppResponseMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppResponseMsg.Merge(m, src)
+}
+func (m *AppResponseMsg) XXX_Size() int {
+	return xxx_messageInfo_AppResponseMsg.Size(m)
+}
+func (m *AppResponseMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppResponseMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppResponseMsg proto.InternalMessageInfo
+
+func (m *AppResponseMsg) GetNodeID() []byte {
+	if m != nil {
+		return m.NodeID
+	}
+	return nil
+}
+
+func (m *AppResponseMsg) GetRequestID() uint32 {
+	if m != nil {
+		return m.RequestID
+	}
+	return 0
+}
+
+func (m *AppResponseMsg) GetResponse() []byte {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+type AppGossipMsg struct {
+	NodeID               []byte   `protobuf:"bytes,1,opt,name=nodeID,proto3" json:"nodeID,omitempty"`
+	Msg                  []byte   `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AppGossipMsg) Reset()         { *m = AppGossipMsg{} }
+func (m *AppGossipMsg) String() string { return proto.CompactTextString(m) }
+func (*AppGossipMsg) ProtoMessage()    {}
+func (*AppGossipMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{47}
+}
+
+func (m *AppGossipMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AppGossipMsg.Unmarshal(m, b)
+}
+func (m *AppGossipMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AppGossipMsg.Marshal(b, m, deterministic)
+}
+func (m *AppGossipMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AppGossipMsg.Merge(m, src)
+}
+func (m *AppGossipMsg) XXX_Size() int {
+	return xxx_messageInfo_AppGossipMsg.Size(m)
+}
+func (m *AppGossipMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_AppGossipMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AppGossipMsg proto.InternalMessageInfo
+
+func (m *AppGossipMsg) GetNodeID() []byte {
+	if m != nil {
+		return m.NodeID
+	}
+	return nil
+}
+
+func (m *AppGossipMsg) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+type EmptyMsg struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EmptyMsg) Reset()         { *m = EmptyMsg{} }
+func (m *EmptyMsg) String() string { return proto.CompactTextString(m) }
+func (*EmptyMsg) ProtoMessage()    {}
+func (*EmptyMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{48}
+}
+
+func (m *EmptyMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmptyMsg.Unmarshal(m, b)
+}
+func (m *EmptyMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmptyMsg.Marshal(b, m, deterministic)
+}
+func (m *EmptyMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmptyMsg.Merge(m, src)
+}
+func (m *EmptyMsg) XXX_Size() int {
+	return xxx_messageInfo_EmptyMsg.Size(m)
+}
+func (m *EmptyMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmptyMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EmptyMsg proto.InternalMessageInfo
+
+type HealthRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthRequest) Reset()         { *m = HealthRequest{} }
+func (m *HealthRequest) String() string { return proto.CompactTextString(m) }
+func (*HealthRequest) ProtoMessage()    {}
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{49}
+}
+
+func (m *HealthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthRequest.Unmarshal(m, b)
+}
+func (m *HealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthRequest.Marshal(b, m, deterministic)
+}
+func (m *HealthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthRequest.Merge(m, src)
+}
+func (m *HealthRequest) XXX_Size() int {
+	return xxx_messageInfo_HealthRequest.Size(m)
+}
+func (m *HealthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthRequest proto.InternalMessageInfo
+
+type HealthResponse struct {
+	Details              string   `protobuf:"bytes,1,opt,name=details,proto3" json:"details,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthResponse) Reset()         { *m = HealthResponse{} }
+func (m *HealthResponse) String() string { return proto.CompactTextString(m) }
+func (*HealthResponse) ProtoMessage()    {}
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{50}
+}
+
+func (m *HealthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthResponse.Unmarshal(m, b)
+}
+func (m *HealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthResponse.Marshal(b, m, deterministic)
+}
+func (m *HealthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthResponse.Merge(m, src)
+}
+func (m *HealthResponse) XXX_Size() int {
+	return xxx_messageInfo_HealthResponse.Size(m)
+}
+func (m *HealthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthResponse proto.InternalMessageInfo
+
+func (m *HealthResponse) GetDetails() string {
+	if m != nil {
+		return m.Details
+	}
+	return ""
+}
+
+type GetStateSummaryRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateSummaryRequest) Reset()         { *m = GetStateSummaryRequest{} }
+func (m *GetStateSummaryRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateSummaryRequest) ProtoMessage()    {}
+func (*GetStateSummaryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{51}
+}
+
+func (m *GetStateSummaryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateSummaryRequest.Unmarshal(m, b)
+}
+func (m *GetStateSummaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateSummaryRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStateSummaryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateSummaryRequest.Merge(m, src)
+}
+func (m *GetStateSummaryRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateSummaryRequest.Size(m)
+}
+func (m *GetStateSummaryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateSummaryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateSummaryRequest proto.InternalMessageInfo
+
+type GetStateSummaryResponse struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	BlkID                []byte   `protobuf:"bytes,3,opt,name=blkID,proto3" json:"blkID,omitempty"`
+	NumChunks            uint32   `protobuf:"varint,4,opt,name=numChunks,proto3" json:"numChunks,omitempty"`
+	Bytes                []byte   `protobuf:"bytes,5,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateSummaryResponse) Reset()         { *m = GetStateSummaryResponse{} }
+func (m *GetStateSummaryResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStateSummaryResponse) ProtoMessage()    {}
+func (*GetStateSummaryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{52}
+}
+
+func (m *GetStateSummaryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateSummaryResponse.Unmarshal(m, b)
+}
+func (m *GetStateSummaryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateSummaryResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStateSummaryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateSummaryResponse.Merge(m, src)
+}
+func (m *GetStateSummaryResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStateSummaryResponse.Size(m)
+}
+func (m *GetStateSummaryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateSummaryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateSummaryResponse proto.InternalMessageInfo
+
+func (m *GetStateSummaryResponse) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *GetStateSummaryResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *GetStateSummaryResponse) GetBlkID() []byte {
+	if m != nil {
+		return m.BlkID
+	}
+	return nil
+}
+
+func (m *GetStateSummaryResponse) GetNumChunks() uint32 {
+	if m != nil {
+		return m.NumChunks
+	}
+	return 0
+}
+
+func (m *GetStateSummaryResponse) GetBytes() []byte {
+	if m != nil {
+		return m.Bytes
+	}
+	return nil
+}
+
+type ParseStateSummaryRequest struct {
+	Bytes                []byte   `protobuf:"bytes,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ParseStateSummaryRequest) Reset()         { *m = ParseStateSummaryRequest{} }
+func (m *ParseStateSummaryRequest) String() string { return proto.CompactTextString(m) }
+func (*ParseStateSummaryRequest) ProtoMessage()    {}
+func (*ParseStateSummaryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{53}
+}
+
+func (m *ParseStateSummaryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParseStateSummaryRequest.Unmarshal(m, b)
+}
+func (m *ParseStateSummaryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParseStateSummaryRequest.Marshal(b, m, deterministic)
+}
+func (m *ParseStateSummaryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParseStateSummaryRequest.Merge(m, src)
+}
+func (m *ParseStateSummaryRequest) XXX_Size() int {
+	return xxx_messageInfo_ParseStateSummaryRequest.Size(m)
+}
+func (m *ParseStateSummaryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParseStateSummaryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParseStateSummaryRequest proto.InternalMessageInfo
+
+func (m *ParseStateSummaryRequest) GetBytes() []byte {
+	if m != nil {
+		return m.Bytes
+	}
+	return nil
+}
+
+type ParseStateSummaryResponse struct {
+	Id                   []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Height               uint64   `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	BlkID                []byte   `protobuf:"bytes,3,opt,name=blkID,proto3" json:"blkID,omitempty"`
+	NumChunks            uint32   `protobuf:"varint,4,opt,name=numChunks,proto3" json:"numChunks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ParseStateSummaryResponse) Reset()         { *m = ParseStateSummaryResponse{} }
+func (m *ParseStateSummaryResponse) String() string { return proto.CompactTextString(m) }
+func (*ParseStateSummaryResponse) ProtoMessage()    {}
+func (*ParseStateSummaryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{54}
+}
+
+func (m *ParseStateSummaryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParseStateSummaryResponse.Unmarshal(m, b)
+}
+func (m *ParseStateSummaryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParseStateSummaryResponse.Marshal(b, m, deterministic)
+}
+func (m *ParseStateSummaryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParseStateSummaryResponse.Merge(m, src)
+}
+func (m *ParseStateSummaryResponse) XXX_Size() int {
+	return xxx_messageInfo_ParseStateSummaryResponse.Size(m)
+}
+func (m *ParseStateSummaryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParseStateSummaryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParseStateSummaryResponse proto.InternalMessageInfo
+
+func (m *ParseStateSummaryResponse) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ParseStateSummaryResponse) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ParseStateSummaryResponse) GetBlkID() []byte {
+	if m != nil {
+		return m.BlkID
+	}
+	return nil
+}
+
+func (m *ParseStateSummaryResponse) GetNumChunks() uint32 {
+	if m != nil {
+		return m.NumChunks
+	}
+	return 0
+}
+
+type ShouldSyncStateRequest struct {
+	Summary              []byte   `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ShouldSyncStateRequest) Reset()         { *m = ShouldSyncStateRequest{} }
+func (m *ShouldSyncStateRequest) String() string { return proto.CompactTextString(m) }
+func (*ShouldSyncStateRequest) ProtoMessage()    {}
+func (*ShouldSyncStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{55}
+}
+
+func (m *ShouldSyncStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShouldSyncStateRequest.Unmarshal(m, b)
+}
+func (m *ShouldSyncStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShouldSyncStateRequest.Marshal(b, m, deterministic)
+}
+func (m *ShouldSyncStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShouldSyncStateRequest.Merge(m, src)
+}
+func (m *ShouldSyncStateRequest) XXX_Size() int {
+	return xxx_messageInfo_ShouldSyncStateRequest.Size(m)
+}
+func (m *ShouldSyncStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShouldSyncStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShouldSyncStateRequest proto.InternalMessageInfo
+
+func (m *ShouldSyncStateRequest) GetSummary() []byte {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+type ShouldSyncStateResponse struct {
+	ShouldSync           bool     `protobuf:"varint,1,opt,name=shouldSync,proto3" json:"shouldSync,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ShouldSyncStateResponse) Reset()         { *m = ShouldSyncStateResponse{} }
+func (m *ShouldSyncStateResponse) String() string { return proto.CompactTextString(m) }
+func (*ShouldSyncStateResponse) ProtoMessage()    {}
+func (*ShouldSyncStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{56}
+}
+
+func (m *ShouldSyncStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ShouldSyncStateResponse.Unmarshal(m, b)
+}
+func (m *ShouldSyncStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ShouldSyncStateResponse.Marshal(b, m, deterministic)
+}
+func (m *ShouldSyncStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ShouldSyncStateResponse.Merge(m, src)
+}
+func (m *ShouldSyncStateResponse) XXX_Size() int {
+	return xxx_messageInfo_ShouldSyncStateResponse.Size(m)
+}
+func (m *ShouldSyncStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ShouldSyncStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ShouldSyncStateResponse proto.InternalMessageInfo
+
+func (m *ShouldSyncStateResponse) GetShouldSync() bool {
+	if m != nil {
+		return m.ShouldSync
+	}
+	return false
+}
+
+type GetStateChunkRequest struct {
+	SummaryID            []byte   `protobuf:"bytes,1,opt,name=summaryID,proto3" json:"summaryID,omitempty"`
+	Index                uint32   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateChunkRequest) Reset()         { *m = GetStateChunkRequest{} }
+func (m *GetStateChunkRequest) String() string { return proto.CompactTextString(m) }
+func (*GetStateChunkRequest) ProtoMessage()    {}
+func (*GetStateChunkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{57}
+}
+
+func (m *GetStateChunkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateChunkRequest.Unmarshal(m, b)
+}
+func (m *GetStateChunkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateChunkRequest.Marshal(b, m, deterministic)
+}
+func (m *GetStateChunkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateChunkRequest.Merge(m, src)
+}
+func (m *GetStateChunkRequest) XXX_Size() int {
+	return xxx_messageInfo_GetStateChunkRequest.Size(m)
+}
+func (m *GetStateChunkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateChunkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateChunkRequest proto.InternalMessageInfo
+
+func (m *GetStateChunkRequest) GetSummaryID() []byte {
+	if m != nil {
+		return m.SummaryID
+	}
+	return nil
+}
+
+func (m *GetStateChunkRequest) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+type GetStateChunkResponse struct {
+	Chunk                []byte   `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetStateChunkResponse) Reset()         { *m = GetStateChunkResponse{} }
+func (m *GetStateChunkResponse) String() string { return proto.CompactTextString(m) }
+func (*GetStateChunkResponse) ProtoMessage()    {}
+func (*GetStateChunkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{58}
+}
+
+func (m *GetStateChunkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetStateChunkResponse.Unmarshal(m, b)
+}
+func (m *GetStateChunkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetStateChunkResponse.Marshal(b, m, deterministic)
+}
+func (m *GetStateChunkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetStateChunkResponse.Merge(m, src)
+}
+func (m *GetStateChunkResponse) XXX_Size() int {
+	return xxx_messageInfo_GetStateChunkResponse.Size(m)
+}
+func (m *GetStateChunkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetStateChunkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetStateChunkResponse proto.InternalMessageInfo
+
+func (m *GetStateChunkResponse) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
+type SyncStateChunkRequest struct {
+	Summary              []byte   `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	Index                uint32   `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Chunk                []byte   `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncStateChunkRequest) Reset()         { *m = SyncStateChunkRequest{} }
+func (m *SyncStateChunkRequest) String() string { return proto.CompactTextString(m) }
+func (*SyncStateChunkRequest) ProtoMessage()    {}
+func (*SyncStateChunkRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{59}
+}
+
+func (m *SyncStateChunkRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncStateChunkRequest.Unmarshal(m, b)
+}
+func (m *SyncStateChunkRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncStateChunkRequest.Marshal(b, m, deterministic)
+}
+func (m *SyncStateChunkRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncStateChunkRequest.Merge(m, src)
+}
+func (m *SyncStateChunkRequest) XXX_Size() int {
+	return xxx_messageInfo_SyncStateChunkRequest.Size(m)
+}
+func (m *SyncStateChunkRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncStateChunkRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncStateChunkRequest proto.InternalMessageInfo
+
+func (m *SyncStateChunkRequest) GetSummary() []byte {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+func (m *SyncStateChunkRequest) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *SyncStateChunkRequest) GetChunk() []byte {
+	if m != nil {
+		return m.Chunk
+	}
+	return nil
+}
+
+type SyncStateChunkResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SyncStateChunkResponse) Reset()         { *m = SyncStateChunkResponse{} }
+func (m *SyncStateChunkResponse) String() string { return proto.CompactTextString(m) }
+func (*SyncStateChunkResponse) ProtoMessage()    {}
+func (*SyncStateChunkResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{60}
+}
+
+func (m *SyncStateChunkResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SyncStateChunkResponse.Unmarshal(m, b)
+}
+func (m *SyncStateChunkResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SyncStateChunkResponse.Marshal(b, m, deterministic)
+}
+func (m *SyncStateChunkResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SyncStateChunkResponse.Merge(m, src)
+}
+func (m *SyncStateChunkResponse) XXX_Size() int {
+	return xxx_messageInfo_SyncStateChunkResponse.Size(m)
+}
+func (m *SyncStateChunkResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SyncStateChunkResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SyncStateChunkResponse proto.InternalMessageInfo
+
+type StateSyncedRequest struct {
+	Summary              []byte   `protobuf:"bytes,1,opt,name=summary,proto3" json:"summary,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateSyncedRequest) Reset()         { *m = StateSyncedRequest{} }
+func (m *StateSyncedRequest) String() string { return proto.CompactTextString(m) }
+func (*StateSyncedRequest) ProtoMessage()    {}
+func (*StateSyncedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{61}
+}
+
+func (m *StateSyncedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateSyncedRequest.Unmarshal(m, b)
+}
+func (m *StateSyncedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateSyncedRequest.Marshal(b, m, deterministic)
+}
+func (m *StateSyncedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateSyncedRequest.Merge(m, src)
+}
+func (m *StateSyncedRequest) XXX_Size() int {
+	return xxx_messageInfo_StateSyncedRequest.Size(m)
+}
+func (m *StateSyncedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateSyncedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateSyncedRequest proto.InternalMessageInfo
+
+func (m *StateSyncedRequest) GetSummary() []byte {
+	if m != nil {
+		return m.Summary
+	}
+	return nil
+}
+
+type StateSyncedResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateSyncedResponse) Reset()         { *m = StateSyncedResponse{} }
+func (m *StateSyncedResponse) String() string { return proto.CompactTextString(m) }
+func (*StateSyncedResponse) ProtoMessage()    {}
+func (*StateSyncedResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cab246c8c7c5372d, []int{62}
+}
+
+func (m *StateSyncedResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StateSyncedResponse.Unmarshal(m, b)
+}
+func (m *StateSyncedResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StateSyncedResponse.Marshal(b, m, deterministic)
+}
+func (m *StateSyncedResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateSyncedResponse.Merge(m, src)
+}
+func (m *StateSyncedResponse) XXX_Size() int {
+	return xxx_messageInfo_StateSyncedResponse.Size(m)
+}
+func (m *StateSyncedResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateSyncedResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateSyncedResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*InitializeRequest)(nil), "vmproto.InitializeRequest")
+	proto.RegisterType((*InitializeResponse)(nil), "vmproto.InitializeResponse")
+	proto.RegisterType((*BootstrappingRequest)(nil), "vmproto.BootstrappingRequest")
+	proto.RegisterType((*BootstrappingResponse)(nil), "vmproto.BootstrappingResponse")
+	proto.RegisterType((*BootstrappedRequest)(nil), "vmproto.BootstrappedRequest")
+	proto.RegisterType((*BootstrappedResponse)(nil), "vmproto.BootstrappedResponse")
+	proto.RegisterType((*ShutdownRequest)(nil), "vmproto.ShutdownRequest")
+	proto.RegisterType((*ShutdownResponse)(nil), "vmproto.ShutdownResponse")
+	proto.RegisterType((*CreateHandlersRequest)(nil), "vmproto.CreateHandlersRequest")
+	proto.RegisterType((*CreateHandlersResponse)(nil), "vmproto.CreateHandlersResponse")
+	proto.RegisterType((*Handler)(nil), "vmproto.Handler")
+	proto.RegisterType((*BuildBlockRequest)(nil), "vmproto.BuildBlockRequest")
+	proto.RegisterType((*BuildBlockResponse)(nil), "vmproto.BuildBlockResponse")
+	proto.RegisterType((*ParseBlockRequest)(nil), "vmproto.ParseBlockRequest")
+	proto.RegisterType((*ParseBlockResponse)(nil), "vmproto.ParseBlockResponse")
+	proto.RegisterType((*GetBlockRequest)(nil), "vmproto.GetBlockRequest")
+	proto.RegisterType((*GetBlockResponse)(nil), "vmproto.GetBlockResponse")
+	proto.RegisterType((*GetBlockIDAtHeightRequest)(nil), "vmproto.GetBlockIDAtHeightRequest")
+	proto.RegisterType((*GetBlockIDAtHeightResponse)(nil), "vmproto.GetBlockIDAtHeightResponse")
+	proto.RegisterType((*SetPreferenceRequest)(nil), "vmproto.SetPreferenceRequest")
+	proto.RegisterType((*SetPreferenceResponse)(nil), "vmproto.SetPreferenceResponse")
+	proto.RegisterType((*BlockVerifyRequest)(nil), "vmproto.BlockVerifyRequest")
+	proto.RegisterType((*BlockVerifyResponse)(nil), "vmproto.BlockVerifyResponse")
+	proto.RegisterType((*BlockAcceptRequest)(nil), "vmproto.BlockAcceptRequest")
+	proto.RegisterType((*BlockAcceptResponse)(nil), "vmproto.BlockAcceptResponse")
+	proto.RegisterType((*BlockRejectRequest)(nil), "vmproto.BlockRejectRequest")
+	proto.RegisterType((*BlockRejectResponse)(nil), "vmproto.BlockRejectResponse")
+	proto.RegisterType((*Tx)(nil), "vmproto.Tx")
+	proto.RegisterType((*PendingTxsRequest)(nil), "vmproto.PendingTxsRequest")
+	proto.RegisterType((*PendingTxsResponse)(nil), "vmproto.PendingTxsResponse")
+	proto.RegisterType((*ParseTxRequest)(nil), "vmproto.ParseTxRequest")
+	proto.RegisterType((*ParseTxResponse)(nil), "vmproto.ParseTxResponse")
+	proto.RegisterType((*GetTxRequest)(nil), "vmproto.GetTxRequest")
+	proto.RegisterType((*GetTxResponse)(nil), "vmproto.GetTxResponse")
+	proto.RegisterType((*TxVerifyRequest)(nil), "vmproto.TxVerifyRequest")
+	proto.RegisterType((*TxVerifyResponse)(nil), "vmproto.TxVerifyResponse")
+	proto.RegisterType((*TxAcceptRequest)(nil), "vmproto.TxAcceptRequest")
+	proto.RegisterType((*TxAcceptResponse)(nil), "vmproto.TxAcceptResponse")
+	proto.RegisterType((*TxRejectRequest)(nil), "vmproto.TxRejectRequest")
+	proto.RegisterType((*TxRejectResponse)(nil), "vmproto.TxRejectResponse")
+	proto.RegisterType((*TxDependenciesRequest)(nil), "vmproto.TxDependenciesRequest")
+	proto.RegisterType((*TxDependenciesResponse)(nil), "vmproto.TxDependenciesResponse")
+	proto.RegisterType((*TxInputIDsRequest)(nil), "vmproto.TxInputIDsRequest")
+	proto.RegisterType((*TxInputIDsResponse)(nil), "vmproto.TxInputIDsResponse")
+	proto.RegisterType((*AppRequestMsg)(nil), "vmproto.AppRequestMsg")
+	proto.RegisterType((*AppRequestFailedMsg)(nil), "vmproto.AppRequestFailedMsg")
+	proto.RegisterType((*AppResponseMsg)(nil), "vmproto.AppResponseMsg")
+	proto.RegisterType((*AppGossipMsg)(nil), "vmproto.AppGossipMsg")
+	proto.RegisterType((*EmptyMsg)(nil), "vmproto.EmptyMsg")
+	proto.RegisterType((*HealthRequest)(nil), "vmproto.HealthRequest")
+	proto.RegisterType((*HealthResponse)(nil), "vmproto.HealthResponse")
+	proto.RegisterType((*GetStateSummaryRequest)(nil), "vmproto.GetStateSummaryRequest")
+	proto.RegisterType((*GetStateSummaryResponse)(nil), "vmproto.GetStateSummaryResponse")
+	proto.RegisterType((*ParseStateSummaryRequest)(nil), "vmproto.ParseStateSummaryRequest")
+	proto.RegisterType((*ParseStateSummaryResponse)(nil), "vmproto.ParseStateSummaryResponse")
+	proto.RegisterType((*ShouldSyncStateRequest)(nil), "vmproto.ShouldSyncStateRequest")
+	proto.RegisterType((*ShouldSyncStateResponse)(nil), "vmproto.ShouldSyncStateResponse")
+	proto.RegisterType((*GetStateChunkRequest)(nil), "vmproto.GetStateChunkRequest")
+	proto.RegisterType((*GetStateChunkResponse)(nil), "vmproto.GetStateChunkResponse")
+	proto.RegisterType((*SyncStateChunkRequest)(nil), "vmproto.SyncStateChunkRequest")
+	proto.RegisterType((*SyncStateChunkResponse)(nil), "vmproto.SyncStateChunkResponse")
+	proto.RegisterType((*StateSyncedRequest)(nil), "vmproto.StateSyncedRequest")
+	proto.RegisterType((*StateSyncedResponse)(nil), "vmproto.StateSyncedResponse")
+}
+
+func init() {
+	proto.RegisterFile("vm.proto", fileDescriptor_cab246c8c7c5372d)
+}
+
+var fileDescriptor_cab246c8c7c5372d = []byte{
+	// 1691 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0x5f, 0x6f, 0xdb, 0x46,
+	0x12, 0x87, 0xa4, 0xd8, 0x96, 0xc7, 0x92, 0xff, 0xac, 0x2d, 0x99, 0xa6, 0x1d, 0xdb, 0x61, 0x82,
+	0x9c, 0x2f, 0xb8, 0x33, 0x02, 0x07, 0xf7, 0xc7, 0xc0, 0x1d, 0x02, 0xdb, 0x4a, 0x6c, 0x5d, 0xce,
+	0x77, 0xa9, 0x24, 0x04, 0x45, 0xdb, 0xa0, 0xa0, 0xc5, 0x8d, 0xc4, 0x5a, 0x22, 0x59, 0x2e, 0xe5,
+	0xc8, 0xfd, 0x04, 0x05, 0xfa, 0xb5, 0xfa, 0x8d, 0xfa, 0xd2, 0xc7, 0x82, 0xcb, 0x59, 0xee, 0x92,
+	0x5a, 0x3a, 0x48, 0x80, 0xa2, 0x2f, 0x79, 0xe3, 0xcc, 0xfc, 0xe6, 0xb7, 0xb3, 0xbb, 0xb3, 0x3b,
+	0xb3, 0x84, 0xea, 0xcd, 0xf8, 0x30, 0x08, 0xfd, 0xc8, 0x27, 0x0b, 0x37, 0x63, 0xfe, 0x61, 0xfd,
+	0x52, 0x81, 0xb5, 0xb6, 0xe7, 0x46, 0xae, 0x3d, 0x72, 0x7f, 0xa0, 0x1d, 0xfa, 0xfd, 0x84, 0xb2,
+	0x88, 0xec, 0xc0, 0xa2, 0x47, 0xa3, 0xf7, 0x7e, 0x78, 0xdd, 0x6e, 0x19, 0xa5, 0xfd, 0xd2, 0x41,
+	0xbd, 0x23, 0x15, 0xc4, 0x84, 0x2a, 0x9b, 0x5c, 0x79, 0x34, 0x6a, 0xb7, 0x8c, 0xf2, 0x7e, 0xe9,
+	0xa0, 0xd6, 0x49, 0x65, 0x62, 0xc0, 0x42, 0x7f, 0x68, 0xbb, 0x5e, 0xbb, 0x65, 0x54, 0xb8, 0x49,
+	0x88, 0xa4, 0x09, 0xf3, 0x9e, 0xef, 0xd0, 0x76, 0xcb, 0xb8, 0xc7, 0x0d, 0x28, 0xc5, 0x6c, 0xd3,
+	0x33, 0x74, 0x99, 0x4b, 0xd8, 0x84, 0x4c, 0xf6, 0x61, 0xc9, 0xbe, 0xb1, 0xa7, 0x27, 0x8c, 0xf1,
+	0xc1, 0xe6, 0xb9, 0x59, 0x55, 0x11, 0x0b, 0x6a, 0x03, 0xea, 0x51, 0xe6, 0xb2, 0xd3, 0xdb, 0x88,
+	0x32, 0x63, 0x81, 0x43, 0x32, 0xba, 0x78, 0x04, 0xe7, 0xaa, 0x4b, 0xc3, 0x1b, 0x1a, 0x1a, 0x55,
+	0x3e, 0x99, 0x54, 0x8e, 0xfd, 0xa9, 0x37, 0x70, 0x3d, 0x8a, 0xf6, 0x45, 0x6e, 0xcf, 0xe8, 0xc8,
+	0x63, 0x58, 0xbe, 0xa6, 0xb7, 0x2c, 0xf2, 0x43, 0x81, 0x02, 0x8e, 0xca, 0x69, 0xc9, 0x21, 0x10,
+	0x36, 0xb4, 0x43, 0xea, 0x5c, 0xd2, 0xb1, 0x1f, 0xde, 0x22, 0x76, 0x89, 0x63, 0x35, 0x96, 0x98,
+	0xf7, 0xaa, 0xff, 0x5f, 0xdf, 0xbf, 0x9e, 0x04, 0x88, 0xad, 0x25, 0xbc, 0x59, 0x6d, 0x8c, 0x63,
+	0x5e, 0x06, 0x57, 0x4f, 0x70, 0x59, 0x2d, 0x39, 0x80, 0x15, 0x3b, 0x08, 0xba, 0xd4, 0x73, 0x68,
+	0x88, 0xc0, 0x65, 0x0e, 0xcc, 0xab, 0xad, 0x1f, 0x4b, 0x40, 0xd4, 0x5d, 0x67, 0x81, 0xef, 0x31,
+	0x1a, 0x0f, 0x34, 0xb2, 0x59, 0x74, 0xd2, 0xef, 0xd3, 0x20, 0xa2, 0x0e, 0xee, 0x7d, 0xad, 0x93,
+	0xd3, 0x92, 0x47, 0x50, 0x67, 0x91, 0x1d, 0xd1, 0xee, 0xad, 0xd7, 0xb7, 0xaf, 0x46, 0x94, 0x67,
+	0x41, 0xb5, 0x93, 0x55, 0xc6, 0xa8, 0x21, 0x75, 0x07, 0xc3, 0xa8, 0xed, 0x39, 0x74, 0x4a, 0x1d,
+	0x9e, 0x10, 0xd5, 0x4e, 0x56, 0x69, 0x35, 0x61, 0xe3, 0xd4, 0xf7, 0x23, 0x16, 0x85, 0x76, 0x10,
+	0xb8, 0xde, 0x00, 0x53, 0xd0, 0xda, 0x84, 0x46, 0x4e, 0x9f, 0x04, 0x69, 0x35, 0x60, 0x5d, 0x1a,
+	0xa8, 0x23, 0xf0, 0x19, 0x1e, 0xea, 0xa4, 0xf0, 0x35, 0x58, 0xe9, 0x0e, 0x27, 0x91, 0xe3, 0xbf,
+	0xf7, 0x04, 0x94, 0xc0, 0xaa, 0x54, 0x21, 0x6c, 0x13, 0x1a, 0x67, 0x21, 0xb5, 0x23, 0x7a, 0x61,
+	0x7b, 0xce, 0x88, 0x86, 0x4c, 0x80, 0x5f, 0x42, 0x33, 0x6f, 0xc0, 0xd5, 0xfa, 0x0b, 0x54, 0x87,
+	0xa8, 0x33, 0x4a, 0xfb, 0x95, 0x83, 0xa5, 0xa3, 0xd5, 0x43, 0x3c, 0x56, 0x87, 0x08, 0xee, 0xa4,
+	0x08, 0xeb, 0x6b, 0x58, 0x40, 0x65, 0x7c, 0x12, 0x82, 0x90, 0xbe, 0x73, 0xa7, 0x7c, 0x79, 0x17,
+	0x3b, 0x28, 0xc5, 0xd9, 0x3e, 0xf2, 0xfb, 0xd7, 0xff, 0x0f, 0x22, 0xd7, 0xf7, 0x18, 0x5f, 0xd4,
+	0x7a, 0x47, 0x55, 0xc5, 0x9e, 0x2c, 0xd9, 0xd8, 0x0a, 0x37, 0xa2, 0x64, 0xad, 0xc3, 0xda, 0xe9,
+	0xc4, 0x1d, 0x39, 0xa7, 0x31, 0x58, 0x44, 0xfe, 0x06, 0x88, 0xaa, 0xc4, 0xa8, 0x97, 0xa1, 0xec,
+	0x3a, 0xb8, 0xaf, 0x65, 0xd7, 0x89, 0x0f, 0x47, 0x60, 0x87, 0xd4, 0x53, 0x0e, 0xb3, 0x90, 0xc9,
+	0x06, 0xcc, 0x5d, 0xf1, 0x53, 0x95, 0x1c, 0xe5, 0x44, 0xb0, 0xfe, 0x0c, 0x6b, 0xaf, 0xed, 0x90,
+	0x51, 0x75, 0x30, 0x09, 0x2d, 0xa9, 0xd0, 0x2f, 0x81, 0xa8, 0xd0, 0x4f, 0x08, 0x21, 0x9e, 0x71,
+	0x64, 0x47, 0x13, 0x96, 0xce, 0x98, 0x4b, 0xd6, 0x03, 0x58, 0x39, 0xa7, 0x51, 0x26, 0x84, 0x1c,
+	0xad, 0xf5, 0x0d, 0xac, 0x4a, 0x08, 0x0e, 0xad, 0x0e, 0x55, 0x2a, 0x9a, 0x6d, 0x59, 0x99, 0x42,
+	0x61, 0x00, 0xcf, 0x60, 0x4b, 0xb0, 0xb7, 0x5b, 0x27, 0xd1, 0x05, 0x4f, 0x6a, 0x11, 0x4a, 0x13,
+	0xe6, 0x93, 0x2c, 0xe7, 0x83, 0xdc, 0xeb, 0xa0, 0x64, 0x1d, 0x81, 0xa9, 0x73, 0xc2, 0xe0, 0xe2,
+	0x00, 0x46, 0xd7, 0x69, 0x64, 0x89, 0x60, 0x3d, 0x86, 0x8d, 0x2e, 0x8d, 0x5e, 0x87, 0xf4, 0x1d,
+	0x0d, 0xa9, 0xd7, 0xa7, 0x45, 0xd3, 0xdd, 0x84, 0x46, 0x0e, 0x87, 0xa9, 0xfd, 0x08, 0x08, 0x1f,
+	0xf1, 0x0d, 0x0d, 0xdd, 0x77, 0xb7, 0x45, 0xee, 0xf1, 0xb1, 0x52, 0x51, 0x39, 0xe7, 0xe4, 0xf4,
+	0x7f, 0xc8, 0x59, 0xa0, 0x72, 0xce, 0x1d, 0xfa, 0x1d, 0xed, 0x7f, 0xd0, 0x59, 0xa0, 0xd0, 0xf9,
+	0x14, 0xca, 0xbd, 0xe9, 0x4c, 0xae, 0x7c, 0xdc, 0x26, 0xad, 0xc3, 0xda, 0x6b, 0xea, 0x39, 0xae,
+	0x37, 0xe8, 0x4d, 0xd3, 0x13, 0xfd, 0x0c, 0x88, 0xaa, 0xc4, 0xc5, 0xbf, 0x0f, 0x95, 0x68, 0x2a,
+	0x0e, 0xf2, 0x52, 0x7a, 0x90, 0x7b, 0xd3, 0x4e, 0xac, 0xb7, 0x1e, 0xc3, 0x32, 0xcf, 0xe4, 0xde,
+	0xf4, 0xee, 0x8c, 0x3f, 0x86, 0x95, 0x14, 0x57, 0x90, 0xee, 0x32, 0xd8, 0x72, 0x26, 0xd8, 0x5d,
+	0xa8, 0x9d, 0xd3, 0x48, 0x0e, 0x90, 0x5f, 0xa7, 0x7f, 0x43, 0x1d, 0xed, 0x4a, 0xbe, 0xcc, 0x44,
+	0x50, 0x48, 0xff, 0x00, 0x56, 0x7a, 0xd3, 0xbb, 0x73, 0x80, 0xc0, 0xaa, 0x84, 0xe0, 0x36, 0x70,
+	0xb7, 0xbb, 0x77, 0x9f, 0xbb, 0xe5, 0xb6, 0x9e, 0xbb, 0xdd, 0xbd, 0xef, 0xdc, 0x2d, 0xb7, 0xe9,
+	0x7f, 0x82, 0x46, 0x6f, 0xda, 0xa2, 0x41, 0x5c, 0xac, 0xbc, 0xbe, 0x4b, 0x59, 0x91, 0xf3, 0x13,
+	0x68, 0xe6, 0x81, 0xb8, 0x2a, 0xab, 0x50, 0x71, 0x9d, 0x64, 0x23, 0x6b, 0x9d, 0xf8, 0xd3, 0x7a,
+	0x08, 0x6b, 0xbd, 0x69, 0xdb, 0x0b, 0x26, 0x51, 0xbb, 0x55, 0x48, 0xf8, 0x14, 0x88, 0x0a, 0x92,
+	0xf7, 0x85, 0x8b, 0x3a, 0x64, 0x4c, 0x65, 0xeb, 0x5b, 0xa8, 0x9f, 0x04, 0x01, 0xf2, 0x5d, 0xb2,
+	0x81, 0xd2, 0xe1, 0x94, 0x32, 0x1d, 0xce, 0x0e, 0x2c, 0x86, 0x09, 0x0a, 0x2f, 0xb8, 0x7a, 0x47,
+	0x2a, 0xe2, 0x8e, 0x09, 0x05, 0xd1, 0x31, 0xa1, 0x68, 0xbd, 0x82, 0x75, 0x39, 0xc0, 0x4b, 0xdb,
+	0x1d, 0x51, 0xe7, 0x93, 0x87, 0xb1, 0xae, 0x60, 0x99, 0x93, 0x25, 0x13, 0xfb, 0xf4, 0x70, 0x4d,
+	0xa8, 0x86, 0x48, 0x82, 0xf1, 0xa6, 0xb2, 0xf5, 0x4f, 0xa8, 0x9d, 0x04, 0xc1, 0xb9, 0xcf, 0x98,
+	0x1b, 0xdc, 0x35, 0xc2, 0x2a, 0x54, 0xc6, 0x6c, 0x80, 0x47, 0x38, 0xfe, 0xb4, 0x00, 0xaa, 0x2f,
+	0xc6, 0x41, 0x74, 0x7b, 0xc9, 0x06, 0xd6, 0x0a, 0xd4, 0x2f, 0xa8, 0x3d, 0x8a, 0x86, 0xe2, 0xc0,
+	0x3e, 0x81, 0x65, 0xa1, 0xc0, 0x6d, 0x31, 0x60, 0xc1, 0xa1, 0x91, 0xed, 0x8e, 0x18, 0x96, 0x50,
+	0x21, 0x5a, 0x06, 0x34, 0xcf, 0x69, 0xd4, 0xe5, 0x8d, 0xc8, 0x64, 0x3c, 0xb6, 0x43, 0x91, 0xec,
+	0xd6, 0x4f, 0x25, 0xd8, 0x9c, 0x31, 0x15, 0x1f, 0x51, 0xbc, 0xbf, 0xcb, 0xea, 0xfd, 0x2d, 0x6f,
+	0xe8, 0x8a, 0x72, 0x43, 0xf3, 0x6e, 0x79, 0x32, 0x3e, 0x1b, 0x4e, 0xbc, 0x6b, 0x66, 0xdc, 0xc3,
+	0x6e, 0x59, 0x28, 0xe4, 0x29, 0x9d, 0x53, 0xef, 0x89, 0xa7, 0x60, 0xf0, 0x7b, 0x42, 0x13, 0x69,
+	0xc1, 0xcd, 0xf2, 0x1e, 0xb6, 0x34, 0x1e, 0xbf, 0xff, 0x04, 0xac, 0x23, 0x68, 0x76, 0x87, 0xfe,
+	0x64, 0xe4, 0xc4, 0x9d, 0x1d, 0x1f, 0x5d, 0x04, 0x6a, 0xc0, 0x02, 0x4b, 0x02, 0xc1, 0xa1, 0x85,
+	0x68, 0x1d, 0xc3, 0xe6, 0x8c, 0x0f, 0x86, 0xba, 0x0b, 0xc0, 0x52, 0x13, 0xf7, 0xab, 0x76, 0x14,
+	0x8d, 0xf5, 0x1f, 0xd8, 0x10, 0xdb, 0xc4, 0x03, 0x50, 0xde, 0x24, 0xc8, 0x9e, 0xe6, 0x93, 0x54,
+	0xc4, 0x13, 0x73, 0xe3, 0x8e, 0x12, 0x13, 0x36, 0x11, 0xac, 0xbf, 0x42, 0x23, 0xc7, 0x25, 0xaf,
+	0xce, 0x7e, 0xac, 0x10, 0x4b, 0xcc, 0x05, 0xeb, 0x2d, 0x34, 0xd2, 0x78, 0x33, 0x63, 0x17, 0x4e,
+	0x54, 0x3f, 0xae, 0xa4, 0xaf, 0xa8, 0xf4, 0x06, 0x34, 0xf3, 0xf4, 0x78, 0x70, 0x0e, 0x81, 0x74,
+	0x45, 0xef, 0x4c, 0x9d, 0x0f, 0x8e, 0x1a, 0x97, 0xcc, 0x0c, 0x3e, 0xa1, 0x39, 0xfa, 0xb5, 0x0e,
+	0xe5, 0x37, 0x97, 0xe4, 0x05, 0x80, 0x6c, 0xee, 0x89, 0x99, 0xd6, 0xb2, 0x99, 0x77, 0x9e, 0xb9,
+	0xad, 0xb5, 0xe1, 0x1a, 0xfd, 0x0f, 0xea, 0x99, 0x0e, 0x9c, 0xdc, 0x4f, 0xd1, 0xba, 0x8e, 0xdd,
+	0xdc, 0x2d, 0x32, 0x23, 0xdf, 0x2b, 0xa8, 0xa9, 0x1d, 0x3a, 0xd9, 0xd1, 0xe0, 0xd3, 0xc9, 0x9b,
+	0xf7, 0x0b, 0xac, 0x48, 0xf6, 0x1c, 0xaa, 0xa2, 0x87, 0x27, 0x46, 0x0a, 0xcd, 0x75, 0xfa, 0xe6,
+	0x96, 0xc6, 0x82, 0x04, 0x5f, 0xc0, 0x72, 0xb6, 0xaf, 0x27, 0x32, 0x7e, 0xed, 0x4b, 0xc0, 0xdc,
+	0x2b, 0xb4, 0x23, 0xe5, 0x0b, 0x00, 0xd9, 0x70, 0x2b, 0xeb, 0x3e, 0xd3, 0x9a, 0x9b, 0xdb, 0x5a,
+	0x9b, 0xa4, 0x91, 0x4d, 0xb3, 0x42, 0x33, 0xd3, 0x74, 0x9b, 0xdb, 0x5a, 0x9b, 0x5c, 0x21, 0xd1,
+	0x6b, 0x2a, 0x2b, 0x94, 0x6b, 0x9a, 0xcd, 0x2d, 0x8d, 0x05, 0x09, 0xde, 0x02, 0x99, 0x6d, 0x56,
+	0x89, 0x35, 0xe3, 0x30, 0xd3, 0xfe, 0x9a, 0x0f, 0xef, 0xc4, 0xc8, 0xf4, 0xca, 0xf4, 0xab, 0x4a,
+	0x7a, 0xe9, 0xfa, 0x5d, 0x73, 0xb7, 0xc8, 0x8c, 0x7c, 0xc7, 0x30, 0x9f, 0x54, 0x09, 0xd2, 0x94,
+	0xcf, 0x30, 0xb5, 0x8e, 0x98, 0x9b, 0x33, 0x7a, 0x74, 0xfd, 0x07, 0x80, 0x2c, 0xb4, 0x8a, 0x7b,
+	0xa6, 0xbc, 0x9b, 0x6b, 0xa9, 0x5e, 0x94, 0x2a, 0x72, 0x06, 0xab, 0xf9, 0x0a, 0xad, 0xa4, 0xb5,
+	0xa6, 0x78, 0xeb, 0x48, 0x8e, 0x61, 0x49, 0xa9, 0xcc, 0x64, 0x33, 0xeb, 0x9f, 0xd6, 0x6b, 0x9d,
+	0xeb, 0xdf, 0x60, 0x31, 0x2d, 0xb8, 0xa4, 0xa1, 0x3a, 0xa6, 0x45, 0x58, 0xe7, 0x76, 0x01, 0x4b,
+	0x4a, 0xaf, 0x4f, 0x94, 0x6c, 0x9c, 0x79, 0x27, 0x98, 0x3b, 0x7a, 0x23, 0x06, 0x2b, 0x98, 0x92,
+	0xee, 0x2f, 0xcf, 0x94, 0x69, 0x1b, 0xcd, 0x1d, 0xbd, 0x31, 0xc7, 0x94, 0x34, 0x84, 0x79, 0xa6,
+	0x4c, 0x27, 0x69, 0xee, 0xe8, 0x8d, 0xc8, 0xd4, 0xe3, 0x4f, 0x43, 0xb5, 0x4c, 0x92, 0x3d, 0x35,
+	0x21, 0x35, 0x25, 0xd7, 0xdc, 0x2f, 0x06, 0x20, 0xeb, 0x57, 0xf8, 0xea, 0xcd, 0xf0, 0x3e, 0xc8,
+	0x1e, 0x40, 0x1d, 0xb3, 0x75, 0x17, 0x44, 0x46, 0x9c, 0xab, 0x96, 0x4a, 0xc4, 0xfa, 0xda, 0x6b,
+	0xee, 0x17, 0x03, 0xe4, 0x01, 0xcb, 0x14, 0x3f, 0xe5, 0x80, 0xe9, 0x0a, 0xac, 0xb9, 0x5b, 0x64,
+	0x96, 0x37, 0x66, 0xb6, 0x7c, 0x29, 0x37, 0xa6, 0xb6, 0x6c, 0x9a, 0x7b, 0x85, 0x76, 0xb9, 0xe9,
+	0x4a, 0x1d, 0x53, 0x36, 0x7d, 0xb6, 0x1a, 0x9a, 0x3b, 0x7a, 0x23, 0x96, 0xbe, 0x9f, 0x17, 0x61,
+	0xae, 0x75, 0x72, 0xfe, 0xb9, 0xfa, 0xfd, 0x91, 0xd5, 0x4f, 0x3e, 0xab, 0xd5, 0xb2, 0x95, 0x7f,
+	0x80, 0x9b, 0xdb, 0x5a, 0x1b, 0xd2, 0xfc, 0x0b, 0x16, 0xf0, 0x01, 0xad, 0xdc, 0x84, 0xd9, 0xa7,
+	0xb7, 0x69, 0xcc, 0x1a, 0xd0, 0xfb, 0xef, 0x30, 0xc7, 0xdf, 0xc8, 0xca, 0x65, 0xa8, 0xbe, 0xa9,
+	0xcd, 0x66, 0x5e, 0xfd, 0xb9, 0x78, 0x7c, 0x6c, 0xf1, 0x78, 0x0e, 0x55, 0xf1, 0x93, 0x40, 0xc9,
+	0xbd, 0xdc, 0xaf, 0x05, 0x73, 0x4b, 0x63, 0x91, 0xc9, 0x2b, 0x7e, 0x17, 0x64, 0x08, 0xb2, 0xd5,
+	0x62, 0x4b, 0x63, 0x51, 0x09, 0xb0, 0x4e, 0xa8, 0x04, 0xd9, 0x22, 0xb1, 0xa5, 0xb1, 0xc8, 0xec,
+	0xcf, 0xfe, 0x3c, 0x50, 0xb2, 0x5f, 0xfb, 0xfb, 0xc1, 0xdc, 0x2b, 0xb4, 0xcb, 0xec, 0x97, 0xbf,
+	0x0f, 0x94, 0xec, 0x9f, 0xf9, 0xf1, 0x60, 0x6e, 0x6b, 0x6d, 0x09, 0xcd, 0xd5, 0x3c, 0xb7, 0x3c,
+	0xfb, 0x6d, 0x00, 0x49, 0x5c, 0xf7, 0x48, 0xaa, 0x19, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// VMClient is the client API for VM service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type VMClient interface {
+	Initialize(ctx context.Context, in *InitializeRequest, opts ...grpc.CallOption) (*InitializeResponse, error)
+	Bootstrapping(ctx context.Context, in *BootstrappingRequest, opts ...grpc.CallOption) (*BootstrappingResponse, error)
+	Bootstrapped(ctx context.Context, in *BootstrappedRequest, opts ...grpc.CallOption) (*BootstrappedResponse, error)
+	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	CreateHandlers(ctx context.Context, in *CreateHandlersRequest, opts ...grpc.CallOption) (*CreateHandlersResponse, error)
+	BuildBlock(ctx context.Context, in *BuildBlockRequest, opts ...grpc.CallOption) (*BuildBlockResponse, error)
+	ParseBlock(ctx context.Context, in *ParseBlockRequest, opts ...grpc.CallOption) (*ParseBlockResponse, error)
+	GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error)
+	GetBlockIDAtHeight(ctx context.Context, in *GetBlockIDAtHeightRequest, opts ...grpc.CallOption) (*GetBlockIDAtHeightResponse, error)
+	SetPreference(ctx context.Context, in *SetPreferenceRequest, opts ...grpc.CallOption) (*SetPreferenceResponse, error)
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	AppRequest(ctx context.Context, in *AppRequestMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+	AppRequestFailed(ctx context.Context, in *AppRequestFailedMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+	AppResponse(ctx context.Context, in *AppResponseMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+	AppGossip(ctx context.Context, in *AppGossipMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+	BlockVerify(ctx context.Context, in *BlockVerifyRequest, opts ...grpc.CallOption) (*BlockVerifyResponse, error)
+	BlockAccept(ctx context.Context, in *BlockAcceptRequest, opts ...grpc.CallOption) (*BlockAcceptResponse, error)
+	BlockReject(ctx context.Context, in *BlockRejectRequest, opts ...grpc.CallOption) (*BlockRejectResponse, error)
+	GetStateSummary(ctx context.Context, in *GetStateSummaryRequest, opts ...grpc.CallOption) (*GetStateSummaryResponse, error)
+	ParseStateSummary(ctx context.Context, in *ParseStateSummaryRequest, opts ...grpc.CallOption) (*ParseStateSummaryResponse, error)
+	ShouldSyncState(ctx context.Context, in *ShouldSyncStateRequest, opts ...grpc.CallOption) (*ShouldSyncStateResponse, error)
+	GetStateChunk(ctx context.Context, in *GetStateChunkRequest, opts ...grpc.CallOption) (*GetStateChunkResponse, error)
+	SyncStateChunk(ctx context.Context, in *SyncStateChunkRequest, opts ...grpc.CallOption) (*SyncStateChunkResponse, error)
+	StateSynced(ctx context.Context, in *StateSyncedRequest, opts ...grpc.CallOption) (*StateSyncedResponse, error)
+}
+
+type vMClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVMClient(cc grpc.ClientConnInterface) VMClient {
+	return &vMClient{cc}
+}
+
+func (c *vMClient) Initialize(ctx context.Context, in *InitializeRequest, opts ...grpc.CallOption) (*InitializeResponse, error) {
+	out := new(InitializeResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/Initialize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) Bootstrapping(ctx context.Context, in *BootstrappingRequest, opts ...grpc.CallOption) (*BootstrappingResponse, error) {
+	out := new(BootstrappingResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/Bootstrapping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) Bootstrapped(ctx context.Context, in *BootstrappedRequest, opts ...grpc.CallOption) (*BootstrappedResponse, error) {
+	out := new(BootstrappedResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/Bootstrapped", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
+	out := new(ShutdownResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/Shutdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) CreateHandlers(ctx context.Context, in *CreateHandlersRequest, opts ...grpc.CallOption) (*CreateHandlersResponse, error) {
+	out := new(CreateHandlersResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/CreateHandlers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 func (c *vMClient) BuildBlock(ctx context.Context, in *BuildBlockRequest, opts ...grpc.CallOption) (*BuildBlockResponse, error) {
@@ -1314,423 +2938,1492 @@ func (c *vMClient) BuildBlock(ctx context.Context, in *BuildBlockRequest, opts .
 	return out, nil
 }
 
-func (c *vMClient) ParseBlock(ctx context.Context, in *ParseBlockRequest, opts ...grpc.CallOption) (*ParseBlockResponse, error) {
-	out := new(ParseBlockResponse)
-	err := c.cc.Invoke(ctx, "/vmproto.VM/ParseBlock", in, out, opts...)
+func (c *vMClient) ParseBlock(ctx context.Context, in *ParseBlockRequest, opts ...grpc.CallOption) (*ParseBlockResponse, error) {
+	out := new(ParseBlockResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/ParseBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error) {
+	out := new(GetBlockResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/GetBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) GetBlockIDAtHeight(ctx context.Context, in *GetBlockIDAtHeightRequest, opts ...grpc.CallOption) (*GetBlockIDAtHeightResponse, error) {
+	out := new(GetBlockIDAtHeightResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/GetBlockIDAtHeight", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) SetPreference(ctx context.Context, in *SetPreferenceRequest, opts ...grpc.CallOption) (*SetPreferenceResponse, error) {
+	out := new(SetPreferenceResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/SetPreference", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) AppRequest(ctx context.Context, in *AppRequestMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/AppRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) AppRequestFailed(ctx context.Context, in *AppRequestFailedMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/AppRequestFailed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) AppResponse(ctx context.Context, in *AppResponseMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/AppResponse", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) AppGossip(ctx context.Context, in *AppGossipMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/AppGossip", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) BlockVerify(ctx context.Context, in *BlockVerifyRequest, opts ...grpc.CallOption) (*BlockVerifyResponse, error) {
+	out := new(BlockVerifyResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/BlockVerify", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) BlockAccept(ctx context.Context, in *BlockAcceptRequest, opts ...grpc.CallOption) (*BlockAcceptResponse, error) {
+	out := new(BlockAcceptResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/BlockAccept", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) BlockReject(ctx context.Context, in *BlockRejectRequest, opts ...grpc.CallOption) (*BlockRejectResponse, error) {
+	out := new(BlockRejectResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/BlockReject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) GetStateSummary(ctx context.Context, in *GetStateSummaryRequest, opts ...grpc.CallOption) (*GetStateSummaryResponse, error) {
+	out := new(GetStateSummaryResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/GetStateSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) ParseStateSummary(ctx context.Context, in *ParseStateSummaryRequest, opts ...grpc.CallOption) (*ParseStateSummaryResponse, error) {
+	out := new(ParseStateSummaryResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/ParseStateSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) ShouldSyncState(ctx context.Context, in *ShouldSyncStateRequest, opts ...grpc.CallOption) (*ShouldSyncStateResponse, error) {
+	out := new(ShouldSyncStateResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/ShouldSyncState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) GetStateChunk(ctx context.Context, in *GetStateChunkRequest, opts ...grpc.CallOption) (*GetStateChunkResponse, error) {
+	out := new(GetStateChunkResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/GetStateChunk", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) SyncStateChunk(ctx context.Context, in *SyncStateChunkRequest, opts ...grpc.CallOption) (*SyncStateChunkResponse, error) {
+	out := new(SyncStateChunkResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/SyncStateChunk", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vMClient) StateSynced(ctx context.Context, in *StateSyncedRequest, opts ...grpc.CallOption) (*StateSyncedResponse, error) {
+	out := new(StateSyncedResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.VM/StateSynced", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VMServer is the server API for VM service.
+type VMServer interface {
+	Initialize(context.Context, *InitializeRequest) (*InitializeResponse, error)
+	Bootstrapping(context.Context, *BootstrappingRequest) (*BootstrappingResponse, error)
+	Bootstrapped(context.Context, *BootstrappedRequest) (*BootstrappedResponse, error)
+	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
+	CreateHandlers(context.Context, *CreateHandlersRequest) (*CreateHandlersResponse, error)
+	BuildBlock(context.Context, *BuildBlockRequest) (*BuildBlockResponse, error)
+	ParseBlock(context.Context, *ParseBlockRequest) (*ParseBlockResponse, error)
+	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
+	GetBlockIDAtHeight(context.Context, *GetBlockIDAtHeightRequest) (*GetBlockIDAtHeightResponse, error)
+	SetPreference(context.Context, *SetPreferenceRequest) (*SetPreferenceResponse, error)
+	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	AppRequest(context.Context, *AppRequestMsg) (*EmptyMsg, error)
+	AppRequestFailed(context.Context, *AppRequestFailedMsg) (*EmptyMsg, error)
+	AppResponse(context.Context, *AppResponseMsg) (*EmptyMsg, error)
+	AppGossip(context.Context, *AppGossipMsg) (*EmptyMsg, error)
+	BlockVerify(context.Context, *BlockVerifyRequest) (*BlockVerifyResponse, error)
+	BlockAccept(context.Context, *BlockAcceptRequest) (*BlockAcceptResponse, error)
+	BlockReject(context.Context, *BlockRejectRequest) (*BlockRejectResponse, error)
+	GetStateSummary(context.Context, *GetStateSummaryRequest) (*GetStateSummaryResponse, error)
+	ParseStateSummary(context.Context, *ParseStateSummaryRequest) (*ParseStateSummaryResponse, error)
+	ShouldSyncState(context.Context, *ShouldSyncStateRequest) (*ShouldSyncStateResponse, error)
+	GetStateChunk(context.Context, *GetStateChunkRequest) (*GetStateChunkResponse, error)
+	SyncStateChunk(context.Context, *SyncStateChunkRequest) (*SyncStateChunkResponse, error)
+	StateSynced(context.Context, *StateSyncedRequest) (*StateSyncedResponse, error)
+}
+
+// UnimplementedVMServer can be embedded to have forward compatible implementations.
+type UnimplementedVMServer struct {
+}
+
+func (*UnimplementedVMServer) Initialize(ctx context.Context, req *InitializeRequest) (*InitializeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Initialize not implemented")
+}
+func (*UnimplementedVMServer) Bootstrapping(ctx context.Context, req *BootstrappingRequest) (*BootstrappingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Bootstrapping not implemented")
+}
+func (*UnimplementedVMServer) Bootstrapped(ctx context.Context, req *BootstrappedRequest) (*BootstrappedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Bootstrapped not implemented")
+}
+func (*UnimplementedVMServer) Shutdown(ctx context.Context, req *ShutdownRequest) (*ShutdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (*UnimplementedVMServer) CreateHandlers(ctx context.Context, req *CreateHandlersRequest) (*CreateHandlersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateHandlers not implemented")
+}
+func (*UnimplementedVMServer) BuildBlock(ctx context.Context, req *BuildBlockRequest) (*BuildBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BuildBlock not implemented")
+}
+func (*UnimplementedVMServer) ParseBlock(ctx context.Context, req *ParseBlockRequest) (*ParseBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ParseBlock not implemented")
+}
+func (*UnimplementedVMServer) GetBlock(ctx context.Context, req *GetBlockRequest) (*GetBlockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (*UnimplementedVMServer) GetBlockIDAtHeight(ctx context.Context, req *GetBlockIDAtHeightRequest) (*GetBlockIDAtHeightResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockIDAtHeight not implemented")
+}
+func (*UnimplementedVMServer) SetPreference(ctx context.Context, req *SetPreferenceRequest) (*SetPreferenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPreference not implemented")
+}
+func (*UnimplementedVMServer) Health(ctx context.Context, req *HealthRequest) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
+func (*UnimplementedVMServer) AppRequest(ctx context.Context, req *AppRequestMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppRequest not implemented")
+}
+func (*UnimplementedVMServer) AppRequestFailed(ctx context.Context, req *AppRequestFailedMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppRequestFailed not implemented")
+}
+func (*UnimplementedVMServer) AppResponse(ctx context.Context, req *AppResponseMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppResponse not implemented")
+}
+func (*UnimplementedVMServer) AppGossip(ctx context.Context, req *AppGossipMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppGossip not implemented")
+}
+func (*UnimplementedVMServer) BlockVerify(ctx context.Context, req *BlockVerifyRequest) (*BlockVerifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockVerify not implemented")
+}
+func (*UnimplementedVMServer) BlockAccept(ctx context.Context, req *BlockAcceptRequest) (*BlockAcceptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockAccept not implemented")
+}
+func (*UnimplementedVMServer) BlockReject(ctx context.Context, req *BlockRejectRequest) (*BlockRejectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockReject not implemented")
+}
+func (*UnimplementedVMServer) GetStateSummary(ctx context.Context, req *GetStateSummaryRequest) (*GetStateSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStateSummary not implemented")
+}
+func (*UnimplementedVMServer) ParseStateSummary(ctx context.Context, req *ParseStateSummaryRequest) (*ParseStateSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ParseStateSummary not implemented")
+}
+func (*UnimplementedVMServer) ShouldSyncState(ctx context.Context, req *ShouldSyncStateRequest) (*ShouldSyncStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShouldSyncState not implemented")
+}
+func (*UnimplementedVMServer) GetStateChunk(ctx context.Context, req *GetStateChunkRequest) (*GetStateChunkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStateChunk not implemented")
+}
+func (*UnimplementedVMServer) SyncStateChunk(ctx context.Context, req *SyncStateChunkRequest) (*SyncStateChunkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncStateChunk not implemented")
+}
+func (*UnimplementedVMServer) StateSynced(ctx context.Context, req *StateSyncedRequest) (*StateSyncedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StateSynced not implemented")
+}
+
+func RegisterVMServer(s *grpc.Server, srv VMServer) {
+	s.RegisterService(&_VM_serviceDesc, srv)
+}
+
+func _VM_Initialize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InitializeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).Initialize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/Initialize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).Initialize(ctx, req.(*InitializeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_Bootstrapping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BootstrappingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).Bootstrapping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/Bootstrapping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).Bootstrapping(ctx, req.(*BootstrappingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_Bootstrapped_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BootstrappedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).Bootstrapped(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/Bootstrapped",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).Bootstrapped(ctx, req.(*BootstrappedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/Shutdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).Shutdown(ctx, req.(*ShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_CreateHandlers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHandlersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).CreateHandlers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/CreateHandlers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).CreateHandlers(ctx, req.(*CreateHandlersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_BuildBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuildBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).BuildBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/BuildBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).BuildBlock(ctx, req.(*BuildBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_ParseBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).ParseBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/ParseBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).ParseBlock(ctx, req.(*ParseBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).GetBlock(ctx, req.(*GetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_GetBlockIDAtHeight_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockIDAtHeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).GetBlockIDAtHeight(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/GetBlockIDAtHeight",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).GetBlockIDAtHeight(ctx, req.(*GetBlockIDAtHeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_SetPreference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPreferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).SetPreference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/SetPreference",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).SetPreference(ctx, req.(*SetPreferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).Health(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_AppRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppRequestMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).AppRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/AppRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).AppRequest(ctx, req.(*AppRequestMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_AppRequestFailed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppRequestFailedMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).AppRequestFailed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/AppRequestFailed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).AppRequestFailed(ctx, req.(*AppRequestFailedMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_AppResponse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppResponseMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).AppResponse(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/AppResponse",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).AppResponse(ctx, req.(*AppResponseMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_AppGossip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppGossipMsg)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).AppGossip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/AppGossip",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).AppGossip(ctx, req.(*AppGossipMsg))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_BlockVerify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockVerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).BlockVerify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/BlockVerify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).BlockVerify(ctx, req.(*BlockVerifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_BlockAccept_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockAcceptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).BlockAccept(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/BlockAccept",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).BlockAccept(ctx, req.(*BlockAcceptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_BlockReject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRejectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).BlockReject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/BlockReject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).BlockReject(ctx, req.(*BlockRejectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_GetStateSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).GetStateSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/GetStateSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).GetStateSummary(ctx, req.(*GetStateSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_ParseStateSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseStateSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).ParseStateSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/ParseStateSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).ParseStateSummary(ctx, req.(*ParseStateSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_ShouldSyncState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShouldSyncStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).ShouldSyncState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/ShouldSyncState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).ShouldSyncState(ctx, req.(*ShouldSyncStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_GetStateChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStateChunkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).GetStateChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/GetStateChunk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).GetStateChunk(ctx, req.(*GetStateChunkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_SyncStateChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncStateChunkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).SyncStateChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/SyncStateChunk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).SyncStateChunk(ctx, req.(*SyncStateChunkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VM_StateSynced_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateSyncedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VMServer).StateSynced(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.VM/StateSynced",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VMServer).StateSynced(ctx, req.(*StateSyncedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _VM_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "vmproto.VM",
+	HandlerType: (*VMServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Initialize",
+			Handler:    _VM_Initialize_Handler,
+		},
+		{
+			MethodName: "Bootstrapping",
+			Handler:    _VM_Bootstrapping_Handler,
+		},
+		{
+			MethodName: "Bootstrapped",
+			Handler:    _VM_Bootstrapped_Handler,
+		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _VM_Shutdown_Handler,
+		},
+		{
+			MethodName: "CreateHandlers",
+			Handler:    _VM_CreateHandlers_Handler,
+		},
+		{
+			MethodName: "BuildBlock",
+			Handler:    _VM_BuildBlock_Handler,
+		},
+		{
+			MethodName: "ParseBlock",
+			Handler:    _VM_ParseBlock_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _VM_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetBlockIDAtHeight",
+			Handler:    _VM_GetBlockIDAtHeight_Handler,
+		},
+		{
+			MethodName: "SetPreference",
+			Handler:    _VM_SetPreference_Handler,
+		},
+		{
+			MethodName: "Health",
+			Handler:    _VM_Health_Handler,
+		},
+		{
+			MethodName: "AppRequest",
+			Handler:    _VM_AppRequest_Handler,
+		},
+		{
+			MethodName: "AppRequestFailed",
+			Handler:    _VM_AppRequestFailed_Handler,
+		},
+		{
+			MethodName: "AppResponse",
+			Handler:    _VM_AppResponse_Handler,
+		},
+		{
+			MethodName: "AppGossip",
+			Handler:    _VM_AppGossip_Handler,
+		},
+		{
+			MethodName: "BlockVerify",
+			Handler:    _VM_BlockVerify_Handler,
+		},
+		{
+			MethodName: "BlockAccept",
+			Handler:    _VM_BlockAccept_Handler,
+		},
+		{
+			MethodName: "BlockReject",
+			Handler:    _VM_BlockReject_Handler,
+		},
+		{
+			MethodName: "GetStateSummary",
+			Handler:    _VM_GetStateSummary_Handler,
+		},
+		{
+			MethodName: "ParseStateSummary",
+			Handler:    _VM_ParseStateSummary_Handler,
+		},
+		{
+			MethodName: "ShouldSyncState",
+			Handler:    _VM_ShouldSyncState_Handler,
+		},
+		{
+			MethodName: "GetStateChunk",
+			Handler:    _VM_GetStateChunk_Handler,
+		},
+		{
+			MethodName: "SyncStateChunk",
+			Handler:    _VM_SyncStateChunk_Handler,
+		},
+		{
+			MethodName: "StateSynced",
+			Handler:    _VM_StateSynced_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "vm.proto",
+}
+
+// DAGVMClient is the client API for DAGVM service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DAGVMClient interface {
+	Initialize(ctx context.Context, in *InitializeRequest, opts ...grpc.CallOption) (*InitializeResponse, error)
+	Bootstrapping(ctx context.Context, in *BootstrappingRequest, opts ...grpc.CallOption) (*BootstrappingResponse, error)
+	Bootstrapped(ctx context.Context, in *BootstrappedRequest, opts ...grpc.CallOption) (*BootstrappedResponse, error)
+	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	CreateHandlers(ctx context.Context, in *CreateHandlersRequest, opts ...grpc.CallOption) (*CreateHandlersResponse, error)
+	PendingTxs(ctx context.Context, in *PendingTxsRequest, opts ...grpc.CallOption) (*PendingTxsResponse, error)
+	ParseTx(ctx context.Context, in *ParseTxRequest, opts ...grpc.CallOption) (*ParseTxResponse, error)
+	GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error)
+	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	AppRequest(ctx context.Context, in *AppRequestMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+	AppRequestFailed(ctx context.Context, in *AppRequestFailedMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+	AppResponse(ctx context.Context, in *AppResponseMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+	AppGossip(ctx context.Context, in *AppGossipMsg, opts ...grpc.CallOption) (*EmptyMsg, error)
+	TxVerify(ctx context.Context, in *TxVerifyRequest, opts ...grpc.CallOption) (*TxVerifyResponse, error)
+	TxAccept(ctx context.Context, in *TxAcceptRequest, opts ...grpc.CallOption) (*TxAcceptResponse, error)
+	TxReject(ctx context.Context, in *TxRejectRequest, opts ...grpc.CallOption) (*TxRejectResponse, error)
+	TxDependencies(ctx context.Context, in *TxDependenciesRequest, opts ...grpc.CallOption) (*TxDependenciesResponse, error)
+	TxInputIDs(ctx context.Context, in *TxInputIDsRequest, opts ...grpc.CallOption) (*TxInputIDsResponse, error)
+}
+
+type dAGVMClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDAGVMClient(cc grpc.ClientConnInterface) DAGVMClient {
+	return &dAGVMClient{cc}
+}
+
+func (c *dAGVMClient) Initialize(ctx context.Context, in *InitializeRequest, opts ...grpc.CallOption) (*InitializeResponse, error) {
+	out := new(InitializeResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/Initialize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dAGVMClient) Bootstrapping(ctx context.Context, in *BootstrappingRequest, opts ...grpc.CallOption) (*BootstrappingResponse, error) {
+	out := new(BootstrappingResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/Bootstrapping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dAGVMClient) Bootstrapped(ctx context.Context, in *BootstrappedRequest, opts ...grpc.CallOption) (*BootstrappedResponse, error) {
+	out := new(BootstrappedResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/Bootstrapped", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dAGVMClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
+	out := new(ShutdownResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/Shutdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dAGVMClient) CreateHandlers(ctx context.Context, in *CreateHandlersRequest, opts ...grpc.CallOption) (*CreateHandlersResponse, error) {
+	out := new(CreateHandlersResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/CreateHandlers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dAGVMClient) PendingTxs(ctx context.Context, in *PendingTxsRequest, opts ...grpc.CallOption) (*PendingTxsResponse, error) {
+	out := new(PendingTxsResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/PendingTxs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dAGVMClient) ParseTx(ctx context.Context, in *ParseTxRequest, opts ...grpc.CallOption) (*ParseTxResponse, error) {
+	out := new(ParseTxResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/ParseTx", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *vMClient) GetBlock(ctx context.Context, in *GetBlockRequest, opts ...grpc.CallOption) (*GetBlockResponse, error) {
-	out := new(GetBlockResponse)
-	err := c.cc.Invoke(ctx, "/vmproto.VM/GetBlock", in, out, opts...)
+func (c *dAGVMClient) GetTx(ctx context.Context, in *GetTxRequest, opts ...grpc.CallOption) (*GetTxResponse, error) {
+	out := new(GetTxResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/GetTx", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *vMClient) SetPreference(ctx context.Context, in *SetPreferenceRequest, opts ...grpc.CallOption) (*SetPreferenceResponse, error) {
-	out := new(SetPreferenceResponse)
-	err := c.cc.Invoke(ctx, "/vmproto.VM/SetPreference", in, out, opts...)
+func (c *dAGVMClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/Health", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *vMClient) Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error) {
-	out := new(HealthResponse)
-	err := c.cc.Invoke(ctx, "/vmproto.VM/Health", in, out, opts...)
+func (c *dAGVMClient) AppRequest(ctx context.Context, in *AppRequestMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/AppRequest", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *vMClient) BlockVerify(ctx context.Context, in *BlockVerifyRequest, opts ...grpc.CallOption) (*BlockVerifyResponse, error) {
-	out := new(BlockVerifyResponse)
-	err := c.cc.Invoke(ctx, "/vmproto.VM/BlockVerify", in, out, opts...)
+func (c *dAGVMClient) AppRequestFailed(ctx context.Context, in *AppRequestFailedMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/AppRequestFailed", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *vMClient) BlockAccept(ctx context.Context, in *BlockAcceptRequest, opts ...grpc.CallOption) (*BlockAcceptResponse, error) {
-	out := new(BlockAcceptResponse)
-	err := c.cc.Invoke(ctx, "/vmproto.VM/BlockAccept", in, out, opts...)
+func (c *dAGVMClient) AppResponse(ctx context.Context, in *AppResponseMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/AppResponse", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *vMClient) BlockReject(ctx context.Context, in *BlockRejectRequest, opts ...grpc.CallOption) (*BlockRejectResponse, error) {
-	out := new(BlockRejectResponse)
-	err := c.cc.Invoke(ctx, "/vmproto.VM/BlockReject", in, out, opts...)
+func (c *dAGVMClient) AppGossip(ctx context.Context, in *AppGossipMsg, opts ...grpc.CallOption) (*EmptyMsg, error) {
+	out := new(EmptyMsg)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/AppGossip", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// VMServer is the server API for VM service.
-type VMServer interface {
+func (c *dAGVMClient) TxVerify(ctx context.Context, in *TxVerifyRequest, opts ...grpc.CallOption) (*TxVerifyResponse, error) {
+	out := new(TxVerifyResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/TxVerify", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dAGVMClient) TxAccept(ctx context.Context, in *TxAcceptRequest, opts ...grpc.CallOption) (*TxAcceptResponse, error) {
+	out := new(TxAcceptResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/TxAccept", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dAGVMClient) TxReject(ctx context.Context, in *TxRejectRequest, opts ...grpc.CallOption) (*TxRejectResponse, error) {
+	out := new(TxRejectResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/TxReject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dAGVMClient) TxDependencies(ctx context.Context, in *TxDependenciesRequest, opts ...grpc.CallOption) (*TxDependenciesResponse, error) {
+	out := new(TxDependenciesResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/TxDependencies", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dAGVMClient) TxInputIDs(ctx context.Context, in *TxInputIDsRequest, opts ...grpc.CallOption) (*TxInputIDsResponse, error) {
+	out := new(TxInputIDsResponse)
+	err := c.cc.Invoke(ctx, "/vmproto.DAGVM/TxInputIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DAGVMServer is the server API for DAGVM service.
+type DAGVMServer interface {
 	Initialize(context.Context, *InitializeRequest) (*InitializeResponse, error)
 	Bootstrapping(context.Context, *BootstrappingRequest) (*BootstrappingResponse, error)
 	Bootstrapped(context.Context, *BootstrappedRequest) (*BootstrappedResponse, error)
 	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
 	CreateHandlers(context.Context, *CreateHandlersRequest) (*CreateHandlersResponse, error)
-	BuildBlock(context.Context, *BuildBlockRequest) (*BuildBlockResponse, error)
-	ParseBlock(context.Context, *ParseBlockRequest) (*ParseBlockResponse, error)
-	GetBlock(context.Context, *GetBlockRequest) (*GetBlockResponse, error)
-	SetPreference(context.Context, *SetPreferenceRequest) (*SetPreferenceResponse, error)
+	PendingTxs(context.Context, *PendingTxsRequest) (*PendingTxsResponse, error)
+	ParseTx(context.Context, *ParseTxRequest) (*ParseTxResponse, error)
+	GetTx(context.Context, *GetTxRequest) (*GetTxResponse, error)
 	Health(context.Context, *HealthRequest) (*HealthResponse, error)
-	BlockVerify(context.Context, *BlockVerifyRequest) (*BlockVerifyResponse, error)
-	BlockAccept(context.Context, *BlockAcceptRequest) (*BlockAcceptResponse, error)
-	BlockReject(context.Context, *BlockRejectRequest) (*BlockRejectResponse, error)
+	AppRequest(context.Context, *AppRequestMsg) (*EmptyMsg, error)
+	AppRequestFailed(context.Context, *AppRequestFailedMsg) (*EmptyMsg, error)
+	AppResponse(context.Context, *AppResponseMsg) (*EmptyMsg, error)
+	AppGossip(context.Context, *AppGossipMsg) (*EmptyMsg, error)
+	TxVerify(context.Context, *TxVerifyRequest) (*TxVerifyResponse, error)
+	TxAccept(context.Context, *TxAcceptRequest) (*TxAcceptResponse, error)
+	TxReject(context.Context, *TxRejectRequest) (*TxRejectResponse, error)
+	TxDependencies(context.Context, *TxDependenciesRequest) (*TxDependenciesResponse, error)
+	TxInputIDs(context.Context, *TxInputIDsRequest) (*TxInputIDsResponse, error)
 }
 
-// UnimplementedVMServer can be embedded to have forward compatible implementations.
-type UnimplementedVMServer struct {
+// UnimplementedDAGVMServer can be embedded to have forward compatible implementations.
+type UnimplementedDAGVMServer struct {
 }
 
-func (*UnimplementedVMServer) Initialize(ctx context.Context, req *InitializeRequest) (*InitializeResponse, error) {
+func (*UnimplementedDAGVMServer) Initialize(ctx context.Context, req *InitializeRequest) (*InitializeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Initialize not implemented")
 }
-func (*UnimplementedVMServer) Bootstrapping(ctx context.Context, req *BootstrappingRequest) (*BootstrappingResponse, error) {
+func (*UnimplementedDAGVMServer) Bootstrapping(ctx context.Context, req *BootstrappingRequest) (*BootstrappingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Bootstrapping not implemented")
 }
-func (*UnimplementedVMServer) Bootstrapped(ctx context.Context, req *BootstrappedRequest) (*BootstrappedResponse, error) {
+func (*UnimplementedDAGVMServer) Bootstrapped(ctx context.Context, req *BootstrappedRequest) (*BootstrappedResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Bootstrapped not implemented")
 }
-func (*UnimplementedVMServer) Shutdown(ctx context.Context, req *ShutdownRequest) (*ShutdownResponse, error) {
+func (*UnimplementedDAGVMServer) Shutdown(ctx context.Context, req *ShutdownRequest) (*ShutdownResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
 }
-func (*UnimplementedVMServer) CreateHandlers(ctx context.Context, req *CreateHandlersRequest) (*CreateHandlersResponse, error) {
+func (*UnimplementedDAGVMServer) CreateHandlers(ctx context.Context, req *CreateHandlersRequest) (*CreateHandlersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateHandlers not implemented")
 }
-func (*UnimplementedVMServer) BuildBlock(ctx context.Context, req *BuildBlockRequest) (*BuildBlockResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BuildBlock not implemented")
-}
-func (*UnimplementedVMServer) ParseBlock(ctx context.Context, req *ParseBlockRequest) (*ParseBlockResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ParseBlock not implemented")
+func (*UnimplementedDAGVMServer) PendingTxs(ctx context.Context, req *PendingTxsRequest) (*PendingTxsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PendingTxs not implemented")
 }
-func (*UnimplementedVMServer) GetBlock(ctx context.Context, req *GetBlockRequest) (*GetBlockResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+func (*UnimplementedDAGVMServer) ParseTx(ctx context.Context, req *ParseTxRequest) (*ParseTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ParseTx not implemented")
 }
-func (*UnimplementedVMServer) SetPreference(ctx context.Context, req *SetPreferenceRequest) (*SetPreferenceResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetPreference not implemented")
+func (*UnimplementedDAGVMServer) GetTx(ctx context.Context, req *GetTxRequest) (*GetTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTx not implemented")
 }
-func (*UnimplementedVMServer) Health(ctx context.Context, req *HealthRequest) (*HealthResponse, error) {
+func (*UnimplementedDAGVMServer) Health(ctx context.Context, req *HealthRequest) (*HealthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
 }
-func (*UnimplementedVMServer) BlockVerify(ctx context.Context, req *BlockVerifyRequest) (*BlockVerifyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BlockVerify not implemented")
+func (*UnimplementedDAGVMServer) AppRequest(ctx context.Context, req *AppRequestMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppRequest not implemented")
 }
-func (*UnimplementedVMServer) BlockAccept(ctx context.Context, req *BlockAcceptRequest) (*BlockAcceptResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BlockAccept not implemented")
+func (*UnimplementedDAGVMServer) AppRequestFailed(ctx context.Context, req *AppRequestFailedMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppRequestFailed not implemented")
 }
-func (*UnimplementedVMServer) BlockReject(ctx context.Context, req *BlockRejectRequest) (*BlockRejectResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method BlockReject not implemented")
+func (*UnimplementedDAGVMServer) AppResponse(ctx context.Context, req *AppResponseMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppResponse not implemented")
+}
+func (*UnimplementedDAGVMServer) AppGossip(ctx context.Context, req *AppGossipMsg) (*EmptyMsg, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppGossip not implemented")
+}
+func (*UnimplementedDAGVMServer) TxVerify(ctx context.Context, req *TxVerifyRequest) (*TxVerifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxVerify not implemented")
+}
+func (*UnimplementedDAGVMServer) TxAccept(ctx context.Context, req *TxAcceptRequest) (*TxAcceptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxAccept not implemented")
+}
+func (*UnimplementedDAGVMServer) TxReject(ctx context.Context, req *TxRejectRequest) (*TxRejectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxReject not implemented")
+}
+func (*UnimplementedDAGVMServer) TxDependencies(ctx context.Context, req *TxDependenciesRequest) (*TxDependenciesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxDependencies not implemented")
+}
+func (*UnimplementedDAGVMServer) TxInputIDs(ctx context.Context, req *TxInputIDsRequest) (*TxInputIDsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TxInputIDs not implemented")
 }
 
-func RegisterVMServer(s *grpc.Server, srv VMServer) {
-	s.RegisterService(&_VM_serviceDesc, srv)
+func RegisterDAGVMServer(s *grpc.Server, srv DAGVMServer) {
+	s.RegisterService(&_DAGVM_serviceDesc, srv)
 }
 
-func _VM_Initialize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _DAGVM_Initialize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InitializeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VMServer).Initialize(ctx, in)
+		return srv.(DAGVMServer).Initialize(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vmproto.VM/Initialize",
+		FullMethod: "/vmproto.DAGVM/Initialize",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VMServer).Initialize(ctx, req.(*InitializeRequest))
+		return srv.(DAGVMServer).Initialize(ctx, req.(*InitializeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VM_Bootstrapping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _DAGVM_Bootstrapping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BootstrappingRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VMServer).Bootstrapping(ctx, in)
+		return srv.(DAGVMServer).Bootstrapping(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vmproto.VM/Bootstrapping",
+		FullMethod: "/vmproto.DAGVM/Bootstrapping",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VMServer).Bootstrapping(ctx, req.(*BootstrappingRequest))
+		return srv.(DAGVMServer).Bootstrapping(ctx, req.(*BootstrappingRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VM_Bootstrapped_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _DAGVM_Bootstrapped_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BootstrappedRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VMServer).Bootstrapped(ctx, in)
+		return srv.(DAGVMServer).Bootstrapped(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vmproto.VM/Bootstrapped",
+		FullMethod: "/vmproto.DAGVM/Bootstrapped",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VMServer).Bootstrapped(ctx, req.(*BootstrappedRequest))
+		return srv.(DAGVMServer).Bootstrapped(ctx, req.(*BootstrappedRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VM_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _DAGVM_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ShutdownRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VMServer).Shutdown(ctx, in)
+		return srv.(DAGVMServer).Shutdown(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vmproto.VM/Shutdown",
+		FullMethod: "/vmproto.DAGVM/Shutdown",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VMServer).Shutdown(ctx, req.(*ShutdownRequest))
+		return srv.(DAGVMServer).Shutdown(ctx, req.(*ShutdownRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VM_CreateHandlers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _DAGVM_CreateHandlers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateHandlersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VMServer).CreateHandlers(ctx, in)
+		return srv.(DAGVMServer).CreateHandlers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vmproto.VM/CreateHandlers",
+		FullMethod: "/vmproto.DAGVM/CreateHandlers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VMServer).CreateHandlers(ctx, req.(*CreateHandlersRequest))
+		return srv.(DAGVMServer).CreateHandlers(ctx, req.(*CreateHandlersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VM_BuildBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BuildBlockRequest)
+func _DAGVM_PendingTxs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PendingTxsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VMServer).BuildBlock(ctx, in)
+		return srv.(DAGVMServer).PendingTxs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vmproto.VM/BuildBlock",
+		FullMethod: "/vmproto.DAGVM/PendingTxs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VMServer).BuildBlock(ctx, req.(*BuildBlockRequest))
+		return srv.(DAGVMServer).PendingTxs(ctx, req.(*PendingTxsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VM_ParseBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ParseBlockRequest)
+func _DAGVM_ParseTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseTxRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VMServer).ParseBlock(ctx, in)
+		return srv.(DAGVMServer).ParseTx(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vmproto.VM/ParseBlock",
+		FullMethod: "/vmproto.DAGVM/ParseTx",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VMServer).ParseBlock(ctx, req.(*ParseBlockRequest))
+		return srv.(DAGVMServer).ParseTx(ctx, req.(*ParseTxRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VM_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetBlockRequest)
+func _DAGVM_GetTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VMServer).GetBlock(ctx, in)
+		return srv.(DAGVMServer).GetTx(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vmproto.VM/GetBlock",
+		FullMethod: "/vmproto.DAGVM/GetTx",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VMServer).GetBlock(ctx, req.(*GetBlockRequest))
+		return srv.(DAGVMServer).GetTx(ctx, req.(*GetTxRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VM_SetPreference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetPreferenceRequest)
+func _DAGVM_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VMServer).SetPreference(ctx, in)
+		return srv.(DAGVMServer).Health(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vmproto.VM/SetPreference",
+		FullMethod: "/vmproto.DAGVM/Health",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VMServer).SetPreference(ctx, req.(*SetPreferenceRequest))
+		return srv.(DAGVMServer).Health(ctx, req.(*HealthRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VM_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(HealthRequest)
+func _DAGVM_AppRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppRequestMsg)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VMServer).Health(ctx, in)
+		return srv.(DAGVMServer).AppRequest(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vmproto.VM/Health",
+		FullMethod: "/vmproto.DAGVM/AppRequest",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VMServer).Health(ctx, req.(*HealthRequest))
+		return srv.(DAGVMServer).AppRequest(ctx, req.(*AppRequestMsg))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VM_BlockVerify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BlockVerifyRequest)
+func _DAGVM_AppRequestFailed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppRequestFailedMsg)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VMServer).BlockVerify(ctx, in)
+		return srv.(DAGVMServer).AppRequestFailed(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vmproto.VM/BlockVerify",
+		FullMethod: "/vmproto.DAGVM/AppRequestFailed",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VMServer).BlockVerify(ctx, req.(*BlockVerifyRequest))
+		return srv.(DAGVMServer).AppRequestFailed(ctx, req.(*AppRequestFailedMsg))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VM_BlockAccept_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BlockAcceptRequest)
+func _DAGVM_AppResponse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppResponseMsg)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VMServer).BlockAccept(ctx, in)
+		return srv.(DAGVMServer).AppResponse(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vmproto.VM/BlockAccept",
+		FullMethod: "/vmproto.DAGVM/AppResponse",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VMServer).BlockAccept(ctx, req.(*BlockAcceptRequest))
+		return srv.(DAGVMServer).AppResponse(ctx, req.(*AppResponseMsg))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _VM_BlockReject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(BlockRejectRequest)
+func _DAGVM_AppGossip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppGossipMsg)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(VMServer).BlockReject(ctx, in)
+		return srv.(DAGVMServer).AppGossip(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/vmproto.VM/BlockReject",
+		FullMethod: "/vmproto.DAGVM/AppGossip",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(VMServer).BlockReject(ctx, req.(*BlockRejectRequest))
+		return srv.(DAGVMServer).AppGossip(ctx, req.(*AppGossipMsg))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _VM_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "vmproto.VM",
-	HandlerType: (*VMServer)(nil),
+func _DAGVM_TxVerify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxVerifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DAGVMServer).TxVerify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.DAGVM/TxVerify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DAGVMServer).TxVerify(ctx, req.(*TxVerifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DAGVM_TxAccept_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxAcceptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DAGVMServer).TxAccept(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.DAGVM/TxAccept",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DAGVMServer).TxAccept(ctx, req.(*TxAcceptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DAGVM_TxReject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxRejectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DAGVMServer).TxReject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.DAGVM/TxReject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DAGVMServer).TxReject(ctx, req.(*TxRejectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DAGVM_TxDependencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxDependenciesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DAGVMServer).TxDependencies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.DAGVM/TxDependencies",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DAGVMServer).TxDependencies(ctx, req.(*TxDependenciesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DAGVM_TxInputIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TxInputIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DAGVMServer).TxInputIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vmproto.DAGVM/TxInputIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DAGVMServer).TxInputIDs(ctx, req.(*TxInputIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DAGVM_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "vmproto.DAGVM",
+	HandlerType: (*DAGVMServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
 			MethodName: "Initialize",
-			Handler:    _VM_Initialize_Handler,
+			Handler:    _DAGVM_Initialize_Handler,
 		},
 		{
 			MethodName: "Bootstrapping",
-			Handler:    _VM_Bootstrapping_Handler,
+			Handler:    _DAGVM_Bootstrapping_Handler,
 		},
 		{
 			MethodName: "Bootstrapped",
-			Handler:    _VM_Bootstrapped_Handler,
+			Handler:    _DAGVM_Bootstrapped_Handler,
 		},
 		{
 			MethodName: "Shutdown",
-			Handler:    _VM_Shutdown_Handler,
+			Handler:    _DAGVM_Shutdown_Handler,
 		},
 		{
 			MethodName: "CreateHandlers",
-			Handler:    _VM_CreateHandlers_Handler,
+			Handler:    _DAGVM_CreateHandlers_Handler,
 		},
 		{
-			MethodName: "BuildBlock",
-			Handler:    _VM_BuildBlock_Handler,
+			MethodName: "PendingTxs",
+			Handler:    _DAGVM_PendingTxs_Handler,
 		},
 		{
-			MethodName: "ParseBlock",
-			Handler:    _VM_ParseBlock_Handler,
+			MethodName: "ParseTx",
+			Handler:    _DAGVM_ParseTx_Handler,
 		},
 		{
-			MethodName: "GetBlock",
-			Handler:    _VM_GetBlock_Handler,
+			MethodName: "GetTx",
+			Handler:    _DAGVM_GetTx_Handler,
 		},
 		{
-			MethodName: "SetPreference",
-			Handler:    _VM_SetPreference_Handler,
+			MethodName: "Health",
+			Handler:    _DAGVM_Health_Handler,
 		},
 		{
-			MethodName: "Health",
-			Handler:    _VM_Health_Handler,
+			MethodName: "AppRequest",
+			Handler:    _DAGVM_AppRequest_Handler,
 		},
 		{
-			MethodName: "BlockVerify",
-			Handler:    _VM_BlockVerify_Handler,
+			MethodName: "AppRequestFailed",
+			Handler:    _DAGVM_AppRequestFailed_Handler,
 		},
 		{
-			MethodName: "BlockAccept",
-			Handler:    _VM_BlockAccept_Handler,
+			MethodName: "AppResponse",
+			Handler:    _DAGVM_AppResponse_Handler,
 		},
 		{
-			MethodName: "BlockReject",
-			Handler:    _VM_BlockReject_Handler,
+			MethodName: "AppGossip",
+			Handler:    _DAGVM_AppGossip_Handler,
+		},
+		{
+			MethodName: "TxVerify",
+			Handler:    _DAGVM_TxVerify_Handler,
+		},
+		{
+			MethodName: "TxAccept",
+			Handler:    _DAGVM_TxAccept_Handler,
+		},
+		{
+			MethodName: "TxReject",
+			Handler:    _DAGVM_TxReject_Handler,
+		},
+		{
+			MethodName: "TxDependencies",
+			Handler:    _DAGVM_TxDependencies_Handler,
+		},
+		{
+			MethodName: "TxInputIDs",
+			Handler:    _DAGVM_TxInputIDs_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
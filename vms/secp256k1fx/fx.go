@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/liraxapp/avalanchego/cache"
+	"github.com/liraxapp/avalanchego/cache/metercacher"
 	"github.com/liraxapp/avalanchego/utils/crypto"
 	"github.com/liraxapp/avalanchego/utils/hashing"
 	"github.com/liraxapp/avalanchego/utils/wrappers"
@@ -51,9 +52,16 @@ func (fx *Fx) Initialize(vmIntf interface{}) error {
 	log := fx.VM.Logger()
 	log.Debug("initializing secp561k1 fx")
 
-	fx.SECPFactory = crypto.FactorySECP256K1R{
-		Cache: cache.LRU{Size: defaultCacheSize},
+	var keyCache cache.Cacher = &cache.LRU{Size: defaultCacheSize}
+	if vm, ok := fx.VM.(MeteredVM); ok {
+		ctx := vm.Context()
+		meteredKeyCache, err := metercacher.New(ctx.Namespace, "secp256k1fx_public_key_cache", ctx.Metrics, keyCache)
+		if err != nil {
+			return err
+		}
+		keyCache = meteredKeyCache
 	}
+	fx.SECPFactory = crypto.FactorySECP256K1R{Cache: keyCache}
 	c := fx.VM.CodecRegistry()
 	errs := wrappers.Errs{}
 	errs.Add(
@@ -4,6 +4,7 @@
 package secp256k1fx
 
 import (
+	"github.com/liraxapp/avalanchego/snow"
 	"github.com/liraxapp/avalanchego/utils/codec"
 	"github.com/liraxapp/avalanchego/utils/logging"
 	"github.com/liraxapp/avalanchego/utils/timer"
@@ -16,6 +17,12 @@ type VM interface {
 	Logger() logging.Logger
 }
 
+// MeteredVM is a VM whose context is used to report the metrics of this Fx
+type MeteredVM interface {
+	VM
+	Context() *snow.Context
+}
+
 var (
 	_ VM = &TestVM{}
 )
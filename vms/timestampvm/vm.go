@@ -27,7 +27,8 @@ var (
 	errNoPendingBlocks = errors.New("there is no block to propose")
 	errBadGenesisBytes = errors.New("genesis data should be bytes (max length 32)")
 
-	_ block.ChainVM = &VM{}
+	_ block.ChainVM              = &VM{}
+	_ block.HeightIndexedChainVM = &VM{}
 )
 
 // VM implements the snowman.VM interface
@@ -104,7 +105,7 @@ func (vm *VM) Initialize(
 			return err
 		}
 	}
-	return nil
+	return vm.IndexHeights()
 }
 
 // CreateHandlers returns a map where:
@@ -207,3 +207,46 @@ func TestService(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestGetBlockIDAtHeight(t *testing.T) {
+	db := memdb.New()
+	msgChan := make(chan common.Message, 2)
+	vm := &VM{}
+	ctx := snow.DefaultContextTest()
+	ctx.ChainID = blockchainID
+	if err := vm.Initialize(ctx, db, []byte{0, 0, 0, 0, 0}, msgChan, nil); err != nil {
+		t.Fatal(err)
+	}
+	blkIDs := []ids.ID{vm.LastAccepted()}
+	vm.SetPreference(blkIDs[0])
+
+	for i := byte(1); i <= 2; i++ {
+		vm.proposeBlock([dataLen]byte{i})
+		blk, err := vm.BuildBlock()
+		if err != nil {
+			t.Fatalf("problem building block: %s", err)
+		}
+		if err := blk.Verify(); err != nil {
+			t.Fatal(err)
+		}
+		if err := blk.Accept(); err != nil {
+			t.Fatal(err)
+		}
+		vm.SetPreference(blk.ID())
+		blkIDs = append(blkIDs, blk.ID())
+	}
+	if err := vm.DB.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	for height, blkID := range blkIDs {
+		if id, err := vm.GetBlockIDAtHeight(uint64(height)); err != nil {
+			t.Fatal(err)
+		} else if id != blkID {
+			t.Fatalf("expected block %s at height %d but was %s", blkID, height, id)
+		}
+	}
+	if _, err := vm.GetBlockIDAtHeight(uint64(len(blkIDs))); err == nil {
+		t.Fatal("shouldn't have a block above the last accepted block")
+	}
+}